@@ -5,9 +5,11 @@ import (
 	"cutbray/pppk-json/cmd/config"
 	"cutbray/pppk-json/internal/adapters/db_adapter"
 	"cutbray/pppk-json/internal/adapters/logger"
-	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/question_service"
 	"cutbray/pppk-json/internal/utils"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/fs"
 	"log"
@@ -22,6 +24,7 @@ type QuestionData struct {
 	ID           string       `json:"id"`
 	Category     string       `json:"category"`
 	QuestionText string       `json:"question_text"`
+	Tags         []string     `json:"tags,omitempty"`
 	Options      []OptionData `json:"options"`
 }
 
@@ -31,6 +34,11 @@ type OptionData struct {
 }
 
 func main() {
+	importFile := flag.String("import", "", "append questions from a CSV, XLSX, Moodle XML, GIFT, Aiken or QTI file instead of reseeding from ./migrations/data")
+	category := flag.String("category", "", "category of imported questions whose format carries none (Aiken, QTI)")
+	preview := flag.Bool("preview", false, "validate the questions without saving them")
+	flag.Parse()
+
 	logger.New()
 
 	err := config.LoadEnvFile()
@@ -62,20 +70,88 @@ func main() {
 		log.Fatalf("Database adapter is not properly initialized")
 	}
 
+	if *importFile != "" {
+		if err := importQuestions(db, *importFile, *category, *preview); err != nil {
+			log.Fatalf("Failed to import questions: %v", err)
+		}
+		config.DisconnectAdapters(connectManagers...)
+		return
+	}
+
 	questions, err := readJsonFile("./migrations/data")
 	if err != nil {
 		log.Fatalf("Failed to read JSON files: %v", err)
 	}
 
 	log.Printf("Total questions read from JSON files: %d", len(questions))
-	if err := seedQuestions(db, questions); err != nil {
+	if err := seedQuestions(db, questions, *preview); err != nil {
 		log.Fatalf("Failed to seed questions: %v", err)
 	}
 
-	log.Println("Seeding completed successfully!")
+	if *preview {
+		log.Println("Preview completed, nothing was saved")
+	} else {
+		log.Println("Seeding completed successfully!")
+	}
 	config.DisconnectAdapters(connectManagers...)
 }
 
+// importQuestions appends the questions of a spreadsheet or LMS exchange file to the question bank
+func importQuestions(db *gorm.DB, path, category string, preview bool) error {
+	format, err := question_service.ImportFormatFromFilename(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	service := question_service.NewQuestionService(db)
+
+	inputs, rowErrors, err := service.ParseImportFile(context.Background(), file, info.Size(), format, category)
+	if err != nil {
+		return err
+	}
+	if len(rowErrors) > 0 {
+		logRowErrors(rowErrors)
+		return question_service.ErrImportRejected
+	}
+
+	log.Printf("Total questions read from %s: %d", path, len(inputs))
+
+	report, err := service.ImportQuestions(context.Background(), inputs, preview)
+	if err != nil {
+		if report != nil {
+			logRowErrors(report.Errors)
+		}
+		return err
+	}
+
+	for code, count := range report.ByCategory {
+		log.Printf("Category %s: %d questions", code, count)
+	}
+	if preview {
+		log.Println("Preview completed, nothing was saved")
+	} else {
+		log.Printf("Imported %d questions successfully!", report.Imported)
+	}
+	return nil
+}
+
+func logRowErrors(rowErrors []dto.ImportRowError) {
+	for _, rowErr := range rowErrors {
+		log.Printf("[Error] row %d, %s: %s", rowErr.Row, rowErr.Field, rowErr.Message)
+	}
+}
+
 func readJsonFile(filePath string) ([]QuestionData, error) {
 
 	var allQuestions []QuestionData
@@ -116,34 +192,56 @@ func readJsonFile(filePath string) ([]QuestionData, error) {
 	return allQuestions, nil
 }
 
-func seedQuestions(db *gorm.DB, questions []QuestionData) error {
+func seedQuestions(db *gorm.DB, questions []QuestionData, preview bool) error {
+
+	inputs := make([]question_service.QuestionInput, len(questions))
+	for i, q := range questions {
+		inputs[i] = question_service.QuestionInput{
+			Row:          i + 1,
+			Category:     q.Category,
+			QuestionText: q.QuestionText,
+			Tags:         q.Tags,
+		}
+		for _, opt := range q.Options {
+			inputs[i].Options = append(inputs[i].Options, question_service.OptionInput{
+				OptionText: opt.OptionText,
+				Score:      opt.Score,
+			})
+		}
+	}
 
 	return db.WithContext(context.Background()).Transaction(func(tx *gorm.DB) error {
 
+		// Reject invalid questions before touching existing data
+		rowErrors, err := question_service.ValidateQuestionInputs(tx, inputs)
+		if err != nil {
+			return err
+		}
+		if len(rowErrors) > 0 {
+			for _, rowErr := range rowErrors {
+				q := questions[rowErr.Row-1]
+				log.Printf("[Error] question id %s, %s: %s", q.ID, rowErr.Field, rowErr.Message)
+			}
+			return fmt.Errorf("invalid question data: %d problems found", len(rowErrors))
+		}
+
+		if preview {
+			return nil
+		}
+
 		// Truncate existing questions and options
 		if err := tx.Exec("TRUNCATE TABLE questions RESTART IDENTITY CASCADE").Error; err != nil {
 			return fmt.Errorf("failed to truncate table: %v", err)
 		}
 
-		for _, q := range questions {
-			question := models.Question{
-				Category:     q.Category,
-				QuestionText: q.QuestionText,
-			}
+		for _, in := range inputs {
+			question := in.ToModel()
 
-			options := make([]models.QuestionOption, len(q.Options))
-			for i, opt := range q.Options {
-				if opt.OptionText == "" {
-					log.Printf("[Error Skipping] option with empty text for question: %s (category: %s, text: %s)", q.QuestionText, question.Category, question.QuestionText)
-					return fmt.Errorf("option text cannot be empty")
-				}
-				options[i] = models.QuestionOption{
-					OptionText: opt.OptionText,
-					Score:      opt.Score,
-				}
+			tags, err := question_service.FindOrCreateTags(tx, in.Tags)
+			if err != nil {
+				return fmt.Errorf("failed to prepare tags: %v", err)
 			}
-
-			question.Options = options
+			question.Tags = tags
 
 			result := tx.Create(&question)
 
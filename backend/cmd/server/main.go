@@ -21,20 +21,30 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"cutbray/pppk-json/cmd/config"
 	"cutbray/pppk-json/docs"
 	_ "cutbray/pppk-json/docs" // for swagger docs
 	"cutbray/pppk-json/internal/adapters/db_adapter"
 	"cutbray/pppk-json/internal/adapters/gin_adapter"
 	"cutbray/pppk-json/internal/adapters/logger"
+	"cutbray/pppk-json/internal/adapters/smtp_adapter"
+	"cutbray/pppk-json/internal/adapters/worker_adapter"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/events"
 	"cutbray/pppk-json/internal/handlers"
+	"cutbray/pppk-json/internal/repositories/analytics_service"
+	"cutbray/pppk-json/internal/repositories/notification_service"
+	"cutbray/pppk-json/internal/repositories/webhook_service"
 	"cutbray/pppk-json/internal/utils"
+	"encoding/hex"
 	"fmt"
 	"io/fs"
 	"log"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -52,6 +62,38 @@ func main() {
 	ginMode := utils.GetEnvOrDefault("APP_MODE", gin.ReleaseMode)
 	appHost := utils.GetEnvOrDefault("APP_HOST", "localhost:8080")
 	appScheme := utils.GetEnvOrDefault("APP_SCHEME", "http")
+	reportSigningKey := utils.GetEnvOrDefault("REPORT_SIGNING_KEY", "")
+	eventPollInterval, err := time.ParseDuration(utils.GetEnvOrDefault("EVENT_POLL_INTERVAL", "1s"))
+	if err != nil {
+		log.Fatalf("Invalid EVENT_POLL_INTERVAL: %v", err)
+	}
+	webhookPollInterval, err := time.ParseDuration(utils.GetEnvOrDefault("WEBHOOK_POLL_INTERVAL", "5s"))
+	if err != nil {
+		log.Fatalf("Invalid WEBHOOK_POLL_INTERVAL: %v", err)
+	}
+	reminderLead, err := time.ParseDuration(utils.GetEnvOrDefault("NOTIFICATION_REMINDER_LEAD", "24h"))
+	if err != nil {
+		log.Fatalf("Invalid NOTIFICATION_REMINDER_LEAD: %v", err)
+	}
+	notificationLocation, err := time.LoadLocation(utils.GetEnvOrDefault("NOTIFICATION_TIMEZONE", "Asia/Jakarta"))
+	if err != nil {
+		notificationLocation = time.UTC
+		log.Printf("[Warning] Invalid NOTIFICATION_TIMEZONE, showing times in UTC: %v", err)
+	}
+	smtpConfig := smtp_adapter.SMTPConfig{
+		Host:     utils.GetEnvOrDefault("SMTP_HOST", ""),
+		Port:     utils.GetEnvOrDefault("SMTP_PORT", "587"),
+		Username: utils.GetEnvOrDefault("SMTP_USERNAME", ""),
+		Password: utils.GetEnvOrDefault("SMTP_PASSWORD", ""),
+		From:     utils.GetEnvOrDefault("SMTP_FROM", "PPPK Exam <no-reply@localhost>"),
+		TLS:      utils.GetEnvOrDefault("SMTP_TLS", smtp_adapter.TLSStartTLS),
+	}
+
+	if reportSigningKey == "" {
+		// Codes signed with a random key stop verifying after a restart
+		reportSigningKey = randomKey()
+		log.Println("[Warning] REPORT_SIGNING_KEY is not set, score report codes will not verify after a restart")
+	}
 
 	// Update swagger host dinamically
 	updateSwaggerHost(appHost, appScheme)
@@ -90,6 +132,74 @@ func main() {
 		log.Fatalf("Database adapter is not properly initialized")
 	}
 
+	// Record admin mutations on audited tables
+	if err := audit.RegisterCallbacks(db); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	// Pass domain events on to the subscribers and deliver queued webhooks in the background
+	eventStream := events.NewStream()
+	eventDispatcher := events.NewDispatcher(db)
+	eventDispatcher.Subscribe(webhook_service.NewSubscriber())
+	eventDispatcher.Subscribe(analytics_service.NewCounter())
+	eventDispatcher.Subscribe(eventStream)
+	webhookDispatcher := webhook_service.NewDispatcher(db)
+
+	workerManagers := []config.ConnectManager{
+		{Name: "Event Dispatcher", Adapter: worker_adapter.New(eventDispatcher.DispatchDue, worker_adapter.WorkerConfig{
+			Name:         "Event Dispatcher",
+			PollInterval: eventPollInterval,
+			Value:        eventDispatcher,
+		})},
+		{Name: "Webhook Dispatcher", Adapter: worker_adapter.New(webhookDispatcher.DeliverDue, worker_adapter.WorkerConfig{
+			Name:         "Webhook Dispatcher",
+			PollInterval: webhookPollInterval,
+			Value:        webhookDispatcher,
+		})},
+	}
+
+	// Email score reports and sitting reminders when an SMTP server is configured
+	if smtpConfig.Host != "" {
+		smtpAdapter := smtp_adapter.New(smtpConfig)
+		smtpManager := config.ConnectManager{Name: "SMTP Server", Adapter: smtpAdapter}
+		if err := config.ConnectAdapters(shutdown, smtpManager); err != nil {
+			log.Fatalf("%v", err)
+		}
+		connectManagers = append(connectManagers, smtpManager)
+
+		notifier, err := notification_service.NewNotifier(db, notification_service.NotifierConfig{
+			BaseURL:      appScheme + "://" + appHost,
+			SigningKey:   reportSigningKey,
+			Location:     notificationLocation,
+			ReminderLead: reminderLead,
+		})
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		eventDispatcher.Subscribe(notifier)
+		sender := notification_service.NewSender(db, smtpAdapter)
+
+		workerManagers = append(workerManagers,
+			config.ConnectManager{Name: "Sitting Reminders", Adapter: worker_adapter.New(notifier.ScheduleReminders, worker_adapter.WorkerConfig{
+				Name:         "Sitting Reminders",
+				PollInterval: time.Minute,
+				Value:        notifier,
+			})},
+			config.ConnectManager{Name: "Notification Sender", Adapter: worker_adapter.New(sender.SendDue, worker_adapter.WorkerConfig{
+				Name:         "Notification Sender",
+				PollInterval: 10 * time.Second,
+				Value:        sender,
+			})},
+		)
+	} else {
+		log.Println("[Warning] SMTP_HOST is not set, email notifications are disabled")
+	}
+
+	if err := config.ConnectAdapters(shutdown, workerManagers...); err != nil {
+		log.Fatalf("%v", err)
+	}
+	connectManagers = append(connectManagers, workerManagers...)
+
 	// Setup handlers and routes
 	ginEngine, ok := ginAdapter.Value().(*gin.Engine)
 	if !ok {
@@ -99,6 +209,22 @@ func main() {
 	// Register exam handlers
 	handlers.NewGinExamHandler(db).RegisterRoutes(ginEngine)
 	handlers.NewGinQuestionHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinCategoryHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinGradingHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinAuditHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinCohortHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinSittingHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinSessionHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinAccommodationHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinExportHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinEventHandler(db, eventStream).RegisterRoutes(ginEngine)
+	handlers.NewGinNotificationHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinWebhookHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinExamPaperHandler(db).RegisterRoutes(ginEngine)
+	handlers.NewGinScoreReportHandler(db, handlers.ScoreReportConfig{
+		SigningKey: reportSigningKey,
+		BaseURL:    appScheme + "://" + appHost,
+	}).RegisterRoutes(ginEngine)
 	handlers.NewFrontendHandler().RegisterRoutes(ginEngine)
 	<-shutdown.Done()
 
@@ -127,6 +253,15 @@ func fileExists(f fs.FS, path string) bool {
 	return !stat.IsDir()
 }
 
+// randomKey returns a random hex encoded secret
+func randomKey() string {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("failed to generate key: %v", err)
+	}
+	return hex.EncodeToString(key)
+}
+
 // updateSwaggerHost updates swagger documentation host dynamically
 func updateSwaggerHost(host, scheme string) {
 	if docs.SwaggerInfo == nil {
@@ -24,9 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/dashboard/users": {
+        "/accommodations": {
             "get": {
-                "description": "Gets dashboard data for all users who have taken exams, including their status and results",
+                "description": "Returns the accommodation of every candidate that has one",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,12 +34,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dashboard"
+                    "accommodations"
                 ],
-                "summary": "Get all users dashboard",
+                "summary": "Get accommodations",
                 "responses": {
                     "200": {
-                        "description": "All users dashboard data retrieved",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -49,25 +49,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserListDashboardResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.AccommodationResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "500": {
-                        "description": "Failed to get dashboard data",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
                     }
                 }
             }
         },
-        "/exam/{userID}": {
+        "/accommodations/{userID}": {
             "get": {
-                "description": "Creates a new exam session with 20 random questions (5 per category) or returns existing active session",
+                "description": "Returns the accommodation of a candidate",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,9 +72,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "accommodations"
                 ],
-                "summary": "Create or get exam session",
+                "summary": "Get accommodation",
                 "parameters": [
                     {
                         "type": "string",
@@ -90,7 +87,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exam session ready",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -100,31 +97,23 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ExamSessionResponse"
+                                            "$ref": "#/definitions/dto.AccommodationResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to create exam session",
+                    "404": {
+                        "description": "Accommodation not found",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/exam/{userID}/answer": {
-            "post": {
-                "description": "Submits user's answer for a specific question",
+            },
+            "put": {
+                "description": "Creates or replaces the accommodation of a candidate. The time multiplier and extra breaks lengthen the deadline of every session created or started afterwards, also past the end of a sitting; sessions in progress keep their deadline. The large font flag is only passed on to the frontend.",
                 "consumes": [
                     "application/json"
                 ],
@@ -132,9 +121,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "accommodations"
                 ],
-                "summary": "Submit answer",
+                "summary": "Set accommodation",
                 "parameters": [
                     {
                         "type": "string",
@@ -145,46 +134,44 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Answer submission",
-                        "name": "request",
+                        "description": "Accommodation",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SubmitAnswerRequest"
+                            "$ref": "#/definitions/dto.AccommodationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Answer submitted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AccommodationResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Exam session not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to submit answer",
+                        "description": "Invalid request body or accommodation",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/exam/{userID}/answers": {
-            "get": {
-                "description": "Gets existing answers for user's active exam session to repopulate on page reload",
+            },
+            "delete": {
+                "description": "Removes the accommodation of a candidate. Sessions that are not started yet lose the extra time when started.",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,9 +179,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "accommodations"
                 ],
-                "summary": "Get user's existing answers",
+                "summary": "Delete accommodation",
                 "parameters": [
                     {
                         "type": "string",
@@ -207,32 +194,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User answers retrieved",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user ID",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get user answers",
+                    "404": {
+                        "description": "Accommodation not found",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -240,9 +208,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/exam/{userID}/complete": {
-            "post": {
-                "description": "Completes the exam session and calculates final results",
+        "/audit": {
+            "get": {
+                "description": "Returns recorded changes to questions, options, categories, tag quotas, grading scales, pass rules, blueprints, cohorts, sittings, accommodations and session overrides, newest first. Writes are attributed with the X-Actor header and correlated with the X-Request-ID header. The server does not authenticate clients yet, so the actor is only claimed (actor_claimed) and cannot be trusted; remote_addr tells where the request came from.",
                 "consumes": [
                     "application/json"
                 ],
@@ -250,22 +218,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "audit"
                 ],
-                "summary": "Complete exam",
+                "summary": "Get audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"1234\"",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "CREATE",
+                            "UPDATE",
+                            "DELETE",
+                            "RESCORE",
+                            "SESSION_EXTEND",
+                            "SESSION_FORCE_COMPLETE",
+                            "SESSION_RESET",
+                            "SESSION_VOID",
+                            "SESSION_REOPEN"
+                        ],
+                        "type": "string",
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "question_options",
+                        "description": "Filter by entity type (table name)",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 50, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exam completed successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -275,8 +303,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "$ref": "#/definitions/dto.PaginatedAuditLogResponse"
                                         }
                                     }
                                 }
@@ -284,19 +311,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Exam session not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to complete exam",
+                        "description": "Invalid time filter",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -304,9 +319,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/exam/{userID}/dashboard": {
+        "/blueprints": {
             "get": {
-                "description": "Gets comprehensive dashboard data including exam status, progress, and results if completed",
+                "description": "Returns all exam blueprints with their duration, grading scale and pass rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -314,22 +329,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
-                ],
-                "summary": "Get dashboard information",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"1234\"",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "grading"
                 ],
+                "summary": "Get exam blueprints",
                 "responses": {
                     "200": {
-                        "description": "Dashboard data retrieved",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -339,31 +344,20 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.DashboardResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BlueprintResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to get dashboard data",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/exam/{userID}/detailed-answers": {
-            "get": {
-                "description": "Gets detailed answers with question text, selected options, and scores for completed exam",
+            },
+            "post": {
+                "description": "Creates an exam blueprint selecting a grading scale and pass rule. Marking it default unmarks the previous default.",
                 "consumes": [
                     "application/json"
                 ],
@@ -371,22 +365,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "grading"
                 ],
-                "summary": "Get detailed user answers",
+                "summary": "Create exam blueprint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"1234\"",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
+                        "description": "Blueprint to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BlueprintRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Detailed answers retrieved",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -396,13 +391,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "array",
-                                                "items": {
-                                                    "$ref": "#/definitions/dto.DetailedAnswer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/dto.BlueprintResponse"
                                         }
                                     }
                                 }
@@ -410,19 +399,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
                     "404": {
-                        "description": "No completed exam found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to get detailed answers",
+                        "description": "Grading scale or pass rule not found",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -430,9 +413,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/exam/{userID}/results": {
+        "/blueprints/{blueprintID}": {
             "get": {
-                "description": "Retrieves detailed exam results including summary and category breakdown",
+                "description": "Returns a single exam blueprint",
                 "consumes": [
                     "application/json"
                 ],
@@ -440,22 +423,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "grading"
                 ],
-                "summary": "Get exam results",
+                "summary": "Get exam blueprint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"1234\"",
-                        "description": "User ID",
-                        "name": "userID",
+                        "type": "integer",
+                        "description": "Blueprint ID",
+                        "name": "blueprintID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exam results retrieved",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -465,31 +447,23 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ExamResultsResponse"
+                                            "$ref": "#/definitions/dto.BlueprintResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Exam results not found",
+                        "description": "Blueprint not found",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/exam/{userID}/start": {
-            "post": {
-                "description": "Starts the exam timer and changes status to IN_PROGRESS",
+            },
+            "put": {
+                "description": "Updates an exam blueprint. Completed sessions keep their grades until re-graded.",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,22 +471,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exam"
+                    "grading"
                 ],
-                "summary": "Start exam",
+                "summary": "Update exam blueprint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"1234\"",
-                        "description": "User ID",
-                        "name": "userID",
+                        "type": "integer",
+                        "description": "Blueprint ID",
+                        "name": "blueprintID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Blueprint fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BlueprintRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Exam started successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -522,8 +504,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "$ref": "#/definitions/dto.BlueprintResponse"
                                         }
                                     }
                                 }
@@ -531,19 +512,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
                     "404": {
-                        "description": "Exam session not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to start exam",
+                        "description": "Blueprint, grading scale or pass rule not found",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -551,9 +526,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/categories": {
             "get": {
-                "description": "Get the health status of the application",
+                "description": "Returns all exam categories with display name, order, scoring scheme and question count",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,23 +536,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "categories"
                 ],
-                "summary": "Health Check",
+                "summary": "Get categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/questions": {
-            "get": {
-                "description": "Downloads questions in JSON format based on category and search text filters",
+            },
+            "post": {
+                "description": "Creates a new exam category",
                 "consumes": [
                     "application/json"
                 ],
@@ -585,39 +572,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "categories"
                 ],
-                "summary": "Download questions as JSON file",
+                "summary": "Create category",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Category filter (TEKNIS, MANAJERIAL, SOSIAL KULTURAL, WAWANCARA)",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by question text",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Category to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ExportQuestionResponse"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or scoring scheme",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/questions/categories": {
+        "/categories/{categoryID}": {
             "get": {
-                "description": "Returns list of all available question categories",
+                "description": "Returns a single exam category",
                 "consumes": [
                     "application/json"
                 ],
@@ -625,9 +624,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "categories"
+                ],
+                "summary": "Get category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "categoryID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get question categories",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -640,22 +648,23 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/dto.CategoryResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/questions/management": {
-            "get": {
-                "description": "Retrieves questions with their options, filtered by category and question text, with pagination support",
+            },
+            "put": {
+                "description": "Updates an exam category, renaming the code also renames it on all questions",
                 "consumes": [
                     "application/json"
                 ],
@@ -663,35 +672,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "categories"
                 ],
-                "summary": "Get questions by category and search text with pagination",
+                "summary": "Update category",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Category filter (TEKNIS, MANAJERIAL, SOSIAL KULTURAL, WAWANCARA)",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by question text",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
                         "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Category ID",
+                        "name": "categoryID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "description": "Items per page (default: 10, use 0 for all)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Category fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -706,19 +705,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PaginatedQuestionResponse"
+                                            "$ref": "#/definitions/dto.CategoryResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body or scoring scheme",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/questions/{questionID}/option/{optionID}/score": {
-            "put": {
-                "description": "Updates the score value for a specific question option",
+            },
+            "delete": {
+                "description": "Deletes an exam category, only allowed when no question uses it",
                 "consumes": [
                     "application/json"
                 ],
@@ -726,37 +735,103 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "categories"
                 ],
-                "summary": "Update question option score",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Question ID",
-                        "name": "questionID",
+                        "description": "Category ID",
+                        "name": "categoryID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Option ID",
-                        "name": "optionID",
-                        "in": "path",
-                        "required": true
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
                     },
+                    "409": {
+                        "description": "Category still has questions",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts": {
+            "get": {
+                "description": "Returns all cohorts with their members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Get cohorts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CohortResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a cohort (tryout class) with its initial members and instructors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Create cohort",
+                "parameters": [
                     {
-                        "description": "New score value",
+                        "description": "Cohort to create",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateScoreRequest"
+                            "$ref": "#/definitions/dto.CohortRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -766,79 +841,7019 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.QuestionOptionManagementResponse"
+                                            "$ref": "#/definitions/dto.CohortResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.APIResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "type": "string",
-                    "example": "Error message"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Success message"
-                },
-                "success": {
-                    "type": "boolean",
+        },
+        "/cohorts/{cohortID}": {
+            "get": {
+                "description": "Returns a cohort with its members and exam assignments",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Get cohort",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CohortResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates the code, name and description of a cohort. Members in the body are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Update cohort",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cohort fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CohortRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CohortResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a cohort with its members and assignments. Exam sessions and results are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Delete cohort",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts/{cohortID}/assignments": {
+            "get": {
+                "description": "Returns the blueprints assigned to a cohort, most recently opened first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Get cohort assignments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CohortAssignmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Assigns a blueprint to a cohort inside an availability window. Exam sessions that members create while the window is open use the blueprint instead of the default one. The X-Actor header must name an instructor of the cohort. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Assign exam to cohort",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor making the assignment",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Blueprint and availability window",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CohortAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CohortAssignmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Actor is not an instructor of the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort or blueprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts/{cohortID}/assignments/{assignmentID}": {
+            "delete": {
+                "description": "Withdraws an exam assignment. Sessions already created for it keep their blueprint. The X-Actor header must name an instructor of the cohort. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Withdraw cohort assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor withdrawing the assignment",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Assignment ID",
+                        "name": "assignmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Actor is not an instructor of the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts/{cohortID}/dashboard": {
+            "get": {
+                "description": "Gets the exam status and results of every member of a cohort from their latest session, including members who have not started (NO_EXAM). Pass assignment_id to only consider sessions started for that assignment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get cohort dashboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sessions started for this assignment of the cohort",
+                        "name": "assignment_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cohort dashboard data retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CohortDashboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid assignment ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort or assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts/{cohortID}/members": {
+            "post": {
+                "description": "Adds users to a cohort as members or instructors. Users already in the cohort get the given role. Once the cohort has an instructor, the X-Actor header must name one of its instructors. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Add cohort members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor changing the members, required once the cohort has one",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Users and their roles",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCohortMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CohortMemberResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Actor is not an instructor of the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cohort not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cohorts/{cohortID}/members/{userID}": {
+            "delete": {
+                "description": "Removes a member or instructor from a cohort. Their exam sessions are kept. Once the cohort has an instructor, the X-Actor header must name one of its instructors. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cohorts"
+                ],
+                "summary": "Remove cohort member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor removing the member, required once the cohort has one",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cohort ID",
+                        "name": "cohortID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Actor is not an instructor of the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not in the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/stats": {
+            "get": {
+                "description": "Aggregates sessions and results over a time range: sessions by status, pass rate, average, median and standard deviation of scores, and grade distribution overall and per category, plus completions per day. Sessions count when created in range and results when completed in range. Voided sessions only appear in the status counts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get dashboard statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC3339 (default: 30 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive, RFC3339 (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Asia/Jakarta",
+                        "description": "IANA time zone of the daily series (default: UTC)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sessions of this blueprint",
+                        "name": "blueprint_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only members of this cohort",
+                        "name": "cohort_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dashboard statistics retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DashboardStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range, time zone or ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get dashboard statistics",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/users": {
+            "get": {
+                "description": "Gets the latest exam status and results of every user who has taken an exam, filtered, sorted and paginated with a cursor. Pass next_cursor of a page as cursor, with the same filters and sort, to get the next page. total_users counts every user matching the filters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get all users dashboard",
+                "parameters": [
+                    {
+                        "enum": [
+                            "NOT_STARTED",
+                            "IN_PROGRESS",
+                            "COMPLETED",
+                            "EXPIRED",
+                            "VOIDED"
+                        ],
+                        "type": "string",
+                        "description": "Status of the latest session",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only passed (true) or failed (false) candidates",
+                        "name": "passed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "B",
+                        "description": "Overall grade",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest activity at or after this RFC3339 time (completion, else start, else creation)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest activity before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only members of this cohort",
+                        "name": "cohort_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "12",
+                        "description": "User IDs starting with this prefix",
+                        "name": "user_id_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "completed_at",
+                            "score",
+                            "percentage"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default: created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Users per page (default: 0, all users)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "All users dashboard data retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserListDashboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get dashboard data",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Returns the domain events published by exam session changes, newest first, with the subscribers that handled them. Events are written in the transaction of the change and passed on to every subscriber at least once; failed dispatches are retried with exponential backoff, up to 10 attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get domain events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ExamSessionCreated",
+                            "ExamStarted",
+                            "AnswerSubmitted",
+                            "ExamCompleted",
+                            "ExamExpired"
+                        ],
+                        "type": "string",
+                        "description": "Filter by event type",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "exam_session",
+                        "description": "Filter by aggregate type",
+                        "name": "aggregate_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by aggregate ID",
+                        "name": "aggregate_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "PROCESSED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 50, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginatedDomainEventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/counts": {
+            "get": {
+                "description": "Returns how many domain events of each type occurred per day (UTC), counted by the analytics subscriber as the events are dispatched. Pending events are not counted yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get domain event counts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ExamSessionCreated",
+                            "ExamStarted",
+                            "AnswerSubmitted",
+                            "ExamCompleted",
+                            "ExamExpired"
+                        ],
+                        "type": "string",
+                        "description": "Filter by event type",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "First day included (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-31",
+                        "description": "Last day included (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.EventCountResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid day filter",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Server-sent event stream of the domain events dispatched while the client is connected, one message per event with the event ID as id, the event type as event and the event as JSON data. Only events dispatched by the server instance the client is connected to are sent, and an event may be sent twice, so clients should ignore repeated IDs. Clients falling too far behind miss events. A comment is sent every 25 seconds to keep the connection open.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream domain events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ExamStarted,ExamCompleted",
+                        "description": "Comma separated event types to send, all by default",
+                        "name": "event_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StreamedEventResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/retry": {
+            "post": {
+                "description": "Queues a failed event for immediate dispatch with a fresh set of attempts. Subscribers that already handled it are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Retry domain event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DomainEventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has not failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam-papers/answers": {
+            "post": {
+                "description": "Accepts a CSV upload (multipart field \"file\") with session_code, order_number and option_letter columns, one row per marked question. Letters follow the printed paper: A is the option with the lowest ID; an empty letter leaves the question blank. Rows are validated against the exam questions of each session and one invalid row rejects the whole file. The answers are then stored and every session is completed with the normal scoring, so paper results appear in /dashboard/users next to online ones. Completed sessions are rejected. With dry_run=true the sessions are scored and nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam-papers"
+                ],
+                "summary": "Load offline answer sheets",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV with session_code, order_number and option_letter columns",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and score without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OfflineAnswerReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unreadable CSV",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid, nothing was saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OfflineAnswerReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to load answers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam-papers/{sessionCode}": {
+            "get": {
+                "description": "Renders the questions of an exam session as PDF for offline sittings: the question paper grouped by category, the answer key with the best option and every option score, and an OMR-style answer sheet. Only the question paper and answer sheet are included by default; the answer key has to be asked for with parts=key (or parts=all) and is refused with 409 while the session is NOT_STARTED or IN_PROGRESS. Questions keep their online order numbers and options are lettered in ID order. A session code of the form SEED-\u003cn\u003e draws a paper from seed n with the default blueprint instead of reading a stored session; the same seed gives the same paper while the question bank is unchanged. The .pdf suffix of the path is optional.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "exam-papers"
+                ],
+                "summary": "Download a printable exam paper",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "EXAM_1234_1700000000.pdf",
+                        "description": "Exam session code, or SEED-\u003cn\u003e for a seed-reproducible paper",
+                        "name": "sessionCode",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "questions,key,sheet",
+                        "description": "Comma separated parts to include, questions and sheet by default, all for every part",
+                        "name": "parts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parts or seed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Answer key asked for a session that can still be taken",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to render exam paper",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}": {
+            "get": {
+                "description": "Creates a new exam session with 20 random questions (5 per category) or returns existing active session. A user booked into a sitting that has not ended gets a session of that sitting, expiring at its end. The accommodation of the user adds its extra time to the deadline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Create or get exam session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exam session ready",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExamSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create exam session",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/answer": {
+            "post": {
+                "description": "Submits user's answer for a specific question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Submit answer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answer submission",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubmitAnswerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Answer submitted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Exam session not started, completed, expired or voided",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to submit answer",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/answers": {
+            "get": {
+                "description": "Gets existing answers for user's active exam session to repopulate on page reload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Get user's existing answers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User answers retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get user answers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/complete": {
+            "post": {
+                "description": "Completes the exam session and calculates final results. Completing is idempotent: repeating the request returns the summary stored by the first one, with the Idempotent-Replayed header set, instead of scoring again. Send an Idempotency-Key header to make a retried request find the session it completed even after a new session was started. Sessions that were not started, have expired or were voided cannot be completed. A key that already completed another session, or a different key than the one a completed session was completed with, is rejected with 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Complete exam",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client generated key identifying the completion request, at most 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exam completed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Idempotent-Replayed": {
+                                "type": "string",
+                                "description": "true when the session was completed by an earlier request"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or idempotency key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Exam session not started, expired, voided or changed status meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key used for another completion",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to complete exam",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/dashboard": {
+            "get": {
+                "description": "Gets comprehensive dashboard data including exam status, progress, and results if completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Get dashboard information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dashboard data retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DashboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get dashboard data",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/detailed-answers": {
+            "get": {
+                "description": "Gets detailed answers with question text, selected options, and scores for completed exam",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Get detailed user answers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detailed answers retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "array",
+                                                "items": {
+                                                    "$ref": "#/definitions/dto.DetailedAnswer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No completed exam found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get detailed answers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/results": {
+            "get": {
+                "description": "Retrieves detailed exam results including summary, category breakdown and per-tag (sub-topic) breakdown. The summary and each category carry the rank and percentile among the latest attempts of every candidate of the same blueprint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Get exam results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exam results retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExamResultsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam results not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/results.pdf": {
+            "get": {
+                "description": "Renders the latest completed exam of a user as a one page PDF: summary, results per category, pass/fail verdict and a verification code. The code is an HMAC over the recorded result and can be checked at /verify/{code}; it stops verifying once the result is re-scored or re-graded. No report is issued when the latest session of the user has been voided.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Download the score report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam results not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Latest exam session has been voided",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to render score report",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exam/{userID}/start": {
+            "post": {
+                "description": "Starts the exam timer and changes status to IN_PROGRESS. A session booked into a sitting can only be started while the sitting is open, with its access code when it has one, and expires at the end of the sitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Start exam",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sitting access code",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StartExamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exam started successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Sitting not open, closed or wrong access code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Exam session already completed, expired or voided",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start exam",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/sessions/{sessionID}/answers": {
+            "get": {
+                "description": "Downloads one row per question of an exam session in exam order, with the selected option, its score, the maximum score and the best option of the category. Unanswered questions have empty answer columns. The file is streamed row by row.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export session answers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV or XLSX file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or session ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export answers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/summaries": {
+            "get": {
+                "description": "Downloads one row per completed session with its overall result and the score, percentage and grade of every category as columns, oldest first. Voided sessions are left out. The file is streamed row by row.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export results",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sessions completed at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only sessions completed before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only sessions of this blueprint",
+                        "name": "blueprint_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only members of this cohort",
+                        "name": "cohort_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV or XLSX file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format, time or ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to export results",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading/pass-rules": {
+            "get": {
+                "description": "Returns all pass rules with their per-category minimums",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Get pass rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PassRuleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a pass rule with an optional overall minimum percentage and/or raw score, per-category minimums and a must-pass-all-categories flag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Create pass rule",
+                "parameters": [
+                    {
+                        "description": "Pass rule to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PassRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PassRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or category minimums",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading/pass-rules/{ruleID}": {
+            "get": {
+                "description": "Returns a single pass rule with its per-category minimums",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Get pass rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pass rule ID",
+                        "name": "ruleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PassRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Pass rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading/regrade": {
+            "post": {
+                "description": "Re-applies a grading scale and pass rule to completed sessions selected by blueprint and/or session IDs. Answers, scores and percentages are not changed. The scale and rule default to those of the blueprint. Use dry_run to preview the changed verdicts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Re-grade completed sessions",
+                "parameters": [
+                    {
+                        "description": "Sessions and rule set to re-grade with",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RegradeReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Blueprint, grading scale or pass rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading/scales": {
+            "get": {
+                "description": "Returns all named grading scales with their grade bands",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Get grading scales",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.GradingScaleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a named grading scale. Bands need unique grades and one band must start at 0%.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Create grading scale",
+                "parameters": [
+                    {
+                        "description": "Grading scale to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GradingScaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.GradingScaleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or bands",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grading/scales/{scaleID}": {
+            "get": {
+                "description": "Returns a single grading scale with its grade bands",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "grading"
+                ],
+                "summary": "Get grading scale",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Grading scale ID",
+                        "name": "scaleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.GradingScaleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Grading scale not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get the health status of the application",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard": {
+            "get": {
+                "description": "Ranks the latest completed attempt of every candidate of a blueprint, sitting and/or cohort by total score. Candidates are anonymised by their position; pass user_id to flag and return your own entry. Ties share a rank. The percentile is the share of candidates scoring lower plus half of those scoring the same.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rank candidates of this blueprint",
+                        "name": "blueprint_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rank candidates of this sitting",
+                        "name": "sitting_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rank members of this cohort",
+                        "name": "cohort_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User whose own entry is returned",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 20, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaderboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "No blueprint, sitting or cohort, or invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Returns queued and sent emails, newest first. Failed sends are retried with exponential backoff, up to 6 attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "SCORE_REPORT",
+                            "SITTING_REMINDER"
+                        ],
+                        "type": "string",
+                        "description": "Filter by kind",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "SENT",
+                            "FAILED",
+                            "SKIPPED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 50, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginatedNotificationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/contacts": {
+            "get": {
+                "description": "Returns the email address, language and opt-out of every candidate that has a contact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification contacts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.NotificationContactResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/contacts/{userID}": {
+            "get": {
+                "description": "Returns the notification contact of a candidate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.NotificationContactResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Notification contact not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces the notification contact of a candidate. Candidates with a contact are emailed their score report when an exam is completed and a reminder before a booked sitting opens, in Indonesian (id) or English (en). Opting out stops every email, also those already queued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Set notification contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification contact",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.NotificationContactResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, email address or locale",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the notification contact of a candidate. Nothing is sent to the candidate afterwards, also notifications already queued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Delete notification contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification contact not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{notificationID}/retry": {
+            "post": {
+                "description": "Queues a failed email for immediate sending with a fresh set of attempts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Retry notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "notificationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.NotificationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Notification has not failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions": {
+            "get": {
+                "description": "Downloads questions in JSON format based on category and search text filters. With the format query the questions are exported for learning management systems instead: Moodle XML, GIFT, Aiken or an IMS QTI 2.1 content package (zip). Option scores become fractional credit, the percentage of the highest score of the category scheme earned by the option (penalties of negative marking categories become negative credit). Aiken keeps only the best option as its answer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "text/plain",
+                    "application/zip"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Download questions as JSON or LMS file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category code filter (see /categories)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by question text",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tag names, questions must carry all of them",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "moodle",
+                            "gift",
+                            "aiken",
+                            "qti"
+                        ],
+                        "type": "string",
+                        "description": "Export format, JSON by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ExportQuestionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/categories": {
+            "get": {
+                "description": "Returns the codes of all registered question categories in display order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get question categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/import": {
+            "post": {
+                "description": "Imports questions from a CSV, XLSX, Moodle XML, GIFT, Aiken or QTI 2.1 upload (multipart field \"file\"). Spreadsheets hold one question per row: the header row must contain category and question_text, optional tags (separated by commas or semicolons) and option_N/score_N column pairs. LMS files carry fractional credit, converted back into option scores with the scoring scheme of the category; questions without a category (Aiken, QTI without label) are filed under the category query. All questions go through the same validation as the JSON seeder; one invalid question rejects the whole import and every problem is reported with its row (or question) number. With preview=true the file is validated without saving. The format is taken from the file extension unless given in the format query.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Import questions from a spreadsheet or LMS file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV, XLSX, Moodle XML, GIFT, Aiken or QTI 2.1 file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx",
+                            "moodle",
+                            "gift",
+                            "aiken",
+                            "qti"
+                        ],
+                        "type": "string",
+                        "description": "File format, defaults to the file extension",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category for questions whose format carries none",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate without saving",
+                        "name": "preview",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unreadable file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid, nothing was imported",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/management": {
+            "get": {
+                "description": "Retrieves questions with their options, filtered by category and question text, with pagination support",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get questions by category and search text with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category code filter (see /categories)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by question text",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tag names, questions must carry all of them",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 10, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginatedQuestionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a question with options and tags, the category must be registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Create question",
+                "parameters": [
+                    {
+                        "description": "Question to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionManagementResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/management/{questionID}": {
+            "put": {
+                "description": "Updates the category and question text, the category must be registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Update question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionManagementResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes a question and hides it from future exams",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Delete question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/scores/bulk": {
+            "post": {
+                "description": "Accepts a JSON list of question/option/score rows or a CSV upload (multipart field \"file\" with question_id, option_id and score columns). Every row is validated against the scoring scheme of its category and all rows are applied atomically: one invalid row rejects the whole edit. Completed sessions that drew a changed question are re-scored once. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Bulk edit option scores",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Validate and preview without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Rows to apply (JSON)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkScoreUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV with question_id, option_id and score columns",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BulkScoreReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or CSV",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows are invalid, nothing was applied",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BulkScoreReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/tag-quotas": {
+            "get": {
+                "description": "Returns the number of questions reserved per tag inside each category when exams are generated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get tag quotas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TagQuotaResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Reserves a number of questions with the given tag inside a category during exam generation, a count of 0 removes the quota",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Set tag quota",
+                "parameters": [
+                    {
+                        "description": "Tag quota",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetTagQuotaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TagQuotaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/tags": {
+            "get": {
+                "description": "Returns list of all tags (sub-topics) that can be attached to questions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get question tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TagResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new tag (sub-topic) that can be attached to questions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Create question tag",
+                "parameters": [
+                    {
+                        "description": "Tag to create",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TagResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/option/{optionID}/score": {
+            "put": {
+                "description": "Updates the score value for a specific question option, validated against the scoring scheme of its category. Every completed session that drew the question is re-scored in the same transaction and audited. With dry_run=true nothing is saved and the re-score report previews the effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Update question option score",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option ID",
+                        "name": "optionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the re-score without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the audit log",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "New score value",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateScoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OptionScoreUpdateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or score not allowed by the scoring scheme",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question option not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/rescore": {
+            "post": {
+                "description": "Recomputes answer scores, category results and summaries of every completed session that drew the question using the current option scores. The grading scale and pass rule recorded on each session are kept. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Re-score a question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview the re-score without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who makes the change, recorded in the audit log",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RescoreReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Question not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionID}/tags": {
+            "put": {
+                "description": "Replaces all tags of a question, unknown tags are created automatically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Set question tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag names",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetQuestionTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.QuestionManagementResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Question not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/extend": {
+            "post": {
+                "description": "Moves the deadline of a NOT_STARTED or IN_PROGRESS session by the given minutes, e.g. for an accessibility accommodation. Recorded in the audit log with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Extend exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Minutes to add and reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionMinutesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SessionOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session status does not allow the override",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/force-complete": {
+            "post": {
+                "description": "Completes and scores a NOT_STARTED, IN_PROGRESS or EXPIRED session with the answers it has. Recorded in the audit log with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Force-complete exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SessionOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session status does not allow the override",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/reopen": {
+            "post": {
+                "description": "Gives an EXPIRED session the given minutes from now and resumes it IN_PROGRESS, or NOT_STARTED when it was never started. Answers are kept. Recorded in the audit log with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Reopen exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Minutes from now and reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionMinutesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SessionOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is not expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/reset": {
+            "post": {
+                "description": "Discards the answers and results of a session and puts it back to NOT_STARTED with its full duration, or the sitting deadline for a sitting session. The drawn questions are kept. Voided sessions cannot be reset. Recorded in the audit log with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Reset exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SessionOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session status does not allow the override",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/transitions": {
+            "get": {
+                "description": "Returns every status change of a session, oldest first, with what triggered it: START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an admin override.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get exam session transitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SessionTransitionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/void": {
+            "post": {
+                "description": "Marks a session VOIDED so it is excluded from leaderboards and statistics. Answers and results are kept. The user can start a new session. Recorded in the audit log with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Void exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SessionOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SessionOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exam session not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Session is already voided",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sittings": {
+            "get": {
+                "description": "Returns scheduled sittings by opening time with their booked candidates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Get sittings",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only sittings that have not ended",
+                        "name": "upcoming",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SittingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedules a sitting. Booked candidates can only start between opens_at and closes_at, and all their sessions expire at ends_at. The access code is only returned here and by the update, other sitting responses tell has_access_code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Create sitting",
+                "parameters": [
+                    {
+                        "description": "Sitting to schedule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SittingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SittingWithAccessCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Blueprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sittings/{sittingID}": {
+            "get": {
+                "description": "Returns a scheduled sitting with its booked candidates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Get sitting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitting ID",
+                        "name": "sittingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SittingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Sitting not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a sitting. Unfinished sessions of the sitting move to the new ends_at. The capacity cannot drop below the booked candidates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Update sitting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitting ID",
+                        "name": "sittingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sitting fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SittingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SittingWithAccessCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sitting or blueprint not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "More candidates booked than the new capacity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a sitting and its bookings. Sessions already created keep their deadline and can no longer be restricted by the sitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Delete sitting",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitting ID",
+                        "name": "sittingID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sitting not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sittings/{sittingID}/candidates": {
+            "post": {
+                "description": "Books users into a sitting. Users already booked are skipped. Nothing is booked when the new candidates do not fit the remaining seats. Exam sessions the candidates create afterwards belong to the sitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Book sitting candidates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitting ID",
+                        "name": "sittingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Users to book",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookSittingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SittingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sitting not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Sitting is full",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sittings/{sittingID}/candidates/{userID}": {
+            "delete": {
+                "description": "Cancels the booking of a user, freeing their seat. An exam session already created keeps the sitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sittings"
+                ],
+                "summary": "Remove sitting candidate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitting ID",
+                        "name": "sittingID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1234\"",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not booked into the sitting",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/{code}": {
+            "get": {
+                "description": "Public check of a score report verification code. A valid code returns the result as currently recorded; codes of results re-scored or re-graded after the report was issued no longer verify. Reports of sessions voided afterwards return valid false with reason voided.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exam"
+                ],
+                "summary": "Verify a score report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "R42-ABCD-EFGH-IJKL-MNOP",
+                        "description": "Verification code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report is authentic",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReportVerificationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Report could not be verified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReportVerificationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Returns every configured webhook. Secrets are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WebhookResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a webhook notified of the subscribed exam events: session.created, session.started, answer.submitted, session.completed and session.expired. Each event is POSTed as JSON {id, event, occurred_at, data} with the headers X-Webhook-Event, X-Webhook-Event-ID, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature. The signature is \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret. Any 2xx response acknowledges the event; otherwise it is retried with exponential backoff, up to 8 attempts. Events are delivered at least once, so receivers should ignore repeated event IDs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL or event",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}": {
+            "get": {
+                "description": "Returns a webhook by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a webhook. An empty secret keeps the current one. Deliveries already queued are sent with the new URL and secret; deactivating a webhook fails its pending deliveries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, URL or event",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook. Its pending deliveries fail instead of being sent; the delivery log is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/deliveries": {
+            "get": {
+                "description": "Returns the events queued for a webhook, newest first, with the status of each delivery and the response or error of every attempt. Response bodies are truncated to 1 KiB.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "DELIVERED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "Items per page (default: 50, use 0 for all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PaginatedWebhookDeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhookID}/deliveries/{deliveryID}/retry": {
+            "post": {
+                "description": "Queues a failed delivery for immediate sending with a fresh set of attempts. The payload, event ID and signature scheme are unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery has not failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string",
+                    "example": "Error message"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success message"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.AccommodationRequest": {
+            "type": "object",
+            "required": [
+                "time_multiplier"
+            ],
+            "properties": {
+                "break_minutes": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0,
+                    "example": 10
+                },
+                "extra_breaks": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 0,
+                    "example": 2
+                },
+                "large_font": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Dyslexia"
+                },
+                "time_multiplier": {
+                    "type": "number",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "example": 1.5
+                }
+            }
+        },
+        "dto.AccommodationResponse": {
+            "type": "object",
+            "properties": {
+                "break_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "extra_breaks": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "large_font": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Dyslexia"
+                },
+                "time_multiplier": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-20T09:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "CREATE",
+                        "UPDATE",
+                        "DELETE",
+                        "RESCORE",
+                        "SESSION_EXTEND",
+                        "SESSION_FORCE_COMPLETE",
+                        "SESSION_RESET",
+                        "SESSION_VOID",
+                        "SESSION_REOPEN"
+                    ],
+                    "example": "UPDATE"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "actor_claimed": {
+                    "description": "Named by the client and not verified, the server has no authentication",
+                    "type": "boolean",
+                    "example": true
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "59"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "question_options"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Power outage at the test centre"
+                },
+                "remote_addr": {
+                    "type": "string",
+                    "example": "10.0.0.12"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a9e8b7d6c5e4f3a2b1c0d9e8f7a"
+                }
+            }
+        },
+        "dto.BlueprintRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "duration_minutes",
+                "grading_scale_id",
+                "name",
+                "pass_rule_id"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "PPPK_GURU"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Ujian PPPK untuk formasi guru"
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 130
+                },
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Ujian PPPK Guru"
+                },
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.BlueprintResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DEFAULT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Blueprint default ujian PPPK"
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 130
+                },
+                "grading_scale_code": {
+                    "type": "string",
+                    "example": "DEFAULT"
+                },
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ujian PPPK"
+                },
+                "pass_rule_code": {
+                    "type": "string",
+                    "example": "DEFAULT"
+                },
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.BookSittingRequest": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "user_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1234",
+                        "5678"
+                    ]
+                }
+            }
+        },
+        "dto.BulkScoreReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "False when any row is invalid or on dry run",
+                    "type": "boolean",
+                    "example": true
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed_rows": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "rescore": {
+                    "$ref": "#/definitions/dto.RescoreReport"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkScoreRowResult"
+                    }
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "unchanged_rows": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_rows": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.BulkScoreRowResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "option_id": {
+                    "type": "integer",
+                    "example": 59
+                },
+                "previous_score": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "UPDATED",
+                        "UNCHANGED",
+                        "INVALID"
+                    ],
+                    "example": "UPDATED"
+                }
+            }
+        },
+        "dto.BulkScoreUpdateRequest": {
+            "type": "object",
+            "required": [
+                "updates"
+            ],
+            "properties": {
+                "updates": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.OptionScoreUpdateRequest"
+                    }
+                }
+            }
+        },
+        "dto.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "max_score",
+                "name",
+                "question_count"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "TEKNIS"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Kompetensi teknis sesuai jabatan yang dilamar"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_score": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 450
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Teknis"
+                },
+                "question_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 90
+                },
+                "scoring_scheme": {
+                    "type": "string",
+                    "enum": [
+                        "GRADED",
+                        "RIGHT_WRONG",
+                        "NEGATIVE_MARKING"
+                    ],
+                    "example": "GRADED"
+                }
+            }
+        },
+        "dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "TEKNIS"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Kompetensi teknis sesuai jabatan yang dilamar"
+                },
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 450
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Teknis"
+                },
+                "question_count": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "scoring_scheme": {
+                    "type": "string",
+                    "example": "GRADED"
+                }
+            }
+        },
+        "dto.CategoryScoreStats": {
+            "type": "object",
+            "properties": {
+                "average_percentage": {
+                    "type": "number",
+                    "example": 73
+                },
+                "average_score": {
+                    "type": "number",
+                    "example": 58.4
+                },
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 380
+                },
+                "grades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GradeCount"
+                    }
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 78
+                },
+                "median_score": {
+                    "type": "number",
+                    "example": 60
+                },
+                "min_score": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "pass_rate": {
+                    "description": "Percentage of results that passed",
+                    "type": "number",
+                    "example": 65
+                },
+                "passed": {
+                    "type": "integer",
+                    "example": 247
+                },
+                "std_dev_score": {
+                    "description": "Population standard deviation",
+                    "type": "number",
+                    "example": 9.7
+                }
+            }
+        },
+        "dto.CategoryStatsResponse": {
+            "type": "object",
+            "properties": {
+                "answered_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.CohortAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "available_from",
+                "blueprint_id"
+            ],
+            "properties": {
+                "available_from": {
+                    "type": "string",
+                    "example": "2026-02-01T08:00:00Z"
+                },
+                "available_until": {
+                    "type": "string",
+                    "example": "2026-02-07T17:00:00Z"
+                },
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.CohortAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_by": {
+                    "type": "string",
+                    "example": "instruktur1"
+                },
+                "available_from": {
+                    "type": "string",
+                    "example": "2026-02-01T08:00:00Z"
+                },
+                "available_until": {
+                    "type": "string",
+                    "example": "2026-02-07T17:00:00Z"
+                },
+                "blueprint_code": {
+                    "type": "string",
+                    "example": "GURU"
+                },
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "blueprint_name": {
+                    "type": "string",
+                    "example": "Ujian PPPK Guru"
+                },
+                "cohort_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "is_open": {
+                    "description": "Whether members can start it now",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.CohortDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "assignment_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "cohort_code": {
+                    "type": "string",
+                    "example": "TRYOUT-GURU-A"
+                },
+                "cohort_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cohort_name": {
+                    "type": "string",
+                    "example": "Kelas Tryout Guru A"
+                },
+                "status_counts": {
+                    "description": "Members per exam status, NO_EXAM for members who have not started",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total_members": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserDashboardSummary"
+                    }
+                }
+            }
+        },
+        "dto.CohortMemberRequest": {
+            "type": "object",
+            "required": [
+                "role",
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "MEMBER",
+                        "INSTRUCTOR"
+                    ],
+                    "example": "MEMBER"
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.CohortMemberResponse": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "MEMBER",
+                        "INSTRUCTOR"
+                    ],
+                    "example": "MEMBER"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.CohortRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "TRYOUT-GURU-A"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Kelas tryout formasi guru gelombang 1"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CohortMemberRequest"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Kelas Tryout Guru A"
+                }
+            }
+        },
+        "dto.CohortResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CohortAssignmentResponse"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "example": "TRYOUT-GURU-A"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Kelas tryout formasi guru gelombang 1"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "instructor_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CohortMemberResponse"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kelas Tryout Guru A"
+                }
+            }
+        },
+        "dto.CreateQuestionOptionRequest": {
+            "type": "object",
+            "required": [
+                "option_text",
+                "score"
+            ],
+            "properties": {
+                "option_text": {
+                    "type": "string",
+                    "example": "Menolak dengan tegas dan melaporkan kepada atasan"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.CreateQuestionRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "options",
+                "question_text"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "options": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateQuestionOptionRequest"
+                    }
+                },
+                "question_text": {
+                    "type": "string",
+                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "integritas"
+                    ]
+                }
+            }
+        },
+        "dto.CreateTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Kompetensi pedagogik guru"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "pedagogi"
+                }
+            }
+        },
+        "dto.DailyCompletion": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-01-28"
+                },
+                "passed": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
+        "dto.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "exam_results": {
+                    "$ref": "#/definitions/dto.ExamResultsResponse"
+                },
+                "exam_session": {
+                    "$ref": "#/definitions/dto.ExamSessionResponse"
+                },
+                "exam_status": {
+                    "type": "string",
+                    "enum": [
+                        "NO_EXAM",
+                        "NOT_STARTED",
+                        "IN_PROGRESS",
+                        "COMPLETED",
+                        "EXPIRED",
+                        "VOIDED"
+                    ],
+                    "example": "IN_PROGRESS"
+                },
+                "has_exam": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "progress_info": {
+                    "$ref": "#/definitions/dto.ProgressInfoResponse"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.DashboardStatsResponse": {
+            "type": "object",
+            "properties": {
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryScoreStats"
+                    }
+                },
+                "cohort_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DailyCompletion"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00+07:00"
+                },
+                "overall": {
+                    "$ref": "#/definitions/dto.ScoreStats"
+                },
+                "status_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-02-01T00:00:00+07:00"
+                },
+                "total_sessions": {
+                    "type": "integer",
+                    "example": 420
+                }
+            }
+        },
+        "dto.DetailedAnswer": {
+            "type": "object",
+            "properties": {
+                "answered_at": {
+                    "type": "string",
+                    "example": "2026-01-28T11:15:00Z"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "correct_option": {
+                    "type": "string",
+                    "example": "Menolak dengan tegas dan melaporkan kepada atasan"
+                },
+                "correct_option_id": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "correct_score": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "exam_question_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "question_text": {
+                    "type": "string",
+                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "selected_option": {
+                    "type": "string",
+                    "example": "Dalam hati tidak menyetujui hal tersebut"
+                },
+                "selected_option_id": {
+                    "type": "integer",
+                    "example": 59
+                }
+            }
+        },
+        "dto.DomainEventResponse": {
+            "type": "object",
+            "properties": {
+                "aggregate_id": {
+                    "type": "string",
+                    "example": "42"
+                },
+                "aggregate_type": {
+                    "type": "string",
+                    "example": "exam_session"
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "9f2c4e1a7b3d5f60718293a4b5c6d7e8"
+                },
+                "event_type": {
+                    "type": "string",
+                    "enum": [
+                        "ExamSessionCreated",
+                        "ExamStarted",
+                        "AnswerSubmitted",
+                        "ExamCompleted",
+                        "ExamExpired"
+                    ],
+                    "example": "ExamCompleted"
+                },
+                "handled_by": {
+                    "description": "Subscribers with a receipt",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "webhooks"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "next_attempt_at": {
+                    "description": "Only while pending",
+                    "type": "string",
+                    "example": "2026-01-28T10:00:10Z"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "processed_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:01Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "PROCESSED",
+                        "FAILED"
+                    ],
+                    "example": "PROCESSED"
+                }
+            }
+        },
+        "dto.EventCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2026-01-28"
+                },
+                "event_type": {
+                    "type": "string",
+                    "enum": [
+                        "ExamSessionCreated",
+                        "ExamStarted",
+                        "AnswerSubmitted",
+                        "ExamCompleted",
+                        "ExamExpired"
+                    ],
+                    "example": "ExamCompleted"
+                }
+            }
+        },
+        "dto.ExamResultResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "exam_session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 80
+                },
+                "percentile": {
+                    "type": "number",
+                    "example": 87.5
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_answered": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_score": {
+                    "type": "integer",
+                    "example": 16
+                }
+            }
+        },
+        "dto.ExamResultsResponse": {
+            "type": "object",
+            "properties": {
+                "results_by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamResultResponse"
+                    }
+                },
+                "results_by_tag": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamTagResultResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/dto.ExamSummaryResponse"
+                }
+            }
+        },
+        "dto.ExamSessionResponse": {
+            "type": "object",
+            "properties": {
+                "accommodation": {
+                    "$ref": "#/definitions/dto.AccommodationResponse"
+                },
+                "accommodation_minutes": {
+                    "description": "Part of duration added by the accommodation",
+                    "type": "integer",
+                    "example": 60
+                },
+                "assignment_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryStatsResponse"
+                    }
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-28T12:00:00Z"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuestionResponse"
+                    }
+                },
+                "session_code": {
+                    "type": "string",
+                    "example": "EXAM_1234_1643356800"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sitting_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "NOT_STARTED",
+                        "IN_PROGRESS",
+                        "COMPLETED",
+                        "EXPIRED",
+                        "VOIDED"
+                    ],
+                    "example": "NOT_STARTED"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.ExamSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-01-28T11:30:00Z"
+                },
+                "exam_session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "overall_grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "overall_percentage": {
+                    "type": "number",
+                    "example": 81.25
+                },
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percentile": {
+                    "description": "Share of candidates scoring lower, plus half of those scoring the same",
+                    "type": "number",
+                    "example": 93.75
+                },
+                "rank": {
+                    "description": "Among the latest attempts of every candidate of the blueprint",
+                    "type": "integer",
+                    "example": 4
+                },
+                "ranked_candidates": {
+                    "description": "Candidates ranked together with this session",
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_answered": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total_score": {
+                    "type": "integer",
+                    "example": 65
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.ExamTagResultResponse": {
+            "type": "object",
+            "properties": {
+                "max_score": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 66.67
+                },
+                "tag_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tag_name": {
+                    "type": "string",
+                    "example": "pedagogi"
+                },
+                "total_answered": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "total_score": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "dto.ExportQuestionOptionResponse": {
+            "type": "object",
+            "properties": {
+                "option_text": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExportQuestionResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExportQuestionOptionResponse"
+                    }
+                },
+                "question_text": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.GradeCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                }
+            }
+        },
+        "dto.GradingBandRequest": {
+            "type": "object",
+            "required": [
+                "grade",
+                "min_percentage"
+            ],
+            "properties": {
+                "grade": {
+                    "type": "string",
+                    "maxLength": 5,
+                    "example": "A"
+                },
+                "min_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 95
+                }
+            }
+        },
+        "dto.GradingBandResponse": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "min_percentage": {
+                    "type": "number",
+                    "example": 90
+                }
+            }
+        },
+        "dto.GradingScaleRequest": {
+            "type": "object",
+            "required": [
+                "bands",
+                "code",
+                "name"
+            ],
+            "properties": {
+                "bands": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.GradingBandRequest"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "STRICT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "95%=A, 85%=B, 75%=C, \u003c75%=E"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Skala Ketat"
+                }
+            }
+        },
+        "dto.GradingScaleResponse": {
+            "type": "object",
+            "properties": {
+                "bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GradingBandResponse"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "example": "DEFAULT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "100%=A, 90%=B, 80%=C, 70%=D, \u003c70%=E"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Skala Nilai PPPK"
+                }
+            }
+        },
+        "dto.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "score_2"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid option score: graded options must score between 1 and 4, got 5"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-01-28T11:30:00Z"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "Candidate #4"
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_you": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 450
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 91.56
+                },
+                "percentile": {
+                    "type": "number",
+                    "example": 97.08
+                },
+                "rank": {
+                    "description": "Candidates with the same total score share a rank",
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_score": {
+                    "type": "integer",
+                    "example": 412
+                }
+            }
+        },
+        "dto.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "cohort_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                },
+                "sitting_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_candidates": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "you": {
+                    "description": "Entry of the user_id query parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.LeaderboardEntry"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.NotificationContactRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "candidate@example.com"
+                },
+                "locale": {
+                    "description": "Defaults to id",
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "id"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Siti Rahayu"
+                },
+                "opted_out": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.NotificationContactResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "candidate@example.com"
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "id"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Siti Rahayu"
+                },
+                "opted_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Yth. Siti Rahayu, ..."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "SCORE_REPORT",
+                        "SITTING_REMINDER"
+                    ],
+                    "example": "SCORE_REPORT"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "id"
+                },
+                "next_attempt_at": {
+                    "description": "Only while pending",
+                    "type": "string",
+                    "example": "2026-01-28T10:01:00Z"
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "candidate@example.com"
+                },
+                "sent_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:02Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SENT",
+                        "FAILED",
+                        "SKIPPED"
+                    ],
+                    "example": "SENT"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Hasil Ujian EXAM_1234_1769594400: LULUS"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.OfflineAnswerReport": {
+            "type": "object",
+            "properties": {
+                "answered_rows": {
+                    "type": "integer",
+                    "example": 236
+                },
+                "blank_rows": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowError"
+                    }
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OfflineSessionResult"
+                    }
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 240
+                }
+            }
+        },
+        "dto.OfflineSessionResult": {
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "overall_grade": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "overall_percentage": {
+                    "type": "number",
+                    "example": 85.42
+                },
+                "session_code": {
+                    "type": "string",
+                    "example": "EXAM_1234_1700000000"
+                },
+                "total_score": {
+                    "type": "integer",
+                    "example": 410
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.OptionScoreUpdateRequest": {
+            "type": "object",
+            "required": [
+                "option_id",
+                "question_id",
+                "score"
+            ],
+            "properties": {
+                "option_id": {
+                    "type": "integer",
+                    "example": 59
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.OptionScoreUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "option": {
+                    "$ref": "#/definitions/dto.QuestionOptionManagementResponse"
+                },
+                "rescore": {
+                    "$ref": "#/definitions/dto.RescoreReport"
+                }
+            }
+        },
+        "dto.PaginatedAuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "audit_logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditLogResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                }
+            }
+        },
+        "dto.PaginatedDomainEventResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DomainEventResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                }
+            }
+        },
+        "dto.PaginatedNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NotificationResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                }
+            }
+        },
+        "dto.PaginatedQuestionResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuestionManagementResponse"
+                    }
+                }
+            }
+        },
+        "dto.PaginatedWebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.PaginationMetadata"
+                }
+            }
+        },
+        "dto.PaginationMetadata": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items_per_page": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_items": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.PassRuleCategoryMinimumRequest": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "TEKNIS"
+                },
+                "min_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 90
+                },
+                "min_score": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 300
+                }
+            }
+        },
+        "dto.PassRuleCategoryMinimumResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "TEKNIS"
+                },
+                "min_percentage": {
+                    "type": "number",
+                    "example": 90
+                },
+                "min_score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PassRuleRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "category_minimums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PassRuleCategoryMinimumRequest"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "STRICT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Harus lulus semua kategori"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Kelulusan Ketat"
+                },
+                "overall_min_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 90
+                },
+                "overall_min_score": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "require_all_categories": {
+                    "type": "boolean",
                     "example": true
                 }
             }
         },
-        "dto.CategoryStatsResponse": {
+        "dto.PassRuleResponse": {
+            "type": "object",
+            "properties": {
+                "category_minimums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PassRuleCategoryMinimumResponse"
+                    }
+                },
+                "code": {
+                    "type": "string",
+                    "example": "DEFAULT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Minimal 90% keseluruhan dan 90% per kategori"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kelulusan PPPK"
+                },
+                "overall_min_percentage": {
+                    "type": "number",
+                    "example": 90
+                },
+                "overall_min_score": {
+                    "type": "integer"
+                },
+                "require_all_categories": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.ProgressInfoResponse": {
+            "type": "object",
+            "properties": {
+                "answered_questions": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "remaining_time_minutes": {
+                    "type": "integer",
+                    "example": 95
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "dto.QuestionImportReport": {
+            "type": "object",
+            "properties": {
+                "by_category": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "preview": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "dto.QuestionManagementResponse": {
             "type": "object",
             "properties": {
-                "answered_count": {
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "id": {
                     "type": "integer",
-                    "example": 0
+                    "example": 1
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuestionOptionManagementResponse"
+                    }
+                },
+                "question_text": {
+                    "type": "string",
+                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "pedagogi",
+                        "regulasi"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                }
+            }
+        },
+        "dto.QuestionOptionManagementResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "option_text": {
+                    "type": "string",
+                    "example": "Dalam hati tidak menyetujui hal tersebut"
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                }
+            }
+        },
+        "dto.QuestionOptionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 59
                 },
+                "option_text": {
+                    "type": "string",
+                    "example": "Dalam hati tidak menyetujui hal tersebut"
+                }
+            }
+        },
+        "dto.QuestionResponse": {
+            "type": "object",
+            "properties": {
                 "category": {
                     "type": "string",
                     "example": "MANAJERIAL"
                 },
-                "total_questions": {
+                "exam_question_id": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.QuestionOptionResponse"
+                    }
+                },
+                "order_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "question_text": {
+                    "type": "string",
+                    "example": "Atasan Anda melakukan rekayasa laporan..."
                 }
             }
         },
-        "dto.DashboardResponse": {
+        "dto.RegradeReport": {
             "type": "object",
             "properties": {
-                "exam_results": {
-                    "$ref": "#/definitions/dto.ExamResultsResponse"
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "exam_session": {
-                    "$ref": "#/definitions/dto.ExamSessionResponse"
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "exam_status": {
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RegradedSessionEntry"
+                    }
+                },
+                "sessions_regraded": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "verdicts_changed": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dto.RegradeRequest": {
+            "type": "object",
+            "properties": {
+                "blueprint_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "grading_scale_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pass_rule_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "session_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "dto.RegradedSessionEntry": {
+            "type": "object",
+            "properties": {
+                "exam_session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "grade": {
                     "type": "string",
-                    "enum": [
-                        "NO_EXAM",
-                        "NOT_STARTED",
-                        "IN_PROGRESS",
-                        "COMPLETED",
-                        "EXPIRED"
-                    ],
-                    "example": "IN_PROGRESS"
+                    "example": "B"
                 },
-                "has_exam": {
+                "is_passed": {
                     "type": "boolean",
                     "example": true
                 },
-                "progress_info": {
-                    "$ref": "#/definitions/dto.ProgressInfoResponse"
+                "percentage": {
+                    "type": "number",
+                    "example": 88.5
+                },
+                "previous_grade": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "previous_passed": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "user_id": {
                     "type": "string",
@@ -846,162 +7861,274 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.DetailedAnswer": {
+        "dto.ReportVerificationResponse": {
             "type": "object",
             "properties": {
-                "answered_at": {
+                "code": {
                     "type": "string",
-                    "example": "2026-01-28T11:15:00Z"
+                    "example": "R42-ABCD-EFGH-IJKL-MNOP"
                 },
-                "category": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "overall_grade": {
                     "type": "string",
-                    "example": "MANAJERIAL"
+                    "example": "C"
                 },
-                "correct_option": {
+                "overall_percentage": {
+                    "type": "number",
+                    "example": 85.42
+                },
+                "reason": {
+                    "description": "Why an authentic report no longer verifies",
                     "type": "string",
-                    "example": "Menolak dengan tegas dan melaporkan kepada atasan"
+                    "example": "voided"
                 },
-                "correct_option_id": {
+                "session_code": {
+                    "type": "string",
+                    "example": "EXAM_1234_1700000000"
+                },
+                "total_score": {
                     "type": "integer",
-                    "example": 60
+                    "example": 410
                 },
-                "correct_score": {
+                "user_id": {
+                    "type": "string",
+                    "example": "1234"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.RescoreReport": {
+            "type": "object",
+            "properties": {
+                "answers_changed": {
                     "type": "integer",
-                    "example": 4
+                    "example": 40
                 },
-                "exam_question_id": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "question_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        15
+                    ]
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RescoredSessionEntry"
+                    }
+                },
+                "sessions_rescored": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "verdicts_changed": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.RescoredSessionEntry": {
+            "type": "object",
+            "properties": {
+                "answers_changed": {
                     "type": "integer",
                     "example": 1
                 },
-                "is_correct": {
+                "exam_session_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "is_passed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 89.13
+                },
+                "previous_grade": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "previous_passed": {
                     "type": "boolean",
                     "example": false
                 },
-                "max_score": {
-                    "type": "integer",
-                    "example": 4
+                "previous_percentage": {
+                    "type": "number",
+                    "example": 88.4
                 },
-                "question_id": {
+                "previous_score": {
                     "type": "integer",
-                    "example": 15
-                },
-                "question_text": {
-                    "type": "string",
-                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                    "example": 610
                 },
                 "score": {
                     "type": "integer",
-                    "example": 3
+                    "example": 615
                 },
-                "selected_option": {
+                "user_id": {
                     "type": "string",
-                    "example": "Dalam hati tidak menyetujui hal tersebut"
-                },
-                "selected_option_id": {
-                    "type": "integer",
-                    "example": 59
+                    "example": "1234"
                 }
             }
         },
-        "dto.ExamResultResponse": {
+        "dto.ScoreStats": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string",
-                    "example": "MANAJERIAL"
-                },
-                "exam_session_id": {
-                    "type": "integer",
-                    "example": 1
+                "average_percentage": {
+                    "type": "number",
+                    "example": 73
                 },
-                "grade": {
-                    "type": "string",
-                    "example": "B"
+                "average_score": {
+                    "type": "number",
+                    "example": 58.4
                 },
-                "id": {
+                "count": {
                     "type": "integer",
-                    "example": 1
+                    "example": 380
                 },
-                "is_passed": {
-                    "type": "boolean",
-                    "example": true
+                "grades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GradeCount"
+                    }
                 },
                 "max_score": {
                     "type": "integer",
-                    "example": 20
+                    "example": 78
                 },
-                "percentage": {
+                "median_score": {
                     "type": "number",
-                    "example": 80
+                    "example": 60
                 },
-                "total_answered": {
+                "min_score": {
                     "type": "integer",
-                    "example": 5
+                    "example": 21
                 },
-                "total_questions": {
-                    "type": "integer",
-                    "example": 5
+                "pass_rate": {
+                    "description": "Percentage of results that passed",
+                    "type": "number",
+                    "example": 65
                 },
-                "total_score": {
+                "passed": {
                     "type": "integer",
-                    "example": 16
+                    "example": 247
+                },
+                "std_dev_score": {
+                    "description": "Population standard deviation",
+                    "type": "number",
+                    "example": 9.7
                 }
             }
         },
-        "dto.ExamResultsResponse": {
+        "dto.SessionMinutesRequest": {
             "type": "object",
+            "required": [
+                "minutes",
+                "reason"
+            ],
             "properties": {
-                "results_by_category": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ExamResultResponse"
-                    }
+                "minutes": {
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 1,
+                    "example": 30
                 },
-                "summary": {
-                    "$ref": "#/definitions/dto.ExamSummaryResponse"
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Extra time for visual impairment"
                 }
             }
         },
-        "dto.ExamSessionResponse": {
+        "dto.SessionOverrideRequest": {
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "category_stats": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CategoryStatsResponse"
-                    }
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Candidate disputed a power outage during the exam"
+                }
+            }
+        },
+        "dto.SessionOverrideResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "SESSION_EXTEND",
+                        "SESSION_FORCE_COMPLETE",
+                        "SESSION_RESET",
+                        "SESSION_VOID",
+                        "SESSION_REOPEN"
+                    ],
+                    "example": "SESSION_EXTEND"
+                },
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-01-28T11:30:00Z"
                 },
                 "duration": {
                     "type": "integer",
-                    "example": 120
+                    "example": 160
                 },
                 "expires_at": {
                     "type": "string",
-                    "example": "2026-01-28T12:00:00Z"
+                    "example": "2026-01-28T12:30:00Z"
                 },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.QuestionResponse"
-                    }
+                "previous_status": {
+                    "type": "string",
+                    "example": "IN_PROGRESS"
                 },
-                "session_code": {
+                "reason": {
                     "type": "string",
-                    "example": "EXAM_1234_1643356800"
+                    "example": "Extra time for visual impairment"
                 },
                 "session_id": {
                     "type": "integer",
                     "example": 1
                 },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
                 "status": {
                     "type": "string",
                     "enum": [
                         "NOT_STARTED",
                         "IN_PROGRESS",
                         "COMPLETED",
-                        "EXPIRED"
+                        "EXPIRED",
+                        "VOIDED"
                     ],
-                    "example": "NOT_STARTED"
+                    "example": "IN_PROGRESS"
                 },
                 "user_id": {
                     "type": "string",
@@ -1009,245 +8136,348 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ExamSummaryResponse": {
+        "dto.SessionTransitionResponse": {
             "type": "object",
             "properties": {
-                "completed_at": {
+                "actor": {
                     "type": "string",
-                    "example": "2026-01-28T11:30:00Z"
+                    "example": "system"
                 },
-                "exam_session_id": {
-                    "type": "integer",
-                    "example": 1
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "from_status": {
+                    "type": "string",
+                    "enum": [
+                        "NOT_STARTED",
+                        "IN_PROGRESS",
+                        "COMPLETED",
+                        "EXPIRED",
+                        "VOIDED"
+                    ],
+                    "example": "NOT_STARTED"
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "is_passed": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "max_score": {
-                    "type": "integer",
-                    "example": 80
-                },
-                "overall_grade": {
+                "request_id": {
                     "type": "string",
-                    "example": "B"
-                },
-                "overall_percentage": {
-                    "type": "number",
-                    "example": 81.25
-                },
-                "total_answered": {
-                    "type": "integer",
-                    "example": 18
-                },
-                "total_questions": {
-                    "type": "integer",
-                    "example": 20
+                    "example": "7f3c2a9e-1b4d-4e8a-9c6f-2d5b8a1e0f34"
                 },
-                "total_score": {
-                    "type": "integer",
-                    "example": 65
+                "to_status": {
+                    "type": "string",
+                    "enum": [
+                        "NOT_STARTED",
+                        "IN_PROGRESS",
+                        "COMPLETED",
+                        "EXPIRED",
+                        "VOIDED"
+                    ],
+                    "example": "IN_PROGRESS"
                 },
-                "user_id": {
+                "trigger": {
+                    "description": "START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an admin override",
                     "type": "string",
-                    "example": "1234"
+                    "example": "START"
                 }
             }
         },
-        "dto.ExportQuestionOptionResponse": {
+        "dto.SetCohortMembersRequest": {
             "type": "object",
+            "required": [
+                "members"
+            ],
             "properties": {
-                "option_text": {
-                    "type": "string"
-                },
-                "score": {
-                    "type": "integer"
+                "members": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CohortMemberRequest"
+                    }
                 }
             }
         },
-        "dto.ExportQuestionResponse": {
+        "dto.SetQuestionTagsRequest": {
             "type": "object",
+            "required": [
+                "tags"
+            ],
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "options": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ExportQuestionOptionResponse"
-                    }
-                },
-                "question_text": {
-                    "type": "string"
+                        "type": "string"
+                    },
+                    "example": [
+                        "pedagogi",
+                        "regulasi"
+                    ]
                 }
             }
         },
-        "dto.PaginatedQuestionResponse": {
+        "dto.SetTagQuotaRequest": {
             "type": "object",
+            "required": [
+                "category",
+                "question_count",
+                "tag"
+            ],
             "properties": {
-                "pagination": {
-                    "$ref": "#/definitions/dto.PaginationMetadata"
+                "category": {
+                    "type": "string",
+                    "example": "TEKNIS"
                 },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.QuestionManagementResponse"
-                    }
+                "question_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 30
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "pedagogi"
                 }
             }
         },
-        "dto.PaginationMetadata": {
+        "dto.SittingRequest": {
             "type": "object",
+            "required": [
+                "capacity",
+                "closes_at",
+                "code",
+                "ends_at",
+                "name",
+                "opens_at"
+            ],
             "properties": {
-                "current_page": {
-                    "type": "integer",
-                    "example": 1
+                "access_code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "K7P2Q9"
                 },
-                "items_per_page": {
+                "blueprint_id": {
                     "type": "integer",
-                    "example": 10
+                    "example": 2
                 },
-                "total_items": {
+                "capacity": {
                     "type": "integer",
-                    "example": 50
+                    "minimum": 1,
+                    "example": 40
                 },
-                "total_pages": {
-                    "type": "integer",
-                    "example": 5
+                "closes_at": {
+                    "type": "string",
+                    "example": "2026-02-01T08:30:00+07:00"
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "JKT-2026-02-01-A"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-02-01T10:10:00+07:00"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Gedung A Lt. 3, Jakarta"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "example": "Sesi Pagi Jakarta"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2026-02-01T08:00:00+07:00"
                 }
             }
         },
-        "dto.ProgressInfoResponse": {
+        "dto.SittingResponse": {
             "type": "object",
             "properties": {
-                "answered_questions": {
+                "blueprint_code": {
+                    "type": "string",
+                    "example": "GURU"
+                },
+                "blueprint_id": {
                     "type": "integer",
-                    "example": 8
+                    "example": 2
                 },
-                "remaining_time_minutes": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capacity": {
                     "type": "integer",
-                    "example": 95
+                    "example": 40
                 },
-                "total_questions": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "2026-02-01T08:30:00+07:00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "JKT-2026-02-01-A"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-02-01T10:10:00+07:00"
+                },
+                "has_access_code": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Gedung A Lt. 3, Jakarta"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sesi Pagi Jakarta"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2026-02-01T08:00:00+07:00"
+                },
+                "seats_left": {
                     "type": "integer",
-                    "example": 20
+                    "example": 12
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "SCHEDULED",
+                        "OPEN",
+                        "CLOSED",
+                        "ENDED"
+                    ],
+                    "example": "OPEN"
                 }
             }
         },
-        "dto.QuestionManagementResponse": {
+        "dto.SittingWithAccessCodeResponse": {
             "type": "object",
             "properties": {
-                "category": {
+                "access_code": {
                     "type": "string",
-                    "example": "MANAJERIAL"
+                    "example": "K7P2Q9"
                 },
-                "created_at": {
+                "blueprint_code": {
                     "type": "string",
-                    "example": "2026-01-28T10:00:00Z"
+                    "example": "GURU"
                 },
-                "id": {
+                "blueprint_id": {
                     "type": "integer",
-                    "example": 1
+                    "example": 2
                 },
-                "options": {
+                "candidates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.QuestionOptionManagementResponse"
+                        "type": "string"
                     }
                 },
-                "question_text": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "closes_at": {
                     "type": "string",
-                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                    "example": "2026-02-01T08:30:00+07:00"
                 },
-                "updated_at": {
+                "code": {
                     "type": "string",
-                    "example": "2026-01-28T10:00:00Z"
-                }
-            }
-        },
-        "dto.QuestionOptionManagementResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                    "example": "JKT-2026-02-01-A"
+                },
+                "ends_at": {
                     "type": "string",
-                    "example": "2026-01-28T10:00:00Z"
+                    "example": "2026-02-01T10:10:00+07:00"
+                },
+                "has_access_code": {
+                    "type": "boolean",
+                    "example": true
                 },
                 "id": {
                     "type": "integer",
-                    "example": 1
+                    "example": 5
                 },
-                "option_text": {
+                "location": {
                     "type": "string",
-                    "example": "Dalam hati tidak menyetujui hal tersebut"
+                    "example": "Gedung A Lt. 3, Jakarta"
                 },
-                "question_id": {
-                    "type": "integer",
-                    "example": 1
+                "name": {
+                    "type": "string",
+                    "example": "Sesi Pagi Jakarta"
                 },
-                "score": {
+                "opens_at": {
+                    "type": "string",
+                    "example": "2026-02-01T08:00:00+07:00"
+                },
+                "seats_left": {
                     "type": "integer",
-                    "example": 3
+                    "example": 12
                 },
-                "updated_at": {
+                "status": {
                     "type": "string",
-                    "example": "2026-01-28T10:00:00Z"
+                    "enum": [
+                        "SCHEDULED",
+                        "OPEN",
+                        "CLOSED",
+                        "ENDED"
+                    ],
+                    "example": "OPEN"
                 }
             }
         },
-        "dto.QuestionOptionResponse": {
+        "dto.StartExamRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 59
-                },
-                "option_text": {
+                "access_code": {
+                    "description": "Only needed for sittings with an access code",
                     "type": "string",
-                    "example": "Dalam hati tidak menyetujui hal tersebut"
+                    "example": "K7P2Q9"
                 }
             }
         },
-        "dto.QuestionResponse": {
+        "dto.StreamedEventResponse": {
             "type": "object",
             "properties": {
-                "category": {
+                "aggregate_id": {
                     "type": "string",
-                    "enum": [
-                        "MANAJERIAL",
-                        "SOSIAL KULTURAL",
-                        "TEKNIS",
-                        "WAWANCARA"
-                    ],
-                    "example": "MANAJERIAL"
+                    "example": "42"
                 },
-                "exam_question_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.QuestionOptionResponse"
-                    }
+                "aggregate_type": {
+                    "type": "string",
+                    "example": "exam_session"
                 },
-                "order_number": {
-                    "type": "integer",
-                    "example": 1
+                "event_id": {
+                    "type": "string",
+                    "example": "9f2c4e1a7b3d5f60718293a4b5c6d7e8"
                 },
-                "question_id": {
-                    "type": "integer",
-                    "example": 15
+                "event_type": {
+                    "type": "string",
+                    "enum": [
+                        "ExamSessionCreated",
+                        "ExamStarted",
+                        "AnswerSubmitted",
+                        "ExamCompleted",
+                        "ExamExpired"
+                    ],
+                    "example": "ExamCompleted"
                 },
-                "question_text": {
+                "occurred_at": {
                     "type": "string",
-                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "payload": {
+                    "type": "object"
                 }
             }
         },
@@ -1268,6 +8498,61 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TagQuotaResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "TEKNIS"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "question_count": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "pedagogi"
+                }
+            }
+        },
+        "dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Kompetensi pedagogik guru"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "pedagogi"
+                }
+            }
+        },
+        "dto.UpdateQuestionRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "question_text"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "MANAJERIAL"
+                },
+                "question_text": {
+                    "type": "string",
+                    "example": "Atasan Anda melakukan rekayasa laporan..."
+                }
+            }
+        },
         "dto.UpdateScoreRequest": {
             "type": "object",
             "required": [
@@ -1275,17 +8560,18 @@ const docTemplate = `{
             ],
             "properties": {
                 "score": {
-                    "description": "Score int ` + "`" + `json:\"score\" binding:\"required,min=0,max=10\" example:\"5\"` + "`" + `",
                     "type": "integer",
-                    "maximum": 10,
-                    "minimum": 0,
-                    "example": 5
+                    "example": 4
                 }
             }
         },
         "dto.UserDashboardSummary": {
             "type": "object",
             "properties": {
+                "accommodation_minutes": {
+                    "type": "integer",
+                    "example": 80
+                },
                 "completed_at": {
                     "type": "string",
                     "example": "2026-01-28T11:30:00Z"
@@ -1297,10 +8583,15 @@ const docTemplate = `{
                         "NOT_STARTED",
                         "IN_PROGRESS",
                         "COMPLETED",
-                        "EXPIRED"
+                        "EXPIRED",
+                        "VOIDED"
                     ],
                     "example": "COMPLETED"
                 },
+                "extra_breaks": {
+                    "type": "integer",
+                    "example": 2
+                },
                 "grade": {
                     "type": "string",
                     "example": "B"
@@ -1309,6 +8600,10 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "large_font": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "max_score": {
                     "type": "integer",
                     "example": 16
@@ -1325,6 +8620,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2026-01-28T10:00:00Z"
                 },
+                "time_multiplier": {
+                    "description": "Accommodation of the candidate, omitted when it has none",
+                    "type": "number",
+                    "example": 1.5
+                },
                 "total_score": {
                     "type": "integer",
                     "example": 12
@@ -1338,7 +8638,13 @@ const docTemplate = `{
         "dto.UserListDashboardResponse": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "description": "Cursor of the next page, omitted on the last page",
+                    "type": "string",
+                    "example": "eyJzIjoic2NvcmUiLCJkIjp0cnVlLCJrIjoiMTIiLCJpIjo0Mn0"
+                },
                 "total_users": {
+                    "description": "Users matching the filters, on all pages",
                     "type": "integer",
                     "example": 25
                 },
@@ -1349,6 +8655,188 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "dto.WebhookDeliveryAttemptResponse": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "response_body": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "response_status": {
+                    "description": "Null when no response was received",
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempt_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryAttemptResponse"
+                    }
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "event": {
+                    "type": "string",
+                    "enum": [
+                        "session.created",
+                        "session.started",
+                        "answer.submitted",
+                        "session.completed",
+                        "session.expired"
+                    ],
+                    "example": "session.completed"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "9f2c4e1a7b3d5f60718293a4b5c6d7e8"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_attempt_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "next_attempt_at": {
+                    "description": "Only while pending",
+                    "type": "string",
+                    "example": "2026-01-28T10:00:30Z"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "DELIVERED",
+                        "FAILED"
+                    ],
+                    "example": "DELIVERED"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.WebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "session.started",
+                        "session.completed"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Proctoring system"
+                },
+                "secret": {
+                    "description": "Kept when empty on update",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "s3cr3t-shared-with-receiver"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://proctor.example.com/hooks/pppk"
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "session.started",
+                        "session.completed"
+                    ]
+                },
+                "has_secret": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Proctoring system"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-28T10:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://proctor.example.com/hooks/pppk"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -3,6 +3,7 @@ module cutbray/pppk-json
 go 1.24.4
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/fatih/color v1.18.0
 	github.com/gin-gonic/gin v1.11.0
 	github.com/joho/godotenv v1.5.1
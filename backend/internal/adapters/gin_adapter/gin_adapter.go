@@ -2,6 +2,7 @@ package gin_adapter
 
 import (
 	"context"
+	"cutbray/pppk-json/internal/audit"
 	"cutbray/pppk-json/internal/ports"
 	"log"
 	"net"
@@ -44,6 +45,7 @@ func New(config GinConfig) *ginAdapter {
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 	engine.Use(corsMiddleware())
+	engine.Use(audit.Middleware())
 
 	adapter := &ginAdapter{
 		engine: engine,
@@ -140,8 +142,8 @@ func corsMiddleware() gin.HandlerFunc {
 		// Set CORS headers
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, Origin, X-Requested-With, X-HTTP-Method-Override, Accept-Language, Accept-Encoding")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Cache-Control, Content-Language, Content-Type")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, Origin, X-Requested-With, X-HTTP-Method-Override, Accept-Language, Accept-Encoding, X-Actor, X-Request-ID, Idempotency-Key")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Cache-Control, Content-Language, Content-Type, X-Request-ID, Idempotent-Replayed")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400") // 24 hours
 
@@ -0,0 +1,246 @@
+package smtp_adapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"cutbray/pppk-json/internal/ports"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	_ ports.AdapterPort = &smtpAdapter{}
+	_ ports.MailerPort  = &smtpAdapter{}
+)
+
+// TLS modes of the SMTP connection
+const (
+	TLSStartTLS = "starttls" // Upgrade a plain connection, usually port 587
+	TLSImplicit = "tls"      // TLS from the start, usually port 465
+	TLSNone     = "none"     // Plain text, only for local sinks
+)
+
+// plainAuthHosts are the hosts net/smtp sends credentials to without TLS
+var plainAuthHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+type smtpAdapter struct {
+	config SMTPConfig
+	from   *mail.Address
+	ready  bool
+}
+
+// SMTPConfig holds configuration for the SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // No authentication when empty; with TLS none only allowed for localhost
+	Password string
+	From     string // e.g. "PPPK Exam <no-reply@example.com>"
+	TLS      string // starttls, tls or none
+	Timeout  time.Duration
+}
+
+func New(config SMTPConfig) *smtpAdapter {
+	if config.Port == "" {
+		config.Port = "587"
+	}
+
+	if config.TLS == "" {
+		config.TLS = TLSStartTLS
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &smtpAdapter{
+		config: config,
+	}
+}
+
+// Connect checks the sender address and that the server accepts a session
+func (s *smtpAdapter) Connect(ctx context.Context) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	s.from = from
+
+	switch s.config.TLS {
+	case TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return fmt.Errorf("unknown TLS mode %q, use starttls, tls or none", s.config.TLS)
+	}
+
+	// smtp.PlainAuth refuses to send credentials in plain text to other hosts, which would
+	// otherwise only show up as a failure of every message
+	if s.config.TLS == TLSNone && s.config.Username != "" && !slices.Contains(plainAuthHosts, s.config.Host) {
+		return fmt.Errorf("SMTP authentication needs TLS when the host %q is not localhost, use starttls or tls", s.config.Host)
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("failed to end SMTP session: %w", err)
+	}
+
+	s.ready = true
+	return nil
+}
+
+// Disconnect has nothing to release, every message uses its own connection
+func (s *smtpAdapter) Disconnect(ctx context.Context) error {
+	s.ready = false
+	return nil
+}
+
+func (s *smtpAdapter) IsReady() bool {
+	return s.ready
+}
+
+func (s *smtpAdapter) Value() any {
+	return s
+}
+
+// SendMail sends a plain text UTF-8 email
+func (s *smtpAdapter) SendMail(ctx context.Context, message ports.MailMessage) error {
+	if s.from == nil {
+		return fmt.Errorf("SMTP adapter is not connected")
+	}
+
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	data, err := s.compose(to, message)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("recipient rejected: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial opens an authenticated SMTP session, bounded by the context and the timeout
+func (s *smtpAdapter) dial(ctx context.Context) (*smtp.Client, error) {
+	address := net.JoinHostPort(s.config.Host, s.config.Port)
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: s.config.Host}
+
+	var conn net.Conn
+	var err error
+	if s.config.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	deadline := time.Now().Add(s.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+
+	if s.config.TLS == TLSStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// compose builds the message with encoded headers and a quoted-printable body
+func (s *smtpAdapter) compose(to *mail.Address, message ports.MailMessage) ([]byte, error) {
+	var b bytes.Buffer
+
+	domain := s.from.Address[strings.LastIndex(s.from.Address, "@")+1:]
+	headers := []struct{ name, value string }{
+		{"From", s.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", randomID(), domain)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", header.name, header.value)
+	}
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n")
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// randomID returns a random hex string for message IDs
+func randomID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
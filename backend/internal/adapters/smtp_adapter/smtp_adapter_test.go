@@ -0,0 +1,131 @@
+package smtp_adapter
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/adapters/smtp_adapter/smtptest"
+	"cutbray/pppk-json/internal/ports"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSink(t *testing.T) *smtptest.Server {
+	t.Helper()
+	server, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start SMTP sink: %v", err)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConnect(t *testing.T) {
+	sink := newSink(t)
+
+	tests := []struct {
+		name     string
+		host     string
+		username string
+		tls      string
+		wantErr  string
+	}{
+		{name: "no authentication", host: sink.Host, tls: TLSNone},
+		{name: "plain authentication on loopback", host: sink.Host, username: "mailer", tls: TLSNone},
+		{name: "plain authentication on a remote host", host: "smtp.example.com", username: "mailer", tls: TLSNone, wantErr: "needs TLS"},
+		{name: "unknown TLS mode", host: sink.Host, tls: "ssl", wantErr: "unknown TLS mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := New(SMTPConfig{
+				Host:     tt.host,
+				Port:     sink.Port,
+				Username: tt.username,
+				Password: "secret",
+				From:     "PPPK Exam <no-reply@example.com>",
+				TLS:      tt.tls,
+				Timeout:  5 * time.Second,
+			})
+
+			err := adapter.Connect(context.Background())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Connect() error = %v", err)
+				}
+				if !adapter.IsReady() {
+					t.Fatal("adapter is not ready after connecting")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Connect() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendMail(t *testing.T) {
+	sink := newSink(t)
+	adapter := New(SMTPConfig{Host: sink.Host, Port: sink.Port, From: "PPPK Exam <no-reply@example.com>", TLS: TLSNone})
+	if err := adapter.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	body := "Yth. Budi,\n\nNilai total: 410 dari 480 (85,4%)\nPredikat: Sangat Baik — selamat!\n"
+	err := adapter.SendMail(context.Background(), ports.MailMessage{
+		To:      "Budi Santoso <budi@example.com>",
+		Subject: "Hasil Ujian EXAM_1234: LULUS ✓",
+		Body:    body,
+	})
+	if err != nil {
+		t.Fatalf("SendMail() error = %v", err)
+	}
+
+	messages := sink.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("sink received %d messages, want 1", len(messages))
+	}
+	if messages[0].From != "no-reply@example.com" || len(messages[0].To) != 1 || messages[0].To[0] != "budi@example.com" {
+		t.Fatalf("envelope = %s -> %v, want no-reply@example.com -> [budi@example.com]", messages[0].From, messages[0].To)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+	if err != nil {
+		t.Fatalf("failed to parse sent message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Hasil Ujian EXAM_1234: LULUS ✓" {
+		t.Fatalf("subject = %q (%v), want the UTF-8 subject", subject, err)
+	}
+	if got := parsed.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Fatalf("content type = %q", got)
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if want := strings.ReplaceAll(body, "\n", "\r\n"); string(decoded) != want {
+		t.Fatalf("body = %q, want %q", decoded, want)
+	}
+}
+
+func TestSendMailRejected(t *testing.T) {
+	sink := newSink(t)
+	adapter := New(SMTPConfig{Host: sink.Host, Port: sink.Port, From: "no-reply@example.com", TLS: TLSNone})
+	if err := adapter.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	sink.FailNext("451 4.3.0 Try again later")
+	err := adapter.SendMail(context.Background(), ports.MailMessage{To: "budi@example.com", Subject: "Test", Body: "Test"})
+	if err == nil || !strings.Contains(err.Error(), "451") {
+		t.Fatalf("SendMail() error = %v, want the 451 reply", err)
+	}
+	if len(sink.Messages()) != 0 {
+		t.Fatal("a rejected message was accepted")
+	}
+}
@@ -0,0 +1,173 @@
+// Package smtptest provides a minimal in-process SMTP server that records the messages it
+// accepts, for tests of code sending email.
+package smtptest
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Message is an email accepted by the server
+type Message struct {
+	From string
+	To   []string
+	Data string // Headers and body as sent, without the terminating dot
+}
+
+// Server is a plain text SMTP server on a local port. It accepts any credentials.
+type Server struct {
+	Host string
+	Port string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	failures []string
+}
+
+// NewServer starts a server listening on 127.0.0.1
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	s := &Server{Host: host, Port: port, listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// FailNext makes the next MAIL commands fail with the given replies, one per message,
+// e.g. "451 4.3.0 Try again later"
+func (s *Server) FailNext(replies ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, replies...)
+}
+
+// Messages returns the messages accepted so far
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Close stops the server and waits for open sessions to end
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.session(conn)
+		}()
+	}
+}
+
+// session speaks enough SMTP for net/smtp: EHLO, AUTH, MAIL, RCPT, DATA, RSET, NOOP and QUIT
+func (s *Server) session(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			w.WriteString(line + "\r\n")
+		}
+		w.Flush()
+	}
+
+	reply("220 localhost ESMTP smtptest")
+	var message Message
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO":
+			reply("250-localhost", "250-8BITMIME", "250 AUTH PLAIN")
+		case "HELO":
+			reply("250 localhost")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			if failure := s.nextFailure(); failure != "" {
+				reply(failure)
+				continue
+			}
+			message = Message{From: address(line)}
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			message.To = append(message.To, address(line))
+			reply("250 2.1.5 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			message.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			message = Message{}
+			reply("250 2.0.0 Queued")
+		case "RSET":
+			message = Message{}
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("502 5.5.2 Command not implemented")
+		}
+	}
+}
+
+// nextFailure pops the reply of the next failing message, empty when none is left
+func (s *Server) nextFailure() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) == 0 {
+		return ""
+	}
+	failure := s.failures[0]
+	s.failures = s.failures[1:]
+	return failure
+}
+
+// address returns the address between the angle brackets of a MAIL or RCPT command
+func address(line string) string {
+	start, end := strings.Index(line, "<"), strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
@@ -0,0 +1,129 @@
+package worker_adapter
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/ports"
+	"log"
+	"sync"
+	"time"
+)
+
+var _ ports.AdapterPort = &workerAdapter{}
+
+// WorkFunc processes up to batch items and returns how many it processed
+type WorkFunc func(ctx context.Context, batch int) (int, error)
+
+type workerAdapter struct {
+	name     string
+	work     WorkFunc
+	value    any
+	interval time.Duration
+	batch    int
+	cancel   context.CancelFunc
+	done     chan struct{}
+	mu       sync.Mutex
+}
+
+// WorkerConfig holds configuration for a background worker
+type WorkerConfig struct {
+	Name         string        // Used in log messages
+	PollInterval time.Duration // How often work is looked for
+	BatchSize    int           // Items processed per call of the work function
+	Value        any           // Returned by Value, e.g. the dispatcher doing the work
+}
+
+// New returns an adapter that calls work in the background between Connect and Disconnect
+func New(work WorkFunc, config WorkerConfig) *workerAdapter {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = 20
+	}
+
+	return &workerAdapter{
+		name:     config.Name,
+		work:     work,
+		value:    config.Value,
+		interval: config.PollInterval,
+		batch:    config.BatchSize,
+	}
+}
+
+// Connect starts the worker in the background until Disconnect
+func (w *workerAdapter) Connect(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return nil
+	}
+
+	// The connect context only bounds connecting, the loop runs until Disconnect
+	runCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go w.run(runCtx)
+
+	return nil
+}
+
+func (w *workerAdapter) run(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while full batches come back, so a backlog drains quickly
+		for {
+			count, err := w.work(ctx, w.batch)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("[Error %s] %v", w.name, err)
+				}
+				break
+			}
+			if count < w.batch || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *workerAdapter) Disconnect(ctx context.Context) error {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+func (w *workerAdapter) IsReady() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cancel != nil
+}
+
+func (w *workerAdapter) Value() any {
+	return w.value
+}
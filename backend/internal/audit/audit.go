@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Entry describes a change to record in the audit log.
+// Actor, RequestID and RemoteAddr default to those carried by the context of tx.
+type Entry struct {
+	Actor        string
+	ActorClaimed bool // Actor was named by the client and not verified
+	Action       string
+	EntityType   string
+	EntityID     string
+	Before       interface{} // Marshalled to JSON, nil when the entity did not exist
+	After        interface{} // Marshalled to JSON, nil when the entity was removed
+	RequestID    string
+	Reason       string // Why the change was made, required for session overrides
+	RemoteAddr   string
+}
+
+// Record writes an audit entry using tx so it commits or rolls back with the change it describes
+func Record(tx *gorm.DB, entry Entry) error {
+	before, err := snapshot(entry.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := snapshot(entry.After)
+	if err != nil {
+		return err
+	}
+
+	if entry.Actor == "" {
+		entry.Actor = ActorFromContext(tx.Statement.Context)
+		entry.ActorClaimed = ActorClaimedFromContext(tx.Statement.Context)
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = RequestIDFromContext(tx.Statement.Context)
+	}
+	if entry.RemoteAddr == "" {
+		entry.RemoteAddr = RemoteAddrFromContext(tx.Statement.Context)
+	}
+
+	log := models.AuditLog{
+		Actor:        entry.Actor,
+		ActorClaimed: entry.ActorClaimed,
+		Action:       entry.Action,
+		EntityType:   entry.EntityType,
+		EntityID:     entry.EntityID,
+		Before:       before,
+		After:        after,
+		RequestID:    entry.RequestID,
+		RemoteAddr:   entry.RemoteAddr,
+		CreatedAt:    time.Now(),
+	}
+	if entry.Reason != "" {
+		log.Reason = &entry.Reason
+	}
+
+	if err := tx.Create(&log).Error; err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// snapshot marshals a value to a JSON string, nil stays nil
+func snapshot(value interface{}) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+
+	s := string(data)
+	return &s, nil
+}
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrackedTables are the tables whose writes are recorded automatically
+var TrackedTables = []string{
+	models.Question{}.TableName(),
+	models.QuestionOption{}.TableName(),
+	models.Category{}.TableName(),
+	models.TagQuota{}.TableName(),
+	models.GradingScale{}.TableName(),
+	models.PassRule{}.TableName(),
+	models.ExamBlueprint{}.TableName(),
+	models.Cohort{}.TableName(),
+	models.CohortMember{}.TableName(),
+	models.CohortAssignment{}.TableName(),
+	models.Sitting{}.TableName(),
+	models.SittingCandidate{}.TableName(),
+	models.Accommodation{}.TableName(),
+}
+
+// beforeKey stores the rows captured before an update or delete on the statement
+const beforeKey = "audit:before"
+
+// RegisterCallbacks records every create, update and delete on the tracked tables in the
+// audit log, inside the same transaction, with the actor and request ID of the statement context.
+// Raw SQL executed with Exec is not tracked.
+func RegisterCallbacks(db *gorm.DB) error {
+	tracked := make(map[string]bool, len(TrackedTables))
+	for _, table := range TrackedTables {
+		tracked[table] = true
+	}
+
+	isTracked := func(db *gorm.DB) bool {
+		return db.Error == nil && db.Statement.Schema != nil && tracked[db.Statement.Table]
+	}
+
+	callback := db.Callback()
+
+	if err := callback.Create().After("gorm:create").Register("audit:after_create", func(db *gorm.DB) {
+		if isTracked(db) {
+			recordRows(db, models.AuditActionCreate, nil, primaryKeysOf(db))
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to register audit create callback: %w", err)
+	}
+
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", func(db *gorm.DB) {
+		if isTracked(db) {
+			captureBefore(db)
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to register audit update callback: %w", err)
+	}
+
+	if err := callback.Update().After("gorm:update").Register("audit:after_update", func(db *gorm.DB) {
+		if isTracked(db) {
+			recordCaptured(db, models.AuditActionUpdate)
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to register audit update callback: %w", err)
+	}
+
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", func(db *gorm.DB) {
+		if isTracked(db) {
+			captureBefore(db)
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to register audit delete callback: %w", err)
+	}
+
+	if err := callback.Delete().After("gorm:delete").Register("audit:after_delete", func(db *gorm.DB) {
+		if isTracked(db) {
+			recordCaptured(db, models.AuditActionDelete)
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to register audit delete callback: %w", err)
+	}
+
+	return nil
+}
+
+// snapshotDB returns a fresh session on the statement connection, so snapshots and
+// audit rows are read and written inside the caller's transaction
+func snapshotDB(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).WithContext(db.Statement.Context)
+}
+
+// primaryKeysOf returns the primary key values of the statement model, a struct or a slice
+func primaryKeysOf(db *gorm.DB) []interface{} {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return nil
+	}
+
+	var keys []interface{}
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Struct:
+		if value, isZero := field.ValueOf(db.Statement.Context, rv); !isZero {
+			keys = append(keys, value)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if value, isZero := field.ValueOf(db.Statement.Context, reflect.Indirect(rv.Index(i))); !isZero {
+				keys = append(keys, value)
+			}
+		}
+	}
+	return keys
+}
+
+// captureBefore loads the rows an update or delete is about to change, matched by
+// the statement conditions and/or the primary key of its model
+func captureBefore(db *gorm.DB) {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return
+	}
+
+	query := snapshotDB(db).Table(db.Statement.Table)
+	conditions := 0
+
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			query = query.Clauses(where)
+			conditions++
+		}
+	}
+
+	if keys := primaryKeysOf(db); len(keys) > 0 {
+		query = query.Where(clause.IN{Column: clause.Column{Name: field.DBName}, Values: keys})
+		conditions++
+	}
+
+	// Never snapshot a whole table for a global update
+	if conditions == 0 {
+		return
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to capture audit snapshot: %w", err))
+		return
+	}
+
+	db.Statement.Settings.Store(beforeKey, rows)
+}
+
+// recordCaptured records the rows captured before the statement with their current state
+func recordCaptured(db *gorm.DB, action string) {
+	value, ok := db.Statement.Settings.LoadAndDelete(beforeKey)
+	if !ok {
+		return
+	}
+
+	rows := value.([]map[string]interface{})
+	if len(rows) == 0 {
+		return
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	before := make(map[string]map[string]interface{}, len(rows))
+	keys := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		before[fmt.Sprint(row[pk])] = row
+		keys = append(keys, row[pk])
+	}
+
+	recordRows(db, action, before, keys)
+}
+
+// recordRows writes one audit entry per key with the before snapshot and the current row.
+// Deleted rows are recorded without an after snapshot.
+func recordRows(db *gorm.DB, action string, before map[string]map[string]interface{}, keys []interface{}) {
+	if len(keys) == 0 {
+		return
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	after := make(map[string]map[string]interface{}, len(keys))
+
+	if action != models.AuditActionDelete {
+		var rows []map[string]interface{}
+		if err := snapshotDB(db).Table(db.Statement.Table).
+			Where(clause.IN{Column: clause.Column{Name: pk}, Values: keys}).
+			Find(&rows).Error; err != nil {
+			db.AddError(fmt.Errorf("failed to capture audit snapshot: %w", err))
+			return
+		}
+		for _, row := range rows {
+			after[fmt.Sprint(row[pk])] = row
+		}
+	}
+
+	for _, key := range keys {
+		id := fmt.Sprint(key)
+
+		entry := Entry{
+			Action:     action,
+			EntityType: db.Statement.Table,
+			EntityID:   id,
+		}
+		if row, ok := before[id]; ok {
+			entry.Before = row
+		}
+		if row, ok := after[id]; ok {
+			entry.After = row
+		}
+
+		if err := Record(snapshotDB(db), entry); err != nil {
+			db.AddError(err)
+			return
+		}
+	}
+}
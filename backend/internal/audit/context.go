@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+)
+
+// SystemActor is recorded when a change is not made through an HTTP request
+const SystemActor = "system"
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	actorClaimedKey
+	requestIDKey
+	remoteAddrKey
+)
+
+// WithActor returns a context carrying who makes the changes
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// WithClaimedActor returns a context carrying an actor named by the client, which the server
+// has not verified
+func WithClaimedActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(WithActor(ctx, actor), actorClaimedKey, true)
+}
+
+// ActorClaimedFromContext tells whether the actor stored in ctx was named by the client
+func ActorClaimedFromContext(ctx context.Context) bool {
+	if ctx != nil {
+		if claimed, ok := ctx.Value(actorClaimedKey).(bool); ok {
+			return claimed
+		}
+	}
+	return false
+}
+
+// ActorFromContext returns the actor stored in ctx, SystemActor when none is set
+func ActorFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+			return actor
+		}
+	}
+	return SystemActor
+}
+
+// WithRequestID returns a context carrying the ID of the request making the changes
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, empty when none is set
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+			return requestID
+		}
+	}
+	return ""
+}
+
+// WithRemoteAddr returns a context carrying the network address of the client making the changes
+func WithRemoteAddr(ctx context.Context, remoteAddr string) context.Context {
+	return context.WithValue(ctx, remoteAddrKey, remoteAddr)
+}
+
+// RemoteAddrFromContext returns the client address stored in ctx, empty when none is set
+func RemoteAddrFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if remoteAddr, ok := ctx.Value(remoteAddrKey).(string); ok {
+			return remoteAddr
+		}
+	}
+	return ""
+}
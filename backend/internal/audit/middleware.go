@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ActorHeader names who makes the change, e.g. an admin username. The server has no
+	// authentication yet, so the header is only a claim and anyone can send any name.
+	ActorHeader = "X-Actor"
+	// RequestIDHeader correlates audit entries with a request, generated when missing
+	RequestIDHeader = "X-Request-ID"
+	// AnonymousActor is recorded for requests without an actor header
+	AnonymousActor = "anonymous"
+)
+
+// Middleware stores the actor and request ID of every request in its context so
+// audit entries written while handling it can be attributed and correlated. The actor is
+// recorded as claimed, next to the address the request came from: until the server
+// authenticates its clients the attribution cannot be trusted.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := strings.TrimSpace(c.GetHeader(ActorHeader))
+		if actor == "" {
+			actor = AnonymousActor
+		}
+
+		requestID := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := WithClaimedActor(c.Request.Context(), actor)
+		ctx = WithRequestID(ctx, requestID)
+		ctx = WithRemoteAddr(ctx, remoteAddr(c))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// remoteAddr returns the address of the peer that sent the request. Forwarding headers are
+// ignored, they are as easy to forge as the actor header.
+func remoteAddr(c *gin.Context) string {
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return c.Request.RemoteAddr
+	}
+	return host
+}
+
+// newRequestID returns a random 32 character hex ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -2,7 +2,9 @@ package dto
 
 import (
 	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/json"
 	"strconv"
+	"time"
 )
 
 // ToExamSessionResponse converts domain model to DTO
@@ -47,17 +49,53 @@ func ToExamSessionResponse(examSession *models.ExamSession) ExamSessionResponse
 	}
 
 	return ExamSessionResponse{
-		SessionID:     examSession.ID,
-		UserID:        examSession.UserID,
-		SessionCode:   examSession.SessionCode,
-		Status:        examSession.Status,
-		ExpiresAt:     examSession.ExpiresAt,
-		Duration:      examSession.Duration,
-		Questions:     questions,
-		CategoryStats: categoryStatsSlice,
+		SessionID:            examSession.ID,
+		UserID:               examSession.UserID,
+		SessionCode:          examSession.SessionCode,
+		BlueprintID:          examSession.BlueprintID,
+		AssignmentID:         examSession.AssignmentID,
+		SittingID:            examSession.SittingID,
+		Status:               string(examSession.Status),
+		ExpiresAt:            examSession.ExpiresAt,
+		Duration:             examSession.Duration,
+		AccommodationMinutes: examSession.AccommodationMinutes,
+		Accommodation:        ToAccommodationResponsePtr(examSession.Accommodation),
+		Questions:            questions,
+		CategoryStats:        categoryStatsSlice,
 	}
 }
 
+// ToAccommodationResponse converts an accommodation model to DTO
+func ToAccommodationResponse(accommodation *models.Accommodation) AccommodationResponse {
+	return AccommodationResponse{
+		UserID:         accommodation.UserID,
+		TimeMultiplier: accommodation.TimeMultiplier,
+		LargeFont:      accommodation.LargeFont,
+		ExtraBreaks:    accommodation.ExtraBreaks,
+		BreakMinutes:   accommodation.BreakMinutes,
+		Notes:          accommodation.Notes,
+		UpdatedAt:      accommodation.UpdatedAt,
+	}
+}
+
+// ToAccommodationResponsePtr converts an optional accommodation model to DTO
+func ToAccommodationResponsePtr(accommodation *models.Accommodation) *AccommodationResponse {
+	if accommodation == nil {
+		return nil
+	}
+	response := ToAccommodationResponse(accommodation)
+	return &response
+}
+
+// ToAccommodationResponses converts accommodation models to DTOs
+func ToAccommodationResponses(accommodations []models.Accommodation) []AccommodationResponse {
+	responses := make([]AccommodationResponse, len(accommodations))
+	for i := range accommodations {
+		responses[i] = ToAccommodationResponse(&accommodations[i])
+	}
+	return responses
+}
+
 // ToExamSummaryResponse converts domain model to DTO
 func ToExamSummaryResponse(summary *models.ExamSummary) ExamSummaryResponse {
 	return ExamSummaryResponse{
@@ -71,6 +109,8 @@ func ToExamSummaryResponse(summary *models.ExamSummary) ExamSummaryResponse {
 		OverallPercentage: summary.OverallPercentage,
 		OverallGrade:      summary.OverallGrade,
 		IsPassed:          summary.IsPassed,
+		GradingScaleID:    summary.GradingScaleID,
+		PassRuleID:        summary.PassRuleID,
 		CompletedAt:       summary.CompletedAt,
 	}
 }
@@ -90,16 +130,57 @@ func ToExamResultResponses(results []models.ExamResult) []ExamResultResponse {
 			Percentage:     result.Percentage,
 			Grade:          result.Grade,
 			IsPassed:       result.IsPassed,
+			GradingScaleID: result.GradingScaleID,
+			PassRuleID:     result.PassRuleID,
+		}
+	}
+	return responses
+}
+
+// ToExamTagResultResponses converts domain models to DTOs
+func ToExamTagResultResponses(tagResults []models.ExamTagResult) []ExamTagResultResponse {
+	responses := make([]ExamTagResultResponse, len(tagResults))
+	for i, result := range tagResults {
+		responses[i] = ExamTagResultResponse{
+			TagID:          result.TagID,
+			TagName:        result.TagName,
+			TotalQuestions: result.TotalQuestions,
+			TotalAnswered:  result.TotalAnswered,
+			TotalScore:     result.TotalScore,
+			MaxScore:       result.MaxScore,
+			Percentage:     result.Percentage,
 		}
 	}
 	return responses
 }
 
-// ToExamResultsResponse combines summary and results into single response
-func ToExamResultsResponse(summary *models.ExamSummary, results []models.ExamResult) ExamResultsResponse {
+// ToExamResultsResponse combines summary, category and tag results into single response
+func ToExamResultsResponse(summary *models.ExamSummary, results []models.ExamResult, tagResults []models.ExamTagResult) ExamResultsResponse {
 	return ExamResultsResponse{
 		Summary:           ToExamSummaryResponse(summary),
 		ResultsByCategory: ToExamResultResponses(results),
+		ResultsByTag:      ToExamTagResultResponses(tagResults),
+	}
+}
+
+// ApplySessionStanding adds the rank and percentiles of the session to its results
+func ApplySessionStanding(response *ExamResultsResponse, standing *SessionStanding) {
+	if standing == nil {
+		return
+	}
+
+	response.Summary.Rank = &standing.Rank
+	response.Summary.RankedCandidates = &standing.Candidates
+	response.Summary.Percentile = &standing.Percentile
+
+	for i := range response.ResultsByCategory {
+		category := response.ResultsByCategory[i].Category
+		if rank, ok := standing.CategoryRanks[category]; ok {
+			response.ResultsByCategory[i].Rank = &rank
+		}
+		if percentile, ok := standing.CategoryPercentiles[category]; ok {
+			response.ResultsByCategory[i].Percentile = &percentile
+		}
 	}
 }
 
@@ -120,10 +201,11 @@ func ToDashboardResponse(dashboard *DashboardData) DashboardResponse {
 	}
 
 	// Convert ExamResults if completed
-	if dashboard.ExamStatus == "COMPLETED" && dashboard.ExamSummary != nil && dashboard.ExamResults != nil {
+	if dashboard.ExamStatus == string(models.SessionCompleted) && dashboard.ExamSummary != nil && dashboard.ExamResults != nil {
 		if summary, ok := dashboard.ExamSummary.(*models.ExamSummary); ok {
 			if results, ok := dashboard.ExamResults.([]models.ExamResult); ok {
-				resultsResponse := ToExamResultsResponse(summary, results)
+				tagResults, _ := dashboard.ExamTagResults.([]models.ExamTagResult)
+				resultsResponse := ToExamResultsResponse(summary, results, tagResults)
 				response.ExamResults = &resultsResponse
 			}
 		}
@@ -159,6 +241,7 @@ func ToQuestionManagementResponse(question *models.Question) QuestionManagementR
 		ID:           question.ID,
 		Category:     question.Category,
 		QuestionText: question.QuestionText,
+		Tags:         ToTagNames(question.Tags),
 		Options:      options,
 		CreatedAt:    question.CreatedAt,
 		UpdatedAt:    question.UpdatedAt,
@@ -209,6 +292,506 @@ func ToExportQuestionResponse(question *models.Question, index int) ExportQuesti
 		ID:           strconv.Itoa(index), // Convert index to string (1,2,3...)
 		Category:     question.Category,
 		QuestionText: question.QuestionText,
+		Tags:         ToTagNames(question.Tags),
 		Options:      options,
 	}
 }
+
+// ToTagNames converts tag models to their names
+func ToTagNames(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// ToTagResponses converts tag models to DTOs
+func ToTagResponses(tags []models.Tag) []TagResponse {
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = TagResponse{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			Description: tag.Description,
+		}
+	}
+	return responses
+}
+
+// ToTagQuotaResponse converts tag quota model to DTO
+func ToTagQuotaResponse(quota *models.TagQuota) TagQuotaResponse {
+	return TagQuotaResponse{
+		ID:            quota.ID,
+		Category:      quota.Category,
+		Tag:           quota.Tag.Name,
+		QuestionCount: quota.QuestionCount,
+	}
+}
+
+// ToTagQuotaResponses converts tag quota models to DTOs
+func ToTagQuotaResponses(quotas []models.TagQuota) []TagQuotaResponse {
+	responses := make([]TagQuotaResponse, len(quotas))
+	for i, quota := range quotas {
+		responses[i] = ToTagQuotaResponse(&quota)
+	}
+	return responses
+}
+
+// ToCategoryResponse converts category model to DTO
+func ToCategoryResponse(category *models.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:            category.ID,
+		Code:          category.Code,
+		Name:          category.Name,
+		Description:   category.Description,
+		DisplayOrder:  category.DisplayOrder,
+		ScoringScheme: category.ScoringScheme,
+		QuestionCount: category.QuestionCount,
+		MaxScore:      category.MaxScore,
+	}
+}
+
+// ToCategoryResponses converts category models to DTOs
+func ToCategoryResponses(categories []models.Category) []CategoryResponse {
+	responses := make([]CategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = ToCategoryResponse(&category)
+	}
+	return responses
+}
+
+// ToGradingScaleResponse converts grading scale model to DTO
+func ToGradingScaleResponse(scale *models.GradingScale) GradingScaleResponse {
+	bands := make([]GradingBandResponse, len(scale.Bands))
+	for i, band := range scale.Bands {
+		bands[i] = GradingBandResponse{
+			Grade:         band.Grade,
+			MinPercentage: band.MinPercentage,
+		}
+	}
+
+	return GradingScaleResponse{
+		ID:          scale.ID,
+		Code:        scale.Code,
+		Name:        scale.Name,
+		Description: scale.Description,
+		Bands:       bands,
+	}
+}
+
+// ToGradingScaleResponses converts grading scale models to DTOs
+func ToGradingScaleResponses(scales []models.GradingScale) []GradingScaleResponse {
+	responses := make([]GradingScaleResponse, len(scales))
+	for i, scale := range scales {
+		responses[i] = ToGradingScaleResponse(&scale)
+	}
+	return responses
+}
+
+// ToPassRuleResponse converts pass rule model to DTO
+func ToPassRuleResponse(rule *models.PassRule) PassRuleResponse {
+	minimums := make([]PassRuleCategoryMinimumResponse, len(rule.CategoryMinimums))
+	for i, minimum := range rule.CategoryMinimums {
+		minimums[i] = PassRuleCategoryMinimumResponse{
+			Category:      minimum.Category,
+			MinScore:      minimum.MinScore,
+			MinPercentage: minimum.MinPercentage,
+		}
+	}
+
+	return PassRuleResponse{
+		ID:                   rule.ID,
+		Code:                 rule.Code,
+		Name:                 rule.Name,
+		Description:          rule.Description,
+		OverallMinPercentage: rule.OverallMinPercentage,
+		OverallMinScore:      rule.OverallMinScore,
+		RequireAllCategories: rule.RequireAllCategories,
+		CategoryMinimums:     minimums,
+	}
+}
+
+// ToPassRuleResponses converts pass rule models to DTOs
+func ToPassRuleResponses(rules []models.PassRule) []PassRuleResponse {
+	responses := make([]PassRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToPassRuleResponse(&rule)
+	}
+	return responses
+}
+
+// ToBlueprintResponse converts exam blueprint model to DTO
+func ToBlueprintResponse(blueprint *models.ExamBlueprint) BlueprintResponse {
+	return BlueprintResponse{
+		ID:               blueprint.ID,
+		Code:             blueprint.Code,
+		Name:             blueprint.Name,
+		Description:      blueprint.Description,
+		DurationMinutes:  blueprint.DurationMinutes,
+		GradingScaleID:   blueprint.GradingScaleID,
+		GradingScaleCode: blueprint.GradingScale.Code,
+		PassRuleID:       blueprint.PassRuleID,
+		PassRuleCode:     blueprint.PassRule.Code,
+		IsDefault:        blueprint.IsDefault,
+	}
+}
+
+// ToBlueprintResponses converts exam blueprint models to DTOs
+func ToBlueprintResponses(blueprints []models.ExamBlueprint) []BlueprintResponse {
+	responses := make([]BlueprintResponse, len(blueprints))
+	for i, blueprint := range blueprints {
+		responses[i] = ToBlueprintResponse(&blueprint)
+	}
+	return responses
+}
+
+// ToAuditLogResponse converts audit log model to DTO
+func ToAuditLogResponse(log *models.AuditLog) AuditLogResponse {
+	response := AuditLogResponse{
+		ID:           log.ID,
+		Actor:        log.Actor,
+		ActorClaimed: log.ActorClaimed,
+		Action:       log.Action,
+		EntityType:   log.EntityType,
+		EntityID:     log.EntityID,
+		RequestID:    log.RequestID,
+		RemoteAddr:   log.RemoteAddr,
+		Reason:       log.Reason,
+		CreatedAt:    log.CreatedAt,
+	}
+	if log.Before != nil {
+		response.Before = json.RawMessage(*log.Before)
+	}
+	if log.After != nil {
+		response.After = json.RawMessage(*log.After)
+	}
+	return response
+}
+
+// ToAuditLogResponses converts audit log models to DTOs
+func ToAuditLogResponses(logs []models.AuditLog) []AuditLogResponse {
+	responses := make([]AuditLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = ToAuditLogResponse(&log)
+	}
+	return responses
+}
+
+// ToSessionTransitionResponses converts session transition models to DTOs
+func ToSessionTransitionResponses(transitions []models.SessionTransition) []SessionTransitionResponse {
+	responses := make([]SessionTransitionResponse, len(transitions))
+	for i, transition := range transitions {
+		responses[i] = SessionTransitionResponse{
+			ID:         transition.ID,
+			FromStatus: string(transition.FromStatus),
+			ToStatus:   string(transition.ToStatus),
+			Trigger:    transition.Trigger,
+			Actor:      transition.Actor,
+			RequestID:  transition.RequestID,
+			CreatedAt:  transition.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// ToCohortResponse converts cohort model to DTO
+func ToCohortResponse(cohort *models.Cohort, now time.Time) CohortResponse {
+	response := CohortResponse{
+		ID:          cohort.ID,
+		Code:        cohort.Code,
+		Name:        cohort.Name,
+		Description: cohort.Description,
+		Members:     make([]CohortMemberResponse, len(cohort.Members)),
+	}
+
+	for i, member := range cohort.Members {
+		response.Members[i] = CohortMemberResponse{UserID: member.UserID, Role: member.Role}
+		if member.Role == models.CohortRoleInstructor {
+			response.InstructorCount++
+		} else {
+			response.MemberCount++
+		}
+	}
+
+	if len(cohort.Assignments) > 0 {
+		response.Assignments = ToCohortAssignmentResponses(cohort.Assignments, now)
+	}
+
+	return response
+}
+
+// ToCohortResponses converts cohort models to DTOs
+func ToCohortResponses(cohorts []models.Cohort, now time.Time) []CohortResponse {
+	responses := make([]CohortResponse, len(cohorts))
+	for i, cohort := range cohorts {
+		responses[i] = ToCohortResponse(&cohort, now)
+	}
+	return responses
+}
+
+// ToCohortAssignmentResponse converts cohort assignment model to DTO, open when now is inside its window
+func ToCohortAssignmentResponse(assignment *models.CohortAssignment, now time.Time) CohortAssignmentResponse {
+	return CohortAssignmentResponse{
+		ID:             assignment.ID,
+		CohortID:       assignment.CohortID,
+		BlueprintID:    assignment.BlueprintID,
+		BlueprintCode:  assignment.Blueprint.Code,
+		BlueprintName:  assignment.Blueprint.Name,
+		AvailableFrom:  assignment.AvailableFrom,
+		AvailableUntil: assignment.AvailableUntil,
+		AssignedBy:     assignment.AssignedBy,
+		IsOpen:         !now.Before(assignment.AvailableFrom) && (assignment.AvailableUntil == nil || now.Before(*assignment.AvailableUntil)),
+	}
+}
+
+// ToCohortAssignmentResponses converts cohort assignment models to DTOs
+func ToCohortAssignmentResponses(assignments []models.CohortAssignment, now time.Time) []CohortAssignmentResponse {
+	responses := make([]CohortAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		responses[i] = ToCohortAssignmentResponse(&assignment, now)
+	}
+	return responses
+}
+
+// ToSittingResponse converts sitting model to DTO with its status at now
+func ToSittingResponse(sitting *models.Sitting, now time.Time) SittingResponse {
+	response := SittingResponse{
+		ID:            sitting.ID,
+		Code:          sitting.Code,
+		Name:          sitting.Name,
+		Location:      sitting.Location,
+		BlueprintID:   sitting.BlueprintID,
+		OpensAt:       sitting.OpensAt,
+		ClosesAt:      sitting.ClosesAt,
+		EndsAt:        sitting.EndsAt,
+		Capacity:      sitting.Capacity,
+		SeatsLeft:     sitting.Capacity - len(sitting.Candidates),
+		HasAccessCode: sitting.AccessCode != "",
+		Candidates:    make([]string, len(sitting.Candidates)),
+	}
+
+	if sitting.Blueprint != nil {
+		response.BlueprintCode = sitting.Blueprint.Code
+	}
+
+	for i, candidate := range sitting.Candidates {
+		response.Candidates[i] = candidate.UserID
+	}
+
+	switch {
+	case now.Before(sitting.OpensAt):
+		response.Status = "SCHEDULED"
+	case now.Before(sitting.ClosesAt):
+		response.Status = "OPEN"
+	case now.Before(sitting.EndsAt):
+		response.Status = "CLOSED"
+	default:
+		response.Status = "ENDED"
+	}
+
+	return response
+}
+
+// ToSittingWithAccessCodeResponse converts sitting model to DTO including its access code
+func ToSittingWithAccessCodeResponse(sitting *models.Sitting, now time.Time) SittingWithAccessCodeResponse {
+	return SittingWithAccessCodeResponse{
+		SittingResponse: ToSittingResponse(sitting, now),
+		AccessCode:      sitting.AccessCode,
+	}
+}
+
+// ToSittingResponses converts sitting models to DTOs
+func ToSittingResponses(sittings []models.Sitting, now time.Time) []SittingResponse {
+	responses := make([]SittingResponse, len(sittings))
+	for i, sitting := range sittings {
+		responses[i] = ToSittingResponse(&sitting, now)
+	}
+	return responses
+}
+
+// ToWebhookResponse converts a webhook model to DTO
+func ToWebhookResponse(webhook *models.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:        webhook.ID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		HasSecret: webhook.Secret != "",
+		Events:    webhook.EventList(),
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// ToWebhookResponses converts webhook models to DTOs
+func ToWebhookResponses(webhooks []models.Webhook) []WebhookResponse {
+	responses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = ToWebhookResponse(&webhook)
+	}
+	return responses
+}
+
+// ToWebhookDeliveryResponse converts a webhook delivery model to DTO
+func ToWebhookDeliveryResponse(delivery *models.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       json.RawMessage(delivery.Payload),
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		LastAttemptAt: delivery.LastAttemptAt,
+		DeliveredAt:   delivery.DeliveredAt,
+		CreatedAt:     delivery.CreatedAt,
+		AttemptLog:    make([]WebhookDeliveryAttemptResponse, len(delivery.AttemptLog)),
+	}
+
+	if delivery.Status == models.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	for i, attempt := range delivery.AttemptLog {
+		response.AttemptLog[i] = WebhookDeliveryAttemptResponse{
+			Attempt:        attempt.Attempt,
+			ResponseStatus: attempt.ResponseStatus,
+			ResponseBody:   attempt.ResponseBody,
+			Error:          attempt.Error,
+			DurationMs:     attempt.DurationMs,
+			CreatedAt:      attempt.CreatedAt,
+		}
+	}
+
+	return response
+}
+
+// ToWebhookDeliveryResponses converts webhook delivery models to DTOs
+func ToWebhookDeliveryResponses(deliveries []models.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = ToWebhookDeliveryResponse(&delivery)
+	}
+	return responses
+}
+
+// ToDomainEventResponse converts a domain event model to DTO
+func ToDomainEventResponse(event *models.DomainEvent) DomainEventResponse {
+	response := DomainEventResponse{
+		ID:            event.ID,
+		EventID:       event.EventID,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       json.RawMessage(event.Payload),
+		OccurredAt:    event.OccurredAt,
+		Status:        event.Status,
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		ProcessedAt:   event.ProcessedAt,
+		HandledBy:     make([]string, len(event.Receipts)),
+	}
+
+	if event.Status == models.DomainEventPending {
+		nextAttemptAt := event.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	for i, receipt := range event.Receipts {
+		response.HandledBy[i] = receipt.Subscriber
+	}
+
+	return response
+}
+
+// ToDomainEventResponses converts domain event models to DTOs
+func ToDomainEventResponses(events []models.DomainEvent) []DomainEventResponse {
+	responses := make([]DomainEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = ToDomainEventResponse(&event)
+	}
+	return responses
+}
+
+// ToStreamedEventResponse converts a domain event model to the DTO sent to stream clients
+func ToStreamedEventResponse(event *models.DomainEvent) StreamedEventResponse {
+	return StreamedEventResponse{
+		EventID:       event.EventID,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       json.RawMessage(event.Payload),
+		OccurredAt:    event.OccurredAt,
+	}
+}
+
+// ToEventCountResponses converts event count models to DTOs
+func ToEventCountResponses(counts []models.EventCount) []EventCountResponse {
+	responses := make([]EventCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = EventCountResponse{
+			Day:       count.Day.Format(time.DateOnly),
+			EventType: count.EventType,
+			Count:     count.Count,
+		}
+	}
+	return responses
+}
+
+// ToNotificationContactResponse converts a notification contact model to DTO
+func ToNotificationContactResponse(contact *models.NotificationContact) NotificationContactResponse {
+	return NotificationContactResponse{
+		UserID:    contact.UserID,
+		Email:     contact.Email,
+		Name:      contact.Name,
+		Locale:    contact.Locale,
+		OptedOut:  contact.OptedOut,
+		CreatedAt: contact.CreatedAt,
+		UpdatedAt: contact.UpdatedAt,
+	}
+}
+
+// ToNotificationContactResponses converts notification contact models to DTOs
+func ToNotificationContactResponses(contacts []models.NotificationContact) []NotificationContactResponse {
+	responses := make([]NotificationContactResponse, len(contacts))
+	for i, contact := range contacts {
+		responses[i] = ToNotificationContactResponse(&contact)
+	}
+	return responses
+}
+
+// ToNotificationResponse converts a notification model to DTO
+func ToNotificationResponse(notification *models.Notification) NotificationResponse {
+	response := NotificationResponse{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Kind:      notification.Kind,
+		Recipient: notification.Recipient,
+		Locale:    notification.Locale,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		Status:    notification.Status,
+		Attempts:  notification.Attempts,
+		LastError: notification.LastError,
+		SentAt:    notification.SentAt,
+		CreatedAt: notification.CreatedAt,
+	}
+
+	if notification.Status == models.NotificationPending {
+		nextAttemptAt := notification.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+// ToNotificationResponses converts notification models to DTOs
+func ToNotificationResponses(notifications []models.Notification) []NotificationResponse {
+	responses := make([]NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = ToNotificationResponse(&notification)
+	}
+	return responses
+}
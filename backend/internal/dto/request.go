@@ -1,13 +1,224 @@
 package dto
 
+import (
+	"time"
+)
+
 // SubmitAnswerRequest represents the request payload for submitting an answer
 type SubmitAnswerRequest struct {
 	ExamQuestionID   uint `json:"exam_question_id" binding:"required" example:"1"`
 	QuestionOptionID uint `json:"question_option_id" binding:"required" example:"59"`
 }
 
-// UpdateScoreRequest represents the request payload for updating question option score
+// StartExamRequest represents the optional request payload for starting an exam
+type StartExamRequest struct {
+	AccessCode string `json:"access_code" example:"K7P2Q9"` // Only needed for sittings with an access code
+}
+
+// UpdateScoreRequest represents the request payload for updating question option score.
+// Allowed values depend on the scoring scheme of the question category.
 type UpdateScoreRequest struct {
-	// Score int `json:"score" binding:"required,min=0,max=10" example:"5"`
-	Score *int `json:"score" binding:"required,min=0,max=10" example:"5"`
+	Score *int `json:"score" binding:"required" example:"4"`
+}
+
+// CreateTagRequest represents the request payload for creating a question tag
+type CreateTagRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"pedagogi"`
+	Description string `json:"description" example:"Kompetensi pedagogik guru"`
+}
+
+// SetQuestionTagsRequest represents the request payload for replacing the tags of a question
+type SetQuestionTagsRequest struct {
+	Tags []string `json:"tags" binding:"required" example:"pedagogi,regulasi"`
+}
+
+// SetTagQuotaRequest represents the request payload for setting a tag quota inside a category
+type SetTagQuotaRequest struct {
+	Category      string `json:"category" binding:"required" example:"TEKNIS"`
+	Tag           string `json:"tag" binding:"required" example:"pedagogi"`
+	QuestionCount *int   `json:"question_count" binding:"required,min=0" example:"30"`
+}
+
+// CreateQuestionRequest represents the request payload for creating a question
+type CreateQuestionRequest struct {
+	Category     string                        `json:"category" binding:"required" example:"MANAJERIAL"`
+	QuestionText string                        `json:"question_text" binding:"required" example:"Atasan Anda melakukan rekayasa laporan..."`
+	Tags         []string                      `json:"tags" example:"integritas"`
+	Options      []CreateQuestionOptionRequest `json:"options" binding:"required,min=2,dive"`
+}
+
+// CreateQuestionOptionRequest represents an option inside CreateQuestionRequest
+type CreateQuestionOptionRequest struct {
+	OptionText string `json:"option_text" binding:"required" example:"Menolak dengan tegas dan melaporkan kepada atasan"`
+	Score      *int   `json:"score" binding:"required" example:"4"`
+}
+
+// UpdateQuestionRequest represents the request payload for updating a question
+type UpdateQuestionRequest struct {
+	Category     string `json:"category" binding:"required" example:"MANAJERIAL"`
+	QuestionText string `json:"question_text" binding:"required" example:"Atasan Anda melakukan rekayasa laporan..."`
+}
+
+// CategoryRequest represents the request payload for creating or updating a category
+type CategoryRequest struct {
+	Code          string `json:"code" binding:"required,max=100" example:"TEKNIS"`
+	Name          string `json:"name" binding:"required,max=150" example:"Teknis"`
+	Description   string `json:"description" example:"Kompetensi teknis sesuai jabatan yang dilamar"`
+	DisplayOrder  int    `json:"display_order" example:"1"`
+	ScoringScheme string `json:"scoring_scheme" example:"GRADED" enums:"GRADED,RIGHT_WRONG,NEGATIVE_MARKING"`
+	QuestionCount *int   `json:"question_count" binding:"required,min=0" example:"90"`
+	MaxScore      *int   `json:"max_score" binding:"required,min=0" example:"450"`
+}
+
+// GradingScaleRequest represents the request payload for creating a grading scale
+type GradingScaleRequest struct {
+	Code        string               `json:"code" binding:"required,max=50" example:"STRICT"`
+	Name        string               `json:"name" binding:"required,max=150" example:"Skala Ketat"`
+	Description string               `json:"description" example:"95%=A, 85%=B, 75%=C, <75%=E"`
+	Bands       []GradingBandRequest `json:"bands" binding:"required,min=1,dive"`
+}
+
+// GradingBandRequest represents a grade awarded from a minimum percentage upwards
+type GradingBandRequest struct {
+	Grade         string   `json:"grade" binding:"required,max=5" example:"A"`
+	MinPercentage *float64 `json:"min_percentage" binding:"required,min=0,max=100" example:"95"`
+}
+
+// PassRuleRequest represents the request payload for creating a pass rule
+type PassRuleRequest struct {
+	Code                 string                           `json:"code" binding:"required,max=50" example:"STRICT"`
+	Name                 string                           `json:"name" binding:"required,max=150" example:"Kelulusan Ketat"`
+	Description          string                           `json:"description" example:"Harus lulus semua kategori"`
+	OverallMinPercentage *float64                         `json:"overall_min_percentage" binding:"omitempty,min=0,max=100" example:"90"`
+	OverallMinScore      *int                             `json:"overall_min_score" binding:"omitempty,min=0"`
+	RequireAllCategories bool                             `json:"require_all_categories" example:"true"`
+	CategoryMinimums     []PassRuleCategoryMinimumRequest `json:"category_minimums" binding:"dive"`
+}
+
+// PassRuleCategoryMinimumRequest represents the minimum raw score and/or percentage to pass a category
+type PassRuleCategoryMinimumRequest struct {
+	Category      string   `json:"category" binding:"required" example:"TEKNIS"`
+	MinScore      *int     `json:"min_score" binding:"omitempty,min=0" example:"300"`
+	MinPercentage *float64 `json:"min_percentage" binding:"omitempty,min=0,max=100" example:"90"`
+}
+
+// BlueprintRequest represents the request payload for creating or updating an exam blueprint
+type BlueprintRequest struct {
+	Code            string `json:"code" binding:"required,max=50" example:"PPPK_GURU"`
+	Name            string `json:"name" binding:"required,max=150" example:"Ujian PPPK Guru"`
+	Description     string `json:"description" example:"Ujian PPPK untuk formasi guru"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1" example:"130"`
+	GradingScaleID  uint   `json:"grading_scale_id" binding:"required" example:"1"`
+	PassRuleID      uint   `json:"pass_rule_id" binding:"required" example:"1"`
+	IsDefault       bool   `json:"is_default" example:"false"`
+}
+
+// RegradeRequest represents the request payload for re-grading completed exam sessions.
+// Sessions are selected by blueprint and/or ID; the grading scale and pass rule
+// default to those of the blueprint.
+type RegradeRequest struct {
+	BlueprintID    *uint  `json:"blueprint_id" example:"1"`
+	SessionIDs     []uint `json:"session_ids" example:"1,2,3"`
+	GradingScaleID *uint  `json:"grading_scale_id" example:"2"`
+	PassRuleID     *uint  `json:"pass_rule_id" example:"2"`
+	DryRun         bool   `json:"dry_run" example:"true"`
+}
+
+// BulkScoreUpdateRequest represents the request payload for editing many option scores at once
+type BulkScoreUpdateRequest struct {
+	Updates []OptionScoreUpdateRequest `json:"updates" binding:"required,min=1,dive"`
+}
+
+// OptionScoreUpdateRequest represents the new score of one question option
+type OptionScoreUpdateRequest struct {
+	QuestionID uint `json:"question_id" binding:"required" example:"15"`
+	OptionID   uint `json:"option_id" binding:"required" example:"59"`
+	Score      *int `json:"score" binding:"required" example:"4"`
+}
+
+// CohortRequest represents the request payload for creating or updating a cohort.
+// Members are only used when creating; use the members endpoint afterwards.
+type CohortRequest struct {
+	Code        string                `json:"code" binding:"required,max=50" example:"TRYOUT-GURU-A"`
+	Name        string                `json:"name" binding:"required,max=150" example:"Kelas Tryout Guru A"`
+	Description string                `json:"description" example:"Kelas tryout formasi guru gelombang 1"`
+	Members     []CohortMemberRequest `json:"members" binding:"dive"`
+}
+
+// CohortMemberRequest represents a user added to a cohort with a role
+type CohortMemberRequest struct {
+	UserID string `json:"user_id" binding:"required,max=50" example:"1234"`
+	Role   string `json:"role" binding:"required,oneof=MEMBER INSTRUCTOR" example:"MEMBER" enums:"MEMBER,INSTRUCTOR"`
+}
+
+// SetCohortMembersRequest represents the request payload for adding users to a cohort or changing their role
+type SetCohortMembersRequest struct {
+	Members []CohortMemberRequest `json:"members" binding:"required,min=1,dive"`
+}
+
+// CohortAssignmentRequest represents the request payload for assigning a blueprint to a cohort.
+// The window is open-ended when available_until is omitted.
+type CohortAssignmentRequest struct {
+	BlueprintID    uint       `json:"blueprint_id" binding:"required" example:"2"`
+	AvailableFrom  time.Time  `json:"available_from" binding:"required" example:"2026-02-01T08:00:00Z"`
+	AvailableUntil *time.Time `json:"available_until" example:"2026-02-07T17:00:00Z"`
+}
+
+// SittingRequest represents the request payload for creating or updating a scheduled sitting.
+// Candidates can start between opens_at and closes_at; every session expires at ends_at.
+type SittingRequest struct {
+	Code        string    `json:"code" binding:"required,max=50" example:"JKT-2026-02-01-A"`
+	Name        string    `json:"name" binding:"required,max=150" example:"Sesi Pagi Jakarta"`
+	Location    string    `json:"location" binding:"max=255" example:"Gedung A Lt. 3, Jakarta"`
+	BlueprintID *uint     `json:"blueprint_id" example:"2"`
+	OpensAt     time.Time `json:"opens_at" binding:"required" example:"2026-02-01T08:00:00+07:00"`
+	ClosesAt    time.Time `json:"closes_at" binding:"required" example:"2026-02-01T08:30:00+07:00"`
+	EndsAt      time.Time `json:"ends_at" binding:"required" example:"2026-02-01T10:10:00+07:00"`
+	Capacity    int       `json:"capacity" binding:"required,min=1" example:"40"`
+	AccessCode  string    `json:"access_code" binding:"max=50" example:"K7P2Q9"`
+}
+
+// BookSittingRequest represents the request payload for booking users into a sitting
+type BookSittingRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,dive,required,max=50" example:"1234,5678"`
+}
+
+// SessionOverrideRequest represents the request payload for an admin override of an exam session
+type SessionOverrideRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000" example:"Candidate disputed a power outage during the exam"`
+}
+
+// SessionMinutesRequest represents the request payload for giving an exam session more time
+type SessionMinutesRequest struct {
+	Minutes int    `json:"minutes" binding:"required,min=1,max=1440" example:"30"`
+	Reason  string `json:"reason" binding:"required,max=1000" example:"Extra time for visual impairment"`
+}
+
+// AccommodationRequest represents the request payload for setting the accommodation of a candidate.
+// A time multiplier of 1.5 gives 50% extra time; extra breaks add break_minutes each.
+type AccommodationRequest struct {
+	TimeMultiplier float64 `json:"time_multiplier" binding:"required,gte=1,lte=3" example:"1.5"`
+	LargeFont      bool    `json:"large_font" example:"true"`
+	ExtraBreaks    int     `json:"extra_breaks" binding:"min=0,max=10" example:"2"`
+	BreakMinutes   int     `json:"break_minutes" binding:"min=0,max=60" example:"10"`
+	Notes          string  `json:"notes" binding:"max=1000" example:"Dyslexia"`
+}
+
+// WebhookRequest represents the request payload for creating or updating a webhook. Every
+// request carries an X-Webhook-Signature header signed with the secret.
+type WebhookRequest struct {
+	Name   string   `json:"name" binding:"required,max=100" example:"Proctoring system"`
+	URL    string   `json:"url" binding:"required,max=2048" example:"https://proctor.example.com/hooks/pppk"`
+	Secret string   `json:"secret" binding:"max=255" example:"s3cr3t-shared-with-receiver"` // Kept when empty on update
+	Events []string `json:"events" binding:"required,min=1" example:"session.started,session.completed"`
+	Active *bool    `json:"active" example:"true"` // Defaults to true
+}
+
+// NotificationContactRequest represents the request payload for setting the contact of a candidate.
+// Opted out candidates are sent nothing, also notifications already queued.
+type NotificationContactRequest struct {
+	Email    string `json:"email" binding:"required,max=255" example:"candidate@example.com"`
+	Name     string `json:"name" binding:"max=150" example:"Siti Rahayu"`
+	Locale   string `json:"locale" binding:"omitempty,oneof=id en" example:"id"` // Defaults to id
+	OptedOut bool   `json:"opted_out" example:"false"`
 }
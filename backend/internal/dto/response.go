@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // APIResponse represents the standard API response format
 type APIResponse struct {
@@ -12,21 +15,37 @@ type APIResponse struct {
 
 // ExamSessionResponse represents the exam session response
 type ExamSessionResponse struct {
-	SessionID     uint                    `json:"session_id" example:"1"`
-	UserID        string                  `json:"user_id" example:"1234"`
-	SessionCode   string                  `json:"session_code" example:"EXAM_1234_1643356800"`
-	Status        string                  `json:"status" example:"NOT_STARTED" enums:"NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED"`
-	ExpiresAt     time.Time               `json:"expires_at" example:"2026-01-28T12:00:00Z"`
-	Duration      int                     `json:"duration" example:"120"`
-	Questions     []QuestionResponse      `json:"questions"`
-	CategoryStats []CategoryStatsResponse `json:"category_stats"`
+	SessionID            uint                    `json:"session_id" example:"1"`
+	UserID               string                  `json:"user_id" example:"1234"`
+	SessionCode          string                  `json:"session_code" example:"EXAM_1234_1643356800"`
+	BlueprintID          *uint                   `json:"blueprint_id" example:"1"`
+	AssignmentID         *uint                   `json:"assignment_id" example:"3"`
+	SittingID            *uint                   `json:"sitting_id" example:"5"`
+	Status               string                  `json:"status" example:"NOT_STARTED" enums:"NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
+	ExpiresAt            time.Time               `json:"expires_at" example:"2026-01-28T12:00:00Z"`
+	Duration             int                     `json:"duration" example:"180"`
+	AccommodationMinutes int                     `json:"accommodation_minutes" example:"60"` // Part of duration added by the accommodation
+	Accommodation        *AccommodationResponse  `json:"accommodation,omitempty"`
+	Questions            []QuestionResponse      `json:"questions"`
+	CategoryStats        []CategoryStatsResponse `json:"category_stats"`
+}
+
+// AccommodationResponse represents the accommodation of a candidate
+type AccommodationResponse struct {
+	UserID         string    `json:"user_id" example:"1234"`
+	TimeMultiplier float64   `json:"time_multiplier" example:"1.5"`
+	LargeFont      bool      `json:"large_font" example:"true"`
+	ExtraBreaks    int       `json:"extra_breaks" example:"2"`
+	BreakMinutes   int       `json:"break_minutes" example:"10"`
+	Notes          string    `json:"notes,omitempty" example:"Dyslexia"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2026-01-20T09:00:00Z"`
 }
 
 // QuestionResponse represents a question in the exam session
 type QuestionResponse struct {
 	ExamQuestionID uint                     `json:"exam_question_id" example:"1"`
 	QuestionID     uint                     `json:"question_id" example:"15"`
-	Category       string                   `json:"category" example:"MANAJERIAL" enums:"MANAJERIAL,SOSIAL KULTURAL,TEKNIS,WAWANCARA"`
+	Category       string                   `json:"category" example:"MANAJERIAL"`
 	OrderNumber    int                      `json:"order_number" example:"1"`
 	QuestionText   string                   `json:"question_text" example:"Atasan Anda melakukan rekayasa laporan..."`
 	Options        []QuestionOptionResponse `json:"options"`
@@ -47,8 +66,9 @@ type CategoryStatsResponse struct {
 
 // ExamResultsResponse represents the complete exam results
 type ExamResultsResponse struct {
-	Summary           ExamSummaryResponse  `json:"summary"`
-	ResultsByCategory []ExamResultResponse `json:"results_by_category"`
+	Summary           ExamSummaryResponse     `json:"summary"`
+	ResultsByCategory []ExamResultResponse    `json:"results_by_category"`
+	ResultsByTag      []ExamTagResultResponse `json:"results_by_tag"`
 }
 
 // ExamSummaryResponse represents the exam summary
@@ -63,28 +83,48 @@ type ExamSummaryResponse struct {
 	OverallPercentage float64   `json:"overall_percentage" example:"81.25"`
 	OverallGrade      string    `json:"overall_grade" example:"B"`
 	IsPassed          bool      `json:"is_passed" example:"true"`
+	GradingScaleID    *uint     `json:"grading_scale_id" example:"1"`
+	PassRuleID        *uint     `json:"pass_rule_id" example:"1"`
 	CompletedAt       time.Time `json:"completed_at" example:"2026-01-28T11:30:00Z"`
+	Rank              *int      `json:"rank,omitempty" example:"4"`                // Among the latest attempts of every candidate of the blueprint
+	RankedCandidates  *int      `json:"ranked_candidates,omitempty" example:"120"` // Candidates ranked together with this session
+	Percentile        *float64  `json:"percentile,omitempty" example:"93.75"`      // Share of candidates scoring lower, plus half of those scoring the same
 }
 
 // ExamResultResponse represents exam results by category
 type ExamResultResponse struct {
-	ID             uint    `json:"id" example:"1"`
-	ExamSessionID  uint    `json:"exam_session_id" example:"1"`
-	Category       string  `json:"category" example:"MANAJERIAL"`
-	TotalQuestions int     `json:"total_questions" example:"5"`
-	TotalAnswered  int     `json:"total_answered" example:"5"`
-	TotalScore     int     `json:"total_score" example:"16"`
-	MaxScore       int     `json:"max_score" example:"20"`
-	Percentage     float64 `json:"percentage" example:"80.0"`
-	Grade          string  `json:"grade" example:"B"`
-	IsPassed       bool    `json:"is_passed" example:"true"`
+	ID             uint     `json:"id" example:"1"`
+	ExamSessionID  uint     `json:"exam_session_id" example:"1"`
+	Category       string   `json:"category" example:"MANAJERIAL"`
+	TotalQuestions int      `json:"total_questions" example:"5"`
+	TotalAnswered  int      `json:"total_answered" example:"5"`
+	TotalScore     int      `json:"total_score" example:"16"`
+	MaxScore       int      `json:"max_score" example:"20"`
+	Percentage     float64  `json:"percentage" example:"80.0"`
+	Grade          string   `json:"grade" example:"B"`
+	IsPassed       bool     `json:"is_passed" example:"true"`
+	GradingScaleID *uint    `json:"grading_scale_id" example:"1"`
+	PassRuleID     *uint    `json:"pass_rule_id" example:"1"`
+	Rank           *int     `json:"rank,omitempty" example:"10"`
+	Percentile     *float64 `json:"percentile,omitempty" example:"87.5"`
+}
+
+// ExamTagResultResponse represents exam results by tag (sub-topic)
+type ExamTagResultResponse struct {
+	TagID          uint    `json:"tag_id" example:"3"`
+	TagName        string  `json:"tag_name" example:"pedagogi"`
+	TotalQuestions int     `json:"total_questions" example:"12"`
+	TotalAnswered  int     `json:"total_answered" example:"11"`
+	TotalScore     int     `json:"total_score" example:"40"`
+	MaxScore       int     `json:"max_score" example:"60"`
+	Percentage     float64 `json:"percentage" example:"66.67"`
 }
 
 // DashboardResponse represents the dashboard data response
 type DashboardResponse struct {
 	UserID       string                `json:"user_id" example:"1234"`
 	HasExam      bool                  `json:"has_exam" example:"true"`
-	ExamStatus   string                `json:"exam_status" example:"IN_PROGRESS" enums:"NO_EXAM,NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED"`
+	ExamStatus   string                `json:"exam_status" example:"IN_PROGRESS" enums:"NO_EXAM,NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
 	ExamSession  *ExamSessionResponse  `json:"exam_session,omitempty"`
 	ExamResults  *ExamResultsResponse  `json:"exam_results,omitempty"`
 	ProgressInfo *ProgressInfoResponse `json:"progress_info,omitempty"`
@@ -99,13 +139,14 @@ type ProgressInfoResponse struct {
 
 // DashboardData represents dashboard information for a user (internal use)
 type DashboardData struct {
-	UserID       string        `json:"user_id"`
-	HasExam      bool          `json:"has_exam"`
-	ExamStatus   string        `json:"exam_status"` // NO_EXAM, NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED
-	ExamSession  interface{}   `json:"exam_session,omitempty"`
-	ExamSummary  interface{}   `json:"exam_summary,omitempty"`
-	ExamResults  interface{}   `json:"exam_results,omitempty"`
-	ProgressInfo *ProgressInfo `json:"progress_info,omitempty"`
+	UserID         string        `json:"user_id"`
+	HasExam        bool          `json:"has_exam"`
+	ExamStatus     string        `json:"exam_status"` // NO_EXAM, NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED
+	ExamSession    interface{}   `json:"exam_session,omitempty"`
+	ExamSummary    interface{}   `json:"exam_summary,omitempty"`
+	ExamResults    interface{}   `json:"exam_results,omitempty"`
+	ExamTagResults interface{}   `json:"exam_tag_results,omitempty"`
+	ProgressInfo   *ProgressInfo `json:"progress_info,omitempty"`
 }
 
 // ProgressInfo represents exam progress information (internal use)
@@ -117,14 +158,15 @@ type ProgressInfo struct {
 
 // UserListDashboardResponse represents the response for list of users dashboard
 type UserListDashboardResponse struct {
-	TotalUsers int                    `json:"total_users" example:"25"`
+	TotalUsers int                    `json:"total_users" example:"25"` // Users matching the filters, on all pages
 	Users      []UserDashboardSummary `json:"users"`
+	NextCursor string                 `json:"next_cursor,omitempty" example:"eyJzIjoic2NvcmUiLCJkIjp0cnVlLCJrIjoiMTIiLCJpIjo0Mn0"` // Cursor of the next page, omitted on the last page
 }
 
 // UserDashboardSummary represents summary information for each user
 type UserDashboardSummary struct {
 	UserID      string   `json:"user_id" example:"1234"`
-	ExamStatus  string   `json:"exam_status" example:"COMPLETED" enums:"NO_EXAM,NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED"`
+	ExamStatus  string   `json:"exam_status" example:"COMPLETED" enums:"NO_EXAM,NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
 	SessionCode string   `json:"session_code,omitempty" example:"EXAM_1234_1643356800"`
 	StartedAt   *string  `json:"started_at,omitempty" example:"2026-01-28T10:00:00Z"`
 	CompletedAt *string  `json:"completed_at,omitempty" example:"2026-01-28T11:30:00Z"`
@@ -133,6 +175,12 @@ type UserDashboardSummary struct {
 	Percentage  *float64 `json:"percentage,omitempty" example:"75.0"`
 	Grade       *string  `json:"grade,omitempty" example:"B"`
 	IsPassed    *bool    `json:"is_passed,omitempty" example:"true"`
+
+	// Accommodation of the candidate, omitted when it has none
+	TimeMultiplier       *float64 `json:"time_multiplier,omitempty" example:"1.5"`
+	LargeFont            *bool    `json:"large_font,omitempty" example:"true"`
+	ExtraBreaks          *int     `json:"extra_breaks,omitempty" example:"2"`
+	AccommodationMinutes *int     `json:"accommodation_minutes,omitempty" example:"80"`
 }
 
 // DetailedAnswer represents a detailed user answer with question and score information
@@ -157,6 +205,7 @@ type QuestionManagementResponse struct {
 	ID           uint                               `json:"id" example:"1"`
 	Category     string                             `json:"category" example:"MANAJERIAL"`
 	QuestionText string                             `json:"question_text" example:"Atasan Anda melakukan rekayasa laporan..."`
+	Tags         []string                           `json:"tags" example:"pedagogi,regulasi"`
 	Options      []QuestionOptionManagementResponse `json:"options"`
 	CreatedAt    time.Time                          `json:"created_at" example:"2026-01-28T10:00:00Z"`
 	UpdatedAt    time.Time                          `json:"updated_at" example:"2026-01-28T10:00:00Z"`
@@ -191,6 +240,7 @@ type ExportQuestionResponse struct {
 	ID           string                         `json:"id"`
 	Category     string                         `json:"category"`
 	QuestionText string                         `json:"question_text"`
+	Tags         []string                       `json:"tags,omitempty"`
 	Options      []ExportQuestionOptionResponse `json:"options"`
 }
 
@@ -199,3 +249,520 @@ type ExportQuestionOptionResponse struct {
 	OptionText string `json:"option_text"`
 	Score      int    `json:"score"`
 }
+
+// TagResponse represents a question tag
+type TagResponse struct {
+	ID          uint   `json:"id" example:"3"`
+	Name        string `json:"name" example:"pedagogi"`
+	Description string `json:"description" example:"Kompetensi pedagogik guru"`
+}
+
+// TagQuotaResponse represents the number of questions reserved for a tag within a category
+type TagQuotaResponse struct {
+	ID            uint   `json:"id" example:"1"`
+	Category      string `json:"category" example:"TEKNIS"`
+	Tag           string `json:"tag" example:"pedagogi"`
+	QuestionCount int    `json:"question_count" example:"30"`
+}
+
+// CategoryResponse represents an exam category (section)
+type CategoryResponse struct {
+	ID            uint   `json:"id" example:"1"`
+	Code          string `json:"code" example:"TEKNIS"`
+	Name          string `json:"name" example:"Teknis"`
+	Description   string `json:"description" example:"Kompetensi teknis sesuai jabatan yang dilamar"`
+	DisplayOrder  int    `json:"display_order" example:"1"`
+	ScoringScheme string `json:"scoring_scheme" example:"GRADED"`
+	QuestionCount int    `json:"question_count" example:"90"`
+	MaxScore      int    `json:"max_score" example:"450"`
+}
+
+// GradingScaleResponse represents a grading scale with its bands
+type GradingScaleResponse struct {
+	ID          uint                  `json:"id" example:"1"`
+	Code        string                `json:"code" example:"DEFAULT"`
+	Name        string                `json:"name" example:"Skala Nilai PPPK"`
+	Description string                `json:"description" example:"100%=A, 90%=B, 80%=C, 70%=D, <70%=E"`
+	Bands       []GradingBandResponse `json:"bands"`
+}
+
+// GradingBandResponse represents a grade band, ordered from the highest minimum
+type GradingBandResponse struct {
+	Grade         string  `json:"grade" example:"B"`
+	MinPercentage float64 `json:"min_percentage" example:"90"`
+}
+
+// PassRuleResponse represents a pass rule
+type PassRuleResponse struct {
+	ID                   uint                              `json:"id" example:"1"`
+	Code                 string                            `json:"code" example:"DEFAULT"`
+	Name                 string                            `json:"name" example:"Kelulusan PPPK"`
+	Description          string                            `json:"description" example:"Minimal 90% keseluruhan dan 90% per kategori"`
+	OverallMinPercentage *float64                          `json:"overall_min_percentage" example:"90"`
+	OverallMinScore      *int                              `json:"overall_min_score"`
+	RequireAllCategories bool                              `json:"require_all_categories" example:"false"`
+	CategoryMinimums     []PassRuleCategoryMinimumResponse `json:"category_minimums"`
+}
+
+// PassRuleCategoryMinimumResponse represents the minimum to pass a category
+type PassRuleCategoryMinimumResponse struct {
+	Category      string   `json:"category" example:"TEKNIS"`
+	MinScore      *int     `json:"min_score"`
+	MinPercentage *float64 `json:"min_percentage" example:"90"`
+}
+
+// BlueprintResponse represents an exam blueprint
+type BlueprintResponse struct {
+	ID               uint   `json:"id" example:"1"`
+	Code             string `json:"code" example:"DEFAULT"`
+	Name             string `json:"name" example:"Ujian PPPK"`
+	Description      string `json:"description" example:"Blueprint default ujian PPPK"`
+	DurationMinutes  int    `json:"duration_minutes" example:"130"`
+	GradingScaleID   uint   `json:"grading_scale_id" example:"1"`
+	GradingScaleCode string `json:"grading_scale_code" example:"DEFAULT"`
+	PassRuleID       uint   `json:"pass_rule_id" example:"1"`
+	PassRuleCode     string `json:"pass_rule_code" example:"DEFAULT"`
+	IsDefault        bool   `json:"is_default" example:"true"`
+}
+
+// RegradeReport represents the outcome of re-grading completed exam sessions
+type RegradeReport struct {
+	DryRun           bool                   `json:"dry_run" example:"false"`
+	GradingScaleID   uint                   `json:"grading_scale_id" example:"2"`
+	PassRuleID       uint                   `json:"pass_rule_id" example:"2"`
+	SessionsRegraded int                    `json:"sessions_regraded" example:"120"`
+	VerdictsChanged  int                    `json:"verdicts_changed" example:"7"`
+	Sessions         []RegradedSessionEntry `json:"sessions"`
+}
+
+// RegradedSessionEntry represents the grade and verdict of a session before and after re-grading
+type RegradedSessionEntry struct {
+	ExamSessionID  uint    `json:"exam_session_id" example:"1"`
+	UserID         string  `json:"user_id" example:"1234"`
+	Percentage     float64 `json:"percentage" example:"88.5"`
+	PreviousGrade  string  `json:"previous_grade" example:"C"`
+	Grade          string  `json:"grade" example:"B"`
+	PreviousPassed bool    `json:"previous_passed" example:"false"`
+	IsPassed       bool    `json:"is_passed" example:"true"`
+}
+
+// RescoreReport represents the outcome of re-scoring the sessions that drew the given questions
+type RescoreReport struct {
+	DryRun           bool                   `json:"dry_run" example:"false"`
+	QuestionIDs      []uint                 `json:"question_ids" example:"15"`
+	SessionsRescored int                    `json:"sessions_rescored" example:"42"`
+	AnswersChanged   int                    `json:"answers_changed" example:"40"`
+	VerdictsChanged  int                    `json:"verdicts_changed" example:"3"`
+	Sessions         []RescoredSessionEntry `json:"sessions"`
+}
+
+// RescoredSessionEntry represents the score and verdict of a session before and after re-scoring
+type RescoredSessionEntry struct {
+	ExamSessionID      uint    `json:"exam_session_id" example:"1"`
+	UserID             string  `json:"user_id" example:"1234"`
+	AnswersChanged     int     `json:"answers_changed" example:"1"`
+	PreviousScore      int     `json:"previous_score" example:"610"`
+	Score              int     `json:"score" example:"615"`
+	PreviousPercentage float64 `json:"previous_percentage" example:"88.4"`
+	Percentage         float64 `json:"percentage" example:"89.13"`
+	PreviousGrade      string  `json:"previous_grade" example:"C"`
+	Grade              string  `json:"grade" example:"C"`
+	PreviousPassed     bool    `json:"previous_passed" example:"false"`
+	IsPassed           bool    `json:"is_passed" example:"false"`
+}
+
+// OptionScoreUpdateResponse represents an updated option with the re-score it caused
+type OptionScoreUpdateResponse struct {
+	Option  QuestionOptionManagementResponse `json:"option"`
+	Rescore *RescoreReport                   `json:"rescore"`
+}
+
+// AuditLogResponse represents a recorded change with its before and after snapshots
+type AuditLogResponse struct {
+	ID           uint            `json:"id" example:"1"`
+	Actor        string          `json:"actor" example:"admin"`
+	ActorClaimed bool            `json:"actor_claimed" example:"true"` // Named by the client and not verified, the server has no authentication
+	Action       string          `json:"action" example:"UPDATE" enums:"CREATE,UPDATE,DELETE,RESCORE,SESSION_EXTEND,SESSION_FORCE_COMPLETE,SESSION_RESET,SESSION_VOID,SESSION_REOPEN"`
+	EntityType   string          `json:"entity_type" example:"question_options"`
+	EntityID     string          `json:"entity_id" example:"59"`
+	Before       json.RawMessage `json:"before" swaggertype:"object"`
+	After        json.RawMessage `json:"after" swaggertype:"object"`
+	RequestID    string          `json:"request_id" example:"4f1c2a9e8b7d6c5e4f3a2b1c0d9e8f7a"`
+	RemoteAddr   string          `json:"remote_addr" example:"10.0.0.12"`
+	Reason       *string         `json:"reason,omitempty" example:"Power outage at the test centre"`
+	CreatedAt    time.Time       `json:"created_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// PaginatedAuditLogResponse represents paginated audit log response
+type PaginatedAuditLogResponse struct {
+	AuditLogs  []AuditLogResponse `json:"audit_logs"`
+	Pagination PaginationMetadata `json:"pagination"`
+}
+
+// BulkScoreReport represents the per-row outcome of a bulk score edit
+type BulkScoreReport struct {
+	DryRun        bool                 `json:"dry_run" example:"false"`
+	Applied       bool                 `json:"applied" example:"true"` // False when any row is invalid or on dry run
+	TotalRows     int                  `json:"total_rows" example:"3"`
+	UpdatedRows   int                  `json:"updated_rows" example:"2"`
+	UnchangedRows int                  `json:"unchanged_rows" example:"1"`
+	FailedRows    int                  `json:"failed_rows" example:"0"`
+	Rows          []BulkScoreRowResult `json:"rows"`
+	Rescore       *RescoreReport       `json:"rescore,omitempty"`
+}
+
+// BulkScoreRowResult represents the outcome of one row of a bulk score edit
+type BulkScoreRowResult struct {
+	Row           int    `json:"row" example:"1"`
+	QuestionID    uint   `json:"question_id" example:"15"`
+	OptionID      uint   `json:"option_id" example:"59"`
+	PreviousScore *int   `json:"previous_score" example:"3"`
+	Score         int    `json:"score" example:"4"`
+	Status        string `json:"status" example:"UPDATED" enums:"UPDATED,UNCHANGED,INVALID"`
+	Error         string `json:"error,omitempty" example:""`
+}
+
+// QuestionImportReport represents the outcome or preview of a question import
+type QuestionImportReport struct {
+	Preview    bool             `json:"preview" example:"false"`
+	TotalRows  int              `json:"total_rows" example:"120"`
+	Imported   int              `json:"imported" example:"120"`
+	ByCategory map[string]int   `json:"by_category"`
+	Errors     []ImportRowError `json:"errors"`
+}
+
+// ImportRowError represents a problem found on a row of an imported file
+type ImportRowError struct {
+	Row     int    `json:"row" example:"7"`
+	Field   string `json:"field" example:"score_2"`
+	Message string `json:"message" example:"invalid option score: graded options must score between 1 and 4, got 5"`
+}
+
+// OfflineAnswerReport represents the outcome or dry run of loading paper answer sheets
+type OfflineAnswerReport struct {
+	DryRun       bool                   `json:"dry_run" example:"false"`
+	TotalRows    int                    `json:"total_rows" example:"240"`
+	AnsweredRows int                    `json:"answered_rows" example:"236"`
+	BlankRows    int                    `json:"blank_rows" example:"4"`
+	Sessions     []OfflineSessionResult `json:"sessions"`
+	Errors       []ImportRowError       `json:"errors"`
+}
+
+// OfflineSessionResult represents the result of a session completed from a paper answer sheet
+type OfflineSessionResult struct {
+	SessionCode       string  `json:"session_code" example:"EXAM_1234_1700000000"`
+	UserID            string  `json:"user_id" example:"1234"`
+	Answered          int     `json:"answered" example:"118"`
+	TotalScore        int     `json:"total_score" example:"410"`
+	MaxScore          int     `json:"max_score" example:"480"`
+	OverallPercentage float64 `json:"overall_percentage" example:"85.42"`
+	OverallGrade      string  `json:"overall_grade" example:"C"`
+	IsPassed          bool    `json:"is_passed" example:"false"`
+}
+
+// ReportVerificationResponse represents the result recorded for a verified score report
+type ReportVerificationResponse struct {
+	Valid             bool      `json:"valid" example:"true"`
+	Reason            string    `json:"reason,omitempty" example:"voided"` // Why an authentic report no longer verifies
+	Code              string    `json:"code" example:"R42-ABCD-EFGH-IJKL-MNOP"`
+	UserID            string    `json:"user_id" example:"1234"`
+	SessionCode       string    `json:"session_code" example:"EXAM_1234_1700000000"`
+	TotalScore        int       `json:"total_score" example:"410"`
+	MaxScore          int       `json:"max_score" example:"480"`
+	OverallPercentage float64   `json:"overall_percentage" example:"85.42"`
+	OverallGrade      string    `json:"overall_grade" example:"C"`
+	IsPassed          bool      `json:"is_passed" example:"false"`
+	CompletedAt       time.Time `json:"completed_at"`
+}
+
+// CohortResponse represents a cohort with its members and exam assignments
+type CohortResponse struct {
+	ID              uint                       `json:"id" example:"1"`
+	Code            string                     `json:"code" example:"TRYOUT-GURU-A"`
+	Name            string                     `json:"name" example:"Kelas Tryout Guru A"`
+	Description     string                     `json:"description" example:"Kelas tryout formasi guru gelombang 1"`
+	MemberCount     int                        `json:"member_count" example:"30"`
+	InstructorCount int                        `json:"instructor_count" example:"2"`
+	Members         []CohortMemberResponse     `json:"members"`
+	Assignments     []CohortAssignmentResponse `json:"assignments,omitempty"`
+}
+
+// CohortMemberResponse represents a user in a cohort
+type CohortMemberResponse struct {
+	UserID string `json:"user_id" example:"1234"`
+	Role   string `json:"role" example:"MEMBER" enums:"MEMBER,INSTRUCTOR"`
+}
+
+// CohortAssignmentResponse represents a blueprint assigned to a cohort inside an availability window
+type CohortAssignmentResponse struct {
+	ID             uint       `json:"id" example:"3"`
+	CohortID       uint       `json:"cohort_id" example:"1"`
+	BlueprintID    uint       `json:"blueprint_id" example:"2"`
+	BlueprintCode  string     `json:"blueprint_code" example:"GURU"`
+	BlueprintName  string     `json:"blueprint_name" example:"Ujian PPPK Guru"`
+	AvailableFrom  time.Time  `json:"available_from" example:"2026-02-01T08:00:00Z"`
+	AvailableUntil *time.Time `json:"available_until" example:"2026-02-07T17:00:00Z"`
+	AssignedBy     string     `json:"assigned_by" example:"instruktur1"`
+	IsOpen         bool       `json:"is_open" example:"true"` // Whether members can start it now
+}
+
+// CohortDashboardResponse represents the exam status of every member of a cohort
+type CohortDashboardResponse struct {
+	CohortID     uint                   `json:"cohort_id" example:"1"`
+	CohortCode   string                 `json:"cohort_code" example:"TRYOUT-GURU-A"`
+	CohortName   string                 `json:"cohort_name" example:"Kelas Tryout Guru A"`
+	AssignmentID *uint                  `json:"assignment_id,omitempty" example:"3"`
+	TotalMembers int                    `json:"total_members" example:"30"`
+	StatusCounts map[string]int         `json:"status_counts"` // Members per exam status, NO_EXAM for members who have not started
+	Users        []UserDashboardSummary `json:"users"`
+}
+
+// SittingResponse represents a scheduled sitting with its booked candidates; the access code is never returned
+type SittingResponse struct {
+	ID            uint      `json:"id" example:"5"`
+	Code          string    `json:"code" example:"JKT-2026-02-01-A"`
+	Name          string    `json:"name" example:"Sesi Pagi Jakarta"`
+	Location      string    `json:"location" example:"Gedung A Lt. 3, Jakarta"`
+	BlueprintID   *uint     `json:"blueprint_id" example:"2"`
+	BlueprintCode string    `json:"blueprint_code,omitempty" example:"GURU"`
+	OpensAt       time.Time `json:"opens_at" example:"2026-02-01T08:00:00+07:00"`
+	ClosesAt      time.Time `json:"closes_at" example:"2026-02-01T08:30:00+07:00"`
+	EndsAt        time.Time `json:"ends_at" example:"2026-02-01T10:10:00+07:00"`
+	Capacity      int       `json:"capacity" example:"40"`
+	SeatsLeft     int       `json:"seats_left" example:"12"`
+	HasAccessCode bool      `json:"has_access_code" example:"true"`
+	Status        string    `json:"status" example:"OPEN" enums:"SCHEDULED,OPEN,CLOSED,ENDED"`
+	Candidates    []string  `json:"candidates"`
+}
+
+// SittingWithAccessCodeResponse represents a sitting just created or updated, with its access code
+type SittingWithAccessCodeResponse struct {
+	SittingResponse
+	AccessCode string `json:"access_code,omitempty" example:"K7P2Q9"`
+}
+
+// LeaderboardResponse represents a page of anonymised candidates ranked by total score
+type LeaderboardResponse struct {
+	BlueprintID     *uint              `json:"blueprint_id,omitempty" example:"1"`
+	SittingID       *uint              `json:"sitting_id,omitempty" example:"5"`
+	CohortID        *uint              `json:"cohort_id,omitempty" example:"1"`
+	TotalCandidates int                `json:"total_candidates" example:"120"`
+	Entries         []LeaderboardEntry `json:"entries"`
+	You             *LeaderboardEntry  `json:"you,omitempty"` // Entry of the user_id query parameter
+	Pagination      PaginationMetadata `json:"pagination"`
+}
+
+// LeaderboardEntry represents the latest completed attempt of one candidate
+type LeaderboardEntry struct {
+	Rank        int       `json:"rank" example:"4"` // Candidates with the same total score share a rank
+	DisplayName string    `json:"display_name" example:"Candidate #4"`
+	TotalScore  int       `json:"total_score" example:"412"`
+	MaxScore    int       `json:"max_score" example:"450"`
+	Percentage  float64   `json:"percentage" example:"91.56"`
+	Grade       string    `json:"grade" example:"B"`
+	IsPassed    bool      `json:"is_passed" example:"true"`
+	Percentile  float64   `json:"percentile" example:"97.08"`
+	CompletedAt time.Time `json:"completed_at" example:"2026-01-28T11:30:00Z"`
+	IsYou       bool      `json:"is_you" example:"false"`
+}
+
+// SessionStanding represents how a completed session compares with the other candidates of its blueprint (internal use)
+type SessionStanding struct {
+	BlueprintID         uint
+	Candidates          int
+	Rank                int
+	Percentile          float64
+	CategoryRanks       map[string]int
+	CategoryPercentiles map[string]float64
+}
+
+// SessionOverrideResponse represents an exam session after an admin override
+type SessionOverrideResponse struct {
+	SessionID      uint       `json:"session_id" example:"1"`
+	UserID         string     `json:"user_id" example:"1234"`
+	Action         string     `json:"action" example:"SESSION_EXTEND" enums:"SESSION_EXTEND,SESSION_FORCE_COMPLETE,SESSION_RESET,SESSION_VOID,SESSION_REOPEN"`
+	PreviousStatus string     `json:"previous_status" example:"IN_PROGRESS"`
+	Status         string     `json:"status" example:"IN_PROGRESS" enums:"NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
+	StartedAt      *time.Time `json:"started_at" example:"2026-01-28T10:00:00Z"`
+	CompletedAt    *time.Time `json:"completed_at" example:"2026-01-28T11:30:00Z"`
+	ExpiresAt      time.Time  `json:"expires_at" example:"2026-01-28T12:30:00Z"`
+	Duration       int        `json:"duration" example:"160"`
+	Reason         string     `json:"reason" example:"Extra time for visual impairment"`
+}
+
+// SessionTransitionResponse represents a status change of an exam session
+type SessionTransitionResponse struct {
+	ID         uint      `json:"id" example:"1"`
+	FromStatus string    `json:"from_status" example:"NOT_STARTED" enums:"NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
+	ToStatus   string    `json:"to_status" example:"IN_PROGRESS" enums:"NOT_STARTED,IN_PROGRESS,COMPLETED,EXPIRED,VOIDED"`
+	Trigger    string    `json:"trigger" example:"START"` // START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an admin override
+	Actor      string    `json:"actor" example:"system"`
+	RequestID  string    `json:"request_id" example:"7f3c2a9e-1b4d-4e8a-9c6f-2d5b8a1e0f34"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// DashboardStatsResponse represents aggregate exam statistics over a time range. Sessions are
+// counted when created in range, results when completed in range; voided sessions only
+// appear in the status counts.
+type DashboardStatsResponse struct {
+	From          time.Time            `json:"from" example:"2026-01-01T00:00:00+07:00"`
+	To            time.Time            `json:"to" example:"2026-02-01T00:00:00+07:00"`
+	BlueprintID   *uint                `json:"blueprint_id,omitempty" example:"2"`
+	CohortID      *uint                `json:"cohort_id,omitempty" example:"1"`
+	TotalSessions int                  `json:"total_sessions" example:"420"`
+	StatusCounts  map[string]int       `json:"status_counts"`
+	Overall       ScoreStats           `json:"overall"`
+	Categories    []CategoryScoreStats `json:"categories"`
+	Daily         []DailyCompletion    `json:"daily"`
+}
+
+// ScoreStats represents the score statistics and grade distribution of a set of results
+type ScoreStats struct {
+	Count             int          `json:"count" example:"380"`
+	Passed            int          `json:"passed" example:"247"`
+	PassRate          float64      `json:"pass_rate" example:"65.0"` // Percentage of results that passed
+	AverageScore      float64      `json:"average_score" example:"58.4"`
+	MedianScore       float64      `json:"median_score" example:"60"`
+	StdDevScore       float64      `json:"std_dev_score" example:"9.7"` // Population standard deviation
+	MinScore          int          `json:"min_score" example:"21"`
+	MaxScore          int          `json:"max_score" example:"78"`
+	AveragePercentage float64      `json:"average_percentage" example:"73.0"`
+	Grades            []GradeCount `json:"grades"`
+}
+
+// CategoryScoreStats represents the score statistics of one category
+type CategoryScoreStats struct {
+	Category string `json:"category" example:"MANAJERIAL"`
+	ScoreStats
+}
+
+// GradeCount represents the number of results with a grade
+type GradeCount struct {
+	Grade string `json:"grade" example:"B"`
+	Count int    `json:"count" example:"120"`
+}
+
+// DailyCompletion represents the results completed on one calendar day
+type DailyCompletion struct {
+	Date      string `json:"date" example:"2026-01-28"`
+	Completed int    `json:"completed" example:"35"`
+	Passed    int    `json:"passed" example:"22"`
+}
+
+// WebhookResponse represents a webhook; the secret is never returned
+type WebhookResponse struct {
+	ID        uint      `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Proctoring system"`
+	URL       string    `json:"url" example:"https://proctor.example.com/hooks/pppk"`
+	HasSecret bool      `json:"has_secret" example:"true"`
+	Events    []string  `json:"events" example:"session.started,session.completed"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2026-01-28T10:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// WebhookDeliveryResponse represents one event delivered to a webhook with its attempts
+type WebhookDeliveryResponse struct {
+	ID            uint                             `json:"id" example:"1"`
+	WebhookID     uint                             `json:"webhook_id" example:"1"`
+	EventID       string                           `json:"event_id" example:"9f2c4e1a7b3d5f60718293a4b5c6d7e8"`
+	Event         string                           `json:"event" example:"session.completed" enums:"session.created,session.started,answer.submitted,session.completed,session.expired"`
+	Payload       json.RawMessage                  `json:"payload" swaggertype:"object"`
+	Status        string                           `json:"status" example:"DELIVERED" enums:"PENDING,DELIVERED,FAILED"`
+	Attempts      int                              `json:"attempts" example:"1"`
+	NextAttemptAt *time.Time                       `json:"next_attempt_at" example:"2026-01-28T10:00:30Z"` // Only while pending
+	LastAttemptAt *time.Time                       `json:"last_attempt_at" example:"2026-01-28T10:00:00Z"`
+	DeliveredAt   *time.Time                       `json:"delivered_at" example:"2026-01-28T10:00:00Z"`
+	CreatedAt     time.Time                        `json:"created_at" example:"2026-01-28T10:00:00Z"`
+	AttemptLog    []WebhookDeliveryAttemptResponse `json:"attempt_log"`
+}
+
+// WebhookDeliveryAttemptResponse represents one HTTP request of a webhook delivery
+type WebhookDeliveryAttemptResponse struct {
+	Attempt        int       `json:"attempt" example:"1"`
+	ResponseStatus *int      `json:"response_status" example:"200"` // Null when no response was received
+	ResponseBody   string    `json:"response_body" example:"ok"`
+	Error          string    `json:"error,omitempty" example:""`
+	DurationMs     int       `json:"duration_ms" example:"84"`
+	CreatedAt      time.Time `json:"created_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// PaginatedWebhookDeliveryResponse represents paginated webhook delivery response
+type PaginatedWebhookDeliveryResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Pagination PaginationMetadata        `json:"pagination"`
+}
+
+// DomainEventResponse represents a published domain event and the subscribers that handled it
+type DomainEventResponse struct {
+	ID            uint            `json:"id" example:"1"`
+	EventID       string          `json:"event_id" example:"9f2c4e1a7b3d5f60718293a4b5c6d7e8"`
+	EventType     string          `json:"event_type" example:"ExamCompleted" enums:"ExamSessionCreated,ExamStarted,AnswerSubmitted,ExamCompleted,ExamExpired"`
+	AggregateType string          `json:"aggregate_type" example:"exam_session"`
+	AggregateID   string          `json:"aggregate_id" example:"42"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt    time.Time       `json:"occurred_at" example:"2026-01-28T10:00:00Z"`
+	Status        string          `json:"status" example:"PROCESSED" enums:"PENDING,PROCESSED,FAILED"`
+	Attempts      int             `json:"attempts" example:"1"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at" example:"2026-01-28T10:00:10Z"` // Only while pending
+	LastError     string          `json:"last_error,omitempty" example:""`
+	ProcessedAt   *time.Time      `json:"processed_at" example:"2026-01-28T10:00:01Z"`
+	HandledBy     []string        `json:"handled_by" example:"webhooks"` // Subscribers with a receipt
+}
+
+// StreamedEventResponse represents a domain event sent to server-sent event clients
+type StreamedEventResponse struct {
+	EventID       string          `json:"event_id" example:"9f2c4e1a7b3d5f60718293a4b5c6d7e8"`
+	EventType     string          `json:"event_type" example:"ExamCompleted" enums:"ExamSessionCreated,ExamStarted,AnswerSubmitted,ExamCompleted,ExamExpired"`
+	AggregateType string          `json:"aggregate_type" example:"exam_session"`
+	AggregateID   string          `json:"aggregate_id" example:"42"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt    time.Time       `json:"occurred_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// EventCountResponse represents the number of domain events of a type on a day (UTC)
+type EventCountResponse struct {
+	Day       string `json:"day" example:"2026-01-28"`
+	EventType string `json:"event_type" example:"ExamCompleted" enums:"ExamSessionCreated,ExamStarted,AnswerSubmitted,ExamCompleted,ExamExpired"`
+	Count     int64  `json:"count" example:"35"`
+}
+
+// PaginatedDomainEventResponse represents paginated domain event response
+type PaginatedDomainEventResponse struct {
+	Events     []DomainEventResponse `json:"events"`
+	Pagination PaginationMetadata    `json:"pagination"`
+}
+
+// NotificationContactResponse represents where and in which language a candidate is notified
+type NotificationContactResponse struct {
+	UserID    string    `json:"user_id" example:"1234"`
+	Email     string    `json:"email" example:"candidate@example.com"`
+	Name      string    `json:"name" example:"Siti Rahayu"`
+	Locale    string    `json:"locale" example:"id" enums:"id,en"`
+	OptedOut  bool      `json:"opted_out" example:"false"`
+	CreatedAt time.Time `json:"created_at" example:"2026-01-28T10:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// NotificationResponse represents an email in the send queue
+type NotificationResponse struct {
+	ID            uint       `json:"id" example:"1"`
+	UserID        string     `json:"user_id" example:"1234"`
+	Kind          string     `json:"kind" example:"SCORE_REPORT" enums:"SCORE_REPORT,SITTING_REMINDER"`
+	Recipient     string     `json:"recipient" example:"candidate@example.com"`
+	Locale        string     `json:"locale" example:"id" enums:"id,en"`
+	Subject       string     `json:"subject" example:"Hasil Ujian EXAM_1234_1769594400: LULUS"`
+	Body          string     `json:"body" example:"Yth. Siti Rahayu, ..."`
+	Status        string     `json:"status" example:"SENT" enums:"PENDING,SENT,FAILED,SKIPPED"`
+	Attempts      int        `json:"attempts" example:"1"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" example:"2026-01-28T10:01:00Z"` // Only while pending
+	LastError     string     `json:"last_error,omitempty" example:""`
+	SentAt        *time.Time `json:"sent_at" example:"2026-01-28T10:00:02Z"`
+	CreatedAt     time.Time  `json:"created_at" example:"2026-01-28T10:00:00Z"`
+}
+
+// PaginatedNotificationResponse represents paginated notification response
+type PaginatedNotificationResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Pagination    PaginationMetadata     `json:"pagination"`
+}
@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxAttempts is the number of dispatches made before an event fails
+	MaxAttempts = 10
+	// eventLease keeps claimed events from being claimed again while they are dispatched
+	eventLease = 2 * time.Minute
+	// baseBackoff is the wait after the first failed dispatch, doubled after every later one
+	baseBackoff = 10 * time.Second
+	// maxBackoff caps the wait between dispatches
+	maxBackoff = 1 * time.Hour
+)
+
+// Backoff returns the wait after the given number of failed dispatches
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
+
+// Dispatcher passes pending domain events on to the registered subscribers. Several
+// dispatchers may share a database, claimed events are skipped by the others.
+type Dispatcher struct {
+	db          *gorm.DB
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	return &Dispatcher{db: db}
+}
+
+// Subscribe registers a subscriber for every event dispatched afterwards
+func (d *Dispatcher) Subscribe(subscriber Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+// DispatchDue dispatches up to batch events that are due and returns how many were attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context, batch int) (int, error) {
+	events, err := d.claim(ctx, batch)
+	if err != nil {
+		return 0, err
+	}
+
+	d.mu.RLock()
+	subscribers := d.subscribers
+	d.mu.RUnlock()
+
+	for i := range events {
+		if ctx.Err() != nil {
+			// Undispatched events are claimed again once their lease runs out
+			break
+		}
+		if err := d.dispatch(ctx, &events[i], subscribers); err != nil {
+			log.Printf("[Error] Failed to record dispatch of event %s: %v", events[i].EventID, err)
+		}
+	}
+	return len(events), nil
+}
+
+// claim leases due events, oldest first, by moving their next attempt past the lease
+func (d *Dispatcher) claim(ctx context.Context, batch int) ([]models.DomainEvent, error) {
+	var events []models.DomainEvent
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Receipts").
+			Where("status = ? AND next_attempt_at <= ?", models.DomainEventPending, time.Now()).
+			Order("id ASC").
+			Limit(batch).
+			Find(&events).Error; err != nil {
+			return fmt.Errorf("failed to get due events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		if err := tx.Model(&models.DomainEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(eventLease)).Error; err != nil {
+			return fmt.Errorf("failed to claim events: %w", err)
+		}
+		return nil
+	})
+	return events, err
+}
+
+// dispatch hands an event to every subscriber without a receipt and records the outcome
+func (d *Dispatcher) dispatch(ctx context.Context, event *models.DomainEvent, subscribers []Subscriber) error {
+	handled := make(map[string]bool, len(event.Receipts))
+	for _, receipt := range event.Receipts {
+		handled[receipt.Subscriber] = true
+	}
+
+	var failures []string
+	for _, subscriber := range subscribers {
+		if handled[subscriber.Name()] {
+			continue
+		}
+		if err := d.handle(ctx, event, subscriber); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", subscriber.Name(), err))
+		}
+	}
+
+	if ctx.Err() != nil {
+		// Interrupted by shutdown, not by a subscriber; retried after the lease
+		return nil
+	}
+
+	attempts := event.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts": attempts,
+	}
+	switch {
+	case len(failures) == 0:
+		updates["status"] = models.DomainEventProcessed
+		updates["processed_at"] = time.Now()
+		updates["last_error"] = ""
+	case attempts >= MaxAttempts:
+		updates["status"] = models.DomainEventFailed
+		updates["last_error"] = strings.Join(failures, "; ")
+	default:
+		updates["next_attempt_at"] = time.Now().Add(Backoff(attempts))
+		updates["last_error"] = strings.Join(failures, "; ")
+	}
+
+	return d.db.WithContext(ctx).Model(&models.DomainEvent{}).Where("id = ?", event.ID).Updates(updates).Error
+}
+
+// handle runs one subscriber in a transaction that records its receipt
+func (d *Dispatcher) handle(ctx context.Context, event *models.DomainEvent, subscriber Subscriber) (err error) {
+	defer func() {
+		// A panicking subscriber must not stop the others
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := subscriber.Handle(ctx, tx, event); err != nil {
+			return err
+		}
+		return tx.Create(&models.DomainEventReceipt{
+			DomainEventID: event.ID,
+			Subscriber:    subscriber.Name(),
+		}).Error
+	})
+}
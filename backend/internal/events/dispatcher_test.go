@@ -0,0 +1,223 @@
+package events
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+// recordingSubscriber records the events it is handed and fails with err
+type recordingSubscriber struct {
+	name    string
+	err     error
+	handled []string
+}
+
+func (s *recordingSubscriber) Name() string {
+	return s.name
+}
+
+func (s *recordingSubscriber) Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error {
+	s.handled = append(s.handled, event.EventID)
+	return s.err
+}
+
+// expectClaim expects the claim of the given due events with the receipts they have
+func expectClaim(mock sqlmock.Sqlmock, events []models.DomainEvent) {
+	eventRows := sqlmock.NewRows([]string{"id", "event_id", "event_type", "aggregate_type", "aggregate_id", "payload", "occurred_at", "status", "attempts", "next_attempt_at"})
+	receiptRows := sqlmock.NewRows([]string{"id", "domain_event_id", "subscriber"})
+	for _, event := range events {
+		eventRows.AddRow(event.ID, event.EventID, event.EventType, models.AggregateExamSession, "7", `{"session_id":7}`,
+			time.Now(), models.DomainEventPending, event.Attempts, time.Now())
+		for _, receipt := range event.Receipts {
+			receiptRows.AddRow(receipt.ID, event.ID, receipt.Subscriber)
+		}
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "domain_events" WHERE status = $1 AND next_attempt_at <= $2 ORDER BY id ASC`) + `.*` + regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WillReturnRows(eventRows)
+	if len(events) == 0 {
+		mock.ExpectCommit()
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "domain_event_receipts" WHERE "domain_event_receipts"."domain_event_id"`)).
+		WillReturnRows(receiptRows)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "domain_events" SET "next_attempt_at"=$1,"updated_at"=$2 WHERE id IN`)).
+		WithArgs(sqlmocktest.TimeFromNow(eventLease), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(events))))
+	mock.ExpectCommit()
+}
+
+// expectReceipt expects a subscriber to handle an event in a transaction recording its receipt
+func expectReceipt(mock sqlmock.Sqlmock, eventID uint, subscriber string) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "domain_event_receipts" ("domain_event_id","subscriber","created_at")`)).
+		WithArgs(eventID, subscriber, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+}
+
+func TestPublishRolledBackIsNeverDispatched(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	subscriber := &recordingSubscriber{name: "analytics"}
+	errChangeFailed := errors.New("change failed")
+
+	// The event is written inside the transaction of the change and rolled back with it
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "domain_events"`)).
+		WithArgs(sqlmock.AnyArg(), models.EventExamCompleted, models.AggregateExamSession, "7", `{"session_id":7}`,
+			sqlmock.AnyArg(), models.DomainEventPending, 0, sqlmock.AnyArg(), "", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := Publish(tx, Event{
+			Type:          models.EventExamCompleted,
+			AggregateType: models.AggregateExamSession,
+			AggregateID:   "7",
+			Data:          map[string]uint{"session_id": 7},
+		}); err != nil {
+			return err
+		}
+		return errChangeFailed
+	})
+	if !errors.Is(err, errChangeFailed) {
+		t.Fatalf("Transaction() error = %v, want the change error", err)
+	}
+
+	// The outbox holds nothing to dispatch afterwards
+	expectClaim(mock, nil)
+
+	dispatcher := NewDispatcher(db)
+	dispatcher.Subscribe(subscriber)
+	attempted, err := dispatcher.DispatchDue(context.Background(), 10)
+	if err != nil || attempted != 0 {
+		t.Fatalf("DispatchDue() = %d, %v, want nothing attempted", attempted, err)
+	}
+	if len(subscriber.handled) != 0 {
+		t.Fatalf("subscriber was handed %v, want no events", subscriber.handled)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDispatcherRedeliversAfterRestart(t *testing.T) {
+	errUnavailable := errors.New("analytics store unavailable")
+
+	tests := []struct {
+		name        string
+		receipts    []string // subscribers that acknowledged the event before the restart
+		attempts    int
+		failing     string // subscriber failing this time
+		wantHandled map[string]bool
+		wantUpdate  string
+		wantArgs    []driver.Value
+	}{
+		{
+			name:        "crashed after one subscriber",
+			receipts:    []string{"webhooks"},
+			attempts:    0,
+			wantHandled: map[string]bool{"webhooks": false, "analytics": true, "stream": true},
+			wantUpdate:  `UPDATE "domain_events" SET "attempts"=$1,"last_error"=$2,"processed_at"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6`,
+			wantArgs:    []driver.Value{1, "", sqlmocktest.TimeFromNow(0), models.DomainEventProcessed, sqlmock.AnyArg(), 3},
+		},
+		{
+			name:        "crashed before any receipt",
+			attempts:    0,
+			wantHandled: map[string]bool{"webhooks": true, "analytics": true, "stream": true},
+			wantUpdate:  `UPDATE "domain_events" SET "attempts"=$1,"last_error"=$2,"processed_at"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6`,
+			wantArgs:    []driver.Value{1, "", sqlmocktest.TimeFromNow(0), models.DomainEventProcessed, sqlmock.AnyArg(), 3},
+		},
+		{
+			name:        "subscriber fails again",
+			receipts:    []string{"webhooks", "stream"},
+			attempts:    2,
+			failing:     "analytics",
+			wantHandled: map[string]bool{"webhooks": false, "analytics": true, "stream": false},
+			wantUpdate:  `UPDATE "domain_events" SET "attempts"=$1,"last_error"=$2,"next_attempt_at"=$3,"updated_at"=$4 WHERE id = $5`,
+			wantArgs:    []driver.Value{3, sqlmocktest.Containing("analytics: analytics store unavailable"), sqlmocktest.TimeFromNow(Backoff(3)), sqlmock.AnyArg(), 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := sqlmocktest.NewDB(t)
+
+			event := models.DomainEvent{ID: 3, EventID: "ev-3", EventType: models.EventExamCompleted, Attempts: tt.attempts}
+			for i, subscriber := range tt.receipts {
+				event.Receipts = append(event.Receipts, models.DomainEventReceipt{ID: uint(i + 1), DomainEventID: 3, Subscriber: subscriber})
+			}
+			expectClaim(mock, []models.DomainEvent{event})
+
+			// A fresh dispatcher, as after a restart, with the same subscribers
+			dispatcher := NewDispatcher(db)
+			subscribers := []*recordingSubscriber{{name: "webhooks"}, {name: "analytics"}, {name: "stream"}}
+			for _, subscriber := range subscribers {
+				if subscriber.name == tt.failing {
+					subscriber.err = errUnavailable
+				}
+				dispatcher.Subscribe(subscriber)
+
+				if !tt.wantHandled[subscriber.name] {
+					continue
+				}
+				if subscriber.err != nil {
+					mock.ExpectBegin()
+					mock.ExpectRollback()
+					continue
+				}
+				expectReceipt(mock, 3, subscriber.name)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.wantUpdate)).
+				WithArgs(tt.wantArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			attempted, err := dispatcher.DispatchDue(context.Background(), 10)
+			if err != nil || attempted != 1 {
+				t.Fatalf("DispatchDue() = %d, %v, want 1 event attempted", attempted, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, subscriber := range subscribers {
+				if got := len(subscriber.handled) > 0; got != tt.wantHandled[subscriber.name] {
+					t.Errorf("subscriber %s handled the event: %v, want %v", subscriber.name, got, tt.wantHandled[subscriber.name])
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{5, 160 * time.Second},
+		{9, 2560 * time.Second},
+		{10, maxBackoff},
+		{30, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
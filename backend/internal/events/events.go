@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Event describes a domain event to publish
+type Event struct {
+	Type          string
+	AggregateType string
+	AggregateID   string
+	Data          interface{} // Marshalled to JSON as the payload
+}
+
+// Subscriber reacts to domain events. Every event is handed to every subscriber at least once;
+// after a crash or a failure of another subscriber it may be handed again, so subscribers
+// should be idempotent on the event ID.
+type Subscriber interface {
+	// Name identifies the subscriber in the receipts, so it must not change between releases
+	Name() string
+	// Handle is called with a transaction that also records the receipt of the event. Changes
+	// made with tx commit exactly once; an error rolls them back and retries the event later.
+	Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error
+}
+
+// Publish writes a domain event using tx, so it exists exactly when the change it describes
+// is committed. The dispatcher passes it on to the subscribers afterwards.
+func Publish(tx *gorm.DB, event Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	now := time.Now()
+	domainEvent := models.DomainEvent{
+		EventID:       newEventID(),
+		EventType:     event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Payload:       string(payload),
+		OccurredAt:    now,
+		Status:        models.DomainEventPending,
+		NextAttemptAt: now,
+	}
+
+	if err := tx.Create(&domainEvent).Error; err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// newEventID returns a random 128-bit event ID in hex
+func newEventID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
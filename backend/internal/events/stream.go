@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var _ Subscriber = &Stream{}
+
+// streamBuffer is the number of events a listener may fall behind before it misses events
+const streamBuffer = 64
+
+// Stream is a subscriber fanning events out to live listeners, such as server-sent event
+// clients. Listeners only see the events this process dispatches while they listen, and like
+// every subscriber may see an event twice, so they should ignore repeated event IDs.
+type Stream struct {
+	mu        sync.Mutex
+	listeners map[chan models.DomainEvent]struct{}
+}
+
+func NewStream() *Stream {
+	return &Stream{listeners: make(map[chan models.DomainEvent]struct{})}
+}
+
+func (s *Stream) Name() string {
+	return "stream"
+}
+
+// Handle passes the event to every listener. A listener whose buffer is full misses it rather
+// than holding up the dispatcher.
+func (s *Stream) Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	streamed := *event
+	streamed.Receipts = nil
+	for listener := range s.listeners {
+		select {
+		case listener <- streamed:
+		default:
+		}
+	}
+	return nil
+}
+
+// Listen returns a channel receiving the events dispatched from now on, and the function
+// that stops listening and closes it
+func (s *Stream) Listen() (<-chan models.DomainEvent, func()) {
+	listener := make(chan models.DomainEvent, streamBuffer)
+
+	s.mu.Lock()
+	s.listeners[listener] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return listener, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.listeners, listener)
+			s.mu.Unlock()
+			close(listener)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"testing"
+)
+
+func TestStreamFansOutToListeners(t *testing.T) {
+	stream := NewStream()
+	first, stopFirst := stream.Listen()
+	second, stopSecond := stream.Listen()
+	defer stopSecond()
+
+	event := &models.DomainEvent{EventID: "ev-1", EventType: models.EventExamStarted,
+		Receipts: []models.DomainEventReceipt{{Subscriber: "webhooks"}}}
+	if err := stream.Handle(context.Background(), nil, event); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	for name, listener := range map[string]<-chan models.DomainEvent{"first": first, "second": second} {
+		got := <-listener
+		if got.EventID != "ev-1" || got.Receipts != nil {
+			t.Fatalf("%s listener got %+v, want ev-1 without receipts", name, got)
+		}
+	}
+
+	// A stopped listener is closed and no longer handed events
+	stopFirst()
+	stopFirst()
+	if _, ok := <-first; ok {
+		t.Fatal("stopped listener is still open")
+	}
+	if err := stream.Handle(context.Background(), nil, &models.DomainEvent{EventID: "ev-2"}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := <-second; got.EventID != "ev-2" {
+		t.Fatalf("second listener got %s, want ev-2", got.EventID)
+	}
+}
+
+func TestStreamDropsEventsForSlowListeners(t *testing.T) {
+	stream := NewStream()
+	listener, stop := stream.Listen()
+	defer stop()
+
+	// Handing more events than the buffer holds must not block the dispatcher
+	for i := 0; i < streamBuffer+10; i++ {
+		if err := stream.Handle(context.Background(), nil, &models.DomainEvent{EventID: "ev"}); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+	if got := len(listener); got != streamBuffer {
+		t.Fatalf("listener buffered %d events, want %d", got, streamBuffer)
+	}
+}
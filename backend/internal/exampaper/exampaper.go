@@ -0,0 +1,133 @@
+// Package exampaper renders exam papers for offline sittings as PDF: the question paper,
+// the answer key with option scores and an OMR-style answer sheet.
+package exampaper
+
+import (
+	"cutbray/pppk-json/internal/pdf"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Parts of an exam paper document
+const (
+	PartQuestions   = "questions"
+	PartAnswerKey   = "key"
+	PartAnswerSheet = "sheet"
+)
+
+// AllParts lists every part in the order they are printed
+var AllParts = []string{PartQuestions, PartAnswerKey, PartAnswerSheet}
+
+// CandidateParts are the parts handed to candidates, everything but the answer key
+var CandidateParts = []string{PartQuestions, PartAnswerSheet}
+
+// ErrUnknownPart is returned when a requested part does not exist
+var ErrUnknownPart = errors.New("unknown exam paper part")
+
+// Paper is an exam ready to print
+type Paper struct {
+	Title           string
+	SessionCode     string
+	UserID          string // empty for papers not assigned to a candidate
+	Blueprint       string
+	DurationMinutes int
+	Seed            *int64 // set for papers drawn from a seed instead of a stored session
+	GeneratedAt     time.Time
+	Sections        []Section
+}
+
+// Section groups the questions of a category
+type Section struct {
+	Category  string
+	Name      string
+	Questions []Question
+}
+
+// Question is a numbered question with its options in printing order
+type Question struct {
+	Number  int
+	Text    string
+	Options []Option
+}
+
+// Option is a lettered option with its score for the answer key
+type Option struct {
+	Letter string
+	Text   string
+	Score  int
+	Best   bool
+}
+
+// ParseParts parses a comma separated list of parts. An empty list means the candidate parts,
+// "all" includes the answer key as well.
+func ParseParts(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return CandidateParts, nil
+	}
+	if raw == "all" {
+		return AllParts, nil
+	}
+
+	var parts []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		switch part {
+		case PartQuestions, PartAnswerKey, PartAnswerSheet:
+			parts = append(parts, part)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPart, part)
+		}
+	}
+	return parts, nil
+}
+
+// Render writes the requested parts of a paper as one PDF, each part starting on a new page
+func Render(w io.Writer, paper *Paper, parts []string) error {
+	doc := pdf.New(paper.Title + " - " + paper.SessionCode)
+
+	for _, part := range parts {
+		switch part {
+		case PartQuestions:
+			renderQuestions(doc, paper)
+		case PartAnswerKey:
+			renderAnswerKey(doc, paper)
+		case PartAnswerSheet:
+			renderAnswerSheet(doc, paper)
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownPart, part)
+		}
+	}
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// OptionLetter returns the letter of the option at index i
+func OptionLetter(i int) string {
+	return string(rune('A' + i))
+}
+
+// QuestionCount returns the number of questions of a paper
+func (p *Paper) QuestionCount() int {
+	count := 0
+	for _, section := range p.Sections {
+		count += len(section.Questions)
+	}
+	return count
+}
+
+// maxOptions returns the highest number of options of a question on the paper
+func (p *Paper) maxOptions() int {
+	max := 0
+	for _, section := range p.Sections {
+		for _, q := range section.Questions {
+			if len(q.Options) > max {
+				max = len(q.Options)
+			}
+		}
+	}
+	return max
+}
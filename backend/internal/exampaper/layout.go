@@ -0,0 +1,93 @@
+package exampaper
+
+import (
+	"cutbray/pppk-json/internal/pdf"
+	"fmt"
+)
+
+const (
+	margin       = 50.0
+	contentWidth = pdf.PageWidth - 2*margin
+	footerY      = pdf.PageHeight - 30
+)
+
+// flow lays out content top to bottom, starting new pages as needed
+type flow struct {
+	doc   *pdf.Document
+	paper *Paper
+	part  string
+	page  *pdf.Page
+	y     float64
+}
+
+// newFlow starts a part on a new page
+func newFlow(doc *pdf.Document, paper *Paper, part string) *flow {
+	f := &flow{doc: doc, paper: paper, part: part}
+	f.newPage()
+	return f
+}
+
+func (f *flow) newPage() {
+	f.page = f.doc.AddPage()
+	f.y = margin
+
+	footer := fmt.Sprintf("%s - %s - page %d", f.paper.SessionCode, f.part, f.doc.PageCount())
+	f.page.Line(margin, footerY-12, pdf.PageWidth-margin, footerY-12, 0.5)
+	f.page.Text(margin, footerY, pdf.Helvetica, 8, footer)
+}
+
+// ensure starts a new page when height does not fit on the current one
+func (f *flow) ensure(height float64) {
+	if f.y+height > footerY-20 {
+		f.newPage()
+	}
+}
+
+// text writes wrapped text at an indent and advances the cursor
+func (f *flow) text(indent float64, font pdf.Font, size float64, text string) {
+	lineHeight := size * 1.3
+	for _, line := range pdf.Wrap(font, size, text, contentWidth-indent) {
+		f.ensure(lineHeight)
+		f.y += size
+		f.page.Text(margin+indent, f.y, font, size, line)
+		f.y += lineHeight - size
+	}
+}
+
+// space advances the cursor
+func (f *flow) space(height float64) {
+	f.y += height
+}
+
+// rule draws a horizontal line across the content width
+func (f *flow) rule() {
+	f.ensure(6)
+	f.page.Line(margin, f.y, pdf.PageWidth-margin, f.y, 0.75)
+	f.y += 6
+}
+
+// header writes the title block of a part
+func (f *flow) header(subtitle string) {
+	f.text(0, pdf.HelveticaBold, 16, f.paper.Title)
+	f.text(0, pdf.HelveticaBold, 12, subtitle)
+	f.space(4)
+
+	details := fmt.Sprintf("Session: %s", f.paper.SessionCode)
+	if f.paper.UserID != "" {
+		details += fmt.Sprintf(" · Candidate: %s", f.paper.UserID)
+	}
+	if f.paper.Seed != nil {
+		details += fmt.Sprintf(" · Seed: %d", *f.paper.Seed)
+	}
+	f.text(0, pdf.Helvetica, 9, details)
+
+	meta := fmt.Sprintf("Questions: %d · Duration: %d minutes", f.paper.QuestionCount(), f.paper.DurationMinutes)
+	if f.paper.Blueprint != "" {
+		meta = fmt.Sprintf("Blueprint: %s · %s", f.paper.Blueprint, meta)
+	}
+	f.text(0, pdf.Helvetica, 9, meta)
+	f.text(0, pdf.Helvetica, 9, "Generated: "+f.paper.GeneratedAt.UTC().Format("2006-01-02 15:04 MST"))
+	f.space(4)
+	f.rule()
+	f.space(6)
+}
@@ -0,0 +1,154 @@
+package exampaper
+
+import (
+	"cutbray/pppk-json/internal/pdf"
+	"fmt"
+	"strings"
+)
+
+// renderQuestions prints the numbered questions grouped by category
+func renderQuestions(doc *pdf.Document, paper *Paper) {
+	f := newFlow(doc, paper, "questions")
+	f.header("Question Paper")
+	f.text(0, pdf.Helvetica, 9, "Choose one answer per question and mark it on the answer sheet.")
+	f.space(8)
+
+	for _, section := range paper.Sections {
+		f.ensure(60)
+		f.text(0, pdf.HelveticaBold, 12, sectionTitle(section))
+		f.space(6)
+
+		for _, q := range section.Questions {
+			// Keep the question text together with its first option
+			f.ensure(40)
+			f.page.Text(margin, f.y+10, pdf.HelveticaBold, 10, fmt.Sprintf("%d.", q.Number))
+			f.text(22, pdf.Helvetica, 10, q.Text)
+			f.space(2)
+			for _, opt := range q.Options {
+				f.text(22, pdf.Helvetica, 10, fmt.Sprintf("%s.  %s", opt.Letter, opt.Text))
+			}
+			f.space(8)
+		}
+		f.space(6)
+	}
+}
+
+// renderAnswerKey prints the best option and every option score per question
+func renderAnswerKey(doc *pdf.Document, paper *Paper) {
+	f := newFlow(doc, paper, "answer key")
+	f.header("Answer Key")
+
+	for _, section := range paper.Sections {
+		f.ensure(50)
+		f.text(0, pdf.HelveticaBold, 12, sectionTitle(section))
+		f.space(2)
+
+		f.ensure(16)
+		f.y += 10
+		f.page.Text(margin, f.y, pdf.HelveticaBold, 9, "No.")
+		f.page.Text(margin+40, f.y, pdf.HelveticaBold, 9, "Answer")
+		f.page.Text(margin+100, f.y, pdf.HelveticaBold, 9, "Option scores")
+		f.y += 4
+		f.rule()
+
+		for _, q := range section.Questions {
+			answer := ""
+			scores := make([]string, len(q.Options))
+			for i, opt := range q.Options {
+				if opt.Best && answer == "" {
+					answer = opt.Letter
+				}
+				scores[i] = fmt.Sprintf("%s=%d", opt.Letter, opt.Score)
+			}
+
+			f.ensure(14)
+			f.y += 10
+			f.page.Text(margin, f.y, pdf.Helvetica, 9, fmt.Sprintf("%d", q.Number))
+			f.page.Text(margin+40, f.y, pdf.HelveticaBold, 9, answer)
+			f.page.Text(margin+100, f.y, pdf.Helvetica, 9, strings.Join(scores, "   "))
+			f.y += 4
+		}
+		f.space(12)
+	}
+}
+
+// Answer sheet grid
+const (
+	sheetColumns   = 4
+	bubbleRadius   = 5.0
+	bubbleSpacing  = 16.0
+	sheetRowHeight = 17.0
+	markerSize     = 14.0
+)
+
+// renderAnswerSheet prints an OMR-style sheet: corner markers for alignment, candidate
+// boxes and one row of bubbles per question, numbered in columns
+func renderAnswerSheet(doc *pdf.Document, paper *Paper) {
+	total := paper.QuestionCount()
+	options := paper.maxOptions()
+	if options == 0 {
+		options = 5
+	}
+
+	// Print at least one sheet, then continue on new pages until every question has a row
+	number := 1
+	for {
+		f := newFlow(doc, paper, "answer sheet")
+		drawMarkers(f.page)
+		f.header("Answer Sheet")
+
+		for _, label := range []string{"Name", "Candidate ID", "Signature"} {
+			f.ensure(24)
+			f.page.Text(margin, f.y+14, pdf.Helvetica, 9, label)
+			f.page.Rect(margin+80, f.y, contentWidth-80, 20, 0.75)
+			f.y += 26
+		}
+		f.text(0, pdf.Helvetica, 8, "Fill one bubble completely per question with a dark pencil. Erase changes cleanly.")
+		f.space(10)
+
+		columnWidth := contentWidth / sheetColumns
+		rows := int((footerY - 40 - f.y) / sheetRowHeight)
+		top := f.y
+
+		for column := 0; column < sheetColumns && number <= total; column++ {
+			x := margin + float64(column)*columnWidth
+			for row := 0; row < rows && number <= total; row++ {
+				y := top + float64(row)*sheetRowHeight
+				f.page.Text(x, y+3, pdf.HelveticaBold, 9, fmt.Sprintf("%3d", number))
+				for i := 0; i < options; i++ {
+					cx := x + 30 + float64(i)*bubbleSpacing
+					f.page.Circle(cx, y, bubbleRadius, 0.6)
+					letter := OptionLetter(i)
+					f.page.Text(cx-pdf.TextWidth(pdf.Helvetica, 6, letter)/2, y+2, pdf.Helvetica, 6, letter)
+				}
+				number++
+			}
+		}
+
+		if number > total {
+			break
+		}
+	}
+}
+
+// drawMarkers draws filled squares in the page corners used to align scanned sheets
+func drawMarkers(page *pdf.Page) {
+	inset := 20.0
+	for _, corner := range [][2]float64{
+		{inset, inset},
+		{pdf.PageWidth - inset - markerSize, inset},
+		{inset, footerY - 12 - markerSize - 6},
+		{pdf.PageWidth - inset - markerSize, footerY - 12 - markerSize - 6},
+	} {
+		page.FillRect(corner[0], corner[1], markerSize, markerSize)
+	}
+}
+
+// sectionTitle returns the heading of a category section
+func sectionTitle(section Section) string {
+	title := section.Category
+	if section.Name != "" && section.Name != section.Category {
+		title = fmt.Sprintf("%s (%s)", section.Name, section.Category)
+	}
+	return fmt.Sprintf("%s - %d questions", title, len(section.Questions))
+}
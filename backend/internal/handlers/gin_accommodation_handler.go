@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/accommodation_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginAccommodationHandler struct {
+	accommodationRepo accommodation_service.AccommodationService
+}
+
+func NewGinAccommodationHandler(db *gorm.DB) *ginAccommodationHandler {
+	return &ginAccommodationHandler{
+		accommodationRepo: accommodation_service.NewAccommodationService(db),
+	}
+}
+
+// RegisterRoutes registers candidate accommodation routes
+func (h *ginAccommodationHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	accommodationGroup := v1.Group("/accommodations")
+	{
+		accommodationGroup.GET("", h.GetAccommodations)
+		accommodationGroup.GET("/:userID", h.GetAccommodation)
+		accommodationGroup.PUT("/:userID", h.SaveAccommodation)
+		accommodationGroup.DELETE("/:userID", h.DeleteAccommodation)
+	}
+}
+
+// GetAccommodations returns all candidate accommodations
+// @Summary Get accommodations
+// @Description Returns the accommodation of every candidate that has one
+// @Tags accommodations
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.AccommodationResponse}
+// @Router /accommodations [get]
+func (h *ginAccommodationHandler) GetAccommodations(c *gin.Context) {
+	accommodations, err := h.accommodationRepo.GetAccommodations(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch accommodations",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Accommodations retrieved successfully",
+		Data:    dto.ToAccommodationResponses(accommodations),
+	})
+}
+
+// GetAccommodation returns the accommodation of a candidate
+// @Summary Get accommodation
+// @Description Returns the accommodation of a candidate
+// @Tags accommodations
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse{data=dto.AccommodationResponse}
+// @Failure 404 {object} dto.APIResponse "Accommodation not found"
+// @Router /accommodations/{userID} [get]
+func (h *ginAccommodationHandler) GetAccommodation(c *gin.Context) {
+	accommodation, err := h.accommodationRepo.GetAccommodation(c.Request.Context(), c.Param("userID"))
+	if err != nil {
+		respondAccommodationError(c, err, "Failed to fetch accommodation")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Accommodation retrieved successfully",
+		Data:    dto.ToAccommodationResponse(accommodation),
+	})
+}
+
+// SaveAccommodation creates or replaces the accommodation of a candidate
+// @Summary Set accommodation
+// @Description Creates or replaces the accommodation of a candidate. The time multiplier and extra breaks lengthen the deadline of every session created or started afterwards, also past the end of a sitting; sessions in progress keep their deadline. The large font flag is only passed on to the frontend.
+// @Tags accommodations
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Param body body dto.AccommodationRequest true "Accommodation"
+// @Success 200 {object} dto.APIResponse{data=dto.AccommodationResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or accommodation"
+// @Router /accommodations/{userID} [put]
+func (h *ginAccommodationHandler) SaveAccommodation(c *gin.Context) {
+	var req dto.AccommodationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	accommodation := models.Accommodation{
+		UserID:         c.Param("userID"),
+		TimeMultiplier: req.TimeMultiplier,
+		LargeFont:      req.LargeFont,
+		ExtraBreaks:    req.ExtraBreaks,
+		BreakMinutes:   req.BreakMinutes,
+		Notes:          req.Notes,
+	}
+
+	if err := h.accommodationRepo.SaveAccommodation(c.Request.Context(), &accommodation); err != nil {
+		respondAccommodationError(c, err, "Failed to save accommodation")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Accommodation saved successfully",
+		Data:    dto.ToAccommodationResponse(&accommodation),
+	})
+}
+
+// DeleteAccommodation removes the accommodation of a candidate
+// @Summary Delete accommodation
+// @Description Removes the accommodation of a candidate. Sessions that are not started yet lose the extra time when started.
+// @Tags accommodations
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Accommodation not found"
+// @Router /accommodations/{userID} [delete]
+func (h *ginAccommodationHandler) DeleteAccommodation(c *gin.Context) {
+	if err := h.accommodationRepo.DeleteAccommodation(c.Request.Context(), c.Param("userID")); err != nil {
+		respondAccommodationError(c, err, "Failed to delete accommodation")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Accommodation deleted successfully",
+	})
+}
+
+// respondAccommodationError maps accommodation service errors to HTTP responses
+func respondAccommodationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Accommodation not found",
+		})
+	case errors.Is(err, accommodation_service.ErrInvalidAccommodation):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid accommodation",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/audit_service"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginAuditHandler struct {
+	auditRepo audit_service.AuditService
+}
+
+func NewGinAuditHandler(db *gorm.DB) *ginAuditHandler {
+	return &ginAuditHandler{
+		auditRepo: audit_service.NewAuditService(db),
+	}
+}
+
+// RegisterRoutes registers the audit log routes
+func (h *ginAuditHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	v1.GET("/audit", h.GetAuditLogs)
+}
+
+// GetAuditLogs returns audit log entries with filters and pagination
+// @Summary Get audit log
+// @Description Returns recorded changes to questions, options, categories, tag quotas, grading scales, pass rules, blueprints, cohorts, sittings, accommodations and session overrides, newest first. Writes are attributed with the X-Actor header and correlated with the X-Request-ID header. The server does not authenticate clients yet, so the actor is only claimed (actor_claimed) and cannot be trusted; remote_addr tells where the request came from.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param actor query string false "Filter by actor"
+// @Param action query string false "Filter by action" Enums(CREATE, UPDATE, DELETE, RESCORE, SESSION_EXTEND, SESSION_FORCE_COMPLETE, SESSION_RESET, SESSION_VOID, SESSION_REOPEN)
+// @Param entity_type query string false "Filter by entity type (table name)" example(question_options)
+// @Param entity_id query string false "Filter by entity ID"
+// @Param request_id query string false "Filter by request ID"
+// @Param from query string false "Only entries at or after this RFC3339 time"
+// @Param to query string false "Only entries before this RFC3339 time"
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 50, use 0 for all)" minimum(0)
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedAuditLogResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid time filter"
+// @Router /audit [get]
+func (h *ginAuditHandler) GetAuditLogs(c *gin.Context) {
+	filter := audit_service.AuditLogFilter{
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		RequestID:  c.Query("request_id"),
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid " + param + " time, expected RFC3339",
+				Error:   err.Error(),
+			})
+			return
+		}
+		*target = &parsed
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+
+	totalCount, err := h.auditRepo.CountAuditLogs(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to count audit logs",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	logs, err := h.auditRepo.GetAuditLogs(c.Request.Context(), filter, (page-1)*limit, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch audit logs",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	totalPages := 1
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(totalCount) / float64(limit)))
+	} else {
+		page = 1 // Reset page to 1 when showing all
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Audit logs retrieved successfully",
+		Data: dto.PaginatedAuditLogResponse{
+			AuditLogs: dto.ToAuditLogResponses(logs),
+			Pagination: dto.PaginationMetadata{
+				CurrentPage:  page,
+				ItemsPerPage: limit,
+				TotalItems:   int(totalCount),
+				TotalPages:   totalPages,
+			},
+		},
+	})
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/category_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginCategoryHandler struct {
+	categoryRepo category_service.CategoryService
+}
+
+func NewGinCategoryHandler(db *gorm.DB) *ginCategoryHandler {
+	return &ginCategoryHandler{
+		categoryRepo: category_service.NewCategoryService(db),
+	}
+}
+
+// RegisterRoutes registers all category management routes
+func (h *ginCategoryHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	categoryGroup := v1.Group("/categories")
+	{
+		categoryGroup.GET("", h.GetCategories)
+		categoryGroup.POST("", h.CreateCategory)
+		categoryGroup.GET("/:categoryID", h.GetCategory)
+		categoryGroup.PUT("/:categoryID", h.UpdateCategory)
+		categoryGroup.DELETE("/:categoryID", h.DeleteCategory)
+	}
+}
+
+// GetCategories returns all categories
+// @Summary Get categories
+// @Description Returns all exam categories with display name, order, scoring scheme and question count
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.CategoryResponse}
+// @Router /categories [get]
+func (h *ginCategoryHandler) GetCategories(c *gin.Context) {
+	categories, err := h.categoryRepo.GetCategories(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch categories",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Categories retrieved successfully",
+		Data:    dto.ToCategoryResponses(categories),
+	})
+}
+
+// GetCategory returns a single category
+// @Summary Get category
+// @Description Returns a single exam category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param categoryID path int true "Category ID"
+// @Success 200 {object} dto.APIResponse{data=dto.CategoryResponse}
+// @Failure 404 {object} dto.APIResponse "Category not found"
+// @Router /categories/{categoryID} [get]
+func (h *ginCategoryHandler) GetCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	category, err := h.categoryRepo.GetCategoryByID(c.Request.Context(), categoryID)
+	if err != nil {
+		respondCategoryError(c, err, "Failed to fetch category")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Category retrieved successfully",
+		Data:    dto.ToCategoryResponse(category),
+	})
+}
+
+// CreateCategory creates a new category
+// @Summary Create category
+// @Description Creates a new exam category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param body body dto.CategoryRequest true "Category to create"
+// @Success 201 {object} dto.APIResponse{data=dto.CategoryResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or scoring scheme"
+// @Router /categories [post]
+func (h *ginCategoryHandler) CreateCategory(c *gin.Context) {
+	var req dto.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	category := models.Category{}
+	applyCategoryRequest(&category, &req)
+
+	if err := h.categoryRepo.CreateCategory(c.Request.Context(), &category); err != nil {
+		respondCategoryError(c, err, "Failed to create category")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Category created successfully",
+		Data:    dto.ToCategoryResponse(&category),
+	})
+}
+
+// UpdateCategory updates an existing category
+// @Summary Update category
+// @Description Updates an exam category, renaming the code also renames it on all questions
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param categoryID path int true "Category ID"
+// @Param body body dto.CategoryRequest true "Category fields"
+// @Success 200 {object} dto.APIResponse{data=dto.CategoryResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or scoring scheme"
+// @Failure 404 {object} dto.APIResponse "Category not found"
+// @Router /categories/{categoryID} [put]
+func (h *ginCategoryHandler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	category, err := h.categoryRepo.GetCategoryByID(c.Request.Context(), categoryID)
+	if err != nil {
+		respondCategoryError(c, err, "Failed to fetch category")
+		return
+	}
+
+	applyCategoryRequest(category, &req)
+
+	if err := h.categoryRepo.UpdateCategory(c.Request.Context(), category); err != nil {
+		respondCategoryError(c, err, "Failed to update category")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Category updated successfully",
+		Data:    dto.ToCategoryResponse(category),
+	})
+}
+
+// DeleteCategory deletes a category without questions
+// @Summary Delete category
+// @Description Deletes an exam category, only allowed when no question uses it
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param categoryID path int true "Category ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Category not found"
+// @Failure 409 {object} dto.APIResponse "Category still has questions"
+// @Router /categories/{categoryID} [delete]
+func (h *ginCategoryHandler) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.categoryRepo.DeleteCategory(c.Request.Context(), categoryID); err != nil {
+		respondCategoryError(c, err, "Failed to delete category")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Category deleted successfully",
+	})
+}
+
+// applyCategoryRequest copies request fields onto a category model
+func applyCategoryRequest(category *models.Category, req *dto.CategoryRequest) {
+	category.Code = req.Code
+	category.Name = req.Name
+	category.Description = req.Description
+	category.DisplayOrder = req.DisplayOrder
+	category.QuestionCount = *req.QuestionCount
+	category.MaxScore = *req.MaxScore
+	if req.ScoringScheme != "" {
+		category.ScoringScheme = req.ScoringScheme
+	}
+}
+
+// parseCategoryID parses the categoryID path parameter, writing a 400 response when invalid
+func parseCategoryID(c *gin.Context) (uint, bool) {
+	categoryID, err := strconv.ParseUint(c.Param("categoryID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid category ID",
+			Error:   err.Error(),
+		})
+		return 0, false
+	}
+	return uint(categoryID), true
+}
+
+// respondCategoryError maps category service errors to HTTP responses
+func respondCategoryError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Category not found",
+		})
+	case errors.Is(err, category_service.ErrUnknownScoringScheme):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Unknown scoring scheme",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, category_service.ErrCategoryInUse):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Category still has questions",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,536 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/cohort_service"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginCohortHandler struct {
+	cohortRepo  cohort_service.CohortService
+	examService *exam_service.ExamService
+}
+
+func NewGinCohortHandler(db *gorm.DB) *ginCohortHandler {
+	return &ginCohortHandler{
+		cohortRepo:  cohort_service.NewCohortService(db),
+		examService: exam_service.NewExamService(db),
+	}
+}
+
+// RegisterRoutes registers cohort, membership, assignment and cohort dashboard routes
+func (h *ginCohortHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	cohortGroup := v1.Group("/cohorts")
+	{
+		cohortGroup.GET("", h.GetCohorts)
+		cohortGroup.POST("", h.CreateCohort)
+		cohortGroup.GET("/:cohortID", h.GetCohort)
+		cohortGroup.PUT("/:cohortID", h.UpdateCohort)
+		cohortGroup.DELETE("/:cohortID", h.DeleteCohort)
+		cohortGroup.POST("/:cohortID/members", h.SetCohortMembers)
+		cohortGroup.DELETE("/:cohortID/members/:userID", h.RemoveCohortMember)
+		cohortGroup.GET("/:cohortID/assignments", h.GetCohortAssignments)
+		cohortGroup.POST("/:cohortID/assignments", h.AssignBlueprint)
+		cohortGroup.DELETE("/:cohortID/assignments/:assignmentID", h.DeleteCohortAssignment)
+		cohortGroup.GET("/:cohortID/dashboard", h.GetCohortDashboard)
+	}
+}
+
+// GetCohorts returns all cohorts
+// @Summary Get cohorts
+// @Description Returns all cohorts with their members
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.CohortResponse}
+// @Router /cohorts [get]
+func (h *ginCohortHandler) GetCohorts(c *gin.Context) {
+	cohorts, err := h.cohortRepo.GetCohorts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch cohorts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohorts retrieved successfully",
+		Data:    dto.ToCohortResponses(cohorts, time.Now()),
+	})
+}
+
+// GetCohort returns a single cohort
+// @Summary Get cohort
+// @Description Returns a cohort with its members and exam assignments
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param cohortID path int true "Cohort ID"
+// @Success 200 {object} dto.APIResponse{data=dto.CohortResponse}
+// @Failure 404 {object} dto.APIResponse "Cohort not found"
+// @Router /cohorts/{cohortID} [get]
+func (h *ginCohortHandler) GetCohort(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	cohort, err := h.cohortRepo.GetCohortByID(c.Request.Context(), cohortID)
+	if err != nil {
+		respondCohortError(c, err, "Failed to fetch cohort")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort retrieved successfully",
+		Data:    dto.ToCohortResponse(cohort, time.Now()),
+	})
+}
+
+// CreateCohort creates a new cohort
+// @Summary Create cohort
+// @Description Creates a cohort (tryout class) with its initial members and instructors
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param body body dto.CohortRequest true "Cohort to create"
+// @Success 201 {object} dto.APIResponse{data=dto.CohortResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Router /cohorts [post]
+func (h *ginCohortHandler) CreateCohort(c *gin.Context) {
+	var req dto.CohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	cohort := models.Cohort{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Members:     toCohortMembers(req.Members),
+	}
+
+	if err := h.cohortRepo.CreateCohort(c.Request.Context(), &cohort); err != nil {
+		respondCohortError(c, err, "Failed to create cohort")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Cohort created successfully",
+		Data:    dto.ToCohortResponse(&cohort, time.Now()),
+	})
+}
+
+// UpdateCohort updates a cohort
+// @Summary Update cohort
+// @Description Updates the code, name and description of a cohort. Members in the body are ignored.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param cohortID path int true "Cohort ID"
+// @Param body body dto.CohortRequest true "Cohort fields"
+// @Success 200 {object} dto.APIResponse{data=dto.CohortResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Cohort not found"
+// @Router /cohorts/{cohortID} [put]
+func (h *ginCohortHandler) UpdateCohort(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	cohort, err := h.cohortRepo.GetCohortByID(c.Request.Context(), cohortID)
+	if err != nil {
+		respondCohortError(c, err, "Failed to fetch cohort")
+		return
+	}
+
+	cohort.Code = req.Code
+	cohort.Name = req.Name
+	cohort.Description = req.Description
+
+	if err := h.cohortRepo.UpdateCohort(c.Request.Context(), cohort); err != nil {
+		respondCohortError(c, err, "Failed to update cohort")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort updated successfully",
+		Data:    dto.ToCohortResponse(cohort, time.Now()),
+	})
+}
+
+// DeleteCohort deletes a cohort
+// @Summary Delete cohort
+// @Description Deletes a cohort with its members and assignments. Exam sessions and results are kept.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param cohortID path int true "Cohort ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Cohort not found"
+// @Router /cohorts/{cohortID} [delete]
+func (h *ginCohortHandler) DeleteCohort(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	if err := h.cohortRepo.DeleteCohort(c.Request.Context(), cohortID); err != nil {
+		respondCohortError(c, err, "Failed to delete cohort")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort deleted successfully",
+	})
+}
+
+// SetCohortMembers adds users to a cohort or changes their role
+// @Summary Add cohort members
+// @Description Adds users to a cohort as members or instructors. Users already in the cohort get the given role. Once the cohort has an instructor, the X-Actor header must name one of its instructors. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param X-Actor header string false "Instructor changing the members, required once the cohort has one"
+// @Param cohortID path int true "Cohort ID"
+// @Param body body dto.SetCohortMembersRequest true "Users and their roles"
+// @Success 200 {object} dto.APIResponse{data=[]dto.CohortMemberResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 403 {object} dto.APIResponse "Actor is not an instructor of the cohort"
+// @Failure 404 {object} dto.APIResponse "Cohort not found"
+// @Router /cohorts/{cohortID}/members [post]
+func (h *ginCohortHandler) SetCohortMembers(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SetCohortMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	members, err := h.cohortRepo.SetMembers(c.Request.Context(), cohortID, toCohortMembers(req.Members))
+	if err != nil {
+		respondCohortError(c, err, "Failed to update cohort members")
+		return
+	}
+
+	responses := make([]dto.CohortMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = dto.CohortMemberResponse{UserID: member.UserID, Role: member.Role}
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort members updated successfully",
+		Data:    responses,
+	})
+}
+
+// RemoveCohortMember removes a user from a cohort
+// @Summary Remove cohort member
+// @Description Removes a member or instructor from a cohort. Their exam sessions are kept. Once the cohort has an instructor, the X-Actor header must name one of its instructors. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param X-Actor header string false "Instructor removing the member, required once the cohort has one"
+// @Param cohortID path int true "Cohort ID"
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse "Actor is not an instructor of the cohort"
+// @Failure 404 {object} dto.APIResponse "User is not in the cohort"
+// @Router /cohorts/{cohortID}/members/{userID} [delete]
+func (h *ginCohortHandler) RemoveCohortMember(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	if err := h.cohortRepo.RemoveMember(c.Request.Context(), cohortID, c.Param("userID")); err != nil {
+		respondCohortError(c, err, "Failed to remove cohort member")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort member removed successfully",
+	})
+}
+
+// GetCohortAssignments returns the exam assignments of a cohort
+// @Summary Get cohort assignments
+// @Description Returns the blueprints assigned to a cohort, most recently opened first
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param cohortID path int true "Cohort ID"
+// @Success 200 {object} dto.APIResponse{data=[]dto.CohortAssignmentResponse}
+// @Failure 404 {object} dto.APIResponse "Cohort not found"
+// @Router /cohorts/{cohortID}/assignments [get]
+func (h *ginCohortHandler) GetCohortAssignments(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	assignments, err := h.cohortRepo.GetAssignments(c.Request.Context(), cohortID)
+	if err != nil {
+		respondCohortError(c, err, "Failed to fetch cohort assignments")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort assignments retrieved successfully",
+		Data:    dto.ToCohortAssignmentResponses(assignments, time.Now()),
+	})
+}
+
+// AssignBlueprint assigns a blueprint to a cohort
+// @Summary Assign exam to cohort
+// @Description Assigns a blueprint to a cohort inside an availability window. Exam sessions that members create while the window is open use the blueprint instead of the default one. The X-Actor header must name an instructor of the cohort. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "Instructor making the assignment"
+// @Param cohortID path int true "Cohort ID"
+// @Param body body dto.CohortAssignmentRequest true "Blueprint and availability window"
+// @Success 201 {object} dto.APIResponse{data=dto.CohortAssignmentResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or window"
+// @Failure 403 {object} dto.APIResponse "Actor is not an instructor of the cohort"
+// @Failure 404 {object} dto.APIResponse "Cohort or blueprint not found"
+// @Router /cohorts/{cohortID}/assignments [post]
+func (h *ginCohortHandler) AssignBlueprint(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CohortAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	assignment := models.CohortAssignment{
+		CohortID:       cohortID,
+		BlueprintID:    req.BlueprintID,
+		AvailableFrom:  req.AvailableFrom,
+		AvailableUntil: req.AvailableUntil,
+	}
+
+	if err := h.cohortRepo.AssignBlueprint(c.Request.Context(), &assignment); err != nil {
+		respondCohortError(c, err, "Failed to assign exam to cohort")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Exam assigned to cohort successfully",
+		Data:    dto.ToCohortAssignmentResponse(&assignment, time.Now()),
+	})
+}
+
+// DeleteCohortAssignment withdraws an exam assignment from a cohort
+// @Summary Withdraw cohort assignment
+// @Description Withdraws an exam assignment. Sessions already created for it keep their blueprint. The X-Actor header must name an instructor of the cohort. X-Actor is not authenticated, so this check only guards against mistakes: it is not authorisation, a client can name any instructor.
+// @Tags cohorts
+// @Accept json
+// @Produce json
+// @Param X-Actor header string true "Instructor withdrawing the assignment"
+// @Param cohortID path int true "Cohort ID"
+// @Param assignmentID path int true "Assignment ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse "Actor is not an instructor of the cohort"
+// @Failure 404 {object} dto.APIResponse "Assignment not found"
+// @Router /cohorts/{cohortID}/assignments/{assignmentID} [delete]
+func (h *ginCohortHandler) DeleteCohortAssignment(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	assignmentID, ok := parseUintParam(c, "assignmentID", "Invalid assignment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.cohortRepo.DeleteAssignment(c.Request.Context(), cohortID, assignmentID); err != nil {
+		respondCohortError(c, err, "Failed to withdraw cohort assignment")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort assignment withdrawn successfully",
+	})
+}
+
+// GetCohortDashboard gets dashboard information for the members of a cohort
+// @Summary Get cohort dashboard
+// @Description Gets the exam status and results of every member of a cohort from their latest session, including members who have not started (NO_EXAM). Pass assignment_id to only consider sessions started for that assignment.
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param cohortID path int true "Cohort ID"
+// @Param assignment_id query int false "Only sessions started for this assignment of the cohort"
+// @Success 200 {object} dto.APIResponse{data=dto.CohortDashboardResponse} "Cohort dashboard data retrieved"
+// @Failure 400 {object} dto.APIResponse "Invalid assignment ID"
+// @Failure 404 {object} dto.APIResponse "Cohort or assignment not found"
+// @Router /cohorts/{cohortID}/dashboard [get]
+func (h *ginCohortHandler) GetCohortDashboard(c *gin.Context) {
+	cohortID, ok := parseUintParam(c, "cohortID", "Invalid cohort ID")
+	if !ok {
+		return
+	}
+
+	cohort, err := h.cohortRepo.GetCohortByID(c.Request.Context(), cohortID)
+	if err != nil {
+		respondCohortError(c, err, "Failed to fetch cohort")
+		return
+	}
+
+	var assignmentID *uint
+	if value := c.Query("assignment_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid assignment ID",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		id := uint(parsed)
+		if !hasAssignment(cohort, id) {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Assignment not found in cohort",
+			})
+			return
+		}
+		assignmentID = &id
+	}
+
+	users, err := h.examService.GetCohortExamStatus(c.Request.Context(), cohortID, assignmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to get cohort dashboard data: " + err.Error(),
+		})
+		return
+	}
+
+	statusCounts := make(map[string]int)
+	for _, user := range users {
+		statusCounts[user.ExamStatus]++
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Cohort dashboard data retrieved",
+		Data: dto.CohortDashboardResponse{
+			CohortID:     cohort.ID,
+			CohortCode:   cohort.Code,
+			CohortName:   cohort.Name,
+			AssignmentID: assignmentID,
+			TotalMembers: len(users),
+			StatusCounts: statusCounts,
+			Users:        users,
+		},
+	})
+}
+
+// toCohortMembers converts member requests to cohort member models
+func toCohortMembers(requests []dto.CohortMemberRequest) []models.CohortMember {
+	members := make([]models.CohortMember, len(requests))
+	for i, req := range requests {
+		members[i] = models.CohortMember{UserID: req.UserID, Role: req.Role}
+	}
+	return members
+}
+
+// hasAssignment reports whether the cohort has the assignment
+func hasAssignment(cohort *models.Cohort, assignmentID uint) bool {
+	for _, assignment := range cohort.Assignments {
+		if assignment.ID == assignmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// respondCohortError maps cohort service errors to HTTP responses
+func respondCohortError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, cohort_service.ErrInvalidCohortRole),
+		errors.Is(err, cohort_service.ErrInvalidAssignmentWindow):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, cohort_service.ErrNotCohortInstructor):
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Message: "Only instructors of the cohort can manage its members and exam assignments",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
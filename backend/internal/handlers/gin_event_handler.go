@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/events"
+	"cutbray/pppk-json/internal/repositories/analytics_service"
+	"cutbray/pppk-json/internal/repositories/event_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// streamHeartbeat is how often an idle event stream sends a comment to keep the connection open
+const streamHeartbeat = 25 * time.Second
+
+type ginEventHandler struct {
+	eventRepo     event_service.EventService
+	analyticsRepo analytics_service.AnalyticsService
+	stream        *events.Stream
+}
+
+func NewGinEventHandler(db *gorm.DB, stream *events.Stream) *ginEventHandler {
+	return &ginEventHandler{
+		eventRepo:     event_service.NewEventService(db),
+		analyticsRepo: analytics_service.NewAnalyticsService(db),
+		stream:        stream,
+	}
+}
+
+// RegisterRoutes registers the domain event log routes
+func (h *ginEventHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	eventGroup := v1.Group("/events")
+	{
+		eventGroup.GET("", h.GetEvents)
+		eventGroup.GET("/stream", h.StreamEvents)
+		eventGroup.GET("/counts", h.GetEventCounts)
+		eventGroup.POST("/:eventID/retry", h.RetryEvent)
+	}
+}
+
+// GetEvents returns published domain events with filters and pagination
+// @Summary Get domain events
+// @Description Returns the domain events published by exam session changes, newest first, with the subscribers that handled them. Events are written in the transaction of the change and passed on to every subscriber at least once; failed dispatches are retried with exponential backoff, up to 10 attempts.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_type query string false "Filter by event type" Enums(ExamSessionCreated, ExamStarted, AnswerSubmitted, ExamCompleted, ExamExpired)
+// @Param aggregate_type query string false "Filter by aggregate type" example(exam_session)
+// @Param aggregate_id query string false "Filter by aggregate ID"
+// @Param status query string false "Filter by status" Enums(PENDING, PROCESSED, FAILED)
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 50, use 0 for all)" minimum(0)
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedDomainEventResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid status"
+// @Router /events [get]
+func (h *ginEventHandler) GetEvents(c *gin.Context) {
+	filter := event_service.DomainEventFilter{
+		EventType:     c.Query("event_type"),
+		AggregateType: c.Query("aggregate_type"),
+		AggregateID:   c.Query("aggregate_id"),
+		Status:        strings.ToUpper(c.Query("status")),
+	}
+
+	switch filter.Status {
+	case "", models.DomainEventPending, models.DomainEventProcessed, models.DomainEventFailed:
+	default:
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid status, use PENDING, PROCESSED or FAILED",
+		})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+
+	totalCount, err := h.eventRepo.CountEvents(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to count events",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	events, err := h.eventRepo.GetEvents(c.Request.Context(), filter, (page-1)*limit, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch events",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	totalPages := 1
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(totalCount) / float64(limit)))
+	} else {
+		page = 1 // Reset page to 1 when showing all
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Events retrieved successfully",
+		Data: dto.PaginatedDomainEventResponse{
+			Events: dto.ToDomainEventResponses(events),
+			Pagination: dto.PaginationMetadata{
+				CurrentPage:  page,
+				ItemsPerPage: limit,
+				TotalItems:   int(totalCount),
+				TotalPages:   totalPages,
+			},
+		},
+	})
+}
+
+// RetryEvent dispatches a failed domain event again
+// @Summary Retry domain event
+// @Description Queues a failed event for immediate dispatch with a fresh set of attempts. Subscribers that already handled it are skipped.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param eventID path int true "Event ID"
+// @Success 200 {object} dto.APIResponse{data=dto.DomainEventResponse}
+// @Failure 404 {object} dto.APIResponse "Event not found"
+// @Failure 409 {object} dto.APIResponse "Event has not failed"
+// @Router /events/{eventID}/retry [post]
+func (h *ginEventHandler) RetryEvent(c *gin.Context) {
+	eventID, ok := parseUintParam(c, "eventID", "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	event, err := h.eventRepo.RetryEvent(c.Request.Context(), eventID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Event not found",
+			})
+		case errors.Is(err, event_service.ErrEventNotFailed):
+			c.JSON(http.StatusConflict, dto.APIResponse{
+				Success: false,
+				Message: "Event has not failed",
+				Error:   err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Message: "Failed to retry event",
+				Error:   err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Event queued for retry",
+		Data:    dto.ToDomainEventResponse(event),
+	})
+}
+
+// StreamEvents sends domain events to the client as they are dispatched
+// @Summary Stream domain events
+// @Description Server-sent event stream of the domain events dispatched while the client is connected, one message per event with the event ID as id, the event type as event and the event as JSON data. Only events dispatched by the server instance the client is connected to are sent, and an event may be sent twice, so clients should ignore repeated IDs. Clients falling too far behind miss events. A comment is sent every 25 seconds to keep the connection open.
+// @Tags events
+// @Produce text/event-stream
+// @Param event_type query string false "Comma separated event types to send, all by default" example(ExamStarted,ExamCompleted)
+// @Success 200 {object} dto.StreamedEventResponse "Stream of events"
+// @Router /events/stream [get]
+func (h *ginEventHandler) StreamEvents(c *gin.Context) {
+	eventTypes := make(map[string]bool)
+	for _, eventType := range strings.Split(c.Query("event_type"), ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			eventTypes[eventType] = true
+		}
+	}
+
+	// The stream outlives the write timeout of ordinary requests
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	listener, stop := h.stream.Listen()
+	defer stop()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case event, ok := <-listener:
+			if !ok {
+				return false
+			}
+			if len(eventTypes) > 0 && !eventTypes[event.EventType] {
+				return true
+			}
+			data, err := json.Marshal(dto.ToStreamedEventResponse(&event))
+			if err != nil {
+				return true
+			}
+			_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.EventID, event.EventType, data)
+			return err == nil
+		}
+	})
+}
+
+// GetEventCounts returns the number of domain events per type and day
+// @Summary Get domain event counts
+// @Description Returns how many domain events of each type occurred per day (UTC), counted by the analytics subscriber as the events are dispatched. Pending events are not counted yet.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param event_type query string false "Filter by event type" Enums(ExamSessionCreated, ExamStarted, AnswerSubmitted, ExamCompleted, ExamExpired)
+// @Param from query string false "First day included (YYYY-MM-DD)" example(2026-01-01)
+// @Param to query string false "Last day included (YYYY-MM-DD)" example(2026-01-31)
+// @Success 200 {object} dto.APIResponse{data=[]dto.EventCountResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid day filter"
+// @Router /events/counts [get]
+func (h *ginEventHandler) GetEventCounts(c *gin.Context) {
+	filter := analytics_service.EventCountFilter{
+		EventType: c.Query("event_type"),
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid " + param + " day, expected YYYY-MM-DD",
+				Error:   err.Error(),
+			})
+			return
+		}
+		*target = &parsed
+	}
+
+	counts, err := h.analyticsRepo.GetEventCounts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch event counts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Event counts retrieved successfully",
+		Data:    dto.ToEventCountResponses(counts),
+	})
+}
@@ -3,7 +3,13 @@ package handlers
 import (
 	"cutbray/pppk-json/internal/dto"
 	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/repositories/sitting_service"
+	"errors"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,12 +47,15 @@ func (h *ginExamHandler) RegisterRoutes(router *gin.Engine) {
 	dashboardGroup := v1.Group("/dashboard")
 	{
 		dashboardGroup.GET("/users", h.GetAllUsersDashboard)
+		dashboardGroup.GET("/stats", h.GetDashboardStats)
 	}
+
+	v1.GET("/leaderboard", h.GetLeaderboard)
 }
 
 // GetOrCreateExam creates or gets existing exam session
 // @Summary Create or get exam session
-// @Description Creates a new exam session with 20 random questions (5 per category) or returns existing active session
+// @Description Creates a new exam session with 20 random questions (5 per category) or returns existing active session. A user booked into a sitting that has not ended gets a session of that sitting, expiring at its end. The accommodation of the user adds its extra time to the deadline.
 // @Tags exam
 // @Accept json
 // @Produce json
@@ -91,14 +100,17 @@ func (h *ginExamHandler) GetOrCreateExam(c *gin.Context) {
 
 // StartExam starts the exam session
 // @Summary Start exam
-// @Description Starts the exam timer and changes status to IN_PROGRESS
+// @Description Starts the exam timer and changes status to IN_PROGRESS. A session booked into a sitting can only be started while the sitting is open, with its access code when it has one, and expires at the end of the sitting.
 // @Tags exam
 // @Accept json
 // @Produce json
 // @Param userID path string true "User ID" example("1234")
+// @Param request body dto.StartExamRequest false "Sitting access code"
 // @Success 200 {object} dto.APIResponse{data=map[string]interface{}} "Exam started successfully"
 // @Failure 400 {object} dto.APIResponse "Invalid user ID"
+// @Failure 403 {object} dto.APIResponse "Sitting not open, closed or wrong access code"
 // @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Exam session already completed, expired or voided"
 // @Failure 500 {object} dto.APIResponse "Failed to start exam"
 // @Router /exam/{userID}/start [post]
 func (h *ginExamHandler) StartExam(c *gin.Context) {
@@ -111,19 +123,41 @@ func (h *ginExamHandler) StartExam(c *gin.Context) {
 		return
 	}
 
-	// Get exam session
-	examSession, err := h.examService.GetExamSession(c.Request.Context(), userID)
+	// Get the latest session whatever its status, StartExam refuses the ones that cannot start
+	examSession, err := h.examService.GetLatestExamSession(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.APIResponse{
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.APIResponse{
 			Success: false,
 			Error:   "Exam session not found",
 		})
 		return
 	}
 
+	// The body is optional, it only carries the access code of a sitting
+	var request dto.StartExamRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
 	// Start the exam
-	if err := h.examService.StartExam(c.Request.Context(), examSession.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+	if err := h.examService.StartExam(c.Request.Context(), examSession.ID, request.AccessCode); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, sitting_service.ErrSittingNotOpen) ||
+			errors.Is(err, sitting_service.ErrSittingClosed) ||
+			errors.Is(err, sitting_service.ErrInvalidAccessCode) {
+			status = http.StatusForbidden
+		} else if isSessionConflict(err) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.APIResponse{
 			Success: false,
 			Error:   "Failed to start exam: " + err.Error(),
 		})
@@ -135,7 +169,7 @@ func (h *ginExamHandler) StartExam(c *gin.Context) {
 		Message: "Exam started successfully",
 		Data: gin.H{
 			"session_id": examSession.ID,
-			"status":     "IN_PROGRESS",
+			"status":     models.SessionInProgress,
 			"started_at": time.Now(),
 		},
 	})
@@ -152,6 +186,7 @@ func (h *ginExamHandler) StartExam(c *gin.Context) {
 // @Success 200 {object} dto.APIResponse "Answer submitted successfully"
 // @Failure 400 {object} dto.APIResponse "Invalid request"
 // @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Exam session not started, completed, expired or voided"
 // @Failure 500 {object} dto.APIResponse "Failed to submit answer"
 // @Router /exam/{userID}/answer [post]
 func (h *ginExamHandler) SubmitAnswer(c *gin.Context) {
@@ -186,7 +221,11 @@ func (h *ginExamHandler) SubmitAnswer(c *gin.Context) {
 	// Submit answer
 	err = h.examService.SubmitAnswer(c.Request.Context(), examSession.ID, request.ExamQuestionID, request.QuestionOptionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		status := http.StatusInternalServerError
+		if isSessionConflict(err) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.APIResponse{
 			Success: false,
 			Error:   "Failed to submit answer: " + err.Error(),
 		})
@@ -201,14 +240,18 @@ func (h *ginExamHandler) SubmitAnswer(c *gin.Context) {
 
 // CompleteExam completes the exam and calculates results
 // @Summary Complete exam
-// @Description Completes the exam session and calculates final results
+// @Description Completes the exam session and calculates final results. Completing is idempotent: repeating the request returns the summary stored by the first one, with the Idempotent-Replayed header set, instead of scoring again. Send an Idempotency-Key header to make a retried request find the session it completed even after a new session was started. Sessions that were not started, have expired or were voided cannot be completed. A key that already completed another session, or a different key than the one a completed session was completed with, is rejected with 422.
 // @Tags exam
 // @Accept json
 // @Produce json
 // @Param userID path string true "User ID" example("1234")
+// @Param Idempotency-Key header string false "Client generated key identifying the completion request, at most 255 characters"
 // @Success 200 {object} dto.APIResponse{data=map[string]interface{}} "Exam completed successfully"
-// @Failure 400 {object} dto.APIResponse "Invalid user ID"
+// @Header 200 {string} Idempotent-Replayed "true when the session was completed by an earlier request"
+// @Failure 400 {object} dto.APIResponse "Invalid user ID or idempotency key"
 // @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Exam session not started, expired, voided or changed status meanwhile"
+// @Failure 422 {object} dto.APIResponse "Idempotency key used for another completion"
 // @Failure 500 {object} dto.APIResponse "Failed to complete exam"
 // @Router /exam/{userID}/complete [post]
 func (h *ginExamHandler) CompleteExam(c *gin.Context) {
@@ -220,40 +263,83 @@ func (h *ginExamHandler) CompleteExam(c *gin.Context) {
 		})
 		return
 	}
+	idempotencyKey := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
 
-	// Get exam session
-	examSession, err := h.examService.GetExamSession(c.Request.Context(), userID)
+	// Get the session to complete, also when an earlier request completed it
+	examSession, err := h.examService.GetSessionToComplete(c.Request.Context(), userID, idempotencyKey)
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.APIResponse{
-			Success: false,
-			Error:   "Exam session not found",
-		})
+		respondCompletionError(c, err)
 		return
 	}
 
 	// Complete exam
-	if err := h.examService.CompleteExam(c.Request.Context(), examSession.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
-			Success: false,
-			Error:   "Failed to complete exam: " + err.Error(),
-		})
+	completion, err := h.examService.CompleteExam(c.Request.Context(), examSession.ID, idempotencyKey)
+	if err != nil {
+		respondCompletionError(c, err)
 		return
 	}
 
+	message := "Exam completed successfully"
+	if completion.Replayed {
+		c.Header("Idempotent-Replayed", "true")
+		message = "Exam already completed"
+	}
+
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
-		Message: "Exam completed successfully",
+		Message: message,
 		Data: gin.H{
-			"session_id":   examSession.ID,
-			"status":       "COMPLETED",
-			"completed_at": time.Now(),
+			"session_id":   completion.Session.ID,
+			"summary_id":   completion.Summary.ID,
+			"status":       completion.Session.Status,
+			"completed_at": completion.Summary.CompletedAt,
 		},
 	})
 }
 
+// isSessionConflict tells whether err comes from a session status that does not allow the request
+func isSessionConflict(err error) bool {
+	return errors.Is(err, exam_service.ErrIllegalTransition) ||
+		errors.Is(err, exam_service.ErrSessionNotStarted) ||
+		errors.Is(err, exam_service.ErrSessionExpired) ||
+		errors.Is(err, exam_service.ErrSessionVoided) ||
+		errors.Is(err, exam_service.ErrSessionCompleted)
+}
+
+// respondCompletionError maps exam completion errors to HTTP responses
+func respondCompletionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Error:   "Exam session not found",
+		})
+	case errors.Is(err, exam_service.ErrInvalidIdempotencyKey):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, exam_service.ErrIdempotencyKeyMismatch):
+		c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+	case isSessionConflict(err):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to complete exam: " + err.Error(),
+		})
+	}
+}
+
 // GetExamResults gets exam results for a user
 // @Summary Get exam results
-// @Description Retrieves detailed exam results including summary and category breakdown
+// @Description Retrieves detailed exam results including summary, category breakdown and per-tag (sub-topic) breakdown. The summary and each category carry the rank and percentile among the latest attempts of every candidate of the same blueprint.
 // @Tags exam
 // @Accept json
 // @Produce json
@@ -281,8 +367,27 @@ func (h *ginExamHandler) GetExamResults(c *gin.Context) {
 		return
 	}
 
+	tagResults, err := h.examService.GetExamTagResults(c.Request.Context(), summary.ExamSessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to get exam tag results: " + err.Error(),
+		})
+		return
+	}
+
+	standing, err := h.examService.GetSessionStanding(c.Request.Context(), summary)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to rank exam results: " + err.Error(),
+		})
+		return
+	}
+
 	// Convert to response format using mapper
-	response := dto.ToExamResultsResponse(summary, results)
+	response := dto.ToExamResultsResponse(summary, results, tagResults)
+	dto.ApplySessionStanding(&response, standing)
 
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
@@ -333,28 +438,98 @@ func (h *ginExamHandler) GetDashboard(c *gin.Context) {
 
 // GetAllUsersDashboard gets dashboard information for all users
 // @Summary Get all users dashboard
-// @Description Gets dashboard data for all users who have taken exams, including their status and results
+// @Description Gets the latest exam status and results of every user who has taken an exam, filtered, sorted and paginated with a cursor. Pass next_cursor of a page as cursor, with the same filters and sort, to get the next page. total_users counts every user matching the filters.
 // @Tags dashboard
 // @Accept json
 // @Produce json
+// @Param status query string false "Status of the latest session" Enums(NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED, VOIDED)
+// @Param passed query bool false "Only passed (true) or failed (false) candidates"
+// @Param grade query string false "Overall grade" example(B)
+// @Param from query string false "Latest activity at or after this RFC3339 time (completion, else start, else creation)"
+// @Param to query string false "Latest activity before this RFC3339 time"
+// @Param cohort_id query int false "Only members of this cohort"
+// @Param user_id_prefix query string false "User IDs starting with this prefix" example(12)
+// @Param sort query string false "Sort field (default: created_at)" Enums(created_at, completed_at, score, percentage)
+// @Param order query string false "Sort order (default: desc)" Enums(asc, desc)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Users per page (default: 0, all users)" minimum(0)
 // @Success 200 {object} dto.APIResponse{data=dto.UserListDashboardResponse} "All users dashboard data retrieved"
+// @Failure 400 {object} dto.APIResponse "Invalid filter, sort or cursor"
 // @Failure 500 {object} dto.APIResponse "Failed to get dashboard data"
 // @Router /dashboard/users [get]
 func (h *ginExamHandler) GetAllUsersDashboard(c *gin.Context) {
-	users, err := h.examService.GetAllUsersExamStatus(c.Request.Context())
+	filter := exam_service.UsersExamStatusFilter{
+		Status:       c.Query("status"),
+		Grade:        c.Query("grade"),
+		UserIDPrefix: c.Query("user_id_prefix"),
+	}
+
+	if value := c.Query("passed"); value != "" {
+		passed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid passed: " + err.Error(),
+			})
+			return
+		}
+		filter.Passed = &passed
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid " + param + " time, expected RFC3339: " + err.Error(),
+			})
+			return
+		}
+		*target = &parsed
+	}
+
+	if value := c.Query("cohort_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid cohort_id: " + err.Error(),
+			})
+			return
+		}
+		cohortID := uint(parsed)
+		filter.CohortID = &cohortID
+	}
+
+	page := exam_service.UsersExamStatusPage{
+		Sort:       c.Query("sort"),
+		Descending: c.DefaultQuery("order", "desc") != "asc",
+		Cursor:     c.Query("cursor"),
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		limit = 0
+	}
+	page.Limit = limit
+
+	response, err := h.examService.GetAllUsersExamStatus(c.Request.Context(), filter, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		status := http.StatusInternalServerError
+		if errors.Is(err, exam_service.ErrInvalidUsersFilter) || errors.Is(err, exam_service.ErrInvalidUsersCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.APIResponse{
 			Success: false,
 			Error:   "Failed to get users dashboard data: " + err.Error(),
 		})
 		return
 	}
 
-	response := dto.UserListDashboardResponse{
-		TotalUsers: len(users),
-		Users:      users,
-	}
-
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Message: "All users dashboard data retrieved",
@@ -362,6 +537,92 @@ func (h *ginExamHandler) GetAllUsersDashboard(c *gin.Context) {
 	})
 }
 
+// GetDashboardStats gets aggregate exam statistics
+// @Summary Get dashboard statistics
+// @Description Aggregates sessions and results over a time range: sessions by status, pass rate, average, median and standard deviation of scores, and grade distribution overall and per category, plus completions per day. Sessions count when created in range and results when completed in range. Voided sessions only appear in the status counts.
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param from query string false "Start of the range, RFC3339 (default: 30 days before to)"
+// @Param to query string false "End of the range, exclusive, RFC3339 (default: now)"
+// @Param timezone query string false "IANA time zone of the daily series (default: UTC)" example(Asia/Jakarta)
+// @Param blueprint_id query int false "Only sessions of this blueprint"
+// @Param cohort_id query int false "Only members of this cohort"
+// @Success 200 {object} dto.APIResponse{data=dto.DashboardStatsResponse} "Dashboard statistics retrieved"
+// @Failure 400 {object} dto.APIResponse "Invalid range, time zone or ID"
+// @Failure 500 {object} dto.APIResponse "Failed to get dashboard statistics"
+// @Router /dashboard/stats [get]
+func (h *ginExamHandler) GetDashboardStats(c *gin.Context) {
+	filter := exam_service.StatsFilter{To: time.Now()}
+
+	for param, target := range map[string]*time.Time{"to": &filter.To, "from": &filter.From} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid " + param + " time, expected RFC3339: " + err.Error(),
+			})
+			return
+		}
+		*target = parsed
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -30)
+	}
+
+	if value := c.Query("timezone"); value != "" {
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid timezone: " + err.Error(),
+			})
+			return
+		}
+		filter.Location = location
+	}
+
+	for param, target := range map[string]**uint{"blueprint_id": &filter.BlueprintID, "cohort_id": &filter.CohortID} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid " + param + ": " + err.Error(),
+			})
+			return
+		}
+		id := uint(parsed)
+		*target = &id
+	}
+
+	stats, err := h.examService.GetDashboardStats(c.Request.Context(), filter)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, exam_service.ErrInvalidStatsRange) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to get dashboard statistics: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Dashboard statistics retrieved",
+		Data:    stats,
+	})
+}
+
 // GetUserAnswers gets existing answers for user's active exam session
 // @Summary Get user's existing answers
 // @Description Gets existing answers for user's active exam session to repopulate on page reload
@@ -443,3 +704,82 @@ func (h *ginExamHandler) GetDetailedUserAnswers(c *gin.Context) {
 		Data:    answers,
 	})
 }
+
+// GetLeaderboard ranks candidates by total score
+// @Summary Get leaderboard
+// @Description Ranks the latest completed attempt of every candidate of a blueprint, sitting and/or cohort by total score. Candidates are anonymised by their position; pass user_id to flag and return your own entry. Ties share a rank. The percentile is the share of candidates scoring lower plus half of those scoring the same.
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Param blueprint_id query int false "Rank candidates of this blueprint"
+// @Param sitting_id query int false "Rank candidates of this sitting"
+// @Param cohort_id query int false "Rank members of this cohort"
+// @Param user_id query string false "User whose own entry is returned" example("1234")
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 20, use 0 for all)" minimum(0)
+// @Success 200 {object} dto.APIResponse{data=dto.LeaderboardResponse} "Leaderboard retrieved"
+// @Failure 400 {object} dto.APIResponse "No blueprint, sitting or cohort, or invalid ID"
+// @Failure 500 {object} dto.APIResponse "Failed to get leaderboard"
+// @Router /leaderboard [get]
+func (h *ginExamHandler) GetLeaderboard(c *gin.Context) {
+	var scope exam_service.LeaderboardScope
+	for param, target := range map[string]**uint{"blueprint_id": &scope.BlueprintID, "sitting_id": &scope.SittingID, "cohort_id": &scope.CohortID} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "Invalid " + param + ": " + err.Error(),
+			})
+			return
+		}
+		id := uint(parsed)
+		*target = &id
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 0 {
+		limit = 20
+	}
+
+	leaderboard, err := h.examService.GetLeaderboard(c.Request.Context(), scope, c.Query("user_id"), (page-1)*limit, limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, exam_service.ErrLeaderboardScope) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, dto.APIResponse{
+			Success: false,
+			Error:   "Failed to get leaderboard: " + err.Error(),
+		})
+		return
+	}
+
+	totalPages := 1
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(leaderboard.TotalCandidates) / float64(limit)))
+	} else {
+		page = 1 // Reset page to 1 when showing all
+	}
+
+	leaderboard.Pagination = dto.PaginationMetadata{
+		CurrentPage:  page,
+		ItemsPerPage: limit,
+		TotalItems:   leaderboard.TotalCandidates,
+		TotalPages:   totalPages,
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Leaderboard retrieved",
+		Data:    leaderboard,
+	})
+}
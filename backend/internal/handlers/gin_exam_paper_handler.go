@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"bytes"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/exampaper"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/repositories/grading_service"
+	"cutbray/pppk-json/internal/spreadsheet"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// seedPaperPrefix marks session codes that ask for a paper drawn from a seed
+const seedPaperPrefix = "SEED-"
+
+type ginExamPaperHandler struct {
+	examService *exam_service.ExamService
+}
+
+func NewGinExamPaperHandler(db *gorm.DB) *ginExamPaperHandler {
+	return &ginExamPaperHandler{
+		examService: exam_service.NewExamService(db),
+	}
+}
+
+// RegisterRoutes registers the printable exam paper routes
+func (h *ginExamPaperHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	v1.GET("/exam-papers/:sessionCode", h.GetExamPaperPDF)
+	v1.POST("/exam-papers/answers", h.IngestOfflineAnswers)
+}
+
+// GetExamPaperPDF renders an exam paper as PDF
+// @Summary Download a printable exam paper
+// @Description Renders the questions of an exam session as PDF for offline sittings: the question paper grouped by category, the answer key with the best option and every option score, and an OMR-style answer sheet. Only the question paper and answer sheet are included by default; the answer key has to be asked for with parts=key (or parts=all) and is refused with 409 while the session is NOT_STARTED or IN_PROGRESS. Questions keep their online order numbers and options are lettered in ID order. A session code of the form SEED-<n> draws a paper from seed n with the default blueprint instead of reading a stored session; the same seed gives the same paper while the question bank is unchanged. The .pdf suffix of the path is optional.
+// @Tags exam-papers
+// @Produce application/pdf
+// @Param sessionCode path string true "Exam session code, or SEED-<n> for a seed-reproducible paper" example(EXAM_1234_1700000000.pdf)
+// @Param parts query string false "Comma separated parts to include, questions and sheet by default, all for every part" example(questions,key,sheet)
+// @Success 200 {file} file "PDF document"
+// @Failure 400 {object} dto.APIResponse "Invalid parts or seed"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Answer key asked for a session that can still be taken"
+// @Failure 500 {object} dto.APIResponse "Failed to render exam paper"
+// @Router /exam-papers/{sessionCode} [get]
+func (h *ginExamPaperHandler) GetExamPaperPDF(c *gin.Context) {
+	sessionCode := strings.TrimSuffix(c.Param("sessionCode"), ".pdf")
+
+	parts, err := exampaper.ParseParts(c.Query("parts"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid parts, use questions, key and/or sheet",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var paper *exampaper.Paper
+	if rawSeed, ok := cutPrefixFold(sessionCode, seedPaperPrefix); ok {
+		seed, parseErr := strconv.ParseInt(rawSeed, 10, 64)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid seed",
+				Error:   parseErr.Error(),
+			})
+			return
+		}
+		paper, err = h.examService.GenerateSeedPaper(c.Request.Context(), seed)
+	} else {
+		withAnswerKey := slices.Contains(parts, exampaper.PartAnswerKey)
+		paper, err = h.examService.GetExamPaper(c.Request.Context(), sessionCode, withAnswerKey)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Exam session not found",
+				Error:   err.Error(),
+			})
+		case errors.Is(err, exam_service.ErrAnswerKeyLocked):
+			c.JSON(http.StatusConflict, dto.APIResponse{
+				Success: false,
+				Message: "The answer key is only available once the session is over",
+				Error:   err.Error(),
+			})
+		case errors.Is(err, grading_service.ErrNoDefaultBlueprint):
+			c.JSON(http.StatusConflict, dto.APIResponse{
+				Success: false,
+				Message: "No default exam blueprint configured",
+				Error:   err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Message: "Failed to prepare exam paper",
+				Error:   err.Error(),
+			})
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := exampaper.Render(&buf, paper, parts); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to render exam paper",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pdf\"", paper.SessionCode))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// IngestOfflineAnswers loads answers collected on paper answer sheets
+// @Summary Load offline answer sheets
+// @Description Accepts a CSV upload (multipart field "file") with session_code, order_number and option_letter columns, one row per marked question. Letters follow the printed paper: A is the option with the lowest ID; an empty letter leaves the question blank. Rows are validated against the exam questions of each session and one invalid row rejects the whole file. The answers are then stored and every session is completed with the normal scoring, so paper results appear in /dashboard/users next to online ones. Completed sessions are rejected. With dry_run=true the sessions are scored and nothing is saved.
+// @Tags exam-papers
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV with session_code, order_number and option_letter columns"
+// @Param dry_run query bool false "Validate and score without saving"
+// @Success 200 {object} dto.APIResponse{data=dto.OfflineAnswerReport}
+// @Failure 400 {object} dto.APIResponse "Missing or unreadable CSV"
+// @Failure 422 {object} dto.APIResponse{data=dto.OfflineAnswerReport} "Some rows are invalid, nothing was saved"
+// @Failure 500 {object} dto.APIResponse "Failed to load answers"
+// @Router /exam-papers/answers [post]
+func (h *ginExamPaperHandler) IngestOfflineAnswers(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "CSV file is required in the file field",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Failed to open uploaded file",
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	answers, rowErrors, err := parseOfflineAnswerCSV(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid CSV file",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+			Success: false,
+			Message: "Some rows are invalid, nothing was saved",
+			Data: dto.OfflineAnswerReport{
+				TotalRows: len(answers) + len(rowErrors),
+				Sessions:  []dto.OfflineSessionResult{},
+				Errors:    rowErrors,
+			},
+		})
+		return
+	}
+
+	if len(answers) == 0 {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "No answers to load",
+		})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.examService.IngestOfflineAnswers(c.Request.Context(), answers, dryRun)
+	if err != nil {
+		if errors.Is(err, exam_service.ErrOfflineAnswersRejected) {
+			c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+				Success: false,
+				Message: "Some rows are invalid, nothing was saved",
+				Data:    report,
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to load answers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	message := "Answers loaded and sessions completed successfully"
+	if dryRun {
+		message = "Answer sheet preview generated, nothing was saved"
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    report,
+	})
+}
+
+// parseOfflineAnswerCSV reads session_code, order_number and option_letter columns (in any order).
+// Rows that cannot be parsed are returned as row errors numbered by CSV line.
+func parseOfflineAnswerCSV(r io.Reader) ([]exam_service.OfflineAnswer, []dto.ImportRowError, error) {
+	rows, err := spreadsheet.ReadCSV(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+
+	columns := map[string]int{"session_code": -1, "order_number": -1, "option_letter": -1}
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+	for name, index := range columns {
+		if index < 0 {
+			return nil, nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+
+	cell := func(row []string, name string) string {
+		if index := columns[name]; index < len(row) {
+			return strings.TrimSpace(row[index])
+		}
+		return ""
+	}
+
+	var answers []exam_service.OfflineAnswer
+	var rowErrors []dto.ImportRowError
+
+	for i, row := range rows[1:] {
+		line := i + 2
+		sessionCode := cell(row, "session_code")
+		rawOrder := cell(row, "order_number")
+		letter := strings.ToUpper(cell(row, "option_letter"))
+
+		// Skip blank lines left by spreadsheet exports
+		if sessionCode == "" && rawOrder == "" && letter == "" {
+			continue
+		}
+
+		if sessionCode == "" {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: line, Field: "session_code", Message: "session code is required"})
+			continue
+		}
+
+		orderNumber, err := strconv.Atoi(rawOrder)
+		if err != nil || orderNumber < 1 {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: line, Field: "order_number", Message: fmt.Sprintf("invalid order number %q", rawOrder)})
+			continue
+		}
+
+		answers = append(answers, exam_service.OfflineAnswer{
+			Row:          line,
+			SessionCode:  sessionCode,
+			OrderNumber:  orderNumber,
+			OptionLetter: letter,
+		})
+	}
+
+	return answers, rowErrors, nil
+}
+
+// cutPrefixFold removes a prefix matched case-insensitively
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/spreadsheet"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginExportHandler struct {
+	examService *exam_service.ExamService
+}
+
+func NewGinExportHandler(db *gorm.DB) *ginExportHandler {
+	return &ginExportHandler{
+		examService: exam_service.NewExamService(db),
+	}
+}
+
+// RegisterRoutes registers the result export routes
+func (h *ginExportHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	exportGroup := v1.Group("/exports")
+	{
+		exportGroup.GET("/summaries", h.ExportSummaries)
+		exportGroup.GET("/sessions/:sessionID/answers", h.ExportSessionAnswers)
+	}
+}
+
+// ExportSummaries downloads the results of every completed session
+// @Summary Export results
+// @Description Downloads one row per completed session with its overall result and the score, percentage and grade of every category as columns, oldest first. Voided sessions are left out. The file is streamed row by row.
+// @Tags exports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "File format (default: csv)" Enums(csv, xlsx)
+// @Param from query string false "Only sessions completed at or after this RFC3339 time"
+// @Param to query string false "Only sessions completed before this RFC3339 time"
+// @Param blueprint_id query int false "Only sessions of this blueprint"
+// @Param cohort_id query int false "Only members of this cohort"
+// @Success 200 {file} file "CSV or XLSX file"
+// @Failure 400 {object} dto.APIResponse "Invalid format, time or ID"
+// @Failure 500 {object} dto.APIResponse "Failed to export results"
+// @Router /exports/summaries [get]
+func (h *ginExportHandler) ExportSummaries(c *gin.Context) {
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	var filter exam_service.ExportFilter
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid " + param + " time, expected RFC3339",
+				Error:   err.Error(),
+			})
+			return
+		}
+		*target = &parsed
+	}
+
+	for param, target := range map[string]**uint{"blueprint_id": &filter.BlueprintID, "cohort_id": &filter.CohortID} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid " + param,
+				Error:   err.Error(),
+			})
+			return
+		}
+		id := uint(parsed)
+		*target = &id
+	}
+
+	streamExport(c, format, fmt.Sprintf("results-%s", time.Now().Format("20060102-150405")), "Failed to export results",
+		func(w spreadsheet.RowWriter) error {
+			return h.examService.ExportSummaries(c.Request.Context(), filter, w)
+		})
+}
+
+// ExportSessionAnswers downloads the answers of one session
+// @Summary Export session answers
+// @Description Downloads one row per question of an exam session in exam order, with the selected option, its score, the maximum score and the best option of the category. Unanswered questions have empty answer columns. The file is streamed row by row.
+// @Tags exports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param sessionID path int true "Exam session ID"
+// @Param format query string false "File format (default: csv)" Enums(csv, xlsx)
+// @Success 200 {file} file "CSV or XLSX file"
+// @Failure 400 {object} dto.APIResponse "Invalid format or session ID"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 500 {object} dto.APIResponse "Failed to export answers"
+// @Router /exports/sessions/{sessionID}/answers [get]
+func (h *ginExportHandler) ExportSessionAnswers(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid session ID")
+	if !ok {
+		return
+	}
+
+	format, ok := parseExportFormat(c)
+	if !ok {
+		return
+	}
+
+	streamExport(c, format, fmt.Sprintf("session-%d-answers", sessionID), "Failed to export answers",
+		func(w spreadsheet.RowWriter) error {
+			return h.examService.ExportSessionAnswers(c.Request.Context(), sessionID, w)
+		})
+}
+
+// parseExportFormat parses the format query, writing a 400 response when unsupported
+func parseExportFormat(c *gin.Context) (string, bool) {
+	format := strings.ToLower(c.DefaultQuery("format", spreadsheet.FormatCSV))
+	if format != spreadsheet.FormatCSV && format != spreadsheet.FormatXLSX {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Unsupported format, use csv or xlsx",
+			Error:   fmt.Sprintf("%v: %s", spreadsheet.ErrUnsupportedFormat, format),
+		})
+		return "", false
+	}
+	return format, true
+}
+
+// streamExport writes an export as a file attachment while it is produced. Errors before the
+// first row are reported as JSON; later ones abort the response, leaving the file truncated.
+func streamExport(c *gin.Context, format, name, message string, export func(w spreadsheet.RowWriter) error) {
+	// Large exports outlive the write timeout of ordinary requests
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	contentType, extension := spreadsheet.FileInfo(format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s%s\"", name, extension))
+
+	writer, err := spreadsheet.NewWriter(c.Writer, format)
+	if err == nil {
+		err = export(writer)
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if c.Writer.Written() {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	status := http.StatusInternalServerError
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		status, message = http.StatusNotFound, "Exam session not found"
+	}
+	c.JSON(status, dto.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/repositories/grading_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginGradingHandler struct {
+	gradingRepo grading_service.GradingService
+	examService *exam_service.ExamService
+}
+
+func NewGinGradingHandler(db *gorm.DB) *ginGradingHandler {
+	return &ginGradingHandler{
+		gradingRepo: grading_service.NewGradingService(db),
+		examService: exam_service.NewExamService(db),
+	}
+}
+
+// RegisterRoutes registers grading scale, pass rule, blueprint and re-grade routes
+func (h *ginGradingHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	gradingGroup := v1.Group("/grading")
+	{
+		gradingGroup.GET("/scales", h.GetGradingScales)
+		gradingGroup.POST("/scales", h.CreateGradingScale)
+		gradingGroup.GET("/scales/:scaleID", h.GetGradingScale)
+		gradingGroup.GET("/pass-rules", h.GetPassRules)
+		gradingGroup.POST("/pass-rules", h.CreatePassRule)
+		gradingGroup.GET("/pass-rules/:ruleID", h.GetPassRule)
+		gradingGroup.POST("/regrade", h.RegradeSessions)
+	}
+
+	blueprintGroup := v1.Group("/blueprints")
+	{
+		blueprintGroup.GET("", h.GetBlueprints)
+		blueprintGroup.POST("", h.CreateBlueprint)
+		blueprintGroup.GET("/:blueprintID", h.GetBlueprint)
+		blueprintGroup.PUT("/:blueprintID", h.UpdateBlueprint)
+	}
+}
+
+// GetGradingScales returns all grading scales
+// @Summary Get grading scales
+// @Description Returns all named grading scales with their grade bands
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.GradingScaleResponse}
+// @Router /grading/scales [get]
+func (h *ginGradingHandler) GetGradingScales(c *gin.Context) {
+	scales, err := h.gradingRepo.GetGradingScales(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch grading scales",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Grading scales retrieved successfully",
+		Data:    dto.ToGradingScaleResponses(scales),
+	})
+}
+
+// GetGradingScale returns a single grading scale
+// @Summary Get grading scale
+// @Description Returns a single grading scale with its grade bands
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param scaleID path int true "Grading scale ID"
+// @Success 200 {object} dto.APIResponse{data=dto.GradingScaleResponse}
+// @Failure 404 {object} dto.APIResponse "Grading scale not found"
+// @Router /grading/scales/{scaleID} [get]
+func (h *ginGradingHandler) GetGradingScale(c *gin.Context) {
+	scaleID, ok := parseUintParam(c, "scaleID", "Invalid grading scale ID")
+	if !ok {
+		return
+	}
+
+	scale, err := h.gradingRepo.GetGradingScaleByID(c.Request.Context(), scaleID)
+	if err != nil {
+		respondGradingError(c, err, "Failed to fetch grading scale")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Grading scale retrieved successfully",
+		Data:    dto.ToGradingScaleResponse(scale),
+	})
+}
+
+// CreateGradingScale creates a new grading scale
+// @Summary Create grading scale
+// @Description Creates a named grading scale. Bands need unique grades and one band must start at 0%.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param body body dto.GradingScaleRequest true "Grading scale to create"
+// @Success 201 {object} dto.APIResponse{data=dto.GradingScaleResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or bands"
+// @Router /grading/scales [post]
+func (h *ginGradingHandler) CreateGradingScale(c *gin.Context) {
+	var req dto.GradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	scale := models.GradingScale{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	for _, band := range req.Bands {
+		scale.Bands = append(scale.Bands, models.GradingBand{
+			Grade:         band.Grade,
+			MinPercentage: *band.MinPercentage,
+		})
+	}
+
+	if err := h.gradingRepo.CreateGradingScale(c.Request.Context(), &scale); err != nil {
+		respondGradingError(c, err, "Failed to create grading scale")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Grading scale created successfully",
+		Data:    dto.ToGradingScaleResponse(&scale),
+	})
+}
+
+// GetPassRules returns all pass rules
+// @Summary Get pass rules
+// @Description Returns all pass rules with their per-category minimums
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.PassRuleResponse}
+// @Router /grading/pass-rules [get]
+func (h *ginGradingHandler) GetPassRules(c *gin.Context) {
+	rules, err := h.gradingRepo.GetPassRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch pass rules",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Pass rules retrieved successfully",
+		Data:    dto.ToPassRuleResponses(rules),
+	})
+}
+
+// GetPassRule returns a single pass rule
+// @Summary Get pass rule
+// @Description Returns a single pass rule with its per-category minimums
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param ruleID path int true "Pass rule ID"
+// @Success 200 {object} dto.APIResponse{data=dto.PassRuleResponse}
+// @Failure 404 {object} dto.APIResponse "Pass rule not found"
+// @Router /grading/pass-rules/{ruleID} [get]
+func (h *ginGradingHandler) GetPassRule(c *gin.Context) {
+	ruleID, ok := parseUintParam(c, "ruleID", "Invalid pass rule ID")
+	if !ok {
+		return
+	}
+
+	rule, err := h.gradingRepo.GetPassRuleByID(c.Request.Context(), ruleID)
+	if err != nil {
+		respondGradingError(c, err, "Failed to fetch pass rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Pass rule retrieved successfully",
+		Data:    dto.ToPassRuleResponse(rule),
+	})
+}
+
+// CreatePassRule creates a new pass rule
+// @Summary Create pass rule
+// @Description Creates a pass rule with an optional overall minimum percentage and/or raw score, per-category minimums and a must-pass-all-categories flag
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param body body dto.PassRuleRequest true "Pass rule to create"
+// @Success 201 {object} dto.APIResponse{data=dto.PassRuleResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or category minimums"
+// @Router /grading/pass-rules [post]
+func (h *ginGradingHandler) CreatePassRule(c *gin.Context) {
+	var req dto.PassRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	rule := models.PassRule{
+		Code:                 req.Code,
+		Name:                 req.Name,
+		Description:          req.Description,
+		OverallMinPercentage: req.OverallMinPercentage,
+		OverallMinScore:      req.OverallMinScore,
+		RequireAllCategories: req.RequireAllCategories,
+	}
+	for _, minimum := range req.CategoryMinimums {
+		rule.CategoryMinimums = append(rule.CategoryMinimums, models.PassRuleCategoryMinimum{
+			Category:      minimum.Category,
+			MinScore:      minimum.MinScore,
+			MinPercentage: minimum.MinPercentage,
+		})
+	}
+
+	if err := h.gradingRepo.CreatePassRule(c.Request.Context(), &rule); err != nil {
+		respondGradingError(c, err, "Failed to create pass rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Pass rule created successfully",
+		Data:    dto.ToPassRuleResponse(&rule),
+	})
+}
+
+// GetBlueprints returns all exam blueprints
+// @Summary Get exam blueprints
+// @Description Returns all exam blueprints with their duration, grading scale and pass rule
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.BlueprintResponse}
+// @Router /blueprints [get]
+func (h *ginGradingHandler) GetBlueprints(c *gin.Context) {
+	blueprints, err := h.gradingRepo.GetBlueprints(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch blueprints",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Blueprints retrieved successfully",
+		Data:    dto.ToBlueprintResponses(blueprints),
+	})
+}
+
+// GetBlueprint returns a single exam blueprint
+// @Summary Get exam blueprint
+// @Description Returns a single exam blueprint
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param blueprintID path int true "Blueprint ID"
+// @Success 200 {object} dto.APIResponse{data=dto.BlueprintResponse}
+// @Failure 404 {object} dto.APIResponse "Blueprint not found"
+// @Router /blueprints/{blueprintID} [get]
+func (h *ginGradingHandler) GetBlueprint(c *gin.Context) {
+	blueprintID, ok := parseUintParam(c, "blueprintID", "Invalid blueprint ID")
+	if !ok {
+		return
+	}
+
+	blueprint, err := h.gradingRepo.GetBlueprintByID(c.Request.Context(), blueprintID)
+	if err != nil {
+		respondGradingError(c, err, "Failed to fetch blueprint")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Blueprint retrieved successfully",
+		Data:    dto.ToBlueprintResponse(blueprint),
+	})
+}
+
+// CreateBlueprint creates a new exam blueprint
+// @Summary Create exam blueprint
+// @Description Creates an exam blueprint selecting a grading scale and pass rule. Marking it default unmarks the previous default.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param body body dto.BlueprintRequest true "Blueprint to create"
+// @Success 201 {object} dto.APIResponse{data=dto.BlueprintResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Grading scale or pass rule not found"
+// @Router /blueprints [post]
+func (h *ginGradingHandler) CreateBlueprint(c *gin.Context) {
+	var req dto.BlueprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	blueprint := models.ExamBlueprint{}
+	applyBlueprintRequest(&blueprint, &req)
+
+	if err := h.gradingRepo.CreateBlueprint(c.Request.Context(), &blueprint); err != nil {
+		respondGradingError(c, err, "Failed to create blueprint")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Blueprint created successfully",
+		Data:    dto.ToBlueprintResponse(&blueprint),
+	})
+}
+
+// UpdateBlueprint updates an exam blueprint
+// @Summary Update exam blueprint
+// @Description Updates an exam blueprint. Completed sessions keep their grades until re-graded.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param blueprintID path int true "Blueprint ID"
+// @Param body body dto.BlueprintRequest true "Blueprint fields"
+// @Success 200 {object} dto.APIResponse{data=dto.BlueprintResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Blueprint, grading scale or pass rule not found"
+// @Router /blueprints/{blueprintID} [put]
+func (h *ginGradingHandler) UpdateBlueprint(c *gin.Context) {
+	blueprintID, ok := parseUintParam(c, "blueprintID", "Invalid blueprint ID")
+	if !ok {
+		return
+	}
+
+	var req dto.BlueprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	blueprint, err := h.gradingRepo.GetBlueprintByID(c.Request.Context(), blueprintID)
+	if err != nil {
+		respondGradingError(c, err, "Failed to fetch blueprint")
+		return
+	}
+
+	applyBlueprintRequest(blueprint, &req)
+
+	if err := h.gradingRepo.UpdateBlueprint(c.Request.Context(), blueprint); err != nil {
+		respondGradingError(c, err, "Failed to update blueprint")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Blueprint updated successfully",
+		Data:    dto.ToBlueprintResponse(blueprint),
+	})
+}
+
+// RegradeSessions re-grades completed sessions under a grading scale and pass rule
+// @Summary Re-grade completed sessions
+// @Description Re-applies a grading scale and pass rule to completed sessions selected by blueprint and/or session IDs. Answers, scores and percentages are not changed. The scale and rule default to those of the blueprint. Use dry_run to preview the changed verdicts.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param body body dto.RegradeRequest true "Sessions and rule set to re-grade with"
+// @Success 200 {object} dto.APIResponse{data=dto.RegradeReport}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Blueprint, grading scale or pass rule not found"
+// @Router /grading/regrade [post]
+func (h *ginGradingHandler) RegradeSessions(c *gin.Context) {
+	var req dto.RegradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	report, err := h.examService.RegradeSessions(c.Request.Context(), req)
+	if err != nil {
+		respondGradingError(c, err, "Failed to re-grade sessions")
+		return
+	}
+
+	message := "Sessions re-graded successfully"
+	if report.DryRun {
+		message = "Re-grade preview generated, nothing was saved"
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    report,
+	})
+}
+
+// applyBlueprintRequest copies request fields onto a blueprint model
+func applyBlueprintRequest(blueprint *models.ExamBlueprint, req *dto.BlueprintRequest) {
+	blueprint.Code = req.Code
+	blueprint.Name = req.Name
+	blueprint.Description = req.Description
+	blueprint.DurationMinutes = req.DurationMinutes
+	blueprint.GradingScaleID = req.GradingScaleID
+	blueprint.PassRuleID = req.PassRuleID
+	blueprint.IsDefault = req.IsDefault
+}
+
+// parseUintParam parses a numeric path parameter, writing a 400 response when invalid
+func parseUintParam(c *gin.Context, name, message string) (uint, bool) {
+	value, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+		return 0, false
+	}
+	return uint(value), true
+}
+
+// respondGradingError maps grading and re-grade errors to HTTP responses
+func respondGradingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, scoring.ErrInvalidGradingScale),
+		errors.Is(err, grading_service.ErrInvalidPassRule),
+		errors.Is(err, exam_service.ErrInvalidRegrade):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/repositories/notification_service"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginNotificationHandler struct {
+	notificationRepo notification_service.NotificationService
+}
+
+func NewGinNotificationHandler(db *gorm.DB) *ginNotificationHandler {
+	return &ginNotificationHandler{
+		notificationRepo: notification_service.NewNotificationService(db),
+	}
+}
+
+// RegisterRoutes registers notification contact and send queue routes
+func (h *ginNotificationHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	notificationGroup := v1.Group("/notifications")
+	{
+		notificationGroup.GET("", h.GetNotifications)
+		notificationGroup.POST("/:notificationID/retry", h.RetryNotification)
+		notificationGroup.GET("/contacts", h.GetContacts)
+		notificationGroup.GET("/contacts/:userID", h.GetContact)
+		notificationGroup.PUT("/contacts/:userID", h.SaveContact)
+		notificationGroup.DELETE("/contacts/:userID", h.DeleteContact)
+	}
+}
+
+// GetContacts returns all notification contacts
+// @Summary Get notification contacts
+// @Description Returns the email address, language and opt-out of every candidate that has a contact
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.NotificationContactResponse}
+// @Router /notifications/contacts [get]
+func (h *ginNotificationHandler) GetContacts(c *gin.Context) {
+	contacts, err := h.notificationRepo.GetContacts(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch notification contacts",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification contacts retrieved successfully",
+		Data:    dto.ToNotificationContactResponses(contacts),
+	})
+}
+
+// GetContact returns the notification contact of a candidate
+// @Summary Get notification contact
+// @Description Returns the notification contact of a candidate
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse{data=dto.NotificationContactResponse}
+// @Failure 404 {object} dto.APIResponse "Notification contact not found"
+// @Router /notifications/contacts/{userID} [get]
+func (h *ginNotificationHandler) GetContact(c *gin.Context) {
+	contact, err := h.notificationRepo.GetContact(c.Request.Context(), c.Param("userID"))
+	if err != nil {
+		respondNotificationError(c, err, "Failed to fetch notification contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification contact retrieved successfully",
+		Data:    dto.ToNotificationContactResponse(contact),
+	})
+}
+
+// SaveContact creates or replaces the notification contact of a candidate
+// @Summary Set notification contact
+// @Description Creates or replaces the notification contact of a candidate. Candidates with a contact are emailed their score report when an exam is completed and a reminder before a booked sitting opens, in Indonesian (id) or English (en). Opting out stops every email, also those already queued.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Param body body dto.NotificationContactRequest true "Notification contact"
+// @Success 200 {object} dto.APIResponse{data=dto.NotificationContactResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body, email address or locale"
+// @Router /notifications/contacts/{userID} [put]
+func (h *ginNotificationHandler) SaveContact(c *gin.Context) {
+	var req dto.NotificationContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	contact := models.NotificationContact{
+		UserID:   c.Param("userID"),
+		Email:    req.Email,
+		Name:     req.Name,
+		Locale:   req.Locale,
+		OptedOut: req.OptedOut,
+	}
+
+	if err := h.notificationRepo.SaveContact(c.Request.Context(), &contact); err != nil {
+		respondNotificationError(c, err, "Failed to save notification contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification contact saved successfully",
+		Data:    dto.ToNotificationContactResponse(&contact),
+	})
+}
+
+// DeleteContact removes the notification contact of a candidate
+// @Summary Delete notification contact
+// @Description Removes the notification contact of a candidate. Nothing is sent to the candidate afterwards, also notifications already queued.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Notification contact not found"
+// @Router /notifications/contacts/{userID} [delete]
+func (h *ginNotificationHandler) DeleteContact(c *gin.Context) {
+	if err := h.notificationRepo.DeleteContact(c.Request.Context(), c.Param("userID")); err != nil {
+		respondNotificationError(c, err, "Failed to delete notification contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification contact deleted successfully",
+	})
+}
+
+// GetNotifications returns the send queue with filters and pagination
+// @Summary Get notifications
+// @Description Returns queued and sent emails, newest first. Failed sends are retried with exponential backoff, up to 6 attempts.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param user_id query string false "Filter by user ID"
+// @Param kind query string false "Filter by kind" Enums(SCORE_REPORT, SITTING_REMINDER)
+// @Param status query string false "Filter by status" Enums(PENDING, SENT, FAILED, SKIPPED)
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 50, use 0 for all)" minimum(0)
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedNotificationResponse}
+// @Router /notifications [get]
+func (h *ginNotificationHandler) GetNotifications(c *gin.Context) {
+	filter := notification_service.NotificationFilter{
+		UserID: c.Query("user_id"),
+		Kind:   strings.ToUpper(c.Query("kind")),
+		Status: strings.ToUpper(c.Query("status")),
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+
+	totalCount, err := h.notificationRepo.CountNotifications(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to count notifications",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	notifications, err := h.notificationRepo.GetNotifications(c.Request.Context(), filter, (page-1)*limit, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch notifications",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	totalPages := 1
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(totalCount) / float64(limit)))
+	} else {
+		page = 1 // Reset page to 1 when showing all
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notifications retrieved successfully",
+		Data: dto.PaginatedNotificationResponse{
+			Notifications: dto.ToNotificationResponses(notifications),
+			Pagination: dto.PaginationMetadata{
+				CurrentPage:  page,
+				ItemsPerPage: limit,
+				TotalItems:   int(totalCount),
+				TotalPages:   totalPages,
+			},
+		},
+	})
+}
+
+// RetryNotification queues a failed notification again
+// @Summary Retry notification
+// @Description Queues a failed email for immediate sending with a fresh set of attempts
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param notificationID path int true "Notification ID"
+// @Success 200 {object} dto.APIResponse{data=dto.NotificationResponse}
+// @Failure 404 {object} dto.APIResponse "Notification not found"
+// @Failure 409 {object} dto.APIResponse "Notification has not failed"
+// @Router /notifications/{notificationID}/retry [post]
+func (h *ginNotificationHandler) RetryNotification(c *gin.Context) {
+	notificationID, ok := parseUintParam(c, "notificationID", "Invalid notification ID")
+	if !ok {
+		return
+	}
+
+	notification, err := h.notificationRepo.RetryNotification(c.Request.Context(), notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Notification not found",
+			})
+			return
+		}
+		respondNotificationError(c, err, "Failed to retry notification")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Notification queued for retry",
+		Data:    dto.ToNotificationResponse(notification),
+	})
+}
+
+// respondNotificationError maps notification service errors to HTTP responses
+func respondNotificationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Notification contact not found",
+		})
+	case errors.Is(err, notification_service.ErrInvalidContact):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid notification contact",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, notification_service.ErrNotificationNotFailed):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Notification has not failed",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
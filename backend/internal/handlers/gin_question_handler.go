@@ -1,12 +1,22 @@
 package handlers
 
 import (
+	"bytes"
 	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/lms"
+	"cutbray/pppk-json/internal/repositories/exam_service"
 	"cutbray/pppk-json/internal/repositories/models"
 	"cutbray/pppk-json/internal/repositories/question_service"
+	"cutbray/pppk-json/internal/scoring"
+	"cutbray/pppk-json/internal/spreadsheet"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -14,11 +24,13 @@ import (
 
 type ginQuestionHandler struct {
 	questionRepo question_service.QuestionService
+	examService  *exam_service.ExamService
 }
 
 func NewGinQuestionHandler(db *gorm.DB) *ginQuestionHandler {
 	return &ginQuestionHandler{
 		questionRepo: question_service.NewQuestionService(db),
+		examService:  exam_service.NewExamService(db),
 	}
 }
 
@@ -32,8 +44,19 @@ func (h *ginQuestionHandler) RegisterRoutes(router *gin.Engine) {
 	questionGroup := v1.Group("/questions")
 	{
 		questionGroup.GET("/management", h.GetQuestionsByCategory)
+		questionGroup.POST("/management", h.CreateQuestion)
+		questionGroup.PUT("/management/:questionID", h.UpdateQuestion)
+		questionGroup.DELETE("/management/:questionID", h.DeleteQuestion)
 		questionGroup.PUT("/:questionID/option/:optionID/score", h.UpdateOptionScore)
+		questionGroup.POST("/:questionID/rescore", h.RescoreQuestion)
+		questionGroup.POST("/scores/bulk", h.BulkUpdateOptionScores)
+		questionGroup.POST("/import", h.ImportQuestions)
 		questionGroup.GET("/categories", h.GetCategories)
+		questionGroup.GET("/tags", h.GetTags)
+		questionGroup.POST("/tags", h.CreateTag)
+		questionGroup.PUT("/:questionID/tags", h.SetQuestionTags)
+		questionGroup.GET("/tag-quotas", h.GetTagQuotas)
+		questionGroup.PUT("/tag-quotas", h.SetTagQuota)
 	}
 }
 
@@ -43,8 +66,9 @@ func (h *ginQuestionHandler) RegisterRoutes(router *gin.Engine) {
 // @Tags questions
 // @Accept json
 // @Produce json
-// @Param category query string false "Category filter (TEKNIS, MANAJERIAL, SOSIAL KULTURAL, WAWANCARA)"
+// @Param category query string false "Category code filter (see /categories)"
 // @Param search query string false "Search by question text"
+// @Param tags query string false "Comma separated tag names, questions must carry all of them"
 // @Param page query int false "Page number (default: 1)" minimum(1)
 // @Param limit query int false "Items per page (default: 10, use 0 for all)" minimum(0)
 // @Success 200 {object} dto.APIResponse{data=dto.PaginatedQuestionResponse}
@@ -52,6 +76,7 @@ func (h *ginQuestionHandler) RegisterRoutes(router *gin.Engine) {
 func (h *ginQuestionHandler) GetQuestionsByCategory(c *gin.Context) {
 	category := c.Query("category")
 	searchText := c.Query("search")
+	tags := parseTagsQuery(c.Query("tags"))
 	pageStr := c.DefaultQuery("page", "1")
 	limitStr := c.DefaultQuery("limit", "10")
 
@@ -67,7 +92,7 @@ func (h *ginQuestionHandler) GetQuestionsByCategory(c *gin.Context) {
 	}
 
 	// Get total count using repository
-	totalCount, err := h.questionRepo.CountQuestionsWithFilters(category, searchText)
+	totalCount, err := h.questionRepo.CountQuestionsWithFilters(c.Request.Context(), category, searchText, tags)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -81,9 +106,9 @@ func (h *ginQuestionHandler) GetQuestionsByCategory(c *gin.Context) {
 	var questions []models.Question
 	if limit > 0 {
 		offset := (page - 1) * limit
-		questions, err = h.questionRepo.GetQuestionsWithFilters(category, searchText, offset, limit)
+		questions, err = h.questionRepo.GetQuestionsWithFilters(c.Request.Context(), category, searchText, tags, offset, limit)
 	} else {
-		questions, err = h.questionRepo.GetAllQuestionsWithFilters(category, searchText)
+		questions, err = h.questionRepo.GetAllQuestionsWithFilters(c.Request.Context(), category, searchText, tags)
 	}
 
 	if err != nil {
@@ -123,16 +148,197 @@ func (h *ginQuestionHandler) GetQuestionsByCategory(c *gin.Context) {
 	})
 }
 
+// CreateQuestion creates a new question with its options
+// @Summary Create question
+// @Description Creates a question with options and tags, the category must be registered
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateQuestionRequest true "Question to create"
+// @Success 201 {object} dto.APIResponse{data=dto.QuestionManagementResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request or unknown category"
+// @Router /questions/management [post]
+func (h *ginQuestionHandler) CreateQuestion(c *gin.Context) {
+	var req dto.CreateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	question := models.Question{
+		Category:     req.Category,
+		QuestionText: req.QuestionText,
+		Options:      make([]models.QuestionOption, len(req.Options)),
+	}
+	for i, opt := range req.Options {
+		question.Options[i] = models.QuestionOption{
+			OptionText: opt.OptionText,
+			Score:      *opt.Score,
+		}
+	}
+
+	if err := h.questionRepo.CreateQuestion(c.Request.Context(), &question, req.Tags); err != nil {
+		if errors.Is(err, question_service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Unknown category",
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to create question",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Question created successfully",
+		Data:    dto.ToQuestionManagementResponse(&question),
+	})
+}
+
+// UpdateQuestion updates the category and text of a question
+// @Summary Update question
+// @Description Updates the category and question text, the category must be registered
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param questionID path int true "Question ID"
+// @Param body body dto.UpdateQuestionRequest true "Question fields"
+// @Success 200 {object} dto.APIResponse{data=dto.QuestionManagementResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request or unknown category"
+// @Failure 404 {object} dto.APIResponse "Question not found"
+// @Router /questions/management/{questionID} [put]
+func (h *ginQuestionHandler) UpdateQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("questionID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid question ID",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var req dto.UpdateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	question, err := h.questionRepo.GetQuestionByID(c.Request.Context(), uint(questionID))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Question not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to find question",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	question.Category = req.Category
+	question.QuestionText = req.QuestionText
+
+	if err := h.questionRepo.UpdateQuestion(c.Request.Context(), question); err != nil {
+		if errors.Is(err, question_service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Unknown category",
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to update question",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Question updated successfully",
+		Data:    dto.ToQuestionManagementResponse(question),
+	})
+}
+
+// DeleteQuestion deletes a question
+// @Summary Delete question
+// @Description Soft deletes a question and hides it from future exams
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param questionID path int true "Question ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Question not found"
+// @Router /questions/management/{questionID} [delete]
+func (h *ginQuestionHandler) DeleteQuestion(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("questionID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid question ID",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.questionRepo.DeleteQuestion(c.Request.Context(), uint(questionID)); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Question not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to delete question",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Question deleted successfully",
+	})
+}
+
 // UpdateOptionScore updates the score of a specific question option
 // @Summary Update question option score
-// @Description Updates the score value for a specific question option
+// @Description Updates the score value for a specific question option, validated against the scoring scheme of its category. Every completed session that drew the question is re-scored in the same transaction and audited. With dry_run=true nothing is saved and the re-score report previews the effect.
 // @Tags questions
 // @Accept json
 // @Produce json
 // @Param questionID path int true "Question ID"
 // @Param optionID path int true "Option ID"
+// @Param dry_run query bool false "Preview the re-score without saving"
+// @Param X-Actor header string false "Who makes the change, recorded in the audit log"
 // @Param body body dto.UpdateScoreRequest true "New score value"
-// @Success 200 {object} dto.APIResponse{data=dto.QuestionOptionManagementResponse}
+// @Success 200 {object} dto.APIResponse{data=dto.OptionScoreUpdateResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request or score not allowed by the scoring scheme"
+// @Failure 404 {object} dto.APIResponse "Question option not found"
 // @Router /questions/{questionID}/option/{optionID}/score [put]
 func (h *ginQuestionHandler) UpdateOptionScore(c *gin.Context) {
 	questionIDStr := c.Param("questionID")
@@ -168,65 +374,429 @@ func (h *ginQuestionHandler) UpdateOptionScore(c *gin.Context) {
 		return
 	}
 
-	// Validate score range (0-10)
-	finalScore := *req.Score
-	if finalScore < 0 || finalScore > 10 {
+	dryRun := c.Query("dry_run") == "true"
+
+	// Update the score and re-score historical sessions in one transaction
+	option, report, err := h.examService.UpdateOptionScore(
+		c.Request.Context(), uint(questionID), uint(optionID), *req.Score, dryRun)
+	if err != nil {
+		respondRescoreError(c, err, "Failed to update score")
+		return
+	}
+
+	message := "Score updated successfully"
+	if dryRun {
+		message = "Score change preview generated, nothing was saved"
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data: dto.OptionScoreUpdateResponse{
+			Option:  dto.ToQuestionOptionManagementResponse(option),
+			Rescore: report,
+		},
+	})
+}
+
+// RescoreQuestion re-scores the completed sessions that drew a question
+// @Summary Re-score a question
+// @Description Recomputes answer scores, category results and summaries of every completed session that drew the question using the current option scores. The grading scale and pass rule recorded on each session are kept. With dry_run=true nothing is saved.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param questionID path int true "Question ID"
+// @Param dry_run query bool false "Preview the re-score without saving"
+// @Param X-Actor header string false "Who makes the change, recorded in the audit log"
+// @Success 200 {object} dto.APIResponse{data=dto.RescoreReport}
+// @Failure 404 {object} dto.APIResponse "Question not found"
+// @Router /questions/{questionID}/rescore [post]
+func (h *ginQuestionHandler) RescoreQuestion(c *gin.Context) {
+	questionID, ok := parseUintParam(c, "questionID", "Invalid question ID")
+	if !ok {
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.examService.RescoreQuestion(c.Request.Context(), questionID, dryRun)
+	if err != nil {
+		respondRescoreError(c, err, "Failed to re-score question")
+		return
+	}
+
+	message := "Question re-scored successfully"
+	if dryRun {
+		message = "Re-score preview generated, nothing was saved"
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    report,
+	})
+}
+
+// BulkUpdateOptionScores edits many option scores at once
+// @Summary Bulk edit option scores
+// @Description Accepts a JSON list of question/option/score rows or a CSV upload (multipart field "file" with question_id, option_id and score columns). Every row is validated against the scoring scheme of its category and all rows are applied atomically: one invalid row rejects the whole edit. Completed sessions that drew a changed question are re-scored once. With dry_run=true nothing is saved.
+// @Tags questions
+// @Accept json
+// @Accept mpfd
+// @Produce json
+// @Param dry_run query bool false "Validate and preview without saving"
+// @Param body body dto.BulkScoreUpdateRequest false "Rows to apply (JSON)"
+// @Param file formData file false "CSV with question_id, option_id and score columns"
+// @Success 200 {object} dto.APIResponse{data=dto.BulkScoreReport}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or CSV"
+// @Failure 422 {object} dto.APIResponse{data=dto.BulkScoreReport} "Some rows are invalid, nothing was applied"
+// @Router /questions/scores/bulk [post]
+func (h *ginQuestionHandler) BulkUpdateOptionScores(c *gin.Context) {
+	var changes []exam_service.OptionScoreChange
+
+	if c.ContentType() == "multipart/form-data" {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "CSV file is required in the file field",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Failed to open uploaded file",
+				Error:   err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+
+		var invalidRows []dto.BulkScoreRowResult
+		changes, invalidRows, err = parseScoreCSV(file)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid CSV file",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		if len(invalidRows) > 0 {
+			c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+				Success: false,
+				Message: "Some rows are invalid, nothing was applied",
+				Data: dto.BulkScoreReport{
+					TotalRows:  len(changes) + len(invalidRows),
+					FailedRows: len(invalidRows),
+					Rows:       invalidRows,
+				},
+			})
+			return
+		}
+	} else {
+		var req dto.BulkScoreUpdateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		for i, update := range req.Updates {
+			changes = append(changes, exam_service.OptionScoreChange{
+				Row:        i + 1,
+				QuestionID: update.QuestionID,
+				OptionID:   update.OptionID,
+				Score:      *update.Score,
+			})
+		}
+	}
+
+	if len(changes) == 0 {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
-			Message: "Score must be between 0 and 10",
+			Message: "No rows to apply",
 		})
 		return
 	}
 
-	// Get the question option using repository
-	option, err := h.questionRepo.GetQuestionOptionByID(uint(questionID), uint(optionID))
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.examService.BulkUpdateOptionScores(c.Request.Context(), changes, dryRun)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, dto.APIResponse{
+		if errors.Is(err, exam_service.ErrBulkScoreRejected) {
+			c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
 				Success: false,
-				Message: "Question option not found",
+				Message: "Some rows are invalid, nothing was applied",
+				Data:    report,
+				Error:   err.Error(),
 			})
 			return
 		}
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
-			Message: "Failed to find question option",
+			Message: "Failed to update scores",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	message := "Scores updated successfully"
+	if dryRun {
+		message = "Bulk score preview generated, nothing was saved"
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    report,
+	})
+}
+
+// parseScoreCSV reads question_id, option_id and score columns (in any order) from a CSV.
+// Rows that cannot be parsed are returned as invalid row results numbered by CSV line.
+func parseScoreCSV(r io.Reader) ([]exam_service.OptionScoreChange, []dto.BulkScoreRowResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := map[string]int{"question_id": -1, "option_id": -1, "score": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+	for name, index := range columns {
+		if index < 0 {
+			return nil, nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+
+	var changes []exam_service.OptionScoreChange
+	var invalid []dto.BulkScoreRowResult
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		values := make(map[string]string, len(columns))
+		for name, index := range columns {
+			if index < len(record) {
+				values[name] = strings.TrimSpace(record[index])
+			}
+		}
+
+		// Skip blank lines left by spreadsheet exports
+		if values["question_id"] == "" && values["option_id"] == "" && values["score"] == "" {
+			continue
+		}
+
+		questionID, qErr := strconv.ParseUint(values["question_id"], 10, 32)
+		optionID, oErr := strconv.ParseUint(values["option_id"], 10, 32)
+		score, sErr := strconv.Atoi(values["score"])
+
+		if rowErr := errors.Join(qErr, oErr, sErr); rowErr != nil {
+			invalid = append(invalid, dto.BulkScoreRowResult{
+				Row:        line,
+				QuestionID: uint(questionID),
+				OptionID:   uint(optionID),
+				Score:      score,
+				Status:     exam_service.BulkScoreStatusInvalid,
+				Error:      rowErr.Error(),
+			})
+			continue
+		}
+
+		changes = append(changes, exam_service.OptionScoreChange{
+			Row:        line,
+			QuestionID: uint(questionID),
+			OptionID:   uint(optionID),
+			Score:      score,
+		})
+	}
+
+	return changes, invalid, nil
+}
+
+// ImportQuestions imports questions from a spreadsheet or an LMS exchange file
+// @Summary Import questions from a spreadsheet or LMS file
+// @Description Imports questions from a CSV, XLSX, Moodle XML, GIFT, Aiken or QTI 2.1 upload (multipart field "file"). Spreadsheets hold one question per row: the header row must contain category and question_text, optional tags (separated by commas or semicolons) and option_N/score_N column pairs. LMS files carry fractional credit, converted back into option scores with the scoring scheme of the category; questions without a category (Aiken, QTI without label) are filed under the category query. All questions go through the same validation as the JSON seeder; one invalid question rejects the whole import and every problem is reported with its row (or question) number. With preview=true the file is validated without saving. The format is taken from the file extension unless given in the format query.
+// @Tags questions
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV, XLSX, Moodle XML, GIFT, Aiken or QTI 2.1 file"
+// @Param format query string false "File format, defaults to the file extension" Enums(csv, xlsx, moodle, gift, aiken, qti)
+// @Param category query string false "Category for questions whose format carries none"
+// @Param preview query bool false "Validate without saving"
+// @Success 200 {object} dto.APIResponse{data=dto.QuestionImportReport}
+// @Success 201 {object} dto.APIResponse{data=dto.QuestionImportReport}
+// @Failure 400 {object} dto.APIResponse "Missing or unreadable file"
+// @Failure 422 {object} dto.APIResponse{data=dto.QuestionImportReport} "Some rows are invalid, nothing was imported"
+// @Router /questions/import [post]
+func (h *ginQuestionHandler) ImportQuestions(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Import file is required in the file field",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format, err = question_service.ImportFormatFromFilename(fileHeader.Filename)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Unsupported file type, use .csv, .xlsx, .xml (Moodle), .gift, .txt (Aiken) or .zip (QTI 2.1)",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Failed to open uploaded file",
 			Error:   err.Error(),
 		})
 		return
 	}
+	defer file.Close()
 
-	// Update the score
-	option.Score = finalScore
-	if err := h.questionRepo.UpdateQuestionOption(option); err != nil {
+	inputs, rowErrors, err := h.questionRepo.ParseImportFile(c.Request.Context(), file, fileHeader.Size, format, c.Query("category"))
+	if err != nil {
+		if errors.Is(err, spreadsheet.ErrUnsupportedFormat) {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Unsupported import format",
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Failed to read import file",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+			Success: false,
+			Message: "Some rows are invalid, nothing was imported",
+			Data: dto.QuestionImportReport{
+				TotalRows:  len(inputs) + len(rowErrors),
+				ByCategory: map[string]int{},
+				Errors:     rowErrors,
+			},
+		})
+		return
+	}
+
+	if len(inputs) == 0 {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "No questions to import",
+		})
+		return
+	}
+
+	preview := c.Query("preview") == "true"
+
+	report, err := h.questionRepo.ImportQuestions(c.Request.Context(), inputs, preview)
+	if err != nil {
+		if errors.Is(err, question_service.ErrImportRejected) {
+			c.JSON(http.StatusUnprocessableEntity, dto.APIResponse{
+				Success: false,
+				Message: "Some rows are invalid, nothing was imported",
+				Data:    report,
+				Error:   err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
-			Message: "Failed to update score",
+			Message: "Failed to import questions",
 			Error:   err.Error(),
 		})
 		return
 	}
 
-	// Convert to management DTO
-	optionResponse := dto.ToQuestionOptionManagementResponse(option)
+	if preview {
+		c.JSON(http.StatusOK, dto.APIResponse{
+			Success: true,
+			Message: "Import preview generated, nothing was saved",
+			Data:    report,
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, dto.APIResponse{
+	c.JSON(http.StatusCreated, dto.APIResponse{
 		Success: true,
-		Message: "Score updated successfully",
-		Data:    optionResponse,
+		Message: "Questions imported successfully",
+		Data:    report,
 	})
 }
 
+// respondRescoreError maps option score and re-score errors to HTTP responses
+func respondRescoreError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Question option not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, scoring.ErrInvalidScore):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Score is not allowed by the scoring scheme",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
+
 // GetCategories returns all available question categories
 // @Summary Get question categories
-// @Description Returns list of all available question categories
+// @Description Returns the codes of all registered question categories in display order
 // @Tags questions
 // @Accept json
 // @Produce json
 // @Success 200 {object} dto.APIResponse{data=[]string}
 // @Router /questions/categories [get]
 func (h *ginQuestionHandler) GetCategories(c *gin.Context) {
-	categories, err := h.questionRepo.GetDistinctCategories()
+	categories, err := h.questionRepo.GetCategoryCodes(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -244,21 +814,34 @@ func (h *ginQuestionHandler) GetCategories(c *gin.Context) {
 }
 
 // DownloadQuestionsJSON downloads questions as JSON file based on search parameters
-// @Summary Download questions as JSON file
-// @Description Downloads questions in JSON format based on category and search text filters
+// @Summary Download questions as JSON or LMS file
+// @Description Downloads questions in JSON format based on category and search text filters. With the format query the questions are exported for learning management systems instead: Moodle XML, GIFT, Aiken or an IMS QTI 2.1 content package (zip). Option scores become fractional credit, the percentage of the highest score of the category scheme earned by the option (penalties of negative marking categories become negative credit). Aiken keeps only the best option as its answer.
 // @Tags questions
 // @Accept json
 // @Produce application/json
-// @Param category query string false "Category filter (TEKNIS, MANAJERIAL, SOSIAL KULTURAL, WAWANCARA)"
+// @Produce application/xml
+// @Produce text/plain
+// @Produce application/zip
+// @Param category query string false "Category code filter (see /categories)"
 // @Param search query string false "Search by question text"
+// @Param tags query string false "Comma separated tag names, questions must carry all of them"
+// @Param format query string false "Export format, JSON by default" Enums(json, moodle, gift, aiken, qti)
 // @Success 200 {array} dto.ExportQuestionResponse
+// @Failure 400 {object} dto.APIResponse "Unsupported format"
 // @Router /questions [get]
 func (h *ginQuestionHandler) DownloadQuestionsJSON(c *gin.Context) {
 	category := c.Query("category")
 	searchText := c.Query("search")
+	tags := parseTagsQuery(c.Query("tags"))
+
+	format := strings.ToLower(c.Query("format"))
+	if format != "" && format != "json" {
+		h.downloadQuestionsLMS(c, format, category, searchText, tags)
+		return
+	}
 
 	// Get questions using repository
-	questions, err := h.questionRepo.GetAllQuestionsWithFilters(category, searchText)
+	questions, err := h.questionRepo.GetAllQuestionsWithFilters(c.Request.Context(), category, searchText, tags)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -274,3 +857,262 @@ func (h *ginQuestionHandler) DownloadQuestionsJSON(c *gin.Context) {
 	// Return JSON without download headers (let frontend handle download)
 	c.JSON(http.StatusOK, exportQuestions)
 }
+
+// downloadQuestionsLMS writes the filtered questions as an LMS exchange file attachment
+func (h *ginQuestionHandler) downloadQuestionsLMS(c *gin.Context, format, category, searchText string, tags []string) {
+	if !lms.IsFormat(format) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Unsupported format, use json, moodle, gift, aiken or qti",
+			Error:   fmt.Sprintf("%v: %s", lms.ErrUnsupportedFormat, format),
+		})
+		return
+	}
+
+	questions, err := h.questionRepo.ExportLMSQuestions(c.Request.Context(), category, searchText, tags)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch questions",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// Encode before writing so a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := lms.Encode(&buf, format, questions); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to export questions",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	contentType, extension := lms.FileInfo(format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"questions-%s%s\"", format, extension))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// GetTags returns all question tags
+// @Summary Get question tags
+// @Description Returns list of all tags (sub-topics) that can be attached to questions
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.TagResponse}
+// @Router /questions/tags [get]
+func (h *ginQuestionHandler) GetTags(c *gin.Context) {
+	tags, err := h.questionRepo.GetTags(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Tags retrieved successfully",
+		Data:    dto.ToTagResponses(tags),
+	})
+}
+
+// CreateTag creates a new question tag
+// @Summary Create question tag
+// @Description Creates a new tag (sub-topic) that can be attached to questions
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateTagRequest true "Tag to create"
+// @Success 201 {object} dto.APIResponse{data=dto.TagResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Router /questions/tags [post]
+func (h *ginQuestionHandler) CreateTag(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tag := models.Tag{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := h.questionRepo.CreateTag(c.Request.Context(), &tag); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to create tag",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Tag created successfully",
+		Data:    dto.ToTagResponses([]models.Tag{tag})[0],
+	})
+}
+
+// SetQuestionTags replaces the tags of a question
+// @Summary Set question tags
+// @Description Replaces all tags of a question, unknown tags are created automatically
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param questionID path int true "Question ID"
+// @Param body body dto.SetQuestionTagsRequest true "Tag names"
+// @Success 200 {object} dto.APIResponse{data=dto.QuestionManagementResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request"
+// @Failure 404 {object} dto.APIResponse "Question not found"
+// @Router /questions/{questionID}/tags [put]
+func (h *ginQuestionHandler) SetQuestionTags(c *gin.Context) {
+	questionID, err := strconv.ParseUint(c.Param("questionID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid question ID",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var req dto.SetQuestionTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	question, err := h.questionRepo.SetQuestionTags(c.Request.Context(), uint(questionID), req.Tags)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Question not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to update question tags",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Question tags updated successfully",
+		Data:    dto.ToQuestionManagementResponse(question),
+	})
+}
+
+// GetTagQuotas returns all tag quotas used during exam generation
+// @Summary Get tag quotas
+// @Description Returns the number of questions reserved per tag inside each category when exams are generated
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.TagQuotaResponse}
+// @Router /questions/tag-quotas [get]
+func (h *ginQuestionHandler) GetTagQuotas(c *gin.Context) {
+	quotas, err := h.questionRepo.GetTagQuotas(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch tag quotas",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Tag quotas retrieved successfully",
+		Data:    dto.ToTagQuotaResponses(quotas),
+	})
+}
+
+// SetTagQuota creates, updates or removes a tag quota
+// @Summary Set tag quota
+// @Description Reserves a number of questions with the given tag inside a category during exam generation, a count of 0 removes the quota
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param body body dto.SetTagQuotaRequest true "Tag quota"
+// @Success 200 {object} dto.APIResponse{data=dto.TagQuotaResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 400 {object} dto.APIResponse "Unknown category"
+// @Failure 404 {object} dto.APIResponse "Tag not found"
+// @Router /questions/tag-quotas [put]
+func (h *ginQuestionHandler) SetTagQuota(c *gin.Context) {
+	var req dto.SetTagQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	quota, err := h.questionRepo.SetTagQuota(c.Request.Context(), req.Category, req.Tag, *req.QuestionCount)
+	if err != nil {
+		if errors.Is(err, question_service.ErrUnknownCategory) {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Message: "Unknown category",
+				Error:   err.Error(),
+			})
+			return
+		}
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Tag not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to update tag quota",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if quota == nil {
+		c.JSON(http.StatusOK, dto.APIResponse{
+			Success: true,
+			Message: "Tag quota removed successfully",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Tag quota updated successfully",
+		Data:    dto.ToTagQuotaResponse(quota),
+	})
+}
+
+// parseTagsQuery splits a comma separated tags query parameter
+func parseTagsQuery(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scorereport"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// reportVoidedReason tells that a report was issued for a session voided afterwards
+const reportVoidedReason = "voided"
+
+// ScoreReportConfig holds the settings of score reports
+type ScoreReportConfig struct {
+	SigningKey string // secret of the verification code HMAC
+	BaseURL    string // public URL of the server, printed with the verification link
+}
+
+type ginScoreReportHandler struct {
+	examService *exam_service.ExamService
+	signer      *scorereport.Signer
+	baseURL     string
+}
+
+func NewGinScoreReportHandler(db *gorm.DB, config ScoreReportConfig) *ginScoreReportHandler {
+	return &ginScoreReportHandler{
+		examService: exam_service.NewExamService(db),
+		signer:      scorereport.NewSigner(config.SigningKey),
+		baseURL:     strings.TrimRight(config.BaseURL, "/"),
+	}
+}
+
+// RegisterRoutes registers the score report and verification routes
+func (h *ginScoreReportHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	v1.GET("/exam/:userID/results.pdf", h.GetScoreReportPDF)
+	v1.GET("/verify/:code", h.VerifyScoreReport)
+
+	// Short public link printed on the reports
+	router.GET("/verify/:code", h.VerifyScoreReport)
+}
+
+// GetScoreReportPDF renders the score report of the latest completed exam of a user
+// @Summary Download the score report
+// @Description Renders the latest completed exam of a user as a one page PDF: summary, results per category, pass/fail verdict and a verification code. The code is an HMAC over the recorded result and can be checked at /verify/{code}; it stops verifying once the result is re-scored or re-graded. No report is issued when the latest session of the user has been voided.
+// @Tags exam
+// @Produce application/pdf
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {file} file "PDF document"
+// @Failure 404 {object} dto.APIResponse "Exam results not found"
+// @Failure 409 {object} dto.APIResponse "Latest exam session has been voided"
+// @Failure 500 {object} dto.APIResponse "Failed to render score report"
+// @Router /exam/{userID}/results.pdf [get]
+func (h *ginScoreReportHandler) GetScoreReportPDF(c *gin.Context) {
+	userID := c.Param("userID")
+
+	summary, results, err := h.examService.GetExamResults(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Exam results not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// A voided attempt must not be certified, its verification code would not verify either
+	if summary.ExamSession.Status == models.SessionVoided {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Latest exam session has been voided",
+			Error:   fmt.Sprintf("exam session %s of user %s is voided", summary.ExamSession.SessionCode, userID),
+		})
+		return
+	}
+
+	code := h.signer.Code(summary)
+	report := &scorereport.Report{
+		Summary:          *summary,
+		Results:          results,
+		SessionCode:      summary.ExamSession.SessionCode,
+		VerificationCode: code,
+		GeneratedAt:      time.Now(),
+	}
+	if summary.ExamSession.Blueprint != nil {
+		report.Blueprint = summary.ExamSession.Blueprint.Name
+	}
+	if h.baseURL != "" {
+		report.VerifyURL = h.baseURL + "/verify/" + code
+	}
+
+	var buf bytes.Buffer
+	if err := scorereport.Render(&buf, report); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to render score report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"score-report-%s.pdf\"", summary.ExamSession.SessionCode))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// VerifyScoreReport checks the verification code printed on a score report
+// @Summary Verify a score report
+// @Description Public check of a score report verification code. A valid code returns the result as currently recorded; codes of results re-scored or re-graded after the report was issued no longer verify. Reports of sessions voided afterwards return valid false with reason voided.
+// @Tags exam
+// @Produce json
+// @Param code path string true "Verification code" example(R42-ABCD-EFGH-IJKL-MNOP)
+// @Success 200 {object} dto.APIResponse{data=dto.ReportVerificationResponse} "Report is authentic"
+// @Failure 400 {object} dto.APIResponse "Malformed code"
+// @Failure 404 {object} dto.APIResponse{data=dto.ReportVerificationResponse} "Report could not be verified"
+// @Router /verify/{code} [get]
+func (h *ginScoreReportHandler) VerifyScoreReport(c *gin.Context) {
+	code := strings.ToUpper(strings.TrimSpace(c.Param("code")))
+
+	summaryID, err := scorereport.SummaryID(code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Malformed verification code",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	notVerified := dto.APIResponse{
+		Success: false,
+		Message: "Report could not be verified",
+		Data:    dto.ReportVerificationResponse{Valid: false, Code: code},
+	}
+
+	summary, err := h.examService.GetExamSummary(c.Request.Context(), summaryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, notVerified)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to verify report",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if !h.signer.Verify(summary, code) {
+		c.JSON(http.StatusNotFound, notVerified)
+		return
+	}
+
+	if summary.ExamSession.Status == models.SessionVoided {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Report was issued for an exam session that has been voided",
+			Data: dto.ReportVerificationResponse{
+				Valid:       false,
+				Reason:      reportVoidedReason,
+				Code:        code,
+				UserID:      summary.UserID,
+				SessionCode: summary.ExamSession.SessionCode,
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Report is authentic",
+		Data: dto.ReportVerificationResponse{
+			Valid:             true,
+			Code:              code,
+			UserID:            summary.UserID,
+			SessionCode:       summary.ExamSession.SessionCode,
+			TotalScore:        summary.TotalScore,
+			MaxScore:          summary.MaxScore,
+			OverallPercentage: summary.OverallPercentage,
+			OverallGrade:      summary.OverallGrade,
+			IsPassed:          summary.IsPassed,
+			CompletedAt:       summary.CompletedAt,
+		},
+	})
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/exam_service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginSessionHandler struct {
+	examService *exam_service.ExamService
+}
+
+func NewGinSessionHandler(db *gorm.DB) *ginSessionHandler {
+	return &ginSessionHandler{
+		examService: exam_service.NewExamService(db),
+	}
+}
+
+// RegisterRoutes registers the admin exam session override routes
+func (h *ginSessionHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	sessionGroup := v1.Group("/sessions/:sessionID")
+	{
+		sessionGroup.POST("/extend", h.ExtendSession)
+		sessionGroup.POST("/force-complete", h.ForceCompleteSession)
+		sessionGroup.POST("/reset", h.ResetSession)
+		sessionGroup.POST("/void", h.VoidSession)
+		sessionGroup.POST("/reopen", h.ReopenSession)
+		sessionGroup.GET("/transitions", h.GetSessionTransitions)
+	}
+}
+
+// ExtendSession gives an unfinished exam session more time
+// @Summary Extend exam session
+// @Description Moves the deadline of a NOT_STARTED or IN_PROGRESS session by the given minutes, e.g. for an accessibility accommodation. Recorded in the audit log with the reason.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Param body body dto.SessionMinutesRequest true "Minutes to add and reason"
+// @Success 200 {object} dto.APIResponse{data=dto.SessionOverrideResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Session status does not allow the override"
+// @Router /sessions/{sessionID}/extend [post]
+func (h *ginSessionHandler) ExtendSession(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SessionMinutesRequest
+	if !bindSessionOverride(c, &req) {
+		return
+	}
+
+	override, err := h.examService.ExtendSession(c.Request.Context(), sessionID, req.Minutes, req.Reason)
+	respondSessionOverride(c, override, err, "Exam session extended")
+}
+
+// ForceCompleteSession completes and scores an exam session
+// @Summary Force-complete exam session
+// @Description Completes and scores a NOT_STARTED, IN_PROGRESS or EXPIRED session with the answers it has. Recorded in the audit log with the reason.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Param body body dto.SessionOverrideRequest true "Reason"
+// @Success 200 {object} dto.APIResponse{data=dto.SessionOverrideResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Session status does not allow the override"
+// @Router /sessions/{sessionID}/force-complete [post]
+func (h *ginSessionHandler) ForceCompleteSession(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SessionOverrideRequest
+	if !bindSessionOverride(c, &req) {
+		return
+	}
+
+	override, err := h.examService.ForceCompleteSession(c.Request.Context(), sessionID, req.Reason)
+	respondSessionOverride(c, override, err, "Exam session force-completed")
+}
+
+// ResetSession puts an exam session back to NOT_STARTED
+// @Summary Reset exam session
+// @Description Discards the answers and results of a session and puts it back to NOT_STARTED with its full duration, or the sitting deadline for a sitting session. The drawn questions are kept. Voided sessions cannot be reset. Recorded in the audit log with the reason.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Param body body dto.SessionOverrideRequest true "Reason"
+// @Success 200 {object} dto.APIResponse{data=dto.SessionOverrideResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Session status does not allow the override"
+// @Router /sessions/{sessionID}/reset [post]
+func (h *ginSessionHandler) ResetSession(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SessionOverrideRequest
+	if !bindSessionOverride(c, &req) {
+		return
+	}
+
+	override, err := h.examService.ResetSession(c.Request.Context(), sessionID, req.Reason)
+	respondSessionOverride(c, override, err, "Exam session reset")
+}
+
+// VoidSession excludes an exam attempt from leaderboards and statistics
+// @Summary Void exam session
+// @Description Marks a session VOIDED so it is excluded from leaderboards and statistics. Answers and results are kept. The user can start a new session. Recorded in the audit log with the reason.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Param body body dto.SessionOverrideRequest true "Reason"
+// @Success 200 {object} dto.APIResponse{data=dto.SessionOverrideResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Session is already voided"
+// @Router /sessions/{sessionID}/void [post]
+func (h *ginSessionHandler) VoidSession(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SessionOverrideRequest
+	if !bindSessionOverride(c, &req) {
+		return
+	}
+
+	override, err := h.examService.VoidSession(c.Request.Context(), sessionID, req.Reason)
+	respondSessionOverride(c, override, err, "Exam session voided")
+}
+
+// ReopenSession resumes an expired exam session
+// @Summary Reopen exam session
+// @Description Gives an EXPIRED session the given minutes from now and resumes it IN_PROGRESS, or NOT_STARTED when it was never started. Answers are kept. Recorded in the audit log with the reason.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Param body body dto.SessionMinutesRequest true "Minutes from now and reason"
+// @Success 200 {object} dto.APIResponse{data=dto.SessionOverrideResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Failure 409 {object} dto.APIResponse "Session is not expired"
+// @Router /sessions/{sessionID}/reopen [post]
+func (h *ginSessionHandler) ReopenSession(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SessionMinutesRequest
+	if !bindSessionOverride(c, &req) {
+		return
+	}
+
+	override, err := h.examService.ReopenSession(c.Request.Context(), sessionID, req.Minutes, req.Reason)
+	respondSessionOverride(c, override, err, "Exam session reopened")
+}
+
+// bindSessionOverride binds an override request body, writing a 400 response when invalid
+func bindSessionOverride(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body, a reason is required",
+			Error:   err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// GetSessionTransitions returns the status history of an exam session
+// @Summary Get exam session transitions
+// @Description Returns every status change of a session, oldest first, with what triggered it: START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an admin override.
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Param sessionID path int true "Exam session ID"
+// @Success 200 {object} dto.APIResponse{data=[]dto.SessionTransitionResponse}
+// @Failure 404 {object} dto.APIResponse "Exam session not found"
+// @Router /sessions/{sessionID}/transitions [get]
+func (h *ginSessionHandler) GetSessionTransitions(c *gin.Context) {
+	sessionID, ok := parseUintParam(c, "sessionID", "Invalid exam session ID")
+	if !ok {
+		return
+	}
+
+	transitions, err := h.examService.GetSessionTransitions(c.Request.Context(), sessionID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "Failed to get exam session transitions"
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+			message = "Exam session not found"
+		}
+		c.JSON(status, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Exam session transitions retrieved successfully",
+		Data:    dto.ToSessionTransitionResponses(transitions),
+	})
+}
+
+// respondSessionOverride writes the outcome of a session override
+func respondSessionOverride(c *gin.Context, override *exam_service.SessionOverride, err error, message string) {
+	switch {
+	case err == nil:
+		session := override.Session
+		c.JSON(http.StatusOK, dto.APIResponse{
+			Success: true,
+			Message: message,
+			Data: dto.SessionOverrideResponse{
+				SessionID:      session.ID,
+				UserID:         session.UserID,
+				Action:         override.Action,
+				PreviousStatus: string(override.PreviousStatus),
+				Status:         string(session.Status),
+				StartedAt:      session.StartedAt,
+				CompletedAt:    session.CompletedAt,
+				ExpiresAt:      session.ExpiresAt,
+				Duration:       session.Duration,
+				Reason:         override.Reason,
+			},
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Exam session not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, exam_service.ErrOverrideReasonRequired):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "A reason is required",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, exam_service.ErrInvalidSessionOverride), errors.Is(err, exam_service.ErrIllegalTransition):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Exam session status does not allow this override",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to override exam session",
+			Error:   err.Error(),
+		})
+	}
+}
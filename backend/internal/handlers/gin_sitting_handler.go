@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/repositories/sitting_service"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginSittingHandler struct {
+	sittingRepo sitting_service.SittingService
+}
+
+func NewGinSittingHandler(db *gorm.DB) *ginSittingHandler {
+	return &ginSittingHandler{
+		sittingRepo: sitting_service.NewSittingService(db),
+	}
+}
+
+// RegisterRoutes registers scheduled sitting and booking routes
+func (h *ginSittingHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	sittingGroup := v1.Group("/sittings")
+	{
+		sittingGroup.GET("", h.GetSittings)
+		sittingGroup.POST("", h.CreateSitting)
+		sittingGroup.GET("/:sittingID", h.GetSitting)
+		sittingGroup.PUT("/:sittingID", h.UpdateSitting)
+		sittingGroup.DELETE("/:sittingID", h.DeleteSitting)
+		sittingGroup.POST("/:sittingID/candidates", h.BookCandidates)
+		sittingGroup.DELETE("/:sittingID/candidates/:userID", h.RemoveCandidate)
+	}
+}
+
+// GetSittings returns scheduled sittings
+// @Summary Get sittings
+// @Description Returns scheduled sittings by opening time with their booked candidates
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param upcoming query bool false "Only sittings that have not ended"
+// @Success 200 {object} dto.APIResponse{data=[]dto.SittingResponse}
+// @Router /sittings [get]
+func (h *ginSittingHandler) GetSittings(c *gin.Context) {
+	now := time.Now()
+
+	var from *time.Time
+	if c.Query("upcoming") == "true" {
+		from = &now
+	}
+
+	sittings, err := h.sittingRepo.GetSittings(c.Request.Context(), from)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch sittings",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Sittings retrieved successfully",
+		Data:    dto.ToSittingResponses(sittings, now),
+	})
+}
+
+// GetSitting returns a single sitting
+// @Summary Get sitting
+// @Description Returns a scheduled sitting with its booked candidates
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param sittingID path int true "Sitting ID"
+// @Success 200 {object} dto.APIResponse{data=dto.SittingResponse}
+// @Failure 404 {object} dto.APIResponse "Sitting not found"
+// @Router /sittings/{sittingID} [get]
+func (h *ginSittingHandler) GetSitting(c *gin.Context) {
+	sittingID, ok := parseUintParam(c, "sittingID", "Invalid sitting ID")
+	if !ok {
+		return
+	}
+
+	sitting, err := h.sittingRepo.GetSittingByID(c.Request.Context(), sittingID)
+	if err != nil {
+		respondSittingError(c, err, "Failed to fetch sitting")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Sitting retrieved successfully",
+		Data:    dto.ToSittingResponse(sitting, time.Now()),
+	})
+}
+
+// CreateSitting schedules a new sitting
+// @Summary Create sitting
+// @Description Schedules a sitting. Booked candidates can only start between opens_at and closes_at, and all their sessions expire at ends_at. The access code is only returned here and by the update, other sitting responses tell has_access_code.
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param body body dto.SittingRequest true "Sitting to schedule"
+// @Success 201 {object} dto.APIResponse{data=dto.SittingWithAccessCodeResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or window"
+// @Failure 404 {object} dto.APIResponse "Blueprint not found"
+// @Router /sittings [post]
+func (h *ginSittingHandler) CreateSitting(c *gin.Context) {
+	var req dto.SittingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	sitting := models.Sitting{}
+	applySittingRequest(&sitting, &req)
+
+	if err := h.sittingRepo.CreateSitting(c.Request.Context(), &sitting); err != nil {
+		respondSittingError(c, err, "Failed to create sitting")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Sitting created successfully",
+		Data:    dto.ToSittingWithAccessCodeResponse(&sitting, time.Now()),
+	})
+}
+
+// UpdateSitting updates a sitting
+// @Summary Update sitting
+// @Description Updates a sitting. Unfinished sessions of the sitting move to the new ends_at. The capacity cannot drop below the booked candidates.
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param sittingID path int true "Sitting ID"
+// @Param body body dto.SittingRequest true "Sitting fields"
+// @Success 200 {object} dto.APIResponse{data=dto.SittingWithAccessCodeResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body or window"
+// @Failure 404 {object} dto.APIResponse "Sitting or blueprint not found"
+// @Failure 409 {object} dto.APIResponse "More candidates booked than the new capacity"
+// @Router /sittings/{sittingID} [put]
+func (h *ginSittingHandler) UpdateSitting(c *gin.Context) {
+	sittingID, ok := parseUintParam(c, "sittingID", "Invalid sitting ID")
+	if !ok {
+		return
+	}
+
+	var req dto.SittingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	sitting, err := h.sittingRepo.GetSittingByID(c.Request.Context(), sittingID)
+	if err != nil {
+		respondSittingError(c, err, "Failed to fetch sitting")
+		return
+	}
+
+	applySittingRequest(sitting, &req)
+
+	if err := h.sittingRepo.UpdateSitting(c.Request.Context(), sitting); err != nil {
+		respondSittingError(c, err, "Failed to update sitting")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Sitting updated successfully",
+		Data:    dto.ToSittingWithAccessCodeResponse(sitting, time.Now()),
+	})
+}
+
+// DeleteSitting deletes a sitting
+// @Summary Delete sitting
+// @Description Deletes a sitting and its bookings. Sessions already created keep their deadline and can no longer be restricted by the sitting.
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param sittingID path int true "Sitting ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Sitting not found"
+// @Router /sittings/{sittingID} [delete]
+func (h *ginSittingHandler) DeleteSitting(c *gin.Context) {
+	sittingID, ok := parseUintParam(c, "sittingID", "Invalid sitting ID")
+	if !ok {
+		return
+	}
+
+	if err := h.sittingRepo.DeleteSitting(c.Request.Context(), sittingID); err != nil {
+		respondSittingError(c, err, "Failed to delete sitting")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Sitting deleted successfully",
+	})
+}
+
+// BookCandidates books users into a sitting
+// @Summary Book sitting candidates
+// @Description Books users into a sitting. Users already booked are skipped. Nothing is booked when the new candidates do not fit the remaining seats. Exam sessions the candidates create afterwards belong to the sitting.
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param sittingID path int true "Sitting ID"
+// @Param body body dto.BookSittingRequest true "Users to book"
+// @Success 200 {object} dto.APIResponse{data=dto.SittingResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body"
+// @Failure 404 {object} dto.APIResponse "Sitting not found"
+// @Failure 409 {object} dto.APIResponse "Sitting is full"
+// @Router /sittings/{sittingID}/candidates [post]
+func (h *ginSittingHandler) BookCandidates(c *gin.Context) {
+	sittingID, ok := parseUintParam(c, "sittingID", "Invalid sitting ID")
+	if !ok {
+		return
+	}
+
+	var req dto.BookSittingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	sitting, err := h.sittingRepo.BookCandidates(c.Request.Context(), sittingID, req.UserIDs)
+	if err != nil {
+		respondSittingError(c, err, "Failed to book candidates")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Candidates booked successfully",
+		Data:    dto.ToSittingResponse(sitting, time.Now()),
+	})
+}
+
+// RemoveCandidate cancels the booking of a user
+// @Summary Remove sitting candidate
+// @Description Cancels the booking of a user, freeing their seat. An exam session already created keeps the sitting.
+// @Tags sittings
+// @Accept json
+// @Produce json
+// @Param sittingID path int true "Sitting ID"
+// @Param userID path string true "User ID" example("1234")
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "User is not booked into the sitting"
+// @Router /sittings/{sittingID}/candidates/{userID} [delete]
+func (h *ginSittingHandler) RemoveCandidate(c *gin.Context) {
+	sittingID, ok := parseUintParam(c, "sittingID", "Invalid sitting ID")
+	if !ok {
+		return
+	}
+
+	if err := h.sittingRepo.RemoveCandidate(c.Request.Context(), sittingID, c.Param("userID")); err != nil {
+		respondSittingError(c, err, "Failed to remove candidate")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Candidate removed successfully",
+	})
+}
+
+// applySittingRequest copies request fields onto a sitting model
+func applySittingRequest(sitting *models.Sitting, req *dto.SittingRequest) {
+	sitting.Code = req.Code
+	sitting.Name = req.Name
+	sitting.Location = req.Location
+	sitting.BlueprintID = req.BlueprintID
+	sitting.OpensAt = req.OpensAt
+	sitting.ClosesAt = req.ClosesAt
+	sitting.EndsAt = req.EndsAt
+	sitting.Capacity = req.Capacity
+	sitting.AccessCode = req.AccessCode
+}
+
+// respondSittingError maps sitting service errors to HTTP responses
+func respondSittingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, sitting_service.ErrInvalidSittingWindow):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	case errors.Is(err, sitting_service.ErrSittingFull):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Sitting is full",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/repositories/webhook_service"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ginWebhookHandler struct {
+	webhookRepo webhook_service.WebhookService
+}
+
+func NewGinWebhookHandler(db *gorm.DB) *ginWebhookHandler {
+	return &ginWebhookHandler{
+		webhookRepo: webhook_service.NewWebhookService(db),
+	}
+}
+
+// RegisterRoutes registers webhook configuration and delivery log routes
+func (h *ginWebhookHandler) RegisterRoutes(router *gin.Engine) {
+	// Use the existing /api/v1 group from gin adapter
+	v1 := router.Group("/api/v1")
+	webhookGroup := v1.Group("/webhooks")
+	{
+		webhookGroup.GET("", h.GetWebhooks)
+		webhookGroup.POST("", h.CreateWebhook)
+		webhookGroup.GET("/:webhookID", h.GetWebhook)
+		webhookGroup.PUT("/:webhookID", h.UpdateWebhook)
+		webhookGroup.DELETE("/:webhookID", h.DeleteWebhook)
+		webhookGroup.GET("/:webhookID/deliveries", h.GetDeliveries)
+		webhookGroup.POST("/:webhookID/deliveries/:deliveryID/retry", h.RetryDelivery)
+	}
+}
+
+// GetWebhooks returns all webhooks
+// @Summary Get webhooks
+// @Description Returns every configured webhook. Secrets are never returned.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.WebhookResponse}
+// @Router /webhooks [get]
+func (h *ginWebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookRepo.GetWebhooks(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch webhooks",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhooks retrieved successfully",
+		Data:    dto.ToWebhookResponses(webhooks),
+	})
+}
+
+// GetWebhook returns a webhook by ID
+// @Summary Get webhook
+// @Description Returns a webhook by ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Router /webhooks/{webhookID} [get]
+func (h *ginWebhookHandler) GetWebhook(c *gin.Context) {
+	webhookID, ok := parseUintParam(c, "webhookID", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookRepo.GetWebhookByID(c.Request.Context(), webhookID)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhook retrieved successfully",
+		Data:    dto.ToWebhookResponse(webhook),
+	})
+}
+
+// CreateWebhook creates a webhook
+// @Summary Create webhook
+// @Description Creates a webhook notified of the subscribed exam events: session.created, session.started, answer.submitted, session.completed and session.expired. Each event is POSTed as JSON {id, event, occurred_at, data} with the headers X-Webhook-Event, X-Webhook-Event-ID, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature. The signature is "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. Any 2xx response acknowledges the event; otherwise it is retried with exponential backoff, up to 8 attempts. Events are delivered at least once, so receivers should ignore repeated event IDs.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.WebhookRequest true "Webhook"
+// @Success 201 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body, URL or event"
+// @Router /webhooks [post]
+func (h *ginWebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if req.Secret == "" {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   "secret is required",
+		})
+		return
+	}
+
+	webhook := models.Webhook{
+		Name:   req.Name,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: strings.Join(req.Events, ","),
+		Active: req.Active == nil || *req.Active,
+	}
+
+	if err := h.webhookRepo.CreateWebhook(c.Request.Context(), &webhook); err != nil {
+		respondWebhookError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Message: "Webhook created successfully",
+		Data:    dto.ToWebhookResponse(&webhook),
+	})
+}
+
+// UpdateWebhook updates a webhook
+// @Summary Update webhook
+// @Description Updates a webhook. An empty secret keeps the current one. Deliveries already queued are sent with the new URL and secret; deactivating a webhook fails its pending deliveries.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param body body dto.WebhookRequest true "Webhook"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid request body, URL or event"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Router /webhooks/{webhookID} [put]
+func (h *ginWebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, ok := parseUintParam(c, "webhookID", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	webhook, err := h.webhookRepo.GetWebhookByID(c.Request.Context(), webhookID)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook")
+		return
+	}
+
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	webhook.Events = strings.Join(req.Events, ",")
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := h.webhookRepo.UpdateWebhook(c.Request.Context(), webhook); err != nil {
+		respondWebhookError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhook updated successfully",
+		Data:    dto.ToWebhookResponse(webhook),
+	})
+}
+
+// DeleteWebhook deletes a webhook
+// @Summary Delete webhook
+// @Description Deletes a webhook. Its pending deliveries fail instead of being sent; the delivery log is kept.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Router /webhooks/{webhookID} [delete]
+func (h *ginWebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, ok := parseUintParam(c, "webhookID", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookRepo.DeleteWebhook(c.Request.Context(), webhookID); err != nil {
+		respondWebhookError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// GetDeliveries returns the delivery log of a webhook
+// @Summary Get webhook deliveries
+// @Description Returns the events queued for a webhook, newest first, with the status of each delivery and the response or error of every attempt. Response bodies are truncated to 1 KiB.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param status query string false "Filter by delivery status" Enums(PENDING, DELIVERED, FAILED)
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param limit query int false "Items per page (default: 50, use 0 for all)" minimum(0)
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedWebhookDeliveryResponse}
+// @Failure 400 {object} dto.APIResponse "Invalid webhook ID or status"
+// @Failure 404 {object} dto.APIResponse "Webhook not found"
+// @Router /webhooks/{webhookID}/deliveries [get]
+func (h *ginWebhookHandler) GetDeliveries(c *gin.Context) {
+	webhookID, ok := parseUintParam(c, "webhookID", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	status := strings.ToUpper(c.Query("status"))
+	switch status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryFailed:
+	default:
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid status, use PENDING, DELIVERED or FAILED",
+		})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+
+	if _, err := h.webhookRepo.GetWebhookByID(c.Request.Context(), webhookID); err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook")
+		return
+	}
+
+	totalCount, err := h.webhookRepo.CountDeliveries(c.Request.Context(), webhookID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to count webhook deliveries",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deliveries, err := h.webhookRepo.GetDeliveries(c.Request.Context(), webhookID, status, (page-1)*limit, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: "Failed to fetch webhook deliveries",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	totalPages := 1
+	if limit > 0 {
+		totalPages = int(math.Ceil(float64(totalCount) / float64(limit)))
+	} else {
+		page = 1 // Reset page to 1 when showing all
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhook deliveries retrieved successfully",
+		Data: dto.PaginatedWebhookDeliveryResponse{
+			Deliveries: dto.ToWebhookDeliveryResponses(deliveries),
+			Pagination: dto.PaginationMetadata{
+				CurrentPage:  page,
+				ItemsPerPage: limit,
+				TotalItems:   int(totalCount),
+				TotalPages:   totalPages,
+			},
+		},
+	})
+}
+
+// RetryDelivery queues a failed delivery again
+// @Summary Retry webhook delivery
+// @Description Queues a failed delivery for immediate sending with a fresh set of attempts. The payload, event ID and signature scheme are unchanged.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path int true "Webhook ID"
+// @Param deliveryID path int true "Delivery ID"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 404 {object} dto.APIResponse "Webhook delivery not found"
+// @Failure 409 {object} dto.APIResponse "Delivery has not failed"
+// @Router /webhooks/{webhookID}/deliveries/{deliveryID}/retry [post]
+func (h *ginWebhookHandler) RetryDelivery(c *gin.Context) {
+	webhookID, ok := parseUintParam(c, "webhookID", "Invalid webhook ID")
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := parseUintParam(c, "deliveryID", "Invalid delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookRepo.RetryDelivery(c.Request.Context(), webhookID, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Message: "Webhook delivery not found",
+			})
+			return
+		}
+		respondWebhookError(c, err, "Failed to retry webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "Webhook delivery queued for retry",
+		Data:    dto.ToWebhookDeliveryResponse(delivery),
+	})
+}
+
+// respondWebhookError maps webhook service errors to HTTP responses
+func respondWebhookError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Message: "Webhook not found",
+		})
+	case errors.Is(err, webhook_service.ErrInvalidWebhookURL), errors.Is(err, webhook_service.ErrUnknownWebhookEvent):
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Message: "Invalid webhook",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, webhook_service.ErrDeliveryNotFailed):
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Message: "Webhook delivery has not failed",
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+}
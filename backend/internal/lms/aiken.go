@@ -0,0 +1,97 @@
+package lms
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Aiken text format, see https://docs.moodle.org/en/Aiken_format.
+// Aiken knows a single correct answer and no categories or tags: the best option is
+// exported as the answer and imported with full credit, every other option with none.
+
+var (
+	aikenOption = regexp.MustCompile(`^([A-Z]{1,2})[.)]\s+(.*)$`)
+	aikenAnswer = regexp.MustCompile(`^ANSWER:\s*([A-Z]{1,2})$`)
+)
+
+// EncodeAiken writes questions in the Aiken format, question and options on single lines
+func EncodeAiken(w io.Writer, questions []Question) error {
+	bw := bufio.NewWriter(w)
+	for _, q := range questions {
+		bw.WriteString(singleLine(q.Text) + "\n")
+		for i, opt := range q.Options {
+			fmt.Fprintf(bw, "%s. %s\n", optionLetter(i), singleLine(opt.Text))
+		}
+		if len(q.Options) > 0 {
+			fmt.Fprintf(bw, "ANSWER: %s\n", optionLetter(bestOption(q.Options)))
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// DecodeAiken reads the questions of an Aiken file
+func DecodeAiken(r io.Reader) ([]Question, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var questions []Question
+	var current *Question
+	var letters []string
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" {
+			continue
+		}
+
+		if current == nil {
+			current = &Question{Text: text}
+			letters = nil
+			continue
+		}
+
+		if match := aikenAnswer.FindStringSubmatch(text); match != nil {
+			found := false
+			for i, letter := range letters {
+				if letter == match[1] {
+					current.Options[i].Credit = 100
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("line %d: answer %s does not match any option", line, match[1])
+			}
+			questions = append(questions, *current)
+			current = nil
+			continue
+		}
+
+		if match := aikenOption.FindStringSubmatch(text); match != nil {
+			letters = append(letters, match[1])
+			current.Options = append(current.Options, Option{Text: strings.TrimSpace(match[2])})
+			continue
+		}
+
+		if len(current.Options) > 0 {
+			return nil, fmt.Errorf("line %d: expected an option or ANSWER line", line)
+		}
+		current.Text += " " + text
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Aiken file: %w", err)
+	}
+	if current != nil {
+		return nil, fmt.Errorf("question %d: missing ANSWER line", len(questions)+1)
+	}
+
+	return questions, nil
+}
+
+// singleLine collapses line breaks, Aiken questions and options must fit on one line
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
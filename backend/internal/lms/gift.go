@@ -0,0 +1,208 @@
+package lms
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GIFT text format, see https://docs.moodle.org/en/GIFT_format
+
+var (
+	giftEscaper   = strings.NewReplacer(`\`, `\\`, `~`, `\~`, `=`, `\=`, `#`, `\#`, `{`, `\{`, `}`, `\}`, `:`, `\:`)
+	giftUnescaper = strings.NewReplacer(`\\`, `\`, `\~`, `~`, `\=`, `=`, `\#`, `#`, `\{`, `{`, `\}`, `}`, `\:`, `:`, `\n`, "\n")
+	giftTag       = regexp.MustCompile(`\[tag:([^\]]+)\]`)
+	giftMarkup    = regexp.MustCompile(`^\[(html|moodle|plain|markdown)\]`)
+	giftWeight    = regexp.MustCompile(`^%(-?[0-9]+(?:\.[0-9]+)?)%`)
+)
+
+// EncodeGIFT writes questions as GIFT multiple choice questions with weighted answers.
+// Tags are written as [tag:name] comments, which Moodle reads since version 3.6.
+func EncodeGIFT(w io.Writer, questions []Question) error {
+	bw := bufio.NewWriter(w)
+	category := ""
+
+	for i, q := range questions {
+		if i == 0 || q.Category != category {
+			category = q.Category
+			fmt.Fprintf(bw, "$CATEGORY: $course$/top/%s\n\n", category)
+		}
+
+		if len(q.Tags) > 0 {
+			bw.WriteString("//")
+			for _, tag := range q.Tags {
+				fmt.Fprintf(bw, " [tag:%s]", tag)
+			}
+			bw.WriteString("\n")
+		}
+
+		fmt.Fprintf(bw, "::%s::%s {\n", giftEscape(fmt.Sprintf("%s %d", category, i+1)), giftEscape(q.Text))
+		for _, opt := range q.Options {
+			fmt.Fprintf(bw, "\t~%%%s%%%s\n", formatCredit(opt.Credit), giftEscape(opt.Text))
+		}
+		bw.WriteString("}\n\n")
+	}
+
+	return bw.Flush()
+}
+
+// DecodeGIFT reads the multiple choice questions of a GIFT file. Questions are separated
+// by blank lines, "=" marks a fully correct answer and "~%n%" an answer worth n percent.
+func DecodeGIFT(r io.Reader) ([]Question, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var questions []Question
+	var block []string
+	var tags []string
+	category := ""
+
+	flush := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		q, err := parseGIFTQuestion(strings.Join(block, "\n"))
+		if err != nil {
+			return fmt.Errorf("question %d: %w", len(questions)+1, err)
+		}
+		q.Category = category
+		q.Tags = tags
+		questions = append(questions, q)
+		block, tags = nil, nil
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "//"):
+			for _, match := range giftTag.FindAllStringSubmatch(line, -1) {
+				tags = append(tags, strings.TrimSpace(match[1]))
+			}
+		case strings.HasPrefix(line, "$CATEGORY:"):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			value := strings.TrimSpace(strings.TrimPrefix(line, "$CATEGORY:"))
+			category = path.Base(strings.TrimRight(value, "/"))
+		default:
+			block = append(block, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read GIFT file: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+// parseGIFTQuestion parses one question block: an optional ::title::, the question text
+// and the {answers}
+func parseGIFTQuestion(block string) (Question, error) {
+	if strings.HasPrefix(block, "::") {
+		if end := indexUnescaped(block[2:], "::"); end >= 0 {
+			block = block[end+4:]
+		}
+	}
+
+	open := indexUnescaped(block, "{")
+	if open < 0 {
+		return Question{}, fmt.Errorf("missing answer block")
+	}
+	closing := indexUnescaped(block[open:], "}")
+	if closing < 0 {
+		return Question{}, fmt.Errorf("unterminated answer block")
+	}
+	closing += open
+
+	text := strings.TrimSpace(block[:open] + " " + block[closing+1:])
+	text = giftMarkup.ReplaceAllString(text, "")
+	q := Question{Text: giftUnescape(text)}
+
+	answers := splitUnescaped(block[open+1:closing], "~=")
+	if len(answers) == 0 {
+		return Question{}, fmt.Errorf("only multiple choice questions can be imported")
+	}
+
+	for _, answer := range answers {
+		body := answer[1:]
+		if feedback := indexUnescaped(body, "#"); feedback >= 0 {
+			body = body[:feedback]
+		}
+		body = strings.TrimSpace(body)
+
+		credit := 0.0
+		if answer[0] == '=' {
+			credit = 100
+		}
+		if match := giftWeight.FindStringSubmatch(body); match != nil {
+			value, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return Question{}, fmt.Errorf("invalid answer weight %q", match[0])
+			}
+			credit = value
+			body = strings.TrimSpace(body[len(match[0]):])
+		}
+
+		q.Options = append(q.Options, Option{Text: giftUnescape(body), Credit: credit})
+	}
+
+	return q, nil
+}
+
+// indexUnescaped returns the index of the first occurrence of sep not preceded by a backslash
+func indexUnescaped(s, sep string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitUnescaped splits s before every unescaped marker byte, each part keeps its marker.
+// Text before the first marker is dropped.
+func splitUnescaped(s, markers string) []string {
+	var parts []string
+	start := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte(markers, s[i]) >= 0 {
+			if start >= 0 {
+				parts = append(parts, s[start:i])
+			}
+			start = i
+		}
+	}
+	if start >= 0 {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
+
+func giftEscape(s string) string {
+	return strings.ReplaceAll(giftEscaper.Replace(s), "\n", `\n`)
+}
+
+func giftUnescape(s string) string {
+	return strings.TrimSpace(giftUnescaper.Replace(s))
+}
@@ -0,0 +1,143 @@
+// Package lms converts the question bank to and from the exchange formats read by learning
+// management systems: Moodle XML, GIFT, Aiken and IMS QTI 2.1. Option scores travel as
+// fractional credit, the percentage of the full mark earned by selecting the option.
+package lms
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Supported exchange formats
+const (
+	FormatMoodleXML = "moodle"
+	FormatGIFT      = "gift"
+	FormatAiken     = "aiken"
+	FormatQTI       = "qti"
+)
+
+// ErrUnsupportedFormat is returned for formats this package cannot read or write
+var ErrUnsupportedFormat = errors.New("unsupported LMS format")
+
+// Question is a multiple choice question in its exchange form
+type Question struct {
+	Category string
+	Text     string
+	Tags     []string
+	Options  []Option
+}
+
+// Option is an answer option with the credit, in percent, earned by selecting it
+type Option struct {
+	Text   string
+	Credit float64
+}
+
+// IsFormat reports whether format is one of the supported exchange formats
+func IsFormat(format string) bool {
+	switch format {
+	case FormatMoodleXML, FormatGIFT, FormatAiken, FormatQTI:
+		return true
+	}
+	return false
+}
+
+// FormatFromFilename returns the format implied by a file extension:
+// .xml is Moodle XML, .gift GIFT, .txt Aiken and .zip a QTI 2.1 content package
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xml":
+		return FormatMoodleXML, nil
+	case ".gift":
+		return FormatGIFT, nil
+	case ".txt":
+		return FormatAiken, nil
+	case ".zip":
+		return FormatQTI, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(filename))
+}
+
+// FileInfo returns the content type and file extension used when downloading a format
+func FileInfo(format string) (contentType, extension string) {
+	switch format {
+	case FormatMoodleXML:
+		return "application/xml", ".xml"
+	case FormatGIFT:
+		return "text/plain; charset=utf-8", ".gift"
+	case FormatAiken:
+		return "text/plain; charset=utf-8", ".txt"
+	case FormatQTI:
+		return "application/zip", ".zip"
+	}
+	return "application/octet-stream", ""
+}
+
+// Encode writes questions in the given format
+func Encode(w io.Writer, format string, questions []Question) error {
+	switch format {
+	case FormatMoodleXML:
+		return EncodeMoodleXML(w, questions)
+	case FormatGIFT:
+		return EncodeGIFT(w, questions)
+	case FormatAiken:
+		return EncodeAiken(w, questions)
+	case FormatQTI:
+		return EncodeQTI(w, questions)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// Decode reads questions in the given format
+func Decode(r io.ReaderAt, size int64, format string) ([]Question, error) {
+	switch format {
+	case FormatMoodleXML:
+		return DecodeMoodleXML(io.NewSectionReader(r, 0, size))
+	case FormatGIFT:
+		return DecodeGIFT(io.NewSectionReader(r, 0, size))
+	case FormatAiken:
+		return DecodeAiken(io.NewSectionReader(r, 0, size))
+	case FormatQTI:
+		return DecodeQTI(r, size)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// bestOption returns the index of the option with the highest credit, the first one wins on ties
+func bestOption(options []Option) int {
+	best := 0
+	for i, option := range options {
+		if option.Credit > options[best].Credit {
+			best = i
+		}
+	}
+	return best
+}
+
+// optionLetter returns the letter labelling the option at index i (A, B, ... Z, AA, AB, ...)
+func optionLetter(i int) string {
+	letter := string(rune('A' + i%26))
+	if i >= 26 {
+		return optionLetter(i/26-1) + letter
+	}
+	return letter
+}
+
+// formatCredit formats a credit without trailing zeros
+func formatCredit(credit float64) string {
+	return strconv.FormatFloat(credit, 'f', -1, 64)
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// plainText turns HTML question text from an LMS into plain text
+func plainText(text string) string {
+	text = htmlTag.ReplaceAllString(text, "")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
@@ -0,0 +1,206 @@
+package lms
+
+import (
+	"bytes"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// sampleQuestions holds text every format has to escape and credits of every scheme
+func sampleQuestions() []Question {
+	return []Question{
+		{
+			Category: "TEKNIS",
+			Text:     "Which of {a, b} = the set? Use a:b ~ c # d \\ e & <f> \"g\"\nSecond line",
+			Tags:     []string{"sets", "logic & proofs"},
+			Options: []Option{
+				{Text: "{a} = {b}", Credit: 100},
+				{Text: "a ~ b # c", Credit: 0},
+				{Text: "<b>bold</b> & 'quoted'", Credit: 0},
+			},
+		},
+		{
+			Category: "TEKNIS",
+			Text:     "Plain question",
+			Options: []Option{
+				{Text: "Right", Credit: 100},
+				{Text: "Wrong", Credit: -25},
+			},
+		},
+		{
+			Category: "MANAJERIAL",
+			Text:     "Graded question",
+			Tags:     []string{"leadership"},
+			Options: []Option{
+				{Text: "Best", Credit: 100},
+				{Text: "Good", Credit: 75},
+				{Text: "Fair", Credit: 33.33333},
+				{Text: "Poor", Credit: 25},
+			},
+		},
+	}
+}
+
+// roundTrip encodes questions and decodes the result in the same format
+func roundTrip(t *testing.T, format string, questions []Question) []Question {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, format, questions); err != nil {
+		t.Fatalf("Encode(%s) error = %v", format, err)
+	}
+	decoded, err := Decode(bytes.NewReader(buf.Bytes()), int64(buf.Len()), format)
+	if err != nil {
+		t.Fatalf("Decode(%s) error = %v\n%s", format, err, buf.String())
+	}
+	return decoded
+}
+
+// assertQuestions compares questions, credits up to the precision kept by the formats
+func assertQuestions(t *testing.T, got, want []Question) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d questions, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Category != w.Category || g.Text != w.Text || !reflect.DeepEqual(g.Tags, w.Tags) {
+			t.Errorf("question %d = %q %q %q, want %q %q %q", i+1, g.Category, g.Text, g.Tags, w.Category, w.Text, w.Tags)
+		}
+		if len(g.Options) != len(w.Options) {
+			t.Errorf("question %d has %d options, want %d", i+1, len(g.Options), len(w.Options))
+			continue
+		}
+		for j := range w.Options {
+			if g.Options[j].Text != w.Options[j].Text || math.Abs(g.Options[j].Credit-w.Options[j].Credit) > 1e-9 {
+				t.Errorf("question %d option %d = %+v, want %+v", i+1, j+1, g.Options[j], w.Options[j])
+			}
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatMoodleXML, FormatGIFT, FormatQTI} {
+		t.Run(format, func(t *testing.T) {
+			assertQuestions(t, roundTrip(t, format, sampleQuestions()), sampleQuestions())
+		})
+	}
+}
+
+func TestAikenRoundTrip(t *testing.T) {
+	// Aiken keeps only single-line text and the best option, without categories or tags
+	var want []Question
+	for _, q := range sampleQuestions() {
+		q.Category, q.Tags = "", nil
+		q.Text = singleLine(q.Text)
+		options := make([]Option, len(q.Options))
+		for i, opt := range q.Options {
+			options[i] = Option{Text: opt.Text}
+		}
+		options[bestOption(q.Options)].Credit = 100
+		q.Options = options
+		want = append(want, q)
+	}
+
+	assertQuestions(t, roundTrip(t, FormatAiken, sampleQuestions()), want)
+}
+
+func TestCreditRoundTripKeepsOptionScores(t *testing.T) {
+	for _, scheme := range []string{models.ScoringSchemeGraded, models.ScoringSchemeRightWrong, models.ScoringSchemeNegativeMarking} {
+		scorer, err := scoring.ForScheme(scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var options []models.QuestionOption
+		switch scheme {
+		case models.ScoringSchemeGraded:
+			options = []models.QuestionOption{{Score: 3}, {Score: 1}, {Score: 4}, {Score: 2}}
+		default:
+			options = []models.QuestionOption{{Score: 0}, {Score: scorer.MaxOptionScore()}, {Score: 0}}
+		}
+
+		question := Question{Category: "TEKNIS", Text: "Question"}
+		for _, option := range options {
+			question.Options = append(question.Options, Option{Text: "Option", Credit: scoring.Credit(scorer, option, options)})
+		}
+
+		for _, format := range []string{FormatMoodleXML, FormatGIFT, FormatQTI} {
+			decoded := roundTrip(t, format, []Question{question})
+			for i, option := range decoded[0].Options {
+				if got := scoring.ScoreForCredit(scorer, option.Credit); got != options[i].Score {
+					t.Errorf("%s %s option %d: score after round trip = %d, want %d (credit %v)",
+						scheme, format, i+1, got, options[i].Score, option.Credit)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeGIFT(t *testing.T) {
+	// As exported by Moodle, with feedback, "=" answers and weights
+	const gift = "\ufeff// question: 0  name: Switch category to $course$/top/Default for PPPK/TEKNIS\n" +
+		"$CATEGORY: $course$/top/Default for PPPK/TEKNIS\n\n\n" +
+		"// question: 412  name: Ibu kota\n" +
+		"// [tag:geografi] [tag:indonesia]\n" +
+		"::Ibu kota::[moodle]Ibu kota Indonesia\\: kota mana?{\n" +
+		"\t=Jakarta#Benar\n" +
+		"\t~%-33.33333%Surabaya#Salah\n" +
+		"\t~%50%Nusantara \\= IKN\n" +
+		"}\n\n" +
+		"Soal tanpa judul {~%25%A ~%75%B}\n"
+
+	got, err := DecodeGIFT(bytes.NewReader([]byte(gift)))
+	if err != nil {
+		t.Fatalf("DecodeGIFT() error = %v", err)
+	}
+	assertQuestions(t, got, []Question{
+		{
+			Category: "TEKNIS",
+			Text:     "Ibu kota Indonesia: kota mana?",
+			Tags:     []string{"geografi", "indonesia"},
+			Options: []Option{
+				{Text: "Jakarta", Credit: 100},
+				{Text: "Surabaya", Credit: -33.33333},
+				{Text: "Nusantara = IKN", Credit: 50},
+			},
+		},
+		{
+			Category: "TEKNIS",
+			Text:     "Soal tanpa judul",
+			Options:  []Option{{Text: "A", Credit: 25}, {Text: "B", Credit: 75}},
+		},
+	})
+}
+
+func TestDecodeAikenRejectsUnknownAnswer(t *testing.T) {
+	const aiken = "Question?\nA) One\nB) Two\nANSWER: C\n"
+	if _, err := DecodeAiken(bytes.NewReader([]byte(aiken))); err == nil {
+		t.Fatal("DecodeAiken() error = nil, want an error for an answer without option")
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"quiz.xml", FormatMoodleXML},
+		{"quiz.GIFT", FormatGIFT},
+		{"quiz.txt", FormatAiken},
+		{"package.zip", FormatQTI},
+	}
+	for _, tt := range tests {
+		if got, err := FormatFromFilename(tt.filename); err != nil || got != tt.want {
+			t.Errorf("FormatFromFilename(%q) = %s, %v, want %s", tt.filename, got, err, tt.want)
+		}
+	}
+	if _, err := FormatFromFilename("quiz.csv"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("FormatFromFilename(quiz.csv) error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
@@ -0,0 +1,150 @@
+package lms
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Moodle XML documents, see https://docs.moodle.org/en/Moodle_XML_format
+type moodleQuiz struct {
+	XMLName   xml.Name         `xml:"quiz"`
+	Questions []moodleQuestion `xml:"question"`
+}
+
+type moodleQuestion struct {
+	Type           string         `xml:"type,attr"`
+	Category       *moodleText    `xml:"category,omitempty"`
+	Name           *moodleText    `xml:"name,omitempty"`
+	QuestionText   *moodleText    `xml:"questiontext,omitempty"`
+	DefaultGrade   string         `xml:"defaultgrade,omitempty"`
+	Single         string         `xml:"single,omitempty"`
+	ShuffleAnswers string         `xml:"shuffleanswers,omitempty"`
+	Numbering      string         `xml:"answernumbering,omitempty"`
+	Answers        []moodleAnswer `xml:"answer"`
+	Tags           *moodleTags    `xml:"tags,omitempty"`
+}
+
+type moodleText struct {
+	Format string `xml:"format,attr,omitempty"`
+	Text   string `xml:"text"`
+}
+
+type moodleAnswer struct {
+	Fraction string `xml:"fraction,attr"`
+	Format   string `xml:"format,attr,omitempty"`
+	Text     string `xml:"text"`
+}
+
+type moodleTags struct {
+	Tags []moodleText `xml:"tag"`
+}
+
+// EncodeMoodleXML writes questions as a Moodle XML quiz. A category question precedes
+// every change of category so Moodle files the questions in the same categories.
+func EncodeMoodleXML(w io.Writer, questions []Question) error {
+	var quiz moodleQuiz
+	category := ""
+
+	for i, q := range questions {
+		if i == 0 || q.Category != category {
+			category = q.Category
+			quiz.Questions = append(quiz.Questions, moodleQuestion{
+				Type:     "category",
+				Category: &moodleText{Text: "$course$/top/" + category},
+			})
+		}
+
+		mq := moodleQuestion{
+			Type:           "multichoice",
+			Name:           &moodleText{Text: fmt.Sprintf("%s %d", category, i+1)},
+			QuestionText:   &moodleText{Format: "plain_text", Text: q.Text},
+			DefaultGrade:   "1",
+			Single:         "true",
+			ShuffleAnswers: "false",
+			Numbering:      "ABCD",
+		}
+		for _, opt := range q.Options {
+			mq.Answers = append(mq.Answers, moodleAnswer{
+				Fraction: formatCredit(opt.Credit),
+				Format:   "plain_text",
+				Text:     opt.Text,
+			})
+		}
+		if len(q.Tags) > 0 {
+			mq.Tags = &moodleTags{}
+			for _, tag := range q.Tags {
+				mq.Tags.Tags = append(mq.Tags.Tags, moodleText{Text: tag})
+			}
+		}
+		quiz.Questions = append(quiz.Questions, mq)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(quiz); err != nil {
+		return fmt.Errorf("failed to encode Moodle XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// DecodeMoodleXML reads the multiple choice questions of a Moodle XML quiz. The category
+// of a question is the last segment of the preceding category path.
+func DecodeMoodleXML(r io.Reader) ([]Question, error) {
+	var quiz moodleQuiz
+	if err := xml.NewDecoder(r).Decode(&quiz); err != nil {
+		return nil, fmt.Errorf("failed to decode Moodle XML: %w", err)
+	}
+
+	var questions []Question
+	category := ""
+
+	for i, mq := range quiz.Questions {
+		switch mq.Type {
+		case "category":
+			if mq.Category != nil {
+				category = path.Base(strings.TrimRight(strings.TrimSpace(mq.Category.Text), "/"))
+			}
+			continue
+		case "multichoice":
+		default:
+			return nil, fmt.Errorf("question %d: unsupported question type %q, only multichoice can be imported", i+1, mq.Type)
+		}
+
+		q := Question{Category: category}
+		if mq.QuestionText != nil {
+			q.Text = moodleValue(mq.QuestionText.Format, mq.QuestionText.Text)
+		}
+		for _, answer := range mq.Answers {
+			credit, err := strconv.ParseFloat(strings.TrimSpace(answer.Fraction), 64)
+			if err != nil {
+				return nil, fmt.Errorf("question %d: invalid answer fraction %q", i+1, answer.Fraction)
+			}
+			q.Options = append(q.Options, Option{Text: moodleValue(answer.Format, answer.Text), Credit: credit})
+		}
+		if mq.Tags != nil {
+			for _, tag := range mq.Tags.Tags {
+				q.Tags = append(q.Tags, strings.TrimSpace(tag.Text))
+			}
+		}
+		questions = append(questions, q)
+	}
+
+	return questions, nil
+}
+
+// moodleValue returns the plain text of a Moodle text element, HTML is the default format
+func moodleValue(format, text string) string {
+	switch format {
+	case "plain_text", "moodle_auto_format", "markdown":
+		return strings.TrimSpace(text)
+	}
+	return plainText(text)
+}
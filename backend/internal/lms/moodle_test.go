@@ -0,0 +1,161 @@
+package lms
+
+import (
+	"strings"
+	"testing"
+)
+
+// moodleExport is a question bank exported by Moodle 4.1, trimmed to the elements that matter
+const moodleExport = `<?xml version="1.0" encoding="UTF-8"?>
+<quiz>
+<!-- question: 0  -->
+  <question type="category">
+    <category>
+      <text>$course$/top/Default for PPPK 2025/TEKNIS</text>
+    </category>
+    <info format="moodle_auto_format">
+      <text>The default category for questions shared in context 'PPPK 2025'.</text>
+    </info>
+    <idnumber></idnumber>
+  </question>
+
+<!-- question: 412  -->
+  <question type="multichoice">
+    <name>
+      <text>Ibu kota</text>
+    </name>
+    <questiontext format="html">
+      <text><![CDATA[<p dir="ltr" style="text-align: left;">Ibu kota Indonesia &amp; pusat pemerintahan adalah&nbsp;<strong>kota</strong> mana?</p>]]></text>
+    </questiontext>
+    <generalfeedback format="html">
+      <text></text>
+    </generalfeedback>
+    <defaultgrade>1.0000000</defaultgrade>
+    <penalty>0.3333333</penalty>
+    <hidden>0</hidden>
+    <idnumber></idnumber>
+    <single>true</single>
+    <shuffleanswers>true</shuffleanswers>
+    <answernumbering>abc</answernumbering>
+    <showstandardinstruction>0</showstandardinstruction>
+    <correctfeedback format="html">
+      <text>Your answer is correct.</text>
+    </correctfeedback>
+    <answer fraction="100" format="html">
+      <text><![CDATA[<p dir="ltr" style="text-align: left;">Jakarta</p>]]></text>
+      <feedback format="html">
+        <text></text>
+      </feedback>
+    </answer>
+    <answer fraction="-33.33333" format="html">
+      <text><![CDATA[<p>Surabaya &lt;Jawa Timur&gt;</p>]]></text>
+      <feedback format="html">
+        <text></text>
+      </feedback>
+    </answer>
+    <answer fraction="0" format="moodle_auto_format">
+      <text>Bandung</text>
+      <feedback format="html">
+        <text></text>
+      </feedback>
+    </answer>
+    <tags>
+      <tag><text>geografi</text>
+</tag>
+      <tag><text> indonesia </text>
+</tag>
+    </tags>
+  </question>
+
+<!-- question: 0  -->
+  <question type="category">
+    <category>
+      <text>$course$/top/Default for PPPK 2025/MANAJERIAL/</text>
+    </category>
+  </question>
+
+<!-- question: 413  -->
+  <question type="multichoice">
+    <name>
+      <text>Prioritas</text>
+    </name>
+    <questiontext format="plain_text">
+      <text>  Tugas mana yang didahulukan?  </text>
+    </questiontext>
+    <single>true</single>
+    <answer fraction="50" format="plain_text">
+      <text>Yang mendesak</text>
+    </answer>
+    <answer fraction="50" format="plain_text">
+      <text>Yang penting</text>
+    </answer>
+  </question>
+</quiz>
+`
+
+func TestDecodeMoodleXMLExport(t *testing.T) {
+	got, err := DecodeMoodleXML(strings.NewReader(moodleExport))
+	if err != nil {
+		t.Fatalf("DecodeMoodleXML() error = %v", err)
+	}
+
+	assertQuestions(t, got, []Question{
+		{
+			Category: "TEKNIS",
+			Text:     "Ibu kota Indonesia & pusat pemerintahan adalah\u00a0kota mana?",
+			Tags:     []string{"geografi", "indonesia"},
+			Options: []Option{
+				{Text: "Jakarta", Credit: 100},
+				{Text: "Surabaya <Jawa Timur>", Credit: -33.33333},
+				{Text: "Bandung", Credit: 0},
+			},
+		},
+		{
+			Category: "MANAJERIAL",
+			Text:     "Tugas mana yang didahulukan?",
+			Options: []Option{
+				{Text: "Yang mendesak", Credit: 50},
+				{Text: "Yang penting", Credit: 50},
+			},
+		},
+	})
+}
+
+func TestDecodeMoodleXMLRejectsOtherQuestionTypes(t *testing.T) {
+	const export = `<?xml version="1.0" encoding="UTF-8"?>
+<quiz>
+  <question type="truefalse">
+    <name><text>Benar salah</text></name>
+    <questiontext format="html"><text>Jakarta adalah ibu kota.</text></questiontext>
+    <answer fraction="100" format="moodle_auto_format"><text>true</text></answer>
+    <answer fraction="0" format="moodle_auto_format"><text>false</text></answer>
+  </question>
+</quiz>
+`
+	_, err := DecodeMoodleXML(strings.NewReader(export))
+	if err == nil || !strings.Contains(err.Error(), `"truefalse"`) {
+		t.Fatalf("DecodeMoodleXML() error = %v, want the unsupported question type", err)
+	}
+}
+
+func TestEncodeMoodleXMLWritesCategoryChanges(t *testing.T) {
+	var buf strings.Builder
+	if err := EncodeMoodleXML(&buf, sampleQuestions()); err != nil {
+		t.Fatalf("EncodeMoodleXML() error = %v", err)
+	}
+
+	out := buf.String()
+	if got := strings.Count(out, `<question type="category">`); got != 2 {
+		t.Errorf("got %d category questions, want 2:\n%s", got, out)
+	}
+	for _, want := range []string{
+		"<text>$course$/top/TEKNIS</text>",
+		"<text>$course$/top/MANAJERIAL</text>",
+		`<answer fraction="33.33333" format="plain_text">`,
+		"&lt;b&gt;bold&lt;/b&gt; &amp;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Moodle XML misses %q:\n%s", want, out)
+		}
+	}
+}
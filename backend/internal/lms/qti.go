@@ -0,0 +1,282 @@
+package lms
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// IMS QTI 2.1 content packages: an imsmanifest.xml listing one assessmentItem file per
+// question. Credits are written as a response mapping scaled to 0-1, the category is kept
+// in the item label and tags as keywords of the item metadata in the manifest.
+
+const (
+	qtiNamespace      = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	qtiMapResponse    = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/map_response"
+	qtiResourceType   = "imsqti_item_xmlv2p1"
+	qtiCPNamespace    = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	qtiManifestPath   = "imsmanifest.xml"
+	qtiResponseID     = "RESPONSE"
+	qtiOutcomeScoreID = "SCORE"
+)
+
+type qtiItem struct {
+	XMLName       xml.Name            `xml:"assessmentItem"`
+	Namespace     string              `xml:"xmlns,attr,omitempty"`
+	Identifier    string              `xml:"identifier,attr"`
+	Title         string              `xml:"title,attr"`
+	Label         string              `xml:"label,attr,omitempty"`
+	Adaptive      bool                `xml:"adaptive,attr"`
+	TimeDependent bool                `xml:"timeDependent,attr"`
+	Response      qtiResponse         `xml:"responseDeclaration"`
+	Outcome       qtiOutcome          `xml:"outcomeDeclaration"`
+	Body          qtiItemBody         `xml:"itemBody"`
+	Processing    qtiProcessingTarget `xml:"responseProcessing"`
+}
+
+type qtiResponse struct {
+	Identifier  string     `xml:"identifier,attr"`
+	Cardinality string     `xml:"cardinality,attr"`
+	BaseType    string     `xml:"baseType,attr"`
+	Correct     []string   `xml:"correctResponse>value"`
+	Mapping     qtiMapping `xml:"mapping"`
+}
+
+type qtiMapping struct {
+	DefaultValue string        `xml:"defaultValue,attr"`
+	Entries      []qtiMapEntry `xml:"mapEntry"`
+}
+
+type qtiMapEntry struct {
+	Key   string `xml:"mapKey,attr"`
+	Value string `xml:"mappedValue,attr"`
+}
+
+type qtiOutcome struct {
+	Identifier  string `xml:"identifier,attr"`
+	Cardinality string `xml:"cardinality,attr"`
+	BaseType    string `xml:"baseType,attr"`
+}
+
+type qtiItemBody struct {
+	Interaction qtiChoiceInteraction `xml:"choiceInteraction"`
+}
+
+type qtiChoiceInteraction struct {
+	ResponseIdentifier string      `xml:"responseIdentifier,attr"`
+	Shuffle            bool        `xml:"shuffle,attr"`
+	MaxChoices         int         `xml:"maxChoices,attr"`
+	Prompt             string      `xml:"prompt"`
+	Choices            []qtiChoice `xml:"simpleChoice"`
+}
+
+type qtiChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Text       string `xml:",chardata"`
+}
+
+type qtiProcessingTarget struct {
+	Template string `xml:"template,attr"`
+}
+
+type qtiManifest struct {
+	XMLName    xml.Name      `xml:"manifest"`
+	Namespace  string        `xml:"xmlns,attr,omitempty"`
+	Identifier string        `xml:"identifier,attr"`
+	Resources  []qtiResource `xml:"resources>resource"`
+}
+
+type qtiResource struct {
+	Identifier string       `xml:"identifier,attr"`
+	Type       string       `xml:"type,attr"`
+	Href       string       `xml:"href,attr"`
+	Keywords   []qtiKeyword `xml:"metadata>lom>general>keyword,omitempty"`
+	File       qtiFile      `xml:"file"`
+}
+
+type qtiKeyword struct {
+	Value string `xml:"string"`
+}
+
+type qtiFile struct {
+	Href string `xml:"href,attr"`
+}
+
+// EncodeQTI writes questions as a QTI 2.1 content package (zip)
+func EncodeQTI(w io.Writer, questions []Question) error {
+	zw := zip.NewWriter(w)
+	manifest := qtiManifest{
+		Namespace:  qtiCPNamespace,
+		Identifier: "MANIFEST-QUESTIONS",
+	}
+
+	for i, q := range questions {
+		identifier := fmt.Sprintf("ITEM-%d", i+1)
+		href := fmt.Sprintf("items/%s.xml", identifier)
+
+		item := qtiItem{
+			Namespace:  qtiNamespace,
+			Identifier: identifier,
+			Title:      fmt.Sprintf("%s %d", q.Category, i+1),
+			Label:      q.Category,
+			Response: qtiResponse{
+				Identifier:  qtiResponseID,
+				Cardinality: "single",
+				BaseType:    "identifier",
+				Mapping:     qtiMapping{DefaultValue: "0"},
+			},
+			Outcome: qtiOutcome{Identifier: qtiOutcomeScoreID, Cardinality: "single", BaseType: "float"},
+			Body: qtiItemBody{Interaction: qtiChoiceInteraction{
+				ResponseIdentifier: qtiResponseID,
+				MaxChoices:         1,
+				Prompt:             q.Text,
+			}},
+			Processing: qtiProcessingTarget{Template: qtiMapResponse},
+		}
+
+		for j, opt := range q.Options {
+			choice := optionLetter(j)
+			item.Body.Interaction.Choices = append(item.Body.Interaction.Choices, qtiChoice{Identifier: choice, Text: opt.Text})
+			item.Response.Mapping.Entries = append(item.Response.Mapping.Entries, qtiMapEntry{
+				Key:   choice,
+				Value: formatCredit(opt.Credit / 100),
+			})
+		}
+		if len(q.Options) > 0 {
+			item.Response.Correct = []string{optionLetter(bestOption(q.Options))}
+		}
+
+		if err := writeZipXML(zw, href, item); err != nil {
+			return err
+		}
+
+		resource := qtiResource{
+			Identifier: "RES-" + identifier,
+			Type:       qtiResourceType,
+			Href:       href,
+			File:       qtiFile{Href: href},
+		}
+		for _, tag := range q.Tags {
+			resource.Keywords = append(resource.Keywords, qtiKeyword{Value: tag})
+		}
+		manifest.Resources = append(manifest.Resources, resource)
+	}
+
+	if err := writeZipXML(zw, qtiManifestPath, manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// DecodeQTI reads the choice items of a QTI 2.1 content package in manifest order
+func DecodeQTI(r io.ReaderAt, size int64) ([]Question, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open QTI package: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[path.Clean(f.Name)] = f
+	}
+
+	manifestFile, ok := files[qtiManifestPath]
+	if !ok {
+		return nil, fmt.Errorf("QTI package has no %s", qtiManifestPath)
+	}
+	var manifest qtiManifest
+	if err := readZipXML(manifestFile, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", qtiManifestPath, err)
+	}
+
+	var questions []Question
+	for _, resource := range manifest.Resources {
+		if !strings.HasPrefix(resource.Type, "imsqti_item_xmlv2p") {
+			continue
+		}
+
+		file, ok := files[path.Clean(resource.Href)]
+		if !ok {
+			return nil, fmt.Errorf("resource %s: missing file %s", resource.Identifier, resource.Href)
+		}
+
+		var item qtiItem
+		if err := readZipXML(file, &item); err != nil {
+			return nil, fmt.Errorf("resource %s: %w", resource.Identifier, err)
+		}
+
+		q, err := item.toQuestion()
+		if err != nil {
+			return nil, fmt.Errorf("item %s: %w", item.Identifier, err)
+		}
+		for _, keyword := range resource.Keywords {
+			q.Tags = append(q.Tags, strings.TrimSpace(keyword.Value))
+		}
+		questions = append(questions, q)
+	}
+
+	return questions, nil
+}
+
+// toQuestion converts a choice item, options without a mapping entry earn full credit
+// when they are the correct response and nothing otherwise
+func (item *qtiItem) toQuestion() (Question, error) {
+	interaction := item.Body.Interaction
+	if len(interaction.Choices) == 0 {
+		return Question{}, fmt.Errorf("only choice interactions can be imported")
+	}
+
+	mapped := make(map[string]float64, len(item.Response.Mapping.Entries))
+	for _, entry := range item.Response.Mapping.Entries {
+		value, err := strconv.ParseFloat(strings.TrimSpace(entry.Value), 64)
+		if err != nil {
+			return Question{}, fmt.Errorf("invalid mapped value %q", entry.Value)
+		}
+		mapped[entry.Key] = value * 100
+	}
+
+	correct := make(map[string]bool, len(item.Response.Correct))
+	for _, value := range item.Response.Correct {
+		correct[strings.TrimSpace(value)] = true
+	}
+
+	q := Question{Category: strings.TrimSpace(item.Label), Text: strings.TrimSpace(interaction.Prompt)}
+	for _, choice := range interaction.Choices {
+		credit, ok := mapped[choice.Identifier]
+		if !ok && correct[choice.Identifier] {
+			credit = 100
+		}
+		q.Options = append(q.Options, Option{Text: strings.TrimSpace(choice.Text), Credit: credit})
+	}
+
+	return q, nil
+}
+
+func writeZipXML(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return nil
+}
+
+func readZipXML(file *zip.File, v interface{}) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
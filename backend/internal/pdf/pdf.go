@@ -0,0 +1,173 @@
+// Package pdf writes simple A4 PDF documents: coloured text in the standard Helvetica fonts,
+// lines, rectangles and circles. It needs no font files or third-party library; text is encoded
+// as WinAnsi, so characters outside Latin-1 print as "?".
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font is one of the standard fonts every PDF reader provides
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = map[Font]string{
+	Helvetica:     "Helvetica",
+	HelveticaBold: "Helvetica-Bold",
+}
+
+// Document is a PDF document built page by page
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page is a page of a document. Coordinates are in points from the top-left corner.
+type Page struct {
+	content bytes.Buffer
+}
+
+// New creates an empty document
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage appends a blank A4 page
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// PageCount returns the number of pages added so far
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text draws text with its baseline at y
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, num(size), num(x), num(PageHeight-y), escape(encode(text)))
+}
+
+// Color sets the RGB colour, components between 0 and 1, used by the following text,
+// lines and shapes
+func (p *Page) Color(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s rg %s %s %s RG\n", num(r), num(g), num(b), num(r), num(g), num(b))
+}
+
+// Line draws a straight line
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect draws the outline of a rectangle whose top-left corner is at x, y
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(width), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// FillRect draws a rectangle filled with the current colour whose top-left corner is at x, y
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Circle draws the outline of a circle centred on cx, cy
+func (p *Page) Circle(cx, cy, r, width float64) {
+	// Four Bezier curves approximate the circle
+	k := 0.5523 * r
+	cy = PageHeight - cy
+	fmt.Fprintf(&p.content, "%s w %s %s m ", num(width), num(cx+r), num(cy))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c ", num(cx+r), num(cy+k), num(cx+k), num(cy+r), num(cx), num(cy+r))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c ", num(cx-k), num(cy+r), num(cx-r), num(cy+k), num(cx-r), num(cy))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c ", num(cx-r), num(cy-k), num(cx-k), num(cy-r), num(cx), num(cy-r))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c S\n", num(cx+k), num(cy-r), num(cx+r), num(cy-k), num(cx+r), num(cy))
+}
+
+// WriteTo writes the document as a PDF file
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Fixed objects: 1 catalog, 2 page tree, 3-4 fonts, 5 info; then a page and its content per page
+	const firstPageObject = 6
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	for _, font := range []Font{Helvetica, HelveticaBold} {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[font]))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (pppk-json) >>", escape(encode(d.title))))
+
+	for i, page := range pages {
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), firstPageObject+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// num formats a coordinate with at most two decimals
+func num(v float64) string {
+	v = math.Round(v*100) / 100
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// escape escapes the delimiters of a PDF literal string
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`).Replace(s)
+}
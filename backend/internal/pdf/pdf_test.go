@@ -0,0 +1,197 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNum(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want string
+	}{
+		{0, "0"},
+		{12, "12"},
+		{12.5, "12.5"},
+		{12.345, "12.35"},
+		{-3.1, "-3.1"},
+		{-0.001, "0"},
+		{841.89, "841.89"},
+	}
+
+	for _, tt := range tests {
+		if got := num(tt.v); got != tt.want {
+			t.Errorf("num(%v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeAndEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Plain text", "Plain text"},
+		{"a\tb", "a b"},
+		{"(1) back\\slash", `\(1\) back\\slash`},
+		{"café", "caf\xe9"},
+		{"€5 – “quoted”", "\x805 \x96 \x93quoted\x94"},
+		{"日本", "??"},
+		{"line\nbreak", "line?break"},
+	}
+
+	for _, tt := range tests {
+		if got := escape(encode(tt.text)); got != tt.want {
+			t.Errorf("escape(encode(%q)) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		size float64
+		text string
+		want float64
+	}{
+		{Helvetica, 10, "", 0},
+		{Helvetica, 10, "i", 2.22},
+		{HelveticaBold, 10, "i", 2.78},
+		{Helvetica, 12, "AB", 16.008},
+		{Helvetica, 10, "é", 5.56},
+	}
+
+	for _, tt := range tests {
+		got := TextWidth(tt.font, tt.size, tt.text)
+		if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("TextWidth(%d, %v, %q) = %v, want %v", tt.font, tt.size, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	// Each "m" is 8.33pt at size 10
+	tests := []struct {
+		name  string
+		text  string
+		width float64
+		want  []string
+	}{
+		{"fits", "mm mm", 100, []string{"mm mm"}},
+		{"breaks between words", "mm mm mm", 45, []string{"mm mm", "mm"}},
+		{"explicit line breaks", "mm\r\nmm\n\nmm", 100, []string{"mm", "mm", "", "mm"}},
+		{"cuts long words", "mmmmmmm", 25, []string{"mmm", "mmm", "m"}},
+		{"collapses spaces", "  mm   mm  ", 100, []string{"mm mm"}},
+		{"empty", "", 100, []string{""}},
+	}
+
+	for _, tt := range tests {
+		got := Wrap(Helvetica, 10, tt.text, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: Wrap() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		pages     []string
+		wantPages int
+	}{
+		{"empty document", nil, 1},
+		{"one page", []string{"Hello (world)"}, 1},
+		{"three pages", []string{"First", "Second", "Third"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New("Paper (A)")
+			for _, text := range tt.pages {
+				doc.AddPage().Text(50, 100, HelveticaBold, 12, text)
+			}
+
+			var buf bytes.Buffer
+			n, err := doc.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			out := buf.Bytes()
+			if n != int64(len(out)) {
+				t.Fatalf("WriteTo() = %d bytes, wrote %d", n, len(out))
+			}
+			if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+				t.Fatalf("output is not framed as a PDF file")
+			}
+			if !bytes.Contains(out, []byte(`/Title (Paper \(A\))`)) {
+				t.Errorf("document title is missing or not escaped")
+			}
+			if !bytes.Contains(out, []byte("/Count "+strconv.Itoa(tt.wantPages)+" ")) {
+				t.Errorf("page tree does not count %d pages", tt.wantPages)
+			}
+
+			checkXref(t, out)
+
+			contents := pageContents(t, out)
+			if len(contents) != tt.wantPages {
+				t.Fatalf("found %d page contents, want %d", len(contents), tt.wantPages)
+			}
+			for i, text := range tt.pages {
+				want := "BT /F2 12 Tf 50 741.89 Td (" + escape(encode(text)) + ") Tj ET\n"
+				if contents[i] != want {
+					t.Errorf("page %d content = %q, want %q", i+1, contents[i], want)
+				}
+			}
+		})
+	}
+}
+
+// checkXref verifies that every cross-reference entry points at the start of its object
+// and that startxref points at the table
+func checkXref(t *testing.T, out []byte) {
+	t.Helper()
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if match == nil {
+		t.Fatal("startxref is missing")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		want := strconv.Itoa(i+1) + " 0 obj\n"
+		if !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want object %d", i+1, out[offset:offset+10], i+1)
+		}
+	}
+}
+
+// pageContents inflates the content streams of the pages in order
+func pageContents(t *testing.T, out []byte) []string {
+	t.Helper()
+
+	var contents []string
+	streams := regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindAllSubmatchIndex(out, -1)
+	for _, s := range streams {
+		length, _ := strconv.Atoi(string(out[s[2]:s[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(out[s[1] : s[1]+length]))
+		if err != nil {
+			t.Fatalf("content stream is not zlib compressed: %v", err)
+		}
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("failed to inflate content stream: %v", err)
+		}
+		contents = append(contents, string(content))
+	}
+	return contents
+}
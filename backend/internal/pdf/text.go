@@ -0,0 +1,102 @@
+package pdf
+
+import "strings"
+
+// Glyph widths of the standard fonts for characters 32-126, in 1/1000 of the font size
+var glyphWidths = map[Font][95]int{
+	Helvetica: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	HelveticaBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// winAnsi maps the characters of the WinAnsi 0x80-0x9F range, Latin-1 covers the rest
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encode converts text into WinAnsi bytes, tabs become spaces and unknown characters "?"
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			b.WriteByte(' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		default:
+			if c, ok := winAnsi[r]; ok {
+				b.WriteByte(c)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
+
+// TextWidth returns the width of text in points
+func TextWidth(font Font, size float64, text string) float64 {
+	widths := glyphWidths[font]
+	total := 0
+	for _, c := range []byte(encode(text)) {
+		if c >= 32 && c < 127 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap breaks text into lines no wider than width, honouring explicit line breaks.
+// Words longer than a line are cut.
+func Wrap(font Font, size float64, text string, width float64) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+			runes := []rune(word)
+			for TextWidth(font, size, string(runes)) > width && len(runes) > 1 {
+				cut := len(runes) - 1
+				for cut > 1 && TextWidth(font, size, string(runes[:cut])) > width {
+					cut--
+				}
+				lines = append(lines, string(runes[:cut]))
+				runes = runes[cut:]
+			}
+			line = string(runes)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// MailMessage is a plain text email to one recipient
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type MailerPort interface {
+	SendMail(ctx context.Context, message MailMessage) error
+}
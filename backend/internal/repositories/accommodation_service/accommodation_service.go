@@ -0,0 +1,122 @@
+package accommodation_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// Bounds of a time multiplier
+const (
+	MinTimeMultiplier = 1.0
+	MaxTimeMultiplier = 3.0
+)
+
+// ErrInvalidAccommodation is returned when an accommodation shortens the exam or exceeds its bounds
+var ErrInvalidAccommodation = errors.New("invalid accommodation")
+
+type AccommodationService interface {
+	GetAccommodations(ctx context.Context) ([]models.Accommodation, error)
+	GetAccommodation(ctx context.Context, userID string) (*models.Accommodation, error)
+	SaveAccommodation(ctx context.Context, accommodation *models.Accommodation) error
+	DeleteAccommodation(ctx context.Context, userID string) error
+}
+
+type accommodationService struct {
+	db *gorm.DB
+}
+
+func NewAccommodationService(db *gorm.DB) AccommodationService {
+	return &accommodationService{
+		db: db,
+	}
+}
+
+func (r *accommodationService) GetAccommodations(ctx context.Context) ([]models.Accommodation, error) {
+	var accommodations []models.Accommodation
+	err := r.db.WithContext(ctx).Order("user_id ASC").Find(&accommodations).Error
+	return accommodations, err
+}
+
+func (r *accommodationService) GetAccommodation(ctx context.Context, userID string) (*models.Accommodation, error) {
+	var accommodation models.Accommodation
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&accommodation).Error
+	return &accommodation, err
+}
+
+// SaveAccommodation creates or replaces the accommodation of a user. Sessions that are not
+// started yet pick it up when started; sessions in progress keep their deadline.
+func (r *accommodationService) SaveAccommodation(ctx context.Context, accommodation *models.Accommodation) error {
+	if err := ValidateAccommodation(accommodation); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Accommodation
+		err := tx.Where("user_id = ?", accommodation.UserID).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(accommodation).Error
+		case err != nil:
+			return fmt.Errorf("failed to get accommodation of user %s: %w", accommodation.UserID, err)
+		}
+
+		accommodation.ID = existing.ID
+		accommodation.CreatedAt = existing.CreatedAt
+		return tx.Save(accommodation).Error
+	})
+}
+
+func (r *accommodationService) DeleteAccommodation(ctx context.Context, userID string) error {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Accommodation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ValidateAccommodation checks the multiplier is within bounds and breaks are not negative
+func ValidateAccommodation(accommodation *models.Accommodation) error {
+	if accommodation.TimeMultiplier < MinTimeMultiplier || accommodation.TimeMultiplier > MaxTimeMultiplier {
+		return fmt.Errorf("%w: time multiplier must be between %.1f and %.1f", ErrInvalidAccommodation, MinTimeMultiplier, MaxTimeMultiplier)
+	}
+	if accommodation.ExtraBreaks < 0 || accommodation.BreakMinutes < 0 {
+		return fmt.Errorf("%w: breaks cannot be negative", ErrInvalidAccommodation)
+	}
+	return nil
+}
+
+// LoadAccommodation returns the accommodation of a user, nil when the user has none
+func LoadAccommodation(tx *gorm.DB, userID string) (*models.Accommodation, error) {
+	var accommodations []models.Accommodation
+	if err := tx.Where("user_id = ?", userID).Limit(1).Find(&accommodations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get accommodation of user %s: %w", userID, err)
+	}
+	if len(accommodations) == 0 {
+		return nil, nil
+	}
+	return &accommodations[0], nil
+}
+
+// ExtraMinutes returns the minutes an accommodation adds to an exam of baseMinutes: the
+// multiplied extra time, rounded up, plus all extra breaks. A nil accommodation adds nothing.
+// The multiplier is taken to the hundredth and the rest computed in whole numbers, so float
+// error cannot round ×1.1 of 60 minutes up to 7.
+func ExtraMinutes(accommodation *models.Accommodation, baseMinutes int) int {
+	if accommodation == nil {
+		return 0
+	}
+	percent := int(math.Round((accommodation.TimeMultiplier - 1) * 100))
+	extra := 0
+	if percent > 0 && baseMinutes > 0 {
+		extra = (baseMinutes*percent + 99) / 100
+	}
+	return extra + accommodation.ExtraBreaks*accommodation.BreakMinutes
+}
@@ -0,0 +1,62 @@
+package accommodation_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"testing"
+)
+
+func TestExtraMinutes(t *testing.T) {
+	tests := []struct {
+		name          string
+		accommodation *models.Accommodation
+		base          int
+		want          int
+	}{
+		{"no accommodation", nil, 130, 0},
+		{"no extra time", &models.Accommodation{TimeMultiplier: 1}, 130, 0},
+		{"x1.1 of 60", &models.Accommodation{TimeMultiplier: 1.1}, 60, 6},
+		{"x1.1 of 100", &models.Accommodation{TimeMultiplier: 1.1}, 100, 10},
+		{"x1.3 of 100", &models.Accommodation{TimeMultiplier: 1.3}, 100, 30},
+		{"x1.15 of 130", &models.Accommodation{TimeMultiplier: 1.15}, 130, 20},
+		{"x1.25 of 130 rounds up", &models.Accommodation{TimeMultiplier: 1.25}, 130, 33},
+		{"x1.5 of 130", &models.Accommodation{TimeMultiplier: 1.5}, 130, 65},
+		{"x2 of 130", &models.Accommodation{TimeMultiplier: 2}, 130, 130},
+		{"x3 of 130", &models.Accommodation{TimeMultiplier: 3}, 130, 260},
+		{"multiplier below 1", &models.Accommodation{TimeMultiplier: 0.5}, 130, 0},
+		{"breaks only", &models.Accommodation{TimeMultiplier: 1, ExtraBreaks: 2, BreakMinutes: 10}, 130, 20},
+		{"extra time and breaks", &models.Accommodation{TimeMultiplier: 1.1, ExtraBreaks: 1, BreakMinutes: 15}, 60, 21},
+		{"no base", &models.Accommodation{TimeMultiplier: 1.5, ExtraBreaks: 1, BreakMinutes: 5}, 0, 5},
+	}
+
+	for _, tt := range tests {
+		if got := ExtraMinutes(tt.accommodation, tt.base); got != tt.want {
+			t.Errorf("%s: ExtraMinutes() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAccommodation(t *testing.T) {
+	tests := []struct {
+		name          string
+		accommodation models.Accommodation
+		wantErr       bool
+	}{
+		{"lowest multiplier", models.Accommodation{TimeMultiplier: MinTimeMultiplier}, false},
+		{"highest multiplier", models.Accommodation{TimeMultiplier: MaxTimeMultiplier}, false},
+		{"shortens the exam", models.Accommodation{TimeMultiplier: 0.9}, true},
+		{"beyond the bound", models.Accommodation{TimeMultiplier: 3.01}, true},
+		{"negative breaks", models.Accommodation{TimeMultiplier: 1, ExtraBreaks: -1}, true},
+		{"negative break length", models.Accommodation{TimeMultiplier: 1, BreakMinutes: -5}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateAccommodation(&tt.accommodation)
+		if tt.wantErr && !errors.Is(err, ErrInvalidAccommodation) {
+			t.Errorf("%s: ValidateAccommodation() error = %v, want %v", tt.name, err, ErrInvalidAccommodation)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: ValidateAccommodation() error = %v, want nil", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package analytics_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EventCountFilter narrows down event count queries, zero values are ignored
+type EventCountFilter struct {
+	EventType string
+	From      *time.Time // First day included
+	To        *time.Time // Last day included
+}
+
+type AnalyticsService interface {
+	GetEventCounts(ctx context.Context, filter EventCountFilter) ([]models.EventCount, error)
+}
+
+type analyticsService struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsService(db *gorm.DB) AnalyticsService {
+	return &analyticsService{
+		db: db,
+	}
+}
+
+// GetEventCounts returns the daily event counts matching the filter, oldest day first
+func (r *analyticsService) GetEventCounts(ctx context.Context, filter EventCountFilter) ([]models.EventCount, error) {
+	var counts []models.EventCount
+	query := r.db.WithContext(ctx)
+
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.From != nil {
+		query = query.Where("day >= ?", filter.From.Format(time.DateOnly))
+	}
+	if filter.To != nil {
+		query = query.Where("day <= ?", filter.To.Format(time.DateOnly))
+	}
+
+	err := query.Order("day ASC, event_type ASC").Find(&counts).Error
+	return counts, err
+}
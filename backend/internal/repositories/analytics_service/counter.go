@@ -0,0 +1,43 @@
+package analytics_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/events"
+	"cutbray/pppk-json/internal/repositories/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ events.Subscriber = &counter{}
+
+type counter struct{}
+
+// NewCounter returns the domain event subscriber that counts events per type and day. The
+// count is written in the transaction of the receipt, so a redelivered event is not counted twice.
+func NewCounter() events.Subscriber {
+	return &counter{}
+}
+
+func (c *counter) Name() string {
+	return "analytics"
+}
+
+func (c *counter) Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error {
+	occurredAt := event.OccurredAt.UTC()
+	count := models.EventCount{
+		Day:       time.Date(occurredAt.Year(), occurredAt.Month(), occurredAt.Day(), 0, 0, 0, 0, time.UTC),
+		EventType: event.EventType,
+		Count:     1,
+		UpdatedAt: time.Now(),
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "day"}, {Name: "event_type"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count":      gorm.Expr("event_counts.count + 1"),
+			"updated_at": count.UpdatedAt,
+		}),
+	}).Create(&count).Error
+}
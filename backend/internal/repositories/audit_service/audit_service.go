@@ -0,0 +1,80 @@
+package audit_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter narrows down audit log queries, zero values are ignored
+type AuditLogFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditService interface {
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter, offset, limit int) ([]models.AuditLog, error)
+	CountAuditLogs(ctx context.Context, filter AuditLogFilter) (int64, error)
+}
+
+type auditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) AuditService {
+	return &auditService{
+		db: db,
+	}
+}
+
+// applyAuditFilters applies the audit log filter to a query
+func applyAuditFilters(query *gorm.DB, filter AuditLogFilter) *gorm.DB {
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// GetAuditLogs returns audit logs matching the filter, newest first
+func (r *auditService) GetAuditLogs(ctx context.Context, filter AuditLogFilter, offset, limit int) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+	query := applyAuditFilters(r.db.WithContext(ctx), filter)
+
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+
+	err := query.Order("created_at DESC, id DESC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *auditService) CountAuditLogs(ctx context.Context, filter AuditLogFilter) (int64, error) {
+	var count int64
+	err := applyAuditFilters(r.db.WithContext(ctx).Model(&models.AuditLog{}), filter).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,107 @@
+package category_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnknownScoringScheme is returned when a category uses a scoring scheme that does not exist
+	ErrUnknownScoringScheme = errors.New("unknown scoring scheme")
+	// ErrCategoryInUse is returned when deleting a category that still has questions
+	ErrCategoryInUse = errors.New("category still has questions")
+)
+
+type CategoryService interface {
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	GetCategoryByID(ctx context.Context, categoryID uint) (*models.Category, error)
+	CreateCategory(ctx context.Context, category *models.Category) error
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, categoryID uint) error
+}
+
+type categoryService struct {
+	db *gorm.DB
+}
+
+func NewCategoryService(db *gorm.DB) CategoryService {
+	return &categoryService{
+		db: db,
+	}
+}
+
+// IsKnownScoringScheme reports whether scheme is one of the supported scoring schemes
+func IsKnownScoringScheme(scheme string) bool {
+	_, err := scoring.ForScheme(scheme)
+	return err == nil
+}
+
+func (r *categoryService) GetCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.WithContext(ctx).Order("display_order ASC, id ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryService) GetCategoryByID(ctx context.Context, categoryID uint) (*models.Category, error) {
+	var category models.Category
+	err := r.db.WithContext(ctx).First(&category, categoryID).Error
+	return &category, err
+}
+
+func (r *categoryService) CreateCategory(ctx context.Context, category *models.Category) error {
+	if category.ScoringScheme == "" {
+		category.ScoringScheme = models.ScoringSchemeGraded
+	}
+	if !IsKnownScoringScheme(category.ScoringScheme) {
+		return fmt.Errorf("%w: %s", ErrUnknownScoringScheme, category.ScoringScheme)
+	}
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+// UpdateCategory saves a category. Renaming the code cascades to questions through the foreign key.
+func (r *categoryService) UpdateCategory(ctx context.Context, category *models.Category) error {
+	if !IsKnownScoringScheme(category.ScoringScheme) {
+		return fmt.Errorf("%w: %s", ErrUnknownScoringScheme, category.ScoringScheme)
+	}
+	return r.db.WithContext(ctx).Save(category).Error
+}
+
+func (r *categoryService) DeleteCategory(ctx context.Context, categoryID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.First(&category, categoryID).Error; err != nil {
+			return err
+		}
+
+		var questionCount int64
+		if err := tx.Model(&models.Question{}).Where("category = ?", category.Code).Count(&questionCount).Error; err != nil {
+			return err
+		}
+
+		if questionCount > 0 {
+			return fmt.Errorf("%w: %s has %d questions", ErrCategoryInUse, category.Code, questionCount)
+		}
+
+		// Hard delete so the code can be reused and the foreign key stays consistent
+		return tx.Unscoped().Delete(&category).Error
+	})
+}
+
+// GetActiveCategories returns the categories drawn in an exam, in display order
+func GetActiveCategories(tx *gorm.DB) ([]models.Category, error) {
+	var categories []models.Category
+	err := tx.Where("question_count > 0").Order("display_order ASC, id ASC").Find(&categories).Error
+	return categories, err
+}
+
+// CategoryExists reports whether a category code is registered
+func CategoryExists(tx *gorm.DB, code string) (bool, error) {
+	var count int64
+	err := tx.Model(&models.Category{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,277 @@
+package cohort_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidCohortRole is returned when a member role is neither MEMBER nor INSTRUCTOR
+	ErrInvalidCohortRole = errors.New("invalid cohort role")
+	// ErrNotCohortInstructor is returned when the actor managing members or exam assignments is not an instructor of the cohort
+	ErrNotCohortInstructor = errors.New("actor is not an instructor of the cohort")
+	// ErrInvalidAssignmentWindow is returned when an availability window closes before it opens
+	ErrInvalidAssignmentWindow = errors.New("invalid assignment availability window")
+)
+
+type CohortService interface {
+	GetCohorts(ctx context.Context) ([]models.Cohort, error)
+	GetCohortByID(ctx context.Context, cohortID uint) (*models.Cohort, error)
+	CreateCohort(ctx context.Context, cohort *models.Cohort) error
+	UpdateCohort(ctx context.Context, cohort *models.Cohort) error
+	DeleteCohort(ctx context.Context, cohortID uint) error
+	SetMembers(ctx context.Context, cohortID uint, members []models.CohortMember) ([]models.CohortMember, error)
+	RemoveMember(ctx context.Context, cohortID uint, userID string) error
+	GetAssignments(ctx context.Context, cohortID uint) ([]models.CohortAssignment, error)
+	AssignBlueprint(ctx context.Context, assignment *models.CohortAssignment) error
+	DeleteAssignment(ctx context.Context, cohortID, assignmentID uint) error
+}
+
+type cohortService struct {
+	db *gorm.DB
+}
+
+func NewCohortService(db *gorm.DB) CohortService {
+	return &cohortService{
+		db: db,
+	}
+}
+
+// IsKnownCohortRole reports whether role is one of the supported cohort roles
+func IsKnownCohortRole(role string) bool {
+	return role == models.CohortRoleMember || role == models.CohortRoleInstructor
+}
+
+// preloadMembers lists instructors before members, each by user ID
+func preloadMembers(db *gorm.DB) *gorm.DB {
+	return db.Order("role DESC, user_id ASC")
+}
+
+// preloadAssignments lists the most recently opened windows first
+func preloadAssignments(db *gorm.DB) *gorm.DB {
+	return db.Order("available_from DESC, id DESC")
+}
+
+func (r *cohortService) GetCohorts(ctx context.Context) ([]models.Cohort, error) {
+	var cohorts []models.Cohort
+	err := r.db.WithContext(ctx).Preload("Members", preloadMembers).Order("code ASC").Find(&cohorts).Error
+	return cohorts, err
+}
+
+func (r *cohortService) GetCohortByID(ctx context.Context, cohortID uint) (*models.Cohort, error) {
+	var cohort models.Cohort
+	err := r.db.WithContext(ctx).
+		Preload("Members", preloadMembers).
+		Preload("Assignments", preloadAssignments).
+		Preload("Assignments.Blueprint").
+		First(&cohort, cohortID).Error
+	return &cohort, err
+}
+
+// CreateCohort creates a cohort together with its initial members
+func (r *cohortService) CreateCohort(ctx context.Context, cohort *models.Cohort) error {
+	for _, member := range cohort.Members {
+		if !IsKnownCohortRole(member.Role) {
+			return fmt.Errorf("%w: %s", ErrInvalidCohortRole, member.Role)
+		}
+	}
+	return r.db.WithContext(ctx).Create(cohort).Error
+}
+
+// UpdateCohort saves the cohort fields. Members and assignments are managed separately.
+func (r *cohortService) UpdateCohort(ctx context.Context, cohort *models.Cohort) error {
+	return r.db.WithContext(ctx).Omit("Members", "Assignments").Save(cohort).Error
+}
+
+// DeleteCohort deletes a cohort with its members and assignments. Sessions keep their blueprint.
+func (r *cohortService) DeleteCohort(ctx context.Context, cohortID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cohort models.Cohort
+		if err := tx.First(&cohort, cohortID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("cohort_id = ?", cohortID).Delete(&models.CohortMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete cohort members: %w", err)
+		}
+		if err := tx.Where("cohort_id = ?", cohortID).Delete(&models.CohortAssignment{}).Error; err != nil {
+			return fmt.Errorf("failed to delete cohort assignments: %w", err)
+		}
+		return tx.Delete(&cohort).Error
+	})
+}
+
+// SetMembers adds users to a cohort, changing the role of users who are already in it,
+// and returns the resulting members. Once the cohort has an instructor, the actor of ctx
+// must be one of its instructors.
+func (r *cohortService) SetMembers(ctx context.Context, cohortID uint, members []models.CohortMember) ([]models.CohortMember, error) {
+	for _, member := range members {
+		if !IsKnownCohortRole(member.Role) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCohortRole, member.Role)
+		}
+	}
+
+	saved := make([]models.CohortMember, 0, len(members))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Cohort{}, cohortID).Error; err != nil {
+			return err
+		}
+		if err := requireManager(tx, cohortID, audit.ActorFromContext(ctx)); err != nil {
+			return err
+		}
+
+		for _, member := range members {
+			var existing models.CohortMember
+			err := tx.Where("cohort_id = ? AND user_id = ?", cohortID, member.UserID).First(&existing).Error
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				existing = models.CohortMember{CohortID: cohortID, UserID: member.UserID, Role: member.Role}
+				if err := tx.Create(&existing).Error; err != nil {
+					return fmt.Errorf("failed to add cohort member %s: %w", member.UserID, err)
+				}
+			case err != nil:
+				return fmt.Errorf("failed to get cohort member %s: %w", member.UserID, err)
+			case existing.Role != member.Role:
+				if err := tx.Model(&existing).Update("role", member.Role).Error; err != nil {
+					return fmt.Errorf("failed to update cohort member %s: %w", member.UserID, err)
+				}
+			}
+			saved = append(saved, existing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// RemoveMember removes a user from a cohort. Once the cohort has an instructor, the actor of
+// ctx must be one of its instructors.
+func (r *cohortService) RemoveMember(ctx context.Context, cohortID uint, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireManager(tx, cohortID, audit.ActorFromContext(ctx)); err != nil {
+			return err
+		}
+
+		result := tx.Where("cohort_id = ? AND user_id = ?", cohortID, userID).Delete(&models.CohortMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *cohortService) GetAssignments(ctx context.Context, cohortID uint) ([]models.CohortAssignment, error) {
+	db := r.db.WithContext(ctx)
+	if err := db.First(&models.Cohort{}, cohortID).Error; err != nil {
+		return nil, err
+	}
+
+	var assignments []models.CohortAssignment
+	err := preloadAssignments(db).Preload("Blueprint").Where("cohort_id = ?", cohortID).Find(&assignments).Error
+	return assignments, err
+}
+
+// AssignBlueprint lets the cohort sit a blueprint inside the assignment window. The actor
+// of ctx must be an instructor of the cohort and is recorded as the assigner.
+func (r *cohortService) AssignBlueprint(ctx context.Context, assignment *models.CohortAssignment) error {
+	if assignment.AvailableUntil != nil && !assignment.AvailableUntil.After(assignment.AvailableFrom) {
+		return fmt.Errorf("%w: available_until must be after available_from", ErrInvalidAssignmentWindow)
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Cohort{}, assignment.CohortID).Error; err != nil {
+			return err
+		}
+		if err := RequireInstructor(tx, assignment.CohortID, actor); err != nil {
+			return err
+		}
+		if err := tx.First(&models.ExamBlueprint{}, assignment.BlueprintID).Error; err != nil {
+			return fmt.Errorf("failed to get exam blueprint %d: %w", assignment.BlueprintID, err)
+		}
+
+		assignment.AssignedBy = actor
+		if err := tx.Omit("Cohort", "Blueprint").Create(assignment).Error; err != nil {
+			return fmt.Errorf("failed to create cohort assignment: %w", err)
+		}
+		return tx.First(&assignment.Blueprint, assignment.BlueprintID).Error
+	})
+}
+
+// DeleteAssignment withdraws an assignment. The actor of ctx must be an instructor of the cohort.
+// Sessions already started for it keep their blueprint.
+func (r *cohortService) DeleteAssignment(ctx context.Context, cohortID, assignmentID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var assignment models.CohortAssignment
+		if err := tx.Where("cohort_id = ?", cohortID).First(&assignment, assignmentID).Error; err != nil {
+			return err
+		}
+		if err := RequireInstructor(tx, cohortID, audit.ActorFromContext(ctx)); err != nil {
+			return err
+		}
+		return tx.Delete(&assignment).Error
+	})
+}
+
+// RequireInstructor returns ErrNotCohortInstructor unless userID is an instructor of the cohort
+func RequireInstructor(tx *gorm.DB, cohortID uint, userID string) error {
+	var count int64
+	err := tx.Model(&models.CohortMember{}).
+		Where("cohort_id = ? AND user_id = ? AND role = ?", cohortID, userID, models.CohortRoleInstructor).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check cohort instructor: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrNotCohortInstructor, userID)
+	}
+	return nil
+}
+
+// requireManager lets anyone manage the members of a cohort without instructors, so the first
+// ones can be added, and otherwise only its instructors
+func requireManager(tx *gorm.DB, cohortID uint, userID string) error {
+	var instructors int64
+	err := tx.Model(&models.CohortMember{}).
+		Where("cohort_id = ? AND role = ?", cohortID, models.CohortRoleInstructor).
+		Count(&instructors).Error
+	if err != nil {
+		return fmt.Errorf("failed to count cohort instructors: %w", err)
+	}
+	if instructors == 0 {
+		return nil
+	}
+	return RequireInstructor(tx, cohortID, userID)
+}
+
+// FindOpenAssignment returns the assignment whose window contains at in a cohort the user is a
+// member of, preferring the most recently opened window. It returns nil when there is none.
+func FindOpenAssignment(tx *gorm.DB, userID string, at time.Time) (*models.CohortAssignment, error) {
+	var assignments []models.CohortAssignment
+	err := tx.
+		Joins("JOIN cohort_members cm ON cm.cohort_id = cohort_assignments.cohort_id AND cm.deleted_at IS NULL").
+		Where("cm.user_id = ? AND cm.role = ?", userID, models.CohortRoleMember).
+		Where("cohort_assignments.available_from <= ?", at).
+		Where("cohort_assignments.available_until IS NULL OR cohort_assignments.available_until > ?", at).
+		Order("cohort_assignments.available_from DESC, cohort_assignments.id DESC").
+		Limit(1).
+		Find(&assignments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open cohort assignment: %w", err)
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	return &assignments[0], nil
+}
@@ -0,0 +1,173 @@
+package cohort_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	countInstructorsQuery = `SELECT count\(\*\) FROM "cohort_members" WHERE \(cohort_id = \$1 AND role = \$2\)`
+	countInstructorQuery  = `SELECT count\(\*\) FROM "cohort_members" WHERE \(cohort_id = \$1 AND user_id = \$2 AND role = \$3\)`
+)
+
+func expectCohort(mock sqlmock.Sqlmock, cohortID uint) {
+	mock.ExpectQuery(`SELECT \* FROM "cohorts" WHERE "cohorts"."id" = \$1`).
+		WithArgs(cohortID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(cohortID, "A-1"))
+}
+
+func TestSetMembersRefusesActorsWhoAreNotInstructors(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	service := NewCohortService(db)
+	ctx := audit.WithClaimedActor(context.Background(), "student-1")
+
+	mock.ExpectBegin()
+	expectCohort(mock, 7)
+	mock.ExpectQuery(countInstructorsQuery).
+		WithArgs(uint(7), models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(countInstructorQuery).
+		WithArgs(uint(7), "student-1", models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	_, err := service.SetMembers(ctx, 7, []models.CohortMember{{UserID: "student-1", Role: models.CohortRoleInstructor}})
+	if !errors.Is(err, ErrNotCohortInstructor) {
+		t.Fatalf("expected ErrNotCohortInstructor, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetMembersLetsAnyoneAddTheFirstInstructor(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	service := NewCohortService(db)
+	ctx := audit.WithClaimedActor(context.Background(), "teacher-1")
+
+	mock.ExpectBegin()
+	expectCohort(mock, 7)
+	mock.ExpectQuery(countInstructorsQuery).
+		WithArgs(uint(7), models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`SELECT \* FROM "cohort_members" WHERE \(cohort_id = \$1 AND user_id = \$2\)`).
+		WithArgs(uint(7), "teacher-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`INSERT INTO "cohort_members"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+
+	members, err := service.SetMembers(ctx, 7, []models.CohortMember{{UserID: "teacher-1", Role: models.CohortRoleInstructor}})
+	if err != nil {
+		t.Fatalf("SetMembers: %v", err)
+	}
+	if len(members) != 1 || members[0].ID != 3 || members[0].Role != models.CohortRoleInstructor {
+		t.Errorf("unexpected members %+v", members)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveMemberRefusesActorsWhoAreNotInstructors(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	service := NewCohortService(db)
+	ctx := audit.WithClaimedActor(context.Background(), "anonymous")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countInstructorsQuery).
+		WithArgs(uint(7), models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(countInstructorQuery).
+		WithArgs(uint(7), "anonymous", models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	if err := service.RemoveMember(ctx, 7, "teacher-1"); !errors.Is(err, ErrNotCohortInstructor) {
+		t.Fatalf("expected ErrNotCohortInstructor, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveMemberByInstructor(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	service := NewCohortService(db)
+	ctx := audit.WithClaimedActor(context.Background(), "teacher-1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(countInstructorsQuery).
+		WithArgs(uint(7), models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(countInstructorQuery).
+		WithArgs(uint(7), "teacher-1", models.CohortRoleInstructor).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "cohort_members" SET "deleted_at"=\$1 WHERE \(cohort_id = \$2 AND user_id = \$3\)`).
+		WithArgs(sqlmock.AnyArg(), uint(7), "student-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := service.RemoveMember(ctx, 7, "student-1"); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFindOpenAssignment(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	// Windows are selected by the database: it must contain at, with the latest opened first
+	query := `SELECT "cohort_assignments"."id",.* FROM "cohort_assignments" ` +
+		`JOIN cohort_members cm ON cm.cohort_id = cohort_assignments.cohort_id AND cm.deleted_at IS NULL ` +
+		`WHERE \(cm.user_id = \$1 AND cm.role = \$2\) ` +
+		`AND cohort_assignments.available_from <= \$3 ` +
+		`AND \(cohort_assignments.available_until IS NULL OR cohort_assignments.available_until > \$4\) ` +
+		`AND "cohort_assignments"."deleted_at" IS NULL ` +
+		`ORDER BY cohort_assignments.available_from DESC, cohort_assignments.id DESC LIMIT \$5`
+
+	t.Run("open window", func(t *testing.T) {
+		db, mock := sqlmocktest.NewDB(t)
+		mock.ExpectQuery(query).
+			WithArgs("student-1", models.CohortRoleMember, at, at, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cohort_id", "blueprint_id", "available_from"}).
+				AddRow(4, 7, 2, at.Add(-time.Hour)))
+
+		assignment, err := FindOpenAssignment(db, "student-1", at)
+		if err != nil {
+			t.Fatalf("FindOpenAssignment: %v", err)
+		}
+		if assignment == nil || assignment.ID != 4 || assignment.BlueprintID != 2 {
+			t.Errorf("unexpected assignment %+v", assignment)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("no open window", func(t *testing.T) {
+		db, mock := sqlmocktest.NewDB(t)
+		mock.ExpectQuery(query).
+			WithArgs("student-1", models.CohortRoleMember, at, at, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		assignment, err := FindOpenAssignment(db, "student-1", at)
+		if err != nil {
+			t.Fatalf("FindOpenAssignment: %v", err)
+		}
+		if assignment != nil {
+			t.Errorf("expected no assignment, got %+v", assignment)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
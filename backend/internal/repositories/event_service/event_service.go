@@ -0,0 +1,97 @@
+package event_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrEventNotFailed is returned when retrying an event that has not failed
+var ErrEventNotFailed = errors.New("domain event has not failed")
+
+// DomainEventFilter narrows down domain event queries, zero values are ignored
+type DomainEventFilter struct {
+	EventType     string
+	AggregateType string
+	AggregateID   string
+	Status        string
+}
+
+type EventService interface {
+	GetEvents(ctx context.Context, filter DomainEventFilter, offset, limit int) ([]models.DomainEvent, error)
+	CountEvents(ctx context.Context, filter DomainEventFilter) (int64, error)
+	RetryEvent(ctx context.Context, eventID uint) (*models.DomainEvent, error)
+}
+
+type eventService struct {
+	db *gorm.DB
+}
+
+func NewEventService(db *gorm.DB) EventService {
+	return &eventService{
+		db: db,
+	}
+}
+
+// applyEventFilters applies the domain event filter to a query
+func applyEventFilters(query *gorm.DB, filter DomainEventFilter) *gorm.DB {
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.AggregateType != "" {
+		query = query.Where("aggregate_type = ?", filter.AggregateType)
+	}
+	if filter.AggregateID != "" {
+		query = query.Where("aggregate_id = ?", filter.AggregateID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
+
+// GetEvents returns domain events matching the filter with their receipts, newest first
+func (r *eventService) GetEvents(ctx context.Context, filter DomainEventFilter, offset, limit int) ([]models.DomainEvent, error) {
+	var events []models.DomainEvent
+	query := applyEventFilters(r.db.WithContext(ctx), filter).Preload("Receipts")
+
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+
+	err := query.Order("id DESC").Find(&events).Error
+	return events, err
+}
+
+func (r *eventService) CountEvents(ctx context.Context, filter DomainEventFilter) (int64, error) {
+	var count int64
+	err := applyEventFilters(r.db.WithContext(ctx).Model(&models.DomainEvent{}), filter).Count(&count).Error
+	return count, err
+}
+
+// RetryEvent dispatches a failed event again with a fresh set of attempts. Subscribers that
+// already handled it are skipped.
+func (r *eventService) RetryEvent(ctx context.Context, eventID uint) (*models.DomainEvent, error) {
+	var event models.DomainEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Receipts").First(&event, eventID).Error; err != nil {
+			return err
+		}
+		if event.Status != models.DomainEventFailed {
+			return fmt.Errorf("%w: event %s is %s", ErrEventNotFailed, event.EventID, event.Status)
+		}
+
+		event.Status = models.DomainEventPending
+		event.Attempts = 0
+		event.NextAttemptAt = time.Now()
+		return tx.Omit("Receipts").Save(&event).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -0,0 +1,77 @@
+package exam_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"testing"
+	"time"
+)
+
+func TestApplyAccommodation(t *testing.T) {
+	endsAt := time.Date(2026, 2, 1, 14, 0, 0, 0, time.UTC)
+	extended := &models.Accommodation{TimeMultiplier: 1.5}
+
+	tests := []struct {
+		name          string
+		session       models.ExamSession
+		accommodation *models.Accommodation
+		examMinutes   int
+		wantExtra     int
+	}{
+		{
+			// An 08:00-14:00 sitting running a 100 minute exam: half of the exam, not of the window
+			name:          "sitting session",
+			session:       models.ExamSession{Duration: 360, ExpiresAt: endsAt},
+			accommodation: extended,
+			examMinutes:   100,
+			wantExtra:     50,
+		},
+		{
+			name:          "standalone session",
+			session:       models.ExamSession{Duration: 130, ExpiresAt: endsAt},
+			accommodation: extended,
+			examMinutes:   130,
+			wantExtra:     65,
+		},
+		{
+			name:          "applied again when started",
+			session:       models.ExamSession{Duration: 410, ExpiresAt: endsAt.Add(50 * time.Minute), AccommodationMinutes: 50},
+			accommodation: extended,
+			examMinutes:   100,
+			wantExtra:     50,
+		},
+		{
+			name:          "changed since the session was created",
+			session:       models.ExamSession{Duration: 410, ExpiresAt: endsAt.Add(50 * time.Minute), AccommodationMinutes: 50},
+			accommodation: &models.Accommodation{TimeMultiplier: 1.2, ExtraBreaks: 1, BreakMinutes: 10},
+			examMinutes:   100,
+			wantExtra:     30,
+		},
+		{
+			name:          "removed since the session was created",
+			session:       models.ExamSession{Duration: 410, ExpiresAt: endsAt.Add(50 * time.Minute), AccommodationMinutes: 50},
+			accommodation: nil,
+			examMinutes:   100,
+			wantExtra:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := tt.session
+			baseDuration := session.Duration - session.AccommodationMinutes
+			baseDeadline := session.ExpiresAt.Add(-time.Duration(session.AccommodationMinutes) * time.Minute)
+
+			applyAccommodation(&session, tt.accommodation, tt.examMinutes)
+
+			if session.AccommodationMinutes != tt.wantExtra {
+				t.Errorf("AccommodationMinutes = %d, want %d", session.AccommodationMinutes, tt.wantExtra)
+			}
+			if want := baseDuration + tt.wantExtra; session.Duration != want {
+				t.Errorf("Duration = %d, want %d", session.Duration, want)
+			}
+			if want := baseDeadline.Add(time.Duration(tt.wantExtra) * time.Minute); !session.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %s, want %s", session.ExpiresAt, want)
+			}
+		})
+	}
+}
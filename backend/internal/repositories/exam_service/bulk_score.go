@@ -0,0 +1,165 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrBulkScoreRejected is returned when at least one row of a bulk score edit is invalid.
+// Nothing is applied and the report lists the failing rows.
+var ErrBulkScoreRejected = errors.New("bulk score edit rejected")
+
+// Bulk score row statuses
+const (
+	BulkScoreStatusUpdated   = "UPDATED"
+	BulkScoreStatusUnchanged = "UNCHANGED"
+	BulkScoreStatusInvalid   = "INVALID"
+)
+
+// OptionScoreChange is one row of a bulk score edit
+type OptionScoreChange struct {
+	Row        int // 1-based position in the request or data line number in the CSV
+	QuestionID uint
+	OptionID   uint
+	Score      int
+}
+
+// BulkUpdateOptionScores validates every change against the scoring scheme of its
+// question category and applies them all or none in one transaction, followed by a
+// single re-score of the sessions that drew the changed questions.
+// With dryRun the report previews the result and nothing is saved.
+func (s *ExamService) BulkUpdateOptionScores(ctx context.Context, changes []OptionScoreChange, dryRun bool) (*dto.BulkScoreReport, error) {
+	report := &dto.BulkScoreReport{
+		DryRun:    dryRun,
+		TotalRows: len(changes),
+		Rows:      make([]dto.BulkScoreRowResult, len(changes)),
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		options := make([]*models.QuestionOption, len(changes))
+		scorers := make(map[string]scoring.Scorer)
+		seen := make(map[uint]int) // option ID to the first row changing it
+
+		for i, change := range changes {
+			row := &report.Rows[i]
+			row.Row = change.Row
+			row.QuestionID = change.QuestionID
+			row.OptionID = change.OptionID
+			row.Score = change.Score
+
+			if first, ok := seen[change.OptionID]; ok {
+				row.Status = BulkScoreStatusInvalid
+				row.Error = fmt.Sprintf("option %d is already changed on row %d", change.OptionID, first)
+				continue
+			}
+			seen[change.OptionID] = change.Row
+
+			option, err := validateOptionScoreChange(tx, change, scorers)
+			if err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, scoring.ErrInvalidScore) {
+					return err
+				}
+				row.Status = BulkScoreStatusInvalid
+				row.Error = err.Error()
+				continue
+			}
+
+			previous := option.Score
+			row.PreviousScore = &previous
+			options[i] = option
+		}
+
+		for _, row := range report.Rows {
+			if row.Status == BulkScoreStatusInvalid {
+				report.FailedRows++
+			}
+		}
+		if report.FailedRows > 0 {
+			return ErrBulkScoreRejected
+		}
+
+		var changedQuestions []uint
+		questionSeen := make(map[uint]bool)
+
+		for i, option := range options {
+			row := &report.Rows[i]
+			if option.Score == row.Score {
+				row.Status = BulkScoreStatusUnchanged
+				report.UnchangedRows++
+				continue
+			}
+
+			if err := tx.Model(option).Update("score", row.Score).Error; err != nil {
+				return fmt.Errorf("failed to update score of option %d: %w", option.ID, err)
+			}
+			row.Status = BulkScoreStatusUpdated
+			report.UpdatedRows++
+
+			if !questionSeen[option.QuestionID] {
+				questionSeen[option.QuestionID] = true
+				changedQuestions = append(changedQuestions, option.QuestionID)
+			}
+		}
+
+		report.Rescore = newRescoreReport(dryRun, changedQuestions...)
+		if err := rescoreQuestions(tx, changedQuestions, report.Rescore); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		report.Applied = true
+	case errors.Is(err, errDryRun):
+	case errors.Is(err, ErrBulkScoreRejected):
+		return report, err
+	default:
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// validateOptionScoreChange loads the option of a change and checks the new score
+// against the scorer of its question category, caching scorers by category
+func validateOptionScoreChange(tx *gorm.DB, change OptionScoreChange, scorers map[string]scoring.Scorer) (*models.QuestionOption, error) {
+	var option models.QuestionOption
+	if err := tx.Where("id = ? AND question_id = ?", change.OptionID, change.QuestionID).First(&option).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("option %d of question %d: %w", change.OptionID, change.QuestionID, err)
+		}
+		return nil, err
+	}
+
+	var question models.Question
+	if err := tx.First(&question, change.QuestionID).Error; err != nil {
+		return nil, err
+	}
+
+	scorer, ok := scorers[question.Category]
+	if !ok {
+		var err error
+		scorer, err = categoryScorer(tx, question.Category)
+		if err != nil {
+			return nil, err
+		}
+		scorers[question.Category] = scorer
+	}
+
+	if err := scorer.ValidateOptionScore(change.Score); err != nil {
+		return nil, err
+	}
+
+	return &option, nil
+}
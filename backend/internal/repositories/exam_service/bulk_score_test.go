@@ -0,0 +1,118 @@
+package exam_service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// expectOption expects an option of question 6 to be loaded, with no rows when score is nil
+func expectOption(mock sqlmock.Sqlmock, optionID uint, score *int) {
+	rows := sqlmock.NewRows([]string{"id", "question_id", "score"})
+	if score != nil {
+		rows.AddRow(optionID, 6, *score)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_options" WHERE (id = $1 AND question_id = $2)`)).
+		WithArgs(optionID, 6, 1).
+		WillReturnRows(rows)
+}
+
+func expectQuestion6(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "questions" WHERE "questions"."id" = $1`)).
+		WithArgs(6, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(6, "TEKNIS"))
+}
+
+func TestBulkUpdateOptionScoresRejectsEveryRowWhenOneIsInvalid(t *testing.T) {
+	service, mock := newMockExamService(t)
+	score := 4
+
+	mock.ExpectBegin()
+	expectOptionScoreChange(mock)
+	// Out of the 1-4 range of the TEKNIS scheme, whose scorer is cached by now
+	expectOption(mock, 13, &score)
+	expectQuestion6(mock)
+	// Option 11 again is refused without a query, unknown option 99 by the lookup
+	expectOption(mock, 99, nil)
+	mock.ExpectRollback()
+
+	report, err := service.BulkUpdateOptionScores(context.Background(), []OptionScoreChange{
+		{Row: 1, QuestionID: 5, OptionID: 11, Score: 3},
+		{Row: 2, QuestionID: 6, OptionID: 13, Score: 7},
+		{Row: 3, QuestionID: 5, OptionID: 11, Score: 2},
+		{Row: 4, QuestionID: 6, OptionID: 99, Score: 2},
+	}, false)
+	if !errors.Is(err, ErrBulkScoreRejected) {
+		t.Fatalf("BulkUpdateOptionScores() error = %v, want %v", err, ErrBulkScoreRejected)
+	}
+	if report.Applied || report.FailedRows != 3 || report.UpdatedRows != 0 || report.Rescore != nil {
+		t.Errorf("report = applied %v, %d failed, %d updated, rescore %v, want nothing applied and 3 failed rows",
+			report.Applied, report.FailedRows, report.UpdatedRows, report.Rescore)
+	}
+	if row := report.Rows[0]; row.Status == BulkScoreStatusInvalid || row.PreviousScore == nil || *row.PreviousScore != 1 {
+		t.Errorf("row 1 = %+v, want a valid row with previous score 1", row)
+	}
+	for _, row := range report.Rows[1:] {
+		if row.Status != BulkScoreStatusInvalid || row.Error == "" {
+			t.Errorf("row %d = %+v, want an invalid row with its error", row.Row, row)
+		}
+	}
+	if !strings.Contains(report.Rows[2].Error, "already changed on row 1") {
+		t.Errorf("row 3 error = %q, want the row changing the same option first", report.Rows[2].Error)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBulkUpdateOptionScores(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		service, mock := newMockExamService(t)
+		score := 4
+
+		mock.ExpectBegin()
+		expectOptionScoreChange(mock)
+		expectOption(mock, 13, &score)
+		expectQuestion6(mock)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "question_options" SET "score"=$1,"updated_at"=$2 WHERE "question_options"."deleted_at" IS NULL AND "id" = $3`)).
+			WithArgs(3, sqlmock.AnyArg(), 11).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectRescoreSession(mock)
+		if dryRun {
+			mock.ExpectRollback()
+		} else {
+			mock.ExpectCommit()
+		}
+
+		report, err := service.BulkUpdateOptionScores(context.Background(), []OptionScoreChange{
+			{Row: 1, QuestionID: 5, OptionID: 11, Score: 3},
+			{Row: 2, QuestionID: 6, OptionID: 13, Score: 4},
+		}, dryRun)
+		if err != nil {
+			t.Fatalf("dry run %v: BulkUpdateOptionScores() error = %v", dryRun, err)
+		}
+		if report.Applied == dryRun || report.DryRun != dryRun {
+			t.Errorf("dry run %v: report applied = %v", dryRun, report.Applied)
+		}
+		if report.UpdatedRows != 1 || report.UnchangedRows != 1 || report.FailedRows != 0 {
+			t.Errorf("dry run %v: %d updated, %d unchanged, %d failed rows, want 1, 1 and 0",
+				dryRun, report.UpdatedRows, report.UnchangedRows, report.FailedRows)
+		}
+		if report.Rows[0].Status != BulkScoreStatusUpdated || report.Rows[1].Status != BulkScoreStatusUnchanged {
+			t.Errorf("dry run %v: row statuses = %s, %s", dryRun, report.Rows[0].Status, report.Rows[1].Status)
+		}
+		// Question 5 is re-scored once, question 6 kept its scores
+		if rescore := report.Rescore; len(rescore.QuestionIDs) != 1 || rescore.SessionsRescored != 1 || rescore.Sessions[0].Score != 7 {
+			t.Errorf("dry run %v: rescore = %+v, want session 9 of question 5 at 7 points", dryRun, rescore)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("dry run %v: %v", dryRun, err)
+		}
+	}
+}
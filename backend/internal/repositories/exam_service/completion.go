@@ -0,0 +1,148 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxIdempotencyKeyLength is the size of the completion_key column
+const maxIdempotencyKeyLength = 255
+
+var (
+	// ErrInvalidIdempotencyKey is returned for keys that are too long or contain control characters
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	// ErrIdempotencyKeyMismatch is returned when a key was used for another completion than the one requested
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key does not match the completion")
+)
+
+// Completion is the outcome of completing an exam session. Replayed tells that the session
+// was completed by an earlier request and Summary is the one stored then.
+type Completion struct {
+	Session  models.ExamSession
+	Summary  models.ExamSummary
+	Replayed bool
+}
+
+// GetSessionToComplete finds the session a completion request of the user is meant for: the
+// session completed with the idempotency key when there is one, else the latest session of
+// the user, so a repeated request finds the session it completed before.
+func (s *ExamService) GetSessionToComplete(ctx context.Context, userID, idempotencyKey string) (*models.ExamSession, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	// Sessions past their deadline must be EXPIRED before deciding whether they can be completed
+	s.CheckAndUpdateExpiredSessions(ctx)
+
+	var examSession models.ExamSession
+	if idempotencyKey != "" {
+		err := s.db.WithContext(ctx).
+			Where("user_id = ? AND completion_key = ?", userID, idempotencyKey).
+			First(&examSession).Error
+		if err == nil {
+			return &examSession, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get exam session by idempotency key: %w", err)
+		}
+	}
+
+	return s.latestExamSession(ctx, userID)
+}
+
+// CompleteExam completes an exam session in progress and calculates its results. Completing a
+// session again returns its stored summary instead of scoring it twice; concurrent requests
+// are serialised on the session row. Sessions that were never started, have expired or were
+// voided cannot be completed. A non-empty idempotency key is stored with the session; it may
+// not have completed another session of the user, and replaying a completion with a key
+// other than the stored one is rejected.
+func (s *ExamService) CompleteExam(ctx context.Context, examSessionID uint, idempotencyKey string) (*Completion, error) {
+	if err := validateIdempotencyKey(idempotencyKey); err != nil {
+		return nil, err
+	}
+
+	completion := &Completion{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		examSession, err := lockSession(tx, examSessionID)
+		if err != nil {
+			return err
+		}
+
+		if examSession.Status == models.SessionCompleted {
+			if idempotencyKey != "" && (examSession.CompletionKey == nil || *examSession.CompletionKey != idempotencyKey) {
+				return fmt.Errorf("%w: session %d was completed with another key", ErrIdempotencyKeyMismatch, examSession.ID)
+			}
+			if err := tx.Where("exam_session_id = ?", examSession.ID).First(&completion.Summary).Error; err != nil {
+				return fmt.Errorf("failed to get exam summary of session %d: %w", examSession.ID, err)
+			}
+			completion.Session = *examSession
+			completion.Replayed = true
+			return nil
+		}
+		if err := requireInProgress(examSession); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if now.After(examSession.ExpiresAt) {
+			// Left to the expiry check to mark EXPIRED, with its event
+			return fmt.Errorf("%w: session %d expired at %s", ErrSessionExpired, examSession.ID, examSession.ExpiresAt.Format(time.RFC3339))
+		}
+
+		if idempotencyKey != "" {
+			var used int64
+			if err := tx.Model(&models.ExamSession{}).
+				Where("user_id = ? AND completion_key = ? AND id <> ?", examSession.UserID, idempotencyKey, examSession.ID).
+				Count(&used).Error; err != nil {
+				return fmt.Errorf("failed to check idempotency key of session %d: %w", examSession.ID, err)
+			}
+			if used > 0 {
+				return fmt.Errorf("%w: key already completed another session of user %s", ErrIdempotencyKeyMismatch, examSession.UserID)
+			}
+			if err := tx.Model(examSession).Update("completion_key", idempotencyKey).Error; err != nil {
+				return fmt.Errorf("failed to store idempotency key of session %d: %w", examSession.ID, err)
+			}
+		}
+
+		results, err := completeSession(tx, examSession, now, models.TransitionComplete)
+		if err != nil {
+			return err
+		}
+
+		completion.Session = *examSession
+		completion.Summary = results.Summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// lockSession gets a session and locks its row until the transaction ends
+func lockSession(tx *gorm.DB, examSessionID uint) (*models.ExamSession, error) {
+	var examSession models.ExamSession
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&examSession, examSessionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam session: %w", err)
+	}
+	return &examSession, nil
+}
+
+// validateIdempotencyKey checks that a key fits the completion_key column and is printable
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return fmt.Errorf("%w: longer than %d characters", ErrInvalidIdempotencyKey, maxIdempotencyKeyLength)
+	}
+	if strings.IndexFunc(key, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%w: contains control characters", ErrInvalidIdempotencyKey)
+	}
+	return nil
+}
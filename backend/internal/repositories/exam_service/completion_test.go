@@ -0,0 +1,135 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// newMockExamService returns an exam service backed by a mocked postgres connection
+func newMockExamService(t *testing.T) (*ExamService, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := sqlmocktest.NewDB(t)
+	return NewExamService(db), mock
+}
+
+// sessionRows returns the row of an exam session as selected by GORM
+func sessionRows(examSession models.ExamSession) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "user_id", "session_code", "status", "expires_at", "completion_key"}).
+		AddRow(examSession.ID, examSession.UserID, examSession.SessionCode, examSession.Status, examSession.ExpiresAt, examSession.CompletionKey)
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "empty", key: ""},
+		{name: "uuid", key: "3f1c2a9e-8b7d-4c5e-9f3a-2b1c0d9e8f7a"},
+		{name: "unicode", key: "selesai-ujian-ü"},
+		{name: "longest", key: strings.Repeat("k", maxIdempotencyKeyLength)},
+		{name: "too long", key: strings.Repeat("k", maxIdempotencyKeyLength+1), wantErr: true},
+		{name: "newline", key: "key\nInjected: true", wantErr: true},
+		{name: "nul", key: "key\x00", wantErr: true},
+		{name: "tab", key: "key\tvalue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIdempotencyKey(tt.key)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("validateIdempotencyKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidIdempotencyKey) {
+				t.Fatalf("validateIdempotencyKey() error = %v, want ErrInvalidIdempotencyKey", err)
+			}
+		})
+	}
+}
+
+func TestCompleteExamRejectsKeyMismatch(t *testing.T) {
+	storedKey := "first-key"
+
+	tests := []struct {
+		name    string
+		session models.ExamSession
+		key     string
+		usedBy  int64 // other sessions of the user completed with the key, -1 when not checked
+	}{
+		{
+			name:    "replay with another key",
+			session: models.ExamSession{ID: 7, UserID: "1234", Status: models.SessionCompleted, CompletionKey: &storedKey},
+			key:     "second-key",
+			usedBy:  -1,
+		},
+		{
+			name:    "replay with a key of a session completed without one",
+			session: models.ExamSession{ID: 7, UserID: "1234", Status: models.SessionCompleted},
+			key:     "second-key",
+			usedBy:  -1,
+		},
+		{
+			name:    "key of another session",
+			session: models.ExamSession{ID: 8, UserID: "1234", Status: models.SessionInProgress, ExpiresAt: time.Now().Add(time.Hour)},
+			key:     storedKey,
+			usedBy:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions"`) + `.*FOR UPDATE`).
+				WillReturnRows(sessionRows(tt.session))
+			if tt.usedBy >= 0 {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "exam_sessions" WHERE (user_id = $1 AND completion_key = $2 AND id <> $3)`)).
+					WithArgs(tt.session.UserID, tt.key, tt.session.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.usedBy))
+			}
+			mock.ExpectRollback()
+
+			_, err := service.CompleteExam(context.Background(), tt.session.ID, tt.key)
+			if !errors.Is(err, ErrIdempotencyKeyMismatch) {
+				t.Fatalf("CompleteExam() error = %v, want ErrIdempotencyKeyMismatch", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCompleteExamReplaysWithStoredKey(t *testing.T) {
+	storedKey := "first-key"
+	service, mock := newMockExamService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions"`) + `.*FOR UPDATE`).
+		WillReturnRows(sessionRows(models.ExamSession{ID: 7, UserID: "1234", Status: models.SessionCompleted, CompletionKey: &storedKey}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_summaries" WHERE exam_session_id = $1`)).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "total_score"}).AddRow(3, 7, 410))
+	mock.ExpectCommit()
+
+	completion, err := service.CompleteExam(context.Background(), 7, storedKey)
+	if err != nil {
+		t.Fatalf("CompleteExam() error = %v", err)
+	}
+	if !completion.Replayed || completion.Summary.TotalScore != 410 {
+		t.Fatalf("CompleteExam() = replayed %v, total score %d, want the stored summary replayed", completion.Replayed, completion.Summary.TotalScore)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,81 @@
+package exam_service
+
+import (
+	"cutbray/pppk-json/internal/events"
+	"cutbray/pppk-json/internal/repositories/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sessionEvent is the payload of every exam session domain event
+type sessionEvent struct {
+	SessionID   uint                 `json:"session_id"`
+	SessionCode string               `json:"session_code"`
+	UserID      string               `json:"user_id"`
+	Status      models.SessionStatus `json:"status"`
+	BlueprintID *uint                `json:"blueprint_id"`
+	SittingID   *uint                `json:"sitting_id"`
+	StartedAt   *time.Time           `json:"started_at"`
+	CompletedAt *time.Time           `json:"completed_at"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+}
+
+// answerEvent is the payload of the AnswerSubmitted event
+type answerEvent struct {
+	sessionEvent
+	ExamQuestionID   uint      `json:"exam_question_id"`
+	QuestionID       uint      `json:"question_id"`
+	QuestionOptionID uint      `json:"question_option_id"`
+	AnsweredAt       time.Time `json:"answered_at"`
+}
+
+// completedEvent is the payload of the ExamCompleted event
+type completedEvent struct {
+	sessionEvent
+	TotalQuestions    int     `json:"total_questions"`
+	TotalAnswered     int     `json:"total_answered"`
+	TotalScore        int     `json:"total_score"`
+	MaxScore          int     `json:"max_score"`
+	OverallPercentage float64 `json:"overall_percentage"`
+	OverallGrade      string  `json:"overall_grade"`
+	IsPassed          bool    `json:"is_passed"`
+}
+
+func newSessionEvent(examSession *models.ExamSession) sessionEvent {
+	return sessionEvent{
+		SessionID:   examSession.ID,
+		SessionCode: examSession.SessionCode,
+		UserID:      examSession.UserID,
+		Status:      examSession.Status,
+		BlueprintID: examSession.BlueprintID,
+		SittingID:   examSession.SittingID,
+		StartedAt:   examSession.StartedAt,
+		CompletedAt: examSession.CompletedAt,
+		ExpiresAt:   examSession.ExpiresAt,
+	}
+}
+
+func newCompletedEvent(examSession *models.ExamSession, summary *models.ExamSummary) completedEvent {
+	return completedEvent{
+		sessionEvent:      newSessionEvent(examSession),
+		TotalQuestions:    summary.TotalQuestions,
+		TotalAnswered:     summary.TotalAnswered,
+		TotalScore:        summary.TotalScore,
+		MaxScore:          summary.MaxScore,
+		OverallPercentage: summary.OverallPercentage,
+		OverallGrade:      summary.OverallGrade,
+		IsPassed:          summary.IsPassed,
+	}
+}
+
+// publishSessionEvent publishes an exam session event in the transaction of the change
+func publishSessionEvent(tx *gorm.DB, eventType string, examSessionID uint, data interface{}) error {
+	return events.Publish(tx, events.Event{
+		Type:          eventType,
+		AggregateType: models.AggregateExamSession,
+		AggregateID:   strconv.FormatUint(uint64(examSessionID), 10),
+		Data:          data,
+	})
+}
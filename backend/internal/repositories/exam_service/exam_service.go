@@ -3,11 +3,22 @@ package exam_service
 import (
 	"context"
 	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/accommodation_service"
+	"cutbray/pppk-json/internal/repositories/category_service"
+	"cutbray/pppk-json/internal/repositories/cohort_service"
+	"cutbray/pppk-json/internal/repositories/grading_service"
 	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/repositories/sitting_service"
+	"cutbray/pppk-json/internal/scoring"
+	"cutbray/pppk-json/internal/utils"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ExamService struct {
@@ -25,41 +36,81 @@ func (s *ExamService) CreateExamSession(ctx context.Context, userID string) (*mo
 	examSession := &models.ExamSession{
 		UserID:      userID,
 		SessionCode: sessionCode,
-		Status:      "NOT_STARTED",
-		ExpiresAt:   time.Now().Add(130 * time.Minute), // 130 minutes from now
-		Duration:    130,                               // 130 minutes
+		Status:      models.SessionNotStarted,
 	}
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// The blueprint of a booked sitting, an open cohort assignment, or else the default
+		// blueprint, decides the duration and later the grading of the session
+		assignment, err := cohort_service.FindOpenAssignment(tx, userID, now)
+		if err != nil {
+			return err
+		}
+
+		sitting, err := sitting_service.FindBookedSitting(tx, userID, now)
+		if err != nil {
+			return err
+		}
+
+		var blueprintID *uint
+		if assignment != nil {
+			blueprintID = &assignment.BlueprintID
+			examSession.AssignmentID = &assignment.ID
+		}
+		if sitting != nil && sitting.BlueprintID != nil {
+			blueprintID = sitting.BlueprintID
+		}
+
+		blueprint, err := grading_service.LoadBlueprint(tx, blueprintID)
+		if err != nil {
+			return err
+		}
+
+		examSession.BlueprintID = &blueprint.ID
+		examSession.Duration = blueprint.DurationMinutes
+		examSession.ExpiresAt = now.Add(time.Duration(blueprint.DurationMinutes) * time.Minute)
+
+		// Every session of a sitting shares its hard deadline, whenever it is started
+		if sitting != nil {
+			examSession.SittingID = &sitting.ID
+			examSession.Duration = int(sitting.EndsAt.Sub(sitting.OpensAt).Minutes())
+			examSession.ExpiresAt = sitting.EndsAt
+		}
+
+		// An accommodation extends the deadline, also past the end of a sitting
+		accommodation, err := accommodation_service.LoadAccommodation(tx, userID)
+		if err != nil {
+			return err
+		}
+		applyAccommodation(examSession, accommodation, blueprint.DurationMinutes)
+
 		// Create exam session
 		if err := tx.Create(examSession).Error; err != nil {
 			return fmt.Errorf("failed to create exam session: %w", err)
 		}
+		examSession.Accommodation = accommodation
+
+		// Assign random questions for each category with specific counts in display order
+		categories, err := category_service.GetActiveCategories(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get categories: %w", err)
+		}
 
-		// Assign random questions for each category with specific counts in order
-		categoryOrder := []string{"TEKNIS", "MANAJERIAL", "SOSIAL KULTURAL", "WAWANCARA"}
-		categoryQuestions := map[string]int{
-			"TEKNIS":          90,
-			"MANAJERIAL":      25,
-			"SOSIAL KULTURAL": 20,
-			"WAWANCARA":       10,
+		if len(categories) == 0 {
+			return fmt.Errorf("no categories configured for exam generation")
 		}
-		orderNumber := 1
 
-		for _, category := range categoryOrder {
-			questionCount := categoryQuestions[category]
-			// Get random questions from this category
-			var questions []models.Question
-			if err := tx.Where("category = ?", category).
-				Order("RANDOM()").
-				Limit(questionCount).
-				Find(&questions).Error; err != nil {
-				return fmt.Errorf("failed to get random questions for category %s: %w", category, err)
-			}
+		orderNumber := 1
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-			if len(questions) < questionCount {
-				return fmt.Errorf("not enough questions in category %s: need %d, got %d",
-					category, questionCount, len(questions))
+		for _, cat := range categories {
+			category := cat.Code
+			// Get random questions from this category, honouring tag quotas
+			questions, err := pickCategoryQuestions(tx, category, cat.QuestionCount, rng)
+			if err != nil {
+				return err
 			}
 
 			// Assign questions to exam session
@@ -79,7 +130,7 @@ func (s *ExamService) CreateExamSession(ctx context.Context, userID string) (*mo
 			}
 		}
 
-		return nil
+		return publishSessionEvent(tx, models.EventExamSessionCreated, examSession.ID, newSessionEvent(examSession))
 	})
 
 	if err != nil {
@@ -89,6 +140,138 @@ func (s *ExamService) CreateExamSession(ctx context.Context, userID string) (*mo
 	return examSession, nil
 }
 
+// pickCategoryQuestions selects random questions for a category. Tag quotas
+// configured for the category are filled first, the remainder is drawn from
+// the whole category, and the final selection is shuffled. The same rng seed
+// draws the same questions from an unchanged question bank.
+func pickCategoryQuestions(tx *gorm.DB, category string, questionCount int, rng *rand.Rand) ([]models.Question, error) {
+	var quotas []models.TagQuota
+	if err := tx.Preload("Tag").Where("category = ?", category).Order("id ASC").Find(&quotas).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tag quotas for category %s: %w", category, err)
+	}
+
+	var selected []models.Question
+	selectedIDs := []uint{}
+
+	for _, quota := range quotas {
+		query := tx.Where("category = ?", category).
+			Where("id IN (SELECT question_id FROM question_tags WHERE tag_id = ?)", quota.TagID)
+		if len(selectedIDs) > 0 {
+			query = query.Where("id NOT IN ?", selectedIDs)
+		}
+
+		tagged, err := drawQuestions(tx, query, quota.QuestionCount, rng)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get random questions for tag %s in category %s: %w", quota.Tag.Name, category, err)
+		}
+
+		if len(tagged) < quota.QuestionCount {
+			return nil, fmt.Errorf("not enough questions with tag %s in category %s: need %d, got %d",
+				quota.Tag.Name, category, quota.QuestionCount, len(tagged))
+		}
+
+		for _, question := range tagged {
+			selected = append(selected, question)
+			selectedIDs = append(selectedIDs, question.ID)
+		}
+	}
+
+	remaining := questionCount - len(selected)
+	if remaining < 0 {
+		return nil, fmt.Errorf("tag quotas for category %s exceed its question count: need %d, quotas reserve %d",
+			category, questionCount, len(selected))
+	}
+
+	if remaining > 0 {
+		query := tx.Where("category = ?", category)
+		if len(selectedIDs) > 0 {
+			query = query.Where("id NOT IN ?", selectedIDs)
+		}
+
+		rest, err := drawQuestions(tx, query, remaining, rng)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get random questions for category %s: %w", category, err)
+		}
+
+		if len(rest) < remaining {
+			return nil, fmt.Errorf("not enough questions in category %s: need %d, got %d",
+				category, questionCount, len(selected)+len(rest))
+		}
+
+		selected = append(selected, rest...)
+	}
+
+	// Shuffle so quota questions are not grouped at the start of the category
+	rng.Shuffle(len(selected), func(i, j int) {
+		selected[i], selected[j] = selected[j], selected[i]
+	})
+
+	return selected, nil
+}
+
+// drawQuestions draws up to limit random questions among those matched by query.
+// Candidates are ordered by ID before shuffling so the draw only depends on rng.
+func drawQuestions(tx *gorm.DB, query *gorm.DB, limit int, rng *rand.Rand) ([]models.Question, error) {
+	var ids []uint
+	if err := query.Model(&models.Question{}).Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	rng.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var questions []models.Question
+	if err := tx.Where("id IN ?", ids).Find(&questions).Error; err != nil {
+		return nil, err
+	}
+
+	// Keep the drawn order
+	byID := make(map[uint]models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	drawn := make([]models.Question, 0, len(ids))
+	for _, id := range ids {
+		if question, ok := byID[id]; ok {
+			drawn = append(drawn, question)
+		}
+	}
+	return drawn, nil
+}
+
+// applyAccommodation replaces the accommodation minutes in the duration and deadline of a
+// session with those of the given accommodation, which may be nil. The extra time is a share
+// of examMinutes, the length of the exam, not of the sitting window a deadline may come from.
+func applyAccommodation(examSession *models.ExamSession, accommodation *models.Accommodation, examMinutes int) {
+	extra := accommodation_service.ExtraMinutes(accommodation, examMinutes)
+	delta := extra - examSession.AccommodationMinutes
+
+	examSession.Duration += delta
+	examSession.ExpiresAt = examSession.ExpiresAt.Add(time.Duration(delta) * time.Minute)
+	examSession.AccommodationMinutes = extra
+}
+
+// sessionExamMinutes returns the length of the exam of a session: the duration of its blueprint,
+// or for sessions without one the duration without accommodation minutes
+func sessionExamMinutes(tx *gorm.DB, examSession *models.ExamSession) (int, error) {
+	if examSession.BlueprintID == nil {
+		return examSession.Duration - examSession.AccommodationMinutes, nil
+	}
+
+	var blueprint models.ExamBlueprint
+	if err := tx.Unscoped().Select("id", "duration_minutes").First(&blueprint, *examSession.BlueprintID).Error; err != nil {
+		return 0, fmt.Errorf("failed to get exam blueprint %d: %w", *examSession.BlueprintID, err)
+	}
+	return blueprint.DurationMinutes, nil
+}
+
 // GetExamSession retrieves an exam session with assigned questions
 func (s *ExamService) GetExamSession(ctx context.Context, userID string) (*models.ExamSession, error) {
 	var examSession models.ExamSession
@@ -102,7 +285,8 @@ func (s *ExamService) GetExamSession(ctx context.Context, userID string) (*model
 		}).
 		Preload("ExamQuestions.Question").
 		Preload("ExamQuestions.Question.Options").
-		Where("user_id = ? AND status IN (?)", userID, []string{"NOT_STARTED", "IN_PROGRESS"}).
+		Preload("Accommodation").
+		Where("user_id = ? AND status IN (?)", userID, models.ActiveSessionStatuses).
 		Order("created_at DESC").
 		First(&examSession).Error
 
@@ -113,65 +297,135 @@ func (s *ExamService) GetExamSession(ctx context.Context, userID string) (*model
 	return &examSession, nil
 }
 
-// StartExam starts the exam session
-func (s *ExamService) StartExam(ctx context.Context, sessionID uint) error {
-	now := time.Now()
-	return s.db.WithContext(ctx).
-		Model(&models.ExamSession{}).
-		Where("id = ?", sessionID).
-		Updates(map[string]interface{}{
-			"status":     "IN_PROGRESS",
-			"started_at": now,
-		}).Error
+// GetLatestExamSession gets the latest session of a user whatever its status, so requests
+// that the session status does not allow are refused as such instead of as not found
+func (s *ExamService) GetLatestExamSession(ctx context.Context, userID string) (*models.ExamSession, error) {
+	s.CheckAndUpdateExpiredSessions(ctx)
+	return s.latestExamSession(ctx, userID)
 }
 
-// SubmitAnswer submits an answer for a question
-func (s *ExamService) SubmitAnswer(ctx context.Context, examSessionID, examQuestionID, questionOptionID uint) error {
+// latestExamSession gets the latest session of a user whatever its status
+func (s *ExamService) latestExamSession(ctx context.Context, userID string) (*models.ExamSession, error) {
+	var examSession models.ExamSession
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		First(&examSession).Error; err != nil {
+		return nil, fmt.Errorf("exam session not found for user %s: %w", userID, err)
+	}
+	return &examSession, nil
+}
+
+// StartExam starts the exam session. A session of a sitting can only be started while the
+// sitting is open and with its access code, if it has one; its deadline stays the sitting end.
+// The accommodation of the user is applied again, so a change made after the session was
+// created still counts. Starting a session that is already in progress keeps its original
+// start time; completed, expired and voided sessions cannot be started again.
+func (s *ExamService) StartExam(ctx context.Context, sessionID uint, accessCode string) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// First, validate exam session status and expiry
-		var examSession models.ExamSession
-		if err := tx.First(&examSession, examSessionID).Error; err != nil {
-			return fmt.Errorf("exam session not found: %w", err)
+		examSession, err := lockSession(tx, sessionID)
+		if err != nil {
+			return err
+		}
+		if examSession.SittingID != nil {
+			var sitting models.Sitting
+			if err := tx.First(&sitting, *examSession.SittingID).Error; err == nil {
+				examSession.Sitting = &sitting
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to get sitting of session %d: %w", examSession.ID, err)
+			}
 		}
 
-		// Check if exam session is still in progress
-		if examSession.Status == "COMPLETED" {
-			return fmt.Errorf("exam has already been completed, cannot submit more answers")
+		switch examSession.Status {
+		case models.SessionInProgress:
+			return nil
+		case models.SessionNotStarted:
+		default:
+			return fmt.Errorf("%w: session %d cannot be started, it is %s", ErrIllegalTransition, examSession.ID, examSession.Status)
 		}
 
-		if examSession.Status == "EXPIRED" {
-			return fmt.Errorf("exam has expired, cannot submit answers")
+		now := time.Now()
+		if examSession.Sitting != nil {
+			if err := sitting_service.CheckStart(examSession.Sitting, now, accessCode); err != nil {
+				return err
+			}
 		}
 
-		if examSession.Status == "NOT_STARTED" {
-			return fmt.Errorf("exam has not been started yet")
+		accommodation, err := accommodation_service.LoadAccommodation(tx, examSession.UserID)
+		if err != nil {
+			return err
+		}
+		examMinutes, err := sessionExamMinutes(tx, examSession)
+		if err != nil {
+			return err
+		}
+		applyAccommodation(examSession, accommodation, examMinutes)
+
+		if err := transitionSession(tx, examSession, models.SessionInProgress, models.TransitionStart, map[string]interface{}{
+			"started_at":            now,
+			"duration":              examSession.Duration,
+			"expires_at":            examSession.ExpiresAt,
+			"accommodation_minutes": examSession.AccommodationMinutes,
+		}); err != nil {
+			return err
 		}
 
-		// Check if exam has expired
-		if time.Now().After(examSession.ExpiresAt) {
-			// Update exam session to expired
-			tx.Model(&examSession).Update("status", "EXPIRED")
-			return fmt.Errorf("exam has expired, cannot submit answers")
+		examSession.StartedAt = &now
+		return publishSessionEvent(tx, models.EventExamStarted, examSession.ID, newSessionEvent(examSession))
+	})
+}
+
+// SubmitAnswer submits an answer for a question of a session in progress. A session past its
+// deadline is marked EXPIRED and the answer rejected.
+func (s *ExamService) SubmitAnswer(ctx context.Context, examSessionID, examQuestionID, questionOptionID uint) error {
+	var expired *models.ExamSession
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the session so completion cannot score it while the answer is written,
+		// then validate its status and expiry
+		examSession, err := lockSession(tx, examSessionID)
+		if err != nil {
+			return err
+		}
+
+		if err := requireInProgress(examSession); err != nil {
+			return err
 		}
 
-		// Get the question option to get the score
-		var option models.QuestionOption
-		if err := tx.First(&option, questionOptionID).Error; err != nil {
-			return fmt.Errorf("question option not found: %w", err)
+		// Check if exam has expired, committing the expiry before rejecting the answer
+		if time.Now().After(examSession.ExpiresAt) {
+			if err := expireSession(tx, examSession); err != nil {
+				return err
+			}
+			expired = examSession
+			return nil
 		}
 
 		// Get the exam question and validate it belongs to this exam session
 		var examQuestion models.ExamQuestion
-		if err := tx.Where("id = ? AND exam_session_id = ?", examQuestionID, examSessionID).
+		if err := tx.Preload("Question.Options").
+			Where("id = ? AND exam_session_id = ?", examQuestionID, examSessionID).
 			First(&examQuestion).Error; err != nil {
 			return fmt.Errorf("exam question not found or doesn't belong to this exam session: %w", err)
 		}
 
+		// Get the question option and validate it belongs to the question
+		option, found := selectedOption(&models.UserAnswer{QuestionOptionID: questionOptionID}, examQuestion.Question.Options)
+		if !found {
+			return fmt.Errorf("question option not found for this question: %w", gorm.ErrRecordNotFound)
+		}
+
+		// Score the answer with the category scorer
+		score, err := scoreAnswer(tx, examQuestion.Category, option, examQuestion.Question.Options)
+		if err != nil {
+			return err
+		}
+
 		// Check if answer already exists
 		var existingAnswer models.UserAnswer
-		err := tx.Where("exam_session_id = ? AND exam_question_id = ?",
+		err = tx.Where("exam_session_id = ? AND exam_question_id = ?",
 			examSessionID, examQuestionID).First(&existingAnswer).Error
 
+		answeredAt := time.Now()
 		switch err {
 		case gorm.ErrRecordNotFound:
 			// Create new answer
@@ -180,8 +434,8 @@ func (s *ExamService) SubmitAnswer(ctx context.Context, examSessionID, examQuest
 				ExamQuestionID:   examQuestionID,
 				QuestionID:       examQuestion.QuestionID,
 				QuestionOptionID: questionOptionID,
-				Score:            option.Score,
-				AnsweredAt:       time.Now(),
+				Score:            score,
+				AnsweredAt:       answeredAt,
 			}
 
 			if err := tx.Create(&userAnswer).Error; err != nil {
@@ -190,8 +444,8 @@ func (s *ExamService) SubmitAnswer(ctx context.Context, examSessionID, examQuest
 		case nil:
 			// Update existing answer
 			existingAnswer.QuestionOptionID = questionOptionID
-			existingAnswer.Score = option.Score
-			existingAnswer.AnsweredAt = time.Now()
+			existingAnswer.Score = score
+			existingAnswer.AnsweredAt = answeredAt
 
 			if err := tx.Save(&existingAnswer).Error; err != nil {
 				return fmt.Errorf("failed to update user answer: %w", err)
@@ -200,144 +454,151 @@ func (s *ExamService) SubmitAnswer(ctx context.Context, examSessionID, examQuest
 			return fmt.Errorf("error checking existing answer: %w", err)
 		}
 
-		return nil
+		return publishSessionEvent(tx, models.EventAnswerSubmitted, examSessionID, answerEvent{
+			sessionEvent:     newSessionEvent(examSession),
+			ExamQuestionID:   examQuestionID,
+			QuestionID:       examQuestion.QuestionID,
+			QuestionOptionID: questionOptionID,
+			AnsweredAt:       answeredAt,
+		})
 	})
+	if err != nil {
+		return err
+	}
+	if expired != nil {
+		return requireInProgress(expired)
+	}
+	return nil
 }
 
-// CompleteExam completes the exam and calculates results
-func (s *ExamService) CompleteExam(ctx context.Context, examSessionID uint) error {
-	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		now := time.Now()
+// completeSession marks a locked session completed and stores its scored results. Loading the
+// session with lockSession makes a concurrent completion wait and then fail its transition
+// instead of scoring twice.
+func completeSession(tx *gorm.DB, examSession *models.ExamSession, now time.Time, trigger string) (*sessionResults, error) {
+	if err := transitionSession(tx, examSession, models.SessionCompleted, trigger, map[string]interface{}{
+		"completed_at": now,
+	}); err != nil {
+		return nil, err
+	}
+	examSession.CompletedAt = &now
 
-		// Update exam session status
-		if err := tx.Model(&models.ExamSession{}).
-			Where("id = ?", examSessionID).
-			Updates(map[string]interface{}{
-				"status":       "COMPLETED",
-				"completed_at": now,
-			}).Error; err != nil {
-			return fmt.Errorf("failed to update exam session: %w", err)
-		}
-
-		// Calculate results per category in order
-		categoryOrder := []string{"TEKNIS", "MANAJERIAL", "SOSIAL KULTURAL", "WAWANCARA"}
-		categoryMaxScores := map[string]int{
-			"TEKNIS":          450, // 90 questions → 450 max (official PPPK spec)
-			"MANAJERIAL":      100, // 25 questions → 100 max (from shared 200 total)
-			"SOSIAL KULTURAL": 100, // 20 questions → 100 max (from shared 200 total)
-			"WAWANCARA":       40,  // 10 questions → 40 max (official PPPK spec)
-		}
-
-		// Category passing thresholds - only Grade A (100%) and B (90%) pass
-		categoryThresholds := map[string]float64{
-			"TEKNIS":          90.0, // Minimum 90% (Grade B)
-			"MANAJERIAL":      90.0, // Minimum 90% (Grade B)
-			"SOSIAL KULTURAL": 90.0, // Minimum 90% (Grade B)
-			"WAWANCARA":       90.0, // Minimum 90% (Grade B)
-		}
-
-		categoryQuestionCounts := map[string]int{
-			"TEKNIS":          90,
-			"MANAJERIAL":      25,
-			"SOSIAL KULTURAL": 20,
-			"WAWANCARA":       10,
-		}
-		totalScore := 0
-		totalAnswered := 0
-
-		for _, category := range categoryOrder {
-			questionCount := categoryQuestionCounts[category]
-			var categoryStats struct {
-				TotalAnswered int
-				TotalScore    int
-			}
+	// Calculate results per category in display order
+	categories, err := category_service.GetActiveCategories(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
 
-			err := tx.Table("user_answers ua").
-				Select("COUNT(ua.id) as total_answered, COALESCE(SUM(ua.score), 0) as total_score").
-				Joins("JOIN exam_questions eq ON eq.id = ua.exam_question_id").
-				Where("ua.exam_session_id = ? AND eq.category = ?", examSessionID, category).
-				Scan(&categoryStats).Error
+	results, err := calculateSessionResults(tx, examSession, categories, now)
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				return fmt.Errorf("failed to calculate stats for category %s: %w", category, err)
-			}
+	if err := createSessionResults(tx, results); err != nil {
+		return nil, err
+	}
 
-			maxScore := categoryMaxScores[category]
-			threshold := categoryThresholds[category]
-			percentage := float64(categoryStats.TotalScore) / float64(maxScore) * 100.0
-			grade := calculateGrade(percentage)
-			// Pass if percentage meets minimum threshold
-			isPassed := percentage >= threshold
-
-			examResult := models.ExamResult{
-				ExamSessionID:  examSessionID,
-				Category:       category,
-				TotalQuestions: questionCount,
-				TotalAnswered:  categoryStats.TotalAnswered,
-				TotalScore:     categoryStats.TotalScore,
-				MaxScore:       maxScore,
-				Percentage:     percentage,
-				Grade:          grade,
-				IsPassed:       isPassed,
-			}
+	if err := publishSessionEvent(tx, models.EventExamCompleted, examSession.ID, newCompletedEvent(examSession, &results.Summary)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
 
-			if err := tx.Create(&examResult).Error; err != nil {
-				return fmt.Errorf("failed to create exam result for category %s: %w", category, err)
-			}
+// sessionResults holds the computed category results, summary and tag breakdown of an exam session
+type sessionResults struct {
+	CategoryResults []models.ExamResult
+	Summary         models.ExamSummary
+	TagResults      []models.ExamTagResult
+	ChangedAnswers  []*models.UserAnswer
+}
 
-			totalScore += categoryStats.TotalScore
-			totalAnswered += categoryStats.TotalAnswered
-		}
+// calculateSessionResults scores every answer of a session with its category scorer and
+// builds the results of the categories, in their order, the overall summary and the per-tag
+// breakdown
+func calculateSessionResults(tx *gorm.DB, examSession *models.ExamSession, categories []models.Category, completedAt time.Time) (*sessionResults, error) {
+	scorers, err := loadScorers(categories)
+	if err != nil {
+		return nil, err
+	}
 
-		// Create overall exam summary
-		totalMaxScore := 690  // Official PPPK total: 450 + 100 + 100 + 40
-		totalQuestions := 145 // 90 + 25 + 20 + 10
-		overallPercentage := float64(totalScore) / float64(totalMaxScore) * 100.0
-		overallGrade := calculateGrade(overallPercentage)
-		// Overall passing: minimum 90% overall (Grade B or better)
-		overallPassed := overallPercentage >= 90.0 // Only Grade A and B pass
+	sheet, err := loadAnswerSheet(tx, examSession.ID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Get exam session for user ID
-		var examSession models.ExamSession
-		if err := tx.First(&examSession, examSessionID).Error; err != nil {
-			return fmt.Errorf("failed to get exam session: %w", err)
-		}
+	byCategory, byTag, changed := sheet.tally(scorers)
 
-		examSummary := models.ExamSummary{
-			ExamSessionID:     examSessionID,
-			UserID:            examSession.UserID,
-			TotalQuestions:    totalQuestions,
-			TotalAnswered:     totalAnswered,
-			TotalScore:        totalScore,
-			MaxScore:          totalMaxScore,
-			OverallPercentage: overallPercentage,
-			OverallGrade:      overallGrade,
-			IsPassed:          overallPassed,
-			CompletedAt:       now,
-		}
+	results := &sessionResults{ChangedAnswers: changed}
+	summary := &results.Summary
 
-		if err := tx.Create(&examSummary).Error; err != nil {
-			return fmt.Errorf("failed to create exam summary: %w", err)
+	for _, cat := range categories {
+		stats := byCategory[cat.Code]
+		if stats == nil {
+			stats = &scoreTally{}
 		}
 
-		return nil
-	})
+		// The official section maximum wins over the sum of per-question maximums when configured
+		maxScore := utils.DefaultIfZero(cat.MaxScore, stats.MaxScore)
+		categoryPercentage := percentage(stats.TotalScore, maxScore)
+
+		results.CategoryResults = append(results.CategoryResults, models.ExamResult{
+			ExamSessionID:    examSession.ID,
+			Category:         cat.Code,
+			CategoryMaxScore: cat.MaxScore,
+			TotalQuestions:   stats.TotalQuestions,
+			TotalAnswered:    stats.TotalAnswered,
+			TotalScore:       stats.TotalScore,
+			MaxScore:         maxScore,
+			Percentage:       categoryPercentage,
+		})
+
+		summary.TotalScore += stats.TotalScore
+		summary.TotalAnswered += stats.TotalAnswered
+		summary.TotalQuestions += stats.TotalQuestions
+		summary.MaxScore += maxScore
+	}
+
+	// Create overall exam summary
+	summary.ExamSessionID = examSession.ID
+	summary.UserID = examSession.UserID
+	summary.OverallPercentage = percentage(summary.TotalScore, summary.MaxScore)
+	summary.CompletedAt = completedAt
+
+	// Grades and verdicts come from the grading scale and pass rule of the session blueprint
+	blueprint, err := grading_service.LoadBlueprint(tx, examSession.BlueprintID)
+	if err != nil {
+		return nil, err
+	}
+	scoring.ApplyGrading(&blueprint.GradingScale, &blueprint.PassRule, summary, results.CategoryResults)
+
+	// Per-tag breakdown so candidates can see weak sub-topics
+	results.TagResults = toTagResults(examSession.ID, byTag)
+
+	return results, nil
 }
 
-// calculateGrade calculates grade based on percentage
-// 100%=A, 90%=B, 80%=C, 70%=D (minimum passing), <70%=E (fail)
-func calculateGrade(percentage float64) string {
-	if percentage >= 100 {
-		return "A"
-	} else if percentage >= 90 {
-		return "B"
-	} else if percentage >= 80 {
-		return "C"
-	} else if percentage >= 70 {
-		return "D"
-	} else {
-		return "E"
+// createSessionResults persists corrected answer scores and inserts the computed results
+func createSessionResults(tx *gorm.DB, results *sessionResults) error {
+	if err := saveAnswerScores(tx, results.ChangedAnswers); err != nil {
+		return err
+	}
+
+	for i := range results.CategoryResults {
+		if err := tx.Create(&results.CategoryResults[i]).Error; err != nil {
+			return fmt.Errorf("failed to create exam result for category %s: %w", results.CategoryResults[i].Category, err)
+		}
+	}
+
+	if err := tx.Create(&results.Summary).Error; err != nil {
+		return fmt.Errorf("failed to create exam summary: %w", err)
 	}
+
+	if len(results.TagResults) > 0 {
+		if err := tx.Create(&results.TagResults).Error; err != nil {
+			return fmt.Errorf("failed to create exam tag results: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // GetExamResults gets the exam results for a user
@@ -347,7 +608,7 @@ func (s *ExamService) GetExamResults(ctx context.Context, userID string) (*model
 
 	// Get exam summary
 	err := s.db.WithContext(ctx).
-		Preload("ExamSession").
+		Preload("ExamSession.Blueprint").
 		Where("user_id = ?", userID).
 		Order("completed_at DESC").
 		First(&examSummary).Error
@@ -369,13 +630,58 @@ func (s *ExamService) GetExamResults(ctx context.Context, userID string) (*model
 	return &examSummary, examResults, nil
 }
 
-// CheckAndUpdateExpiredSessions updates expired exam sessions
+// GetExamSummary gets an exam summary by ID with its session
+func (s *ExamService) GetExamSummary(ctx context.Context, summaryID uint) (*models.ExamSummary, error) {
+	var examSummary models.ExamSummary
+	if err := s.db.WithContext(ctx).Preload("ExamSession").First(&examSummary, summaryID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam summary %d: %w", summaryID, err)
+	}
+	return &examSummary, nil
+}
+
+// GetExamTagResults gets the per-tag breakdown of an exam session
+func (s *ExamService) GetExamTagResults(ctx context.Context, examSessionID uint) ([]models.ExamTagResult, error) {
+	var tagResults []models.ExamTagResult
+
+	err := s.db.WithContext(ctx).
+		Where("exam_session_id = ?", examSessionID).
+		Order("percentage ASC, tag_name ASC").
+		Find(&tagResults).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam tag results: %w", err)
+	}
+
+	return tagResults, nil
+}
+
+// CheckAndUpdateExpiredSessions updates expired exam sessions and publishes their expiry.
+// Sessions locked by a running transaction, such as a completion, are left to a later check.
 func (s *ExamService) CheckAndUpdateExpiredSessions(ctx context.Context) error {
 	now := time.Now()
-	return s.db.WithContext(ctx).
-		Model(&models.ExamSession{}).
-		Where("expires_at < ? AND status IN (?)", now, []string{"NOT_STARTED", "IN_PROGRESS"}).
-		Update("status", "EXPIRED").Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var expired []models.ExamSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("expires_at < ? AND status IN (?)", now, models.ActiveSessionStatuses).
+			Find(&expired).Error; err != nil {
+			return err
+		}
+
+		for i := range expired {
+			if err := expireSession(tx, &expired[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// expireSession marks a session past its deadline EXPIRED and publishes its expiry
+func expireSession(tx *gorm.DB, examSession *models.ExamSession) error {
+	if err := transitionSession(tx, examSession, models.SessionExpired, models.TransitionExpire, nil); err != nil {
+		return err
+	}
+	return publishSessionEvent(tx, models.EventExamExpired, examSession.ID, newSessionEvent(examSession))
 }
 
 // GetUserDashboard gets dashboard data including exam status and results
@@ -390,6 +696,7 @@ func (s *ExamService) GetUserDashboard(ctx context.Context, userID string) (*dto
 	// Get the latest exam session
 	var examSession models.ExamSession
 	err := s.db.WithContext(ctx).
+		Preload("Accommodation").
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		First(&examSession).Error
@@ -404,30 +711,42 @@ func (s *ExamService) GetUserDashboard(ctx context.Context, userID string) (*dto
 	}
 
 	dashboard.HasExam = true
-	dashboard.ExamStatus = examSession.Status
+	dashboard.ExamStatus = string(examSession.Status)
 	dashboard.ExamSession = &examSession
 
 	// If exam is completed, get the results
-	if examSession.Status == "COMPLETED" {
+	if examSession.Status == models.SessionCompleted {
 		summary, results, err := s.GetExamResults(ctx, userID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get exam results: %w", err)
 		}
 
+		tagResults, err := s.GetExamTagResults(ctx, summary.ExamSessionID)
+		if err != nil {
+			return nil, err
+		}
+
 		dashboard.ExamSummary = summary
 		dashboard.ExamResults = results
+		dashboard.ExamTagResults = tagResults
 	}
 
 	// If exam is in progress, get progress info
-	if examSession.Status == "IN_PROGRESS" || examSession.Status == "NOT_STARTED" {
+	if examSession.Status.IsActive() {
 		var answeredCount int64
 		s.db.WithContext(ctx).
 			Table("user_answers").
 			Where("exam_session_id = ?", examSession.ID).
 			Count(&answeredCount)
 
+		var questionCount int64
+		s.db.WithContext(ctx).
+			Model(&models.ExamQuestion{}).
+			Where("exam_session_id = ?", examSession.ID).
+			Count(&questionCount)
+
 		dashboard.ProgressInfo = &dto.ProgressInfo{
-			TotalQuestions:    145, // 90 + 25 + 20 + 10 total questions
+			TotalQuestions:    int(questionCount),
 			AnsweredQuestions: int(answeredCount),
 			RemainingTime:     int(time.Until(examSession.ExpiresAt).Minutes()),
 		}
@@ -436,47 +755,68 @@ func (s *ExamService) GetUserDashboard(ctx context.Context, userID string) (*dto
 	return dashboard, nil
 }
 
-// GetAllUsersExamStatus gets exam status for all users who have taken exams
-func (s *ExamService) GetAllUsersExamStatus(ctx context.Context) ([]dto.UserDashboardSummary, error) {
+// GetCohortExamStatus gets the exam status of every member of a cohort from their latest
+// session, optionally only sessions started for one assignment of the cohort. Members
+// without such a session are listed with the NO_EXAM status.
+func (s *ExamService) GetCohortExamStatus(ctx context.Context, cohortID uint, assignmentID *uint) ([]dto.UserDashboardSummary, error) {
 	// First check and update any expired sessions
 	s.CheckAndUpdateExpiredSessions(ctx)
 
-	var results []dto.UserDashboardSummary
+	sessionFilter := ""
+	args := []interface{}{}
+	if assignmentID != nil {
+		sessionFilter = "AND s.assignment_id = ?"
+		args = append(args, *assignmentID)
+	}
+	args = append(args, cohortID, models.CohortRoleMember)
 
-	// Query to get all exam sessions with their summaries (if completed)
 	query := `
 		SELECT 
-			es.user_id,
-			es.status as exam_status,
-			es.session_code,
+			cm.user_id,
+			COALESCE(es.status, 'NO_EXAM') as exam_status,
+			COALESCE(es.session_code, '') as session_code,
 			es.started_at,
 			es.completed_at,
 			esm.total_score,
 			esm.max_score,
 			esm.overall_percentage,
 			esm.overall_grade,
-			esm.is_passed
-		FROM exam_sessions es
-		LEFT JOIN exam_summaries esm ON es.id = esm.exam_session_id
-		WHERE es.id IN (
-			SELECT MAX(id) 
-			FROM exam_sessions 
-			GROUP BY user_id
+			esm.is_passed,
+			acc.time_multiplier,
+			acc.large_font,
+			acc.extra_breaks,
+			NULLIF(es.accommodation_minutes, 0)
+		FROM cohort_members cm
+		LEFT JOIN exam_sessions es ON es.id = (
+			SELECT MAX(s.id)
+			FROM exam_sessions s
+			WHERE s.user_id = cm.user_id AND s.deleted_at IS NULL ` + sessionFilter + `
 		)
-		ORDER BY es.created_at DESC
+		LEFT JOIN exam_summaries esm ON es.id = esm.exam_session_id
+		LEFT JOIN accommodations acc ON acc.user_id = cm.user_id AND acc.deleted_at IS NULL
+		WHERE cm.cohort_id = ? AND cm.role = ? AND cm.deleted_at IS NULL
+		ORDER BY cm.user_id ASC
 	`
 
-	rows, err := s.db.WithContext(ctx).Raw(query).Rows()
+	rows, err := s.db.WithContext(ctx).Raw(query, args...).Rows()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get users exam status: %w", err)
+		return nil, fmt.Errorf("failed to get cohort exam status: %w", err)
 	}
 	defer rows.Close()
 
+	return scanUserDashboardSummaries(rows, nil)
+}
+
+// scanUserDashboardSummaries scans the rows of the users exam status queries. When extra is
+// set, it returns the destinations of the columns following the summary columns of each row.
+func scanUserDashboardSummaries(rows *sql.Rows, extra func() []interface{}) ([]dto.UserDashboardSummary, error) {
+	results := []dto.UserDashboardSummary{}
+
 	for rows.Next() {
 		var userSummary dto.UserDashboardSummary
 		var startedAt, completedAt *time.Time
 
-		err := rows.Scan(
+		dest := []interface{}{
 			&userSummary.UserID,
 			&userSummary.ExamStatus,
 			&userSummary.SessionCode,
@@ -487,7 +827,16 @@ func (s *ExamService) GetAllUsersExamStatus(ctx context.Context) ([]dto.UserDash
 			&userSummary.Percentage,
 			&userSummary.Grade,
 			&userSummary.IsPassed,
-		)
+			&userSummary.TimeMultiplier,
+			&userSummary.LargeFont,
+			&userSummary.ExtraBreaks,
+			&userSummary.AccommodationMinutes,
+		}
+		if extra != nil {
+			dest = append(dest, extra()...)
+		}
+
+		err := rows.Scan(dest...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user summary: %w", err)
 		}
@@ -505,7 +854,7 @@ func (s *ExamService) GetAllUsersExamStatus(ctx context.Context) ([]dto.UserDash
 		results = append(results, userSummary)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
@@ -517,7 +866,7 @@ func (s *ExamService) GetUserAnswers(ctx context.Context, userID string) (map[ui
 	// Get the latest exam session
 	var examSession models.ExamSession
 	err := s.db.WithContext(ctx).
-		Where("user_id = ? AND status IN (?)", userID, []string{"NOT_STARTED", "IN_PROGRESS"}).
+		Where("user_id = ? AND status IN (?)", userID, models.ActiveSessionStatuses).
 		Order("created_at DESC").
 		First(&examSession).Error
 
@@ -552,7 +901,7 @@ func (s *ExamService) GetDetailedUserAnswers(ctx context.Context, userID string)
 	// Get the latest completed exam session
 	var examSession models.ExamSession
 	err := s.db.WithContext(ctx).
-		Where("user_id = ? AND status = ?", userID, "COMPLETED").
+		Where("user_id = ? AND status = ?", userID, models.SessionCompleted).
 		Order("created_at DESC").
 		First(&examSession).Error
 
@@ -568,6 +917,7 @@ func (s *ExamService) GetDetailedUserAnswers(ctx context.Context, userID string)
 	err = s.db.WithContext(ctx).
 		Where("exam_session_id = ?", examSession.ID).
 		Preload("Question").
+		Preload("Question.Options").
 		Preload("QuestionOption").
 		Preload("ExamQuestion").
 		Find(&userAnswers).Error
@@ -576,32 +926,26 @@ func (s *ExamService) GetDetailedUserAnswers(ctx context.Context, userID string)
 		return nil, fmt.Errorf("failed to get user answers: %w", err)
 	}
 
+	// Load the scorer of every category to decide what counts as correct
+	var categories []models.Category
+	if err := s.db.WithContext(ctx).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	scorers, err := loadScorers(categories)
+	if err != nil {
+		return nil, err
+	}
+
 	// Group answers by category and find correct answers
 	categoryAnswers := make(map[string][]dto.DetailedAnswer)
 	for _, answer := range userAnswers {
-		// Get all options for this question to find the correct one (highest score)
-		var allOptions []models.QuestionOption
-		err := s.db.WithContext(ctx).
-			Where("question_id = ?", answer.QuestionID).
-			Order("score DESC").
-			Find(&allOptions).Error
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to get question options: %w", err)
-		}
-
-		// Find the correct answer (option with highest score)
-		var correctOption models.QuestionOption
-		maxScore := 0
-		for _, option := range allOptions {
-			if option.Score > maxScore {
-				maxScore = option.Score
-				correctOption = option
-			}
-		}
+		scorer := scorerFor(scorers, answer.ExamQuestion.Category)
+		allOptions := answer.Question.Options
 
-		// Check if user's answer is correct
-		isCorrect := answer.Score == correctOption.Score
+		// The correct answer is the option the scorer awards the most points
+		correctOption := scorer.BestOption(allOptions)
+		isCorrect := scorer.IsCorrect(answer.QuestionOption, allOptions)
 
 		detailedAnswer := dto.DetailedAnswer{
 			ExamQuestionID:   answer.ExamQuestionID,
@@ -611,11 +955,11 @@ func (s *ExamService) GetDetailedUserAnswers(ctx context.Context, userID string)
 			SelectedOptionID: answer.QuestionOptionID,
 			SelectedOption:   answer.QuestionOption.OptionText,
 			Score:            answer.Score,
-			MaxScore:         correctOption.Score,
+			MaxScore:         scorer.MaxScore(allOptions),
 			IsCorrect:        isCorrect,
 			CorrectOptionID:  correctOption.ID,
 			CorrectOption:    correctOption.OptionText,
-			CorrectScore:     correctOption.Score,
+			CorrectScore:     scorer.ScoreAnswer(correctOption, allOptions),
 			AnsweredAt:       answer.AnsweredAt,
 		}
 
@@ -0,0 +1,237 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/spreadsheet"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportFilter selects the summaries of ExportSummaries. Empty fields do not filter.
+type ExportFilter struct {
+	From        *time.Time // Completed at or after
+	To          *time.Time // Completed before
+	BlueprintID *uint
+	CohortID    *uint
+}
+
+// conditions returns the SQL conditions of the filter on the esm and es aliases
+func (filter ExportFilter) conditions() (string, []interface{}) {
+	var conditions strings.Builder
+	var args []interface{}
+
+	conditions.WriteString("esm.deleted_at IS NULL AND es.deleted_at IS NULL AND es.status <> 'VOIDED'")
+	if filter.From != nil {
+		conditions.WriteString(" AND esm.completed_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions.WriteString(" AND esm.completed_at < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.BlueprintID != nil {
+		conditions.WriteString(" AND es.blueprint_id = ?")
+		args = append(args, *filter.BlueprintID)
+	}
+	if filter.CohortID != nil {
+		conditions.WriteString(` AND es.user_id IN (
+			SELECT cm.user_id FROM cohort_members cm
+			WHERE cm.cohort_id = ? AND cm.role = ? AND cm.deleted_at IS NULL
+		)`)
+		args = append(args, *filter.CohortID, models.CohortRoleMember)
+	}
+	return conditions.String(), args
+}
+
+// ExportSummaries writes a header and one row per completed session, oldest first, with the
+// score, percentage and grade of every category as columns. Rows are read from the database
+// and written one at a time. Voided sessions are left out.
+func (s *ExamService) ExportSummaries(ctx context.Context, filter ExportFilter, w spreadsheet.RowWriter) error {
+	db := s.db.WithContext(ctx)
+
+	var categories []models.Category
+	if err := db.Order("display_order ASC, id ASC").Find(&categories).Error; err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	header := []interface{}{
+		"session_code", "user_id", "blueprint_id", "sitting_id", "status", "started_at", "completed_at",
+		"total_questions", "total_answered", "total_score", "max_score", "overall_percentage", "overall_grade", "is_passed",
+	}
+	var columns strings.Builder
+	var args []interface{}
+	for _, category := range categories {
+		header = append(header, category.Code+"_score", category.Code+"_percentage", category.Code+"_grade")
+		columns.WriteString(`,
+			MAX(er.total_score) FILTER (WHERE er.category = ?),
+			MAX(er.percentage) FILTER (WHERE er.category = ?),
+			MAX(er.grade) FILTER (WHERE er.category = ?)`)
+		args = append(args, category.Code, category.Code, category.Code)
+	}
+
+	conditions, filterArgs := filter.conditions()
+	query := `
+		SELECT
+			es.session_code, esm.user_id, es.blueprint_id, es.sitting_id, es.status, es.started_at, esm.completed_at,
+			esm.total_questions, esm.total_answered, esm.total_score, esm.max_score,
+			esm.overall_percentage, esm.overall_grade, esm.is_passed` + columns.String() + `
+		FROM exam_summaries esm
+		JOIN exam_sessions es ON es.id = esm.exam_session_id
+		LEFT JOIN exam_results er ON er.exam_session_id = esm.exam_session_id AND er.deleted_at IS NULL
+		WHERE ` + conditions + `
+		GROUP BY esm.id, es.id
+		ORDER BY esm.completed_at ASC, esm.id ASC
+	`
+
+	rows, err := db.Raw(query, append(args, filterArgs...)...).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to export summaries: %w", err)
+	}
+	defer rows.Close()
+
+	if err := w.WriteRow(header...); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var (
+			sessionCode, userID, status               string
+			blueprintID, sittingID                    *uint
+			startedAt                                 *time.Time
+			completedAt                               time.Time
+			totalQuestions, totalAnswered, totalScore int
+			maxScore                                  int
+			overallPercentage                         float64
+			overallGrade                              sql.NullString
+			isPassed                                  bool
+		)
+		dest := []interface{}{
+			&sessionCode, &userID, &blueprintID, &sittingID, &status, &startedAt, &completedAt,
+			&totalQuestions, &totalAnswered, &totalScore, &maxScore, &overallPercentage, &overallGrade, &isPassed,
+		}
+
+		categoryScores := make([]*int, len(categories))
+		categoryPercentages := make([]*float64, len(categories))
+		categoryGrades := make([]*string, len(categories))
+		for i := range categories {
+			dest = append(dest, &categoryScores[i], &categoryPercentages[i], &categoryGrades[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan summary: %w", err)
+		}
+
+		cells := []interface{}{
+			sessionCode, userID, blueprintID, sittingID, status, startedAt, completedAt,
+			totalQuestions, totalAnswered, totalScore, maxScore, overallPercentage, overallGrade.String, isPassed,
+		}
+		for i := range categories {
+			cells = append(cells, categoryScores[i], categoryPercentages[i], categoryGrades[i])
+		}
+
+		if err := w.WriteRow(cells...); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating summaries: %w", err)
+	}
+	return nil
+}
+
+// ExportSessionAnswers writes a header and one row per question of a session in exam order,
+// with the selected option, its score and the best option of the category scorer. Unanswered
+// questions have empty answer columns.
+func (s *ExamService) ExportSessionAnswers(ctx context.Context, sessionID uint, w spreadsheet.RowWriter) error {
+	db := s.db.WithContext(ctx)
+
+	var session models.ExamSession
+	if err := db.First(&session, sessionID).Error; err != nil {
+		return fmt.Errorf("failed to get exam session: %w", err)
+	}
+
+	scorers, err := loadAllScorers(db)
+	if err != nil {
+		return err
+	}
+
+	// The options of the questions decide the maximum score and the best option
+	var options []models.QuestionOption
+	if err := db.Unscoped().
+		Where("question_id IN (?)", db.Model(&models.ExamQuestion{}).Select("question_id").Where("exam_session_id = ?", session.ID)).
+		Order("id ASC").
+		Find(&options).Error; err != nil {
+		return fmt.Errorf("failed to get question options: %w", err)
+	}
+	optionsByQuestion := make(map[uint][]models.QuestionOption)
+	for _, option := range options {
+		optionsByQuestion[option.QuestionID] = append(optionsByQuestion[option.QuestionID], option)
+	}
+
+	rows, err := db.Raw(`
+		SELECT eq.order_number, eq.category, eq.question_id, q.question_text,
+			ua.question_option_id, ua.score, ua.answered_at
+		FROM exam_questions eq
+		JOIN questions q ON q.id = eq.question_id
+		LEFT JOIN user_answers ua ON ua.exam_question_id = eq.id AND ua.deleted_at IS NULL
+		WHERE eq.exam_session_id = ? AND eq.deleted_at IS NULL
+		ORDER BY eq.order_number ASC
+	`, session.ID).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to export answers: %w", err)
+	}
+	defer rows.Close()
+
+	if err := w.WriteRow(
+		"session_code", "user_id", "order_number", "category", "question_id", "question_text",
+		"selected_option_id", "selected_option", "score", "max_score", "is_correct",
+		"correct_option_id", "correct_option", "answered_at",
+	); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var (
+			orderNumber      int
+			category         string
+			questionID       uint
+			questionText     string
+			selectedOptionID *uint
+			score            *int
+			answeredAt       *time.Time
+		)
+		if err := rows.Scan(&orderNumber, &category, &questionID, &questionText, &selectedOptionID, &score, &answeredAt); err != nil {
+			return fmt.Errorf("failed to scan answer: %w", err)
+		}
+
+		questionOptions := optionsByQuestion[questionID]
+		scorer := scorerFor(scorers, category)
+		best := scorer.BestOption(questionOptions)
+
+		var selectedText *string
+		var isCorrect *bool
+		if selectedOptionID != nil {
+			if selected, found := selectedOption(&models.UserAnswer{QuestionOptionID: *selectedOptionID}, questionOptions); found {
+				correct := scorer.IsCorrect(selected, questionOptions)
+				selectedText, isCorrect = &selected.OptionText, &correct
+			}
+		}
+
+		if err := w.WriteRow(
+			session.SessionCode, session.UserID, orderNumber, category, questionID, questionText,
+			selectedOptionID, selectedText, score, scorer.MaxScore(questionOptions), isCorrect,
+			best.ID, best.OptionText, answeredAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating answers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrLeaderboardScope is returned when a leaderboard is requested without a blueprint, sitting or cohort
+var ErrLeaderboardScope = errors.New("leaderboard needs a blueprint, sitting or cohort")
+
+// LeaderboardScope selects the candidates ranked together. Filters combine; at least one is required.
+type LeaderboardScope struct {
+	BlueprintID *uint
+	SittingID   *uint
+	CohortID    *uint
+}
+
+// populationQuery returns the latest completed summary of every user in scope. Later attempts
+// replace earlier ones so each candidate is ranked once; voided attempts are left out.
+func (scope LeaderboardScope) populationQuery() (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	if scope.BlueprintID != nil {
+		conditions = append(conditions, "es.blueprint_id = ?")
+		args = append(args, *scope.BlueprintID)
+	}
+	if scope.SittingID != nil {
+		conditions = append(conditions, "es.sitting_id = ?")
+		args = append(args, *scope.SittingID)
+	}
+	if scope.CohortID != nil {
+		conditions = append(conditions, `es.user_id IN (
+			SELECT cm.user_id FROM cohort_members cm
+			WHERE cm.cohort_id = ? AND cm.role = ? AND cm.deleted_at IS NULL
+		)`)
+		args = append(args, *scope.CohortID, models.CohortRoleMember)
+	}
+	if len(conditions) == 0 {
+		return "", nil, ErrLeaderboardScope
+	}
+
+	query := `
+		SELECT DISTINCT ON (esm.user_id)
+			esm.id,
+			esm.exam_session_id,
+			esm.user_id,
+			esm.total_score,
+			esm.max_score,
+			esm.overall_percentage,
+			esm.overall_grade,
+			esm.is_passed,
+			esm.completed_at
+		FROM exam_summaries esm
+		JOIN exam_sessions es ON es.id = esm.exam_session_id AND es.deleted_at IS NULL AND es.status <> 'VOIDED'
+		WHERE esm.deleted_at IS NULL AND ` + strings.Join(conditions, " AND ") + `
+		ORDER BY esm.user_id, esm.completed_at DESC, esm.id DESC
+	`
+	return query, args, nil
+}
+
+// rankedQuery ranks the population by total score. Ties share a rank; the percentile rank is
+// the share of candidates scoring lower plus half of those scoring the same.
+func (scope LeaderboardScope) rankedQuery() (string, []interface{}, error) {
+	population, args, err := scope.populationQuery()
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := `
+		WITH population AS (` + population + `)
+		SELECT
+			p.exam_session_id,
+			p.user_id,
+			p.total_score,
+			p.max_score,
+			p.overall_percentage,
+			p.overall_grade,
+			p.is_passed,
+			p.completed_at,
+			RANK() OVER (ORDER BY p.total_score DESC) AS rank,
+			ROW_NUMBER() OVER (ORDER BY p.total_score DESC, p.completed_at ASC, p.id ASC) AS position,
+			100.0 * ((RANK() OVER (ORDER BY p.total_score ASC) - 1) + 0.5 * COUNT(*) OVER (PARTITION BY p.total_score))
+				/ COUNT(*) OVER () AS percentile
+		FROM population p
+	`
+	return query, args, nil
+}
+
+// rankedEntry is a row of rankedQuery
+type rankedEntry struct {
+	ExamSessionID     uint
+	UserID            string
+	TotalScore        int
+	MaxScore          int
+	OverallPercentage float64
+	OverallGrade      string
+	IsPassed          bool
+	CompletedAt       time.Time
+	Rank              int
+	Position          int
+	Percentile        float64
+}
+
+// toLeaderboardEntry converts a ranked row to an anonymised leaderboard entry
+func (e *rankedEntry) toLeaderboardEntry(userID string) dto.LeaderboardEntry {
+	return dto.LeaderboardEntry{
+		Rank:        e.Rank,
+		DisplayName: fmt.Sprintf("Candidate #%d", e.Position),
+		TotalScore:  e.TotalScore,
+		MaxScore:    e.MaxScore,
+		Percentage:  e.OverallPercentage,
+		Grade:       e.OverallGrade,
+		IsPassed:    e.IsPassed,
+		Percentile:  e.Percentile,
+		CompletedAt: e.CompletedAt,
+		IsYou:       userID != "" && e.UserID == userID,
+	}
+}
+
+// GetLeaderboard ranks the latest completed session of every candidate in scope by total score.
+// Candidates are anonymised by their leaderboard position; when userID is set, its own entry is
+// flagged and returned as You even when it is not on the page.
+func (s *ExamService) GetLeaderboard(ctx context.Context, scope LeaderboardScope, userID string, offset, limit int) (*dto.LeaderboardResponse, error) {
+	ranked, args, err := scope.rankedQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var totalCount int64
+	if err := db.Raw("SELECT COUNT(*) FROM ("+ranked+") r", args...).Scan(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+
+	pageQuery := "SELECT * FROM (" + ranked + ") r ORDER BY r.position ASC"
+	pageArgs := append([]interface{}{}, args...)
+	if limit > 0 {
+		pageQuery += " LIMIT ? OFFSET ?"
+		pageArgs = append(pageArgs, limit, offset)
+	}
+
+	var rows []rankedEntry
+	if err := db.Raw(pageQuery, pageArgs...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	response := &dto.LeaderboardResponse{
+		BlueprintID:     scope.BlueprintID,
+		SittingID:       scope.SittingID,
+		CohortID:        scope.CohortID,
+		TotalCandidates: int(totalCount),
+		Entries:         make([]dto.LeaderboardEntry, len(rows)),
+	}
+	for i := range rows {
+		response.Entries[i] = rows[i].toLeaderboardEntry(userID)
+	}
+
+	if userID != "" {
+		var own []rankedEntry
+		ownArgs := append(append([]interface{}{}, args...), userID)
+		if err := db.Raw("SELECT * FROM ("+ranked+") r WHERE r.user_id = ?", ownArgs...).Scan(&own).Error; err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard entry of user %s: %w", userID, err)
+		}
+		if len(own) > 0 {
+			entry := own[0].toLeaderboardEntry(userID)
+			response.You = &entry
+		}
+	}
+
+	return response, nil
+}
+
+// GetSessionStanding returns the rank and percentile of a completed session overall and per
+// category among the latest completed sessions of every candidate of the same blueprint.
+// It returns nil when the session has no blueprint or is not the latest attempt of its user.
+func (s *ExamService) GetSessionStanding(ctx context.Context, summary *models.ExamSummary) (*dto.SessionStanding, error) {
+	blueprintID := summary.ExamSession.BlueprintID
+	if blueprintID == nil {
+		var session models.ExamSession
+		if err := s.db.WithContext(ctx).Select("id", "blueprint_id").First(&session, summary.ExamSessionID).Error; err != nil {
+			return nil, fmt.Errorf("failed to get exam session: %w", err)
+		}
+		blueprintID = session.BlueprintID
+	}
+	if blueprintID == nil {
+		return nil, nil
+	}
+
+	scope := LeaderboardScope{BlueprintID: blueprintID}
+	ranked, args, err := scope.rankedQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var overall []struct {
+		Rank       int
+		Percentile float64
+		Candidates int
+	}
+	err = db.Raw(`
+		WITH ranked AS (`+ranked+`)
+		SELECT r.rank, r.percentile, (SELECT COUNT(*) FROM ranked) AS candidates
+		FROM ranked r
+		WHERE r.exam_session_id = ?
+	`, append(args, summary.ExamSessionID)...).Scan(&overall).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank exam session: %w", err)
+	}
+	if len(overall) == 0 {
+		return nil, nil
+	}
+
+	population, args, err := scope.populationQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	var categories []struct {
+		Category   string
+		Rank       int
+		Percentile float64
+	}
+	err = db.Raw(`
+		WITH population AS (`+population+`),
+		ranked AS (
+			SELECT
+				er.exam_session_id,
+				er.category,
+				RANK() OVER (PARTITION BY er.category ORDER BY er.total_score DESC) AS rank,
+				100.0 * ((RANK() OVER (PARTITION BY er.category ORDER BY er.total_score ASC) - 1)
+					+ 0.5 * COUNT(*) OVER (PARTITION BY er.category, er.total_score))
+					/ COUNT(*) OVER (PARTITION BY er.category) AS percentile
+			FROM exam_results er
+			JOIN population p ON p.exam_session_id = er.exam_session_id
+			WHERE er.deleted_at IS NULL
+		)
+		SELECT category, rank, percentile FROM ranked WHERE exam_session_id = ?
+	`, append(args, summary.ExamSessionID)...).Scan(&categories).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank exam session categories: %w", err)
+	}
+
+	standing := &dto.SessionStanding{
+		BlueprintID:         *blueprintID,
+		Candidates:          overall[0].Candidates,
+		Rank:                overall[0].Rank,
+		Percentile:          overall[0].Percentile,
+		CategoryRanks:       make(map[string]int, len(categories)),
+		CategoryPercentiles: make(map[string]float64, len(categories)),
+	}
+	for _, category := range categories {
+		standing.CategoryRanks[category.Category] = category.Rank
+		standing.CategoryPercentiles[category.Category] = category.Percentile
+	}
+	return standing, nil
+}
@@ -0,0 +1,214 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLeaderboardScopeQueries(t *testing.T) {
+	blueprintID, sittingID, cohortID := uint(1), uint(2), uint(3)
+
+	tests := []struct {
+		name     string
+		scope    LeaderboardScope
+		want     []string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{
+			name:     "blueprint",
+			scope:    LeaderboardScope{BlueprintID: &blueprintID},
+			want:     []string{"es.blueprint_id = ?"},
+			wantArgs: []interface{}{uint(1)},
+		},
+		{
+			name:     "sitting and cohort members",
+			scope:    LeaderboardScope{SittingID: &sittingID, CohortID: &cohortID},
+			want:     []string{"es.sitting_id = ? AND es.user_id IN (", "cm.cohort_id = ? AND cm.role = ?"},
+			wantArgs: []interface{}{uint(2), uint(3), models.CohortRoleMember},
+		},
+		{
+			name:     "every filter",
+			scope:    LeaderboardScope{BlueprintID: &blueprintID, SittingID: &sittingID, CohortID: &cohortID},
+			want:     []string{"es.blueprint_id = ? AND es.sitting_id = ? AND es.user_id IN ("},
+			wantArgs: []interface{}{uint(1), uint(2), uint(3), models.CohortRoleMember},
+		},
+		{
+			name:    "no scope",
+			scope:   LeaderboardScope{},
+			wantErr: ErrLeaderboardScope,
+		},
+	}
+
+	// Every population ranks the latest completed, non-voided summary once per user
+	common := []string{
+		"SELECT DISTINCT ON (esm.user_id)",
+		"es.deleted_at IS NULL AND es.status <> 'VOIDED'",
+		"WHERE esm.deleted_at IS NULL AND ",
+		"ORDER BY esm.user_id, esm.completed_at DESC, esm.id DESC",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.scope.rankedQuery()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("rankedQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, want := range append(common, tt.want...) {
+				if !strings.Contains(query, want) {
+					t.Errorf("rankedQuery() does not contain %q", want)
+				}
+			}
+			if got := strings.Count(query, "?"); got != len(tt.wantArgs) {
+				t.Errorf("rankedQuery() has %d placeholders, want %d", got, len(tt.wantArgs))
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("rankedQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+			for i := range args {
+				if args[i] != tt.wantArgs[i] {
+					t.Errorf("rankedQuery() arg %d = %v, want %v", i, args[i], tt.wantArgs[i])
+				}
+			}
+		})
+	}
+}
+
+// leaderboardRows returns ranked rows as selected by rankedQuery
+func leaderboardRows(entries ...rankedEntry) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"exam_session_id", "user_id", "total_score", "max_score", "overall_percentage",
+		"overall_grade", "is_passed", "completed_at", "rank", "position", "percentile"})
+	for _, e := range entries {
+		rows.AddRow(e.ExamSessionID, e.UserID, e.TotalScore, e.MaxScore, e.OverallPercentage,
+			e.OverallGrade, e.IsPassed, e.CompletedAt, e.Rank, e.Position, e.Percentile)
+	}
+	return rows
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	blueprintID := uint(1)
+	scope := LeaderboardScope{BlueprintID: &blueprintID}
+	completed := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	first := rankedEntry{ExamSessionID: 10, UserID: "user-1", TotalScore: 500, MaxScore: 600, Rank: 1, Position: 1, Percentile: 90, CompletedAt: completed}
+	tied := rankedEntry{ExamSessionID: 11, UserID: "user-2", TotalScore: 450, MaxScore: 600, Rank: 2, Position: 2, Percentile: 60, CompletedAt: completed}
+	alsoTied := rankedEntry{ExamSessionID: 12, UserID: "user-3", TotalScore: 450, MaxScore: 600, Rank: 2, Position: 3, Percentile: 60, CompletedAt: completed}
+
+	tests := []struct {
+		name      string
+		userID    string
+		limit     int
+		page      []rankedEntry
+		own       []rankedEntry
+		wantArgs  []driver.Value
+		wantYou   int // rank of the own entry, 0 when there is none
+		wantIsYou []bool
+	}{
+		{
+			name:      "anonymous page",
+			limit:     2,
+			page:      []rankedEntry{first, tied},
+			wantArgs:  []driver.Value{blueprintID, 2, 0},
+			wantIsYou: []bool{false, false},
+		},
+		{
+			name:      "own entry on the page",
+			userID:    "user-2",
+			limit:     2,
+			page:      []rankedEntry{first, tied},
+			own:       []rankedEntry{tied},
+			wantArgs:  []driver.Value{blueprintID, 2, 0},
+			wantYou:   2,
+			wantIsYou: []bool{false, true},
+		},
+		{
+			name:      "own entry off the page",
+			userID:    "user-3",
+			limit:     2,
+			page:      []rankedEntry{first, tied},
+			own:       []rankedEntry{alsoTied},
+			wantArgs:  []driver.Value{blueprintID, 2, 0},
+			wantYou:   2,
+			wantIsYou: []bool{false, false},
+		},
+		{
+			name:      "user not ranked, no limit",
+			userID:    "user-9",
+			page:      []rankedEntry{first, tied, alsoTied},
+			wantArgs:  []driver.Value{blueprintID},
+			wantIsYou: []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (")).
+				WithArgs(blueprintID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			pageQuery := regexp.QuoteMeta(") r ORDER BY r.position ASC")
+			if tt.limit > 0 {
+				pageQuery += regexp.QuoteMeta(" LIMIT $2 OFFSET $3")
+			}
+			mock.ExpectQuery(pageQuery + "$").
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(leaderboardRows(tt.page...))
+			if tt.userID != "" {
+				mock.ExpectQuery(regexp.QuoteMeta(") r WHERE r.user_id = $2")).
+					WithArgs(blueprintID, tt.userID).
+					WillReturnRows(leaderboardRows(tt.own...))
+			}
+
+			response, err := service.GetLeaderboard(context.Background(), scope, tt.userID, 0, tt.limit)
+			if err != nil {
+				t.Fatalf("GetLeaderboard() error = %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+
+			if response.TotalCandidates != 3 || len(response.Entries) != len(tt.page) {
+				t.Fatalf("GetLeaderboard() = %d entries of %d, want %d of 3", len(response.Entries), response.TotalCandidates, len(tt.page))
+			}
+			for i, entry := range response.Entries {
+				if entry.DisplayName != fmt.Sprintf("Candidate #%d", i+1) || entry.Rank != tt.page[i].Rank {
+					t.Errorf("entry %d = %s ranked %d, want Candidate #%d ranked %d", i, entry.DisplayName, entry.Rank, i+1, tt.page[i].Rank)
+				}
+				if entry.IsYou != tt.wantIsYou[i] {
+					t.Errorf("entry %d IsYou = %v, want %v", i, entry.IsYou, tt.wantIsYou[i])
+				}
+			}
+
+			switch {
+			case tt.wantYou == 0 && response.You != nil:
+				t.Errorf("You = %+v, want none", response.You)
+			case tt.wantYou != 0 && (response.You == nil || response.You.Rank != tt.wantYou || !response.You.IsYou):
+				t.Errorf("You = %+v, want own entry ranked %d", response.You, tt.wantYou)
+			}
+		})
+	}
+}
+
+func TestGetLeaderboardNeedsScope(t *testing.T) {
+	service, mock := newMockExamService(t)
+	if _, err := service.GetLeaderboard(context.Background(), LeaderboardScope{}, "", 0, 10); !errors.Is(err, ErrLeaderboardScope) {
+		t.Fatalf("GetLeaderboard() error = %v, want %v", err, ErrLeaderboardScope)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
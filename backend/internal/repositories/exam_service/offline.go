@@ -0,0 +1,256 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrOfflineAnswersRejected is returned when at least one offline answer row is invalid.
+// Nothing is saved and the report lists the row errors.
+var ErrOfflineAnswersRejected = errors.New("offline answers rejected")
+
+// OfflineAnswer is one mark read from a paper answer sheet
+type OfflineAnswer struct {
+	Row          int // data line number in the CSV
+	SessionCode  string
+	OrderNumber  int
+	OptionLetter string // empty when the question was left blank
+}
+
+// offlineSheet holds the answers of one session in the order of the file
+type offlineSheet struct {
+	session   models.ExamSession
+	questions map[int]*models.ExamQuestion // keyed by order number
+	answers   []OfflineAnswer
+}
+
+// IngestOfflineAnswers stores answers collected on paper and completes their sessions with the
+// normal scoring, so paper results appear next to online ones. Option letters follow the
+// printed papers: A is the option with the lowest ID. Every row is validated first and one
+// invalid row rejects the whole file. With dryRun the sessions are scored and nothing is saved.
+func (s *ExamService) IngestOfflineAnswers(ctx context.Context, answers []OfflineAnswer, dryRun bool) (*dto.OfflineAnswerReport, error) {
+	report := &dto.OfflineAnswerReport{
+		DryRun:    dryRun,
+		TotalRows: len(answers),
+		Sessions:  []dto.OfflineSessionResult{},
+		Errors:    []dto.ImportRowError{},
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sheets, err := loadOfflineSheets(tx, answers, report)
+		if err != nil {
+			return err
+		}
+		if len(report.Errors) > 0 {
+			return ErrOfflineAnswersRejected
+		}
+
+		scorers, err := loadAllScorers(tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, sheet := range sheets {
+			answered := 0
+			for _, answer := range sheet.answers {
+				if answer.OptionLetter == "" {
+					report.BlankRows++
+					continue
+				}
+
+				eq := sheet.questions[answer.OrderNumber]
+				option := eq.Question.Options[letterIndex(answer.OptionLetter)]
+				score := scorerFor(scorers, eq.Category).ScoreAnswer(option, eq.Question.Options)
+
+				if err := saveOfflineAnswer(tx, eq, option, score, now); err != nil {
+					return err
+				}
+				answered++
+			}
+			report.AnsweredRows += answered
+
+			if sheet.session.StartedAt == nil {
+				if err := tx.Model(&sheet.session).Update("started_at", now).Error; err != nil {
+					return fmt.Errorf("failed to update exam session %s: %w", sheet.session.SessionCode, err)
+				}
+			}
+
+			results, err := completeSession(tx, &sheet.session, now, models.TransitionOfflineComplete)
+			if err != nil {
+				return fmt.Errorf("session %s: %w", sheet.session.SessionCode, err)
+			}
+
+			report.Sessions = append(report.Sessions, dto.OfflineSessionResult{
+				SessionCode:       sheet.session.SessionCode,
+				UserID:            sheet.session.UserID,
+				Answered:          answered,
+				TotalScore:        results.Summary.TotalScore,
+				MaxScore:          results.Summary.MaxScore,
+				OverallPercentage: results.Summary.OverallPercentage,
+				OverallGrade:      results.Summary.OverallGrade,
+				IsPassed:          results.Summary.IsPassed,
+			})
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRun) {
+		if errors.Is(err, ErrOfflineAnswersRejected) {
+			return report, err
+		}
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// loadOfflineSheets groups the rows by session and validates them against the exam
+// questions, adding one error per invalid row to the report
+func loadOfflineSheets(tx *gorm.DB, answers []OfflineAnswer, report *dto.OfflineAnswerReport) ([]*offlineSheet, error) {
+	sheets := make(map[string]*offlineSheet)
+	var order []*offlineSheet
+	seen := make(map[string]int) // session code and order number to the first row answering it
+
+	addError := func(row int, field, format string, args ...interface{}) {
+		report.Errors = append(report.Errors, dto.ImportRowError{Row: row, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, answer := range answers {
+		sheet, ok := sheets[answer.SessionCode]
+		if !ok {
+			loaded, err := loadOfflineSheet(tx, answer.SessionCode)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			sheets[answer.SessionCode] = loaded
+			sheet = loaded
+			if loaded != nil {
+				order = append(order, loaded)
+			}
+		}
+
+		if sheet == nil {
+			addError(answer.Row, "session_code", "exam session %s not found", answer.SessionCode)
+			continue
+		}
+		if sheet.session.Status == models.SessionCompleted {
+			addError(answer.Row, "session_code", "exam session %s is already completed", answer.SessionCode)
+			continue
+		}
+		if sheet.session.Status == models.SessionVoided {
+			addError(answer.Row, "session_code", "exam session %s is voided", answer.SessionCode)
+			continue
+		}
+
+		eq, ok := sheet.questions[answer.OrderNumber]
+		if !ok {
+			addError(answer.Row, "order_number", "question %d is not part of exam session %s", answer.OrderNumber, answer.SessionCode)
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%d", answer.SessionCode, answer.OrderNumber)
+		if first, ok := seen[key]; ok {
+			addError(answer.Row, "order_number", "question %d of session %s is already answered on row %d", answer.OrderNumber, answer.SessionCode, first)
+			continue
+		}
+		seen[key] = answer.Row
+
+		if answer.OptionLetter != "" {
+			index := letterIndex(answer.OptionLetter)
+			if index < 0 || index >= len(eq.Question.Options) {
+				addError(answer.Row, "option_letter", "option %s does not exist, question %d has %d options",
+					answer.OptionLetter, answer.OrderNumber, len(eq.Question.Options))
+				continue
+			}
+		}
+
+		sheet.answers = append(sheet.answers, answer)
+	}
+
+	// Sessions whose every row failed have nothing to complete
+	valid := order[:0]
+	for _, sheet := range order {
+		if len(sheet.answers) > 0 {
+			valid = append(valid, sheet)
+		}
+	}
+	return valid, nil
+}
+
+// loadOfflineSheet loads a session with its questions and options ordered by ID like the printed paper
+func loadOfflineSheet(tx *gorm.DB, sessionCode string) (*offlineSheet, error) {
+	var session models.ExamSession
+	if err := tx.Where("session_code = ?", sessionCode).First(&session).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam session %s: %w", sessionCode, err)
+	}
+
+	var examQuestions []models.ExamQuestion
+	if err := tx.Preload("Question", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).
+		Preload("Question.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Order("id ASC")
+		}).
+		Where("exam_session_id = ?", session.ID).
+		Find(&examQuestions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load exam questions of session %s: %w", sessionCode, err)
+	}
+
+	sheet := &offlineSheet{session: session, questions: make(map[int]*models.ExamQuestion, len(examQuestions))}
+	for i := range examQuestions {
+		sheet.questions[examQuestions[i].OrderNumber] = &examQuestions[i]
+	}
+	return sheet, nil
+}
+
+// saveOfflineAnswer creates or replaces the answer of an exam question
+func saveOfflineAnswer(tx *gorm.DB, eq *models.ExamQuestion, option models.QuestionOption, score int, answeredAt time.Time) error {
+	var answer models.UserAnswer
+	err := tx.Where("exam_session_id = ? AND exam_question_id = ?", eq.ExamSessionID, eq.ID).First(&answer).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error checking existing answer: %w", err)
+	}
+
+	answer.ExamSessionID = eq.ExamSessionID
+	answer.ExamQuestionID = eq.ID
+	answer.QuestionID = eq.QuestionID
+	answer.QuestionOptionID = option.ID
+	answer.Score = score
+	answer.AnsweredAt = answeredAt
+
+	if err := tx.Save(&answer).Error; err != nil {
+		return fmt.Errorf("failed to save answer to question %d: %w", eq.OrderNumber, err)
+	}
+	return nil
+}
+
+// loadAllScorers returns the scorer of every registered category
+func loadAllScorers(tx *gorm.DB) (map[string]scoring.Scorer, error) {
+	var categories []models.Category
+	if err := tx.Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return loadScorers(categories)
+}
+
+// letterIndex returns the option index of a letter (A is 0), -1 when it is not a single letter
+func letterIndex(letter string) int {
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+		return -1
+	}
+	return int(letter[0] - 'A')
+}
@@ -0,0 +1,156 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// expectOfflineSession expects a session to be looked up by code, with no rows when status is empty
+func expectOfflineSession(mock sqlmock.Sqlmock, code string, id uint, status models.SessionStatus) {
+	rows := sqlmock.NewRows([]string{"id", "user_id", "session_code", "status"})
+	if status != "" {
+		rows.AddRow(id, "user-1", code, status)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions" WHERE session_code = $1`)).
+		WithArgs(code, 1).
+		WillReturnRows(rows)
+}
+
+// expectOfflineQuestions expects the paper of session 9: question 5 with options 11 (A, 1 point)
+// and 12 (B, 4 points), then question 6 with options 13 (A, 4 points) and 14 (B, 2 points)
+func expectOfflineQuestions(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_questions" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "question_id", "category", "order_number"}).
+			AddRow(31, 9, 5, "TEKNIS", 1).
+			AddRow(32, 9, 6, "TEKNIS", 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "questions" WHERE "questions"."id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(5, "TEKNIS").AddRow(6, "TEKNIS"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_options" WHERE "question_options"."question_id" IN ($1,$2) ORDER BY id ASC`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "question_id", "score"}).
+			AddRow(11, 5, 1).
+			AddRow(12, 5, 4).
+			AddRow(13, 6, 4).
+			AddRow(14, 6, 2))
+}
+
+func TestIngestOfflineAnswersRejectsEveryRowWhenOneIsInvalid(t *testing.T) {
+	service, mock := newMockExamService(t)
+
+	mock.ExpectBegin()
+	expectOfflineSession(mock, "S1", 9, models.SessionNotStarted)
+	expectOfflineQuestions(mock)
+	expectOfflineSession(mock, "S2", 0, "")
+	expectOfflineSession(mock, "S3", 10, models.SessionCompleted)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_questions" WHERE exam_session_id = $1`)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	report, err := service.IngestOfflineAnswers(context.Background(), []OfflineAnswer{
+		{Row: 2, SessionCode: "S1", OrderNumber: 1, OptionLetter: "B"},
+		{Row: 3, SessionCode: "S1", OrderNumber: 1, OptionLetter: "A"},
+		{Row: 4, SessionCode: "S1", OrderNumber: 2, OptionLetter: "C"},
+		{Row: 5, SessionCode: "S1", OrderNumber: 3, OptionLetter: "A"},
+		{Row: 6, SessionCode: "S2", OrderNumber: 1, OptionLetter: "A"},
+		{Row: 7, SessionCode: "S3", OrderNumber: 1, OptionLetter: "A"},
+	}, false)
+	if !errors.Is(err, ErrOfflineAnswersRejected) {
+		t.Fatalf("IngestOfflineAnswers() error = %v, want %v", err, ErrOfflineAnswersRejected)
+	}
+
+	want := map[int]string{
+		3: "question 1 of session S1 is already answered on row 2",
+		4: "option C does not exist, question 2 has 2 options",
+		5: "question 3 is not part of exam session S1",
+		6: "exam session S2 not found",
+		7: "exam session S3 is already completed",
+	}
+	if len(report.Errors) != len(want) {
+		t.Fatalf("report errors = %+v, want %d errors", report.Errors, len(want))
+	}
+	for _, rowErr := range report.Errors {
+		if want[rowErr.Row] != rowErr.Message {
+			t.Errorf("row %d error = %q, want %q", rowErr.Row, rowErr.Message, want[rowErr.Row])
+		}
+	}
+	if len(report.Sessions) != 0 || report.AnsweredRows != 0 {
+		t.Errorf("report = %d sessions and %d answered rows, want nothing completed", len(report.Sessions), report.AnsweredRows)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIngestOfflineAnswersDryRunRollsBack(t *testing.T) {
+	service, mock := newMockExamService(t)
+
+	mock.ExpectBegin()
+	expectOfflineSession(mock, "S1", 9, models.SessionNotStarted)
+	expectOfflineQuestions(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "scoring_scheme"}).AddRow(1, "TEKNIS", models.ScoringSchemeGraded))
+
+	// Question 1 answered with B, question 2 left blank
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_answers" WHERE (exam_session_id = $1 AND exam_question_id = $2)`)).
+		WithArgs(9, 31, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_answers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(90))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "exam_sessions" SET "started_at"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Completion with the normal scoring
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "exam_sessions" SET "completed_at"=$1,"status"=$2,"updated_at"=$3 WHERE (id = $4 AND status = $5)`)).
+		WithArgs(sqlmock.AnyArg(), models.SessionCompleted, sqlmock.AnyArg(), 9, models.SessionNotStarted).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "session_transitions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE question_count > 0`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "scoring_scheme"}).AddRow(1, "TEKNIS", models.ScoringSchemeGraded))
+	expectOfflineQuestions(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_tags"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "tag_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_answers" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "exam_question_id", "question_option_id", "score"}).
+			AddRow(90, 9, 31, 12, 4))
+	expectDefaultBlueprint(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "exam_results"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(71))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "exam_summaries"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "domain_events"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	report, err := service.IngestOfflineAnswers(context.Background(), []OfflineAnswer{
+		{Row: 2, SessionCode: "S1", OrderNumber: 1, OptionLetter: "B"},
+		{Row: 3, SessionCode: "S1", OrderNumber: 2},
+	}, true)
+	if err != nil {
+		t.Fatalf("IngestOfflineAnswers() error = %v", err)
+	}
+	if !report.DryRun || report.AnsweredRows != 1 || report.BlankRows != 1 {
+		t.Errorf("report = dry run %v, %d answered, %d blank rows, want a dry run with 1 of each",
+			report.DryRun, report.AnsweredRows, report.BlankRows)
+	}
+	if len(report.Sessions) != 1 {
+		t.Fatalf("report sessions = %+v, want session S1", report.Sessions)
+	}
+	if session := report.Sessions[0]; session.Answered != 1 || session.TotalScore != 4 || session.MaxScore != 8 || session.IsPassed {
+		t.Errorf("session = %+v, want 4 of 8 points and failed", session)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,211 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrOverrideReasonRequired is returned when an admin override has no reason
+	ErrOverrideReasonRequired = errors.New("a reason is required to override an exam session")
+	// ErrInvalidSessionOverride is returned when the session status does not allow the override
+	ErrInvalidSessionOverride = errors.New("exam session status does not allow this override")
+)
+
+// sessionSnapshot is the audited state of a session before and after an admin override
+type sessionSnapshot struct {
+	Status      models.SessionStatus `json:"status"`
+	StartedAt   *time.Time           `json:"started_at"`
+	CompletedAt *time.Time           `json:"completed_at"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+	Duration    int                  `json:"duration"`
+	Answers     int                  `json:"answers"`
+	TotalScore  *int                 `json:"total_score,omitempty"`
+	IsPassed    *bool                `json:"is_passed,omitempty"`
+}
+
+// SessionOverride is the outcome of an admin override of an exam session
+type SessionOverride struct {
+	Action         string
+	PreviousStatus models.SessionStatus
+	Reason         string
+	Session        models.ExamSession
+}
+
+// ExtendSession gives an unfinished session more time, e.g. for an accessibility accommodation
+func (s *ExamService) ExtendSession(ctx context.Context, sessionID uint, minutes int, reason string) (*SessionOverride, error) {
+	return s.overrideSession(ctx, sessionID, models.AuditActionSessionExtend, reason, models.ActiveSessionStatuses,
+		func(tx *gorm.DB, session *models.ExamSession, now time.Time) error {
+			return tx.Model(session).Updates(map[string]interface{}{
+				"expires_at": session.ExpiresAt.Add(time.Duration(minutes) * time.Minute),
+				"duration":   session.Duration + minutes,
+			}).Error
+		})
+}
+
+// ForceCompleteSession completes and scores an unfinished or expired session with the answers it has
+func (s *ExamService) ForceCompleteSession(ctx context.Context, sessionID uint, reason string) (*SessionOverride, error) {
+	return s.overrideSession(ctx, sessionID, models.AuditActionSessionForceComplete, reason, []models.SessionStatus{models.SessionNotStarted, models.SessionInProgress, models.SessionExpired},
+		func(tx *gorm.DB, session *models.ExamSession, now time.Time) error {
+			_, err := completeSession(tx, session, now, models.AuditActionSessionForceComplete)
+			return err
+		})
+}
+
+// ResetSession discards the answers and results of a session and puts it back to NOT_STARTED with
+// its full duration, or the sitting deadline for a sitting session. The drawn questions are kept.
+func (s *ExamService) ResetSession(ctx context.Context, sessionID uint, reason string) (*SessionOverride, error) {
+	return s.overrideSession(ctx, sessionID, models.AuditActionSessionReset, reason, []models.SessionStatus{models.SessionNotStarted, models.SessionInProgress, models.SessionCompleted, models.SessionExpired},
+		func(tx *gorm.DB, session *models.ExamSession, now time.Time) error {
+			if err := tx.Unscoped().Where("exam_session_id = ?", session.ID).Delete(&models.UserAnswer{}).Error; err != nil {
+				return fmt.Errorf("failed to remove user answers of session %d: %w", session.ID, err)
+			}
+			if err := tx.Unscoped().Where("exam_session_id = ?", session.ID).Delete(&models.ExamResult{}).Error; err != nil {
+				return fmt.Errorf("failed to remove exam results of session %d: %w", session.ID, err)
+			}
+			if err := tx.Unscoped().Where("exam_session_id = ?", session.ID).Delete(&models.ExamTagResult{}).Error; err != nil {
+				return fmt.Errorf("failed to remove exam tag results of session %d: %w", session.ID, err)
+			}
+			if err := tx.Unscoped().Where("exam_session_id = ?", session.ID).Delete(&models.ExamSummary{}).Error; err != nil {
+				return fmt.Errorf("failed to remove exam summary of session %d: %w", session.ID, err)
+			}
+
+			expiresAt := now.Add(time.Duration(session.Duration) * time.Minute)
+			if session.SittingID != nil {
+				var sitting models.Sitting
+				if err := tx.First(&sitting, *session.SittingID).Error; err == nil {
+					expiresAt = sitting.EndsAt
+				} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("failed to get sitting of session %d: %w", session.ID, err)
+				}
+			}
+
+			return transitionSession(tx, session, models.SessionNotStarted, models.AuditActionSessionReset, map[string]interface{}{
+				"started_at":     nil,
+				"completed_at":   nil,
+				"expires_at":     expiresAt,
+				"completion_key": nil,
+			})
+		})
+}
+
+// VoidSession excludes an attempt from leaderboards and statistics. Its answers and results are kept.
+func (s *ExamService) VoidSession(ctx context.Context, sessionID uint, reason string) (*SessionOverride, error) {
+	return s.overrideSession(ctx, sessionID, models.AuditActionSessionVoid, reason, []models.SessionStatus{models.SessionNotStarted, models.SessionInProgress, models.SessionCompleted, models.SessionExpired},
+		func(tx *gorm.DB, session *models.ExamSession, now time.Time) error {
+			return transitionSession(tx, session, models.SessionVoided, models.AuditActionSessionVoid, nil)
+		})
+}
+
+// ReopenSession gives an expired session minutes more from now, resuming it where it stopped
+func (s *ExamService) ReopenSession(ctx context.Context, sessionID uint, minutes int, reason string) (*SessionOverride, error) {
+	return s.overrideSession(ctx, sessionID, models.AuditActionSessionReopen, reason, []models.SessionStatus{models.SessionExpired},
+		func(tx *gorm.DB, session *models.ExamSession, now time.Time) error {
+			status := models.SessionNotStarted
+			if session.StartedAt != nil {
+				status = models.SessionInProgress
+			}
+			return transitionSession(tx, session, status, models.AuditActionSessionReopen, map[string]interface{}{
+				"expires_at": now.Add(time.Duration(minutes) * time.Minute),
+				"duration":   session.Duration + minutes,
+			})
+		})
+}
+
+// overrideSession applies an admin override to a session whose status is one of allowed and
+// records it in the audit log with the reason, all in one transaction
+func (s *ExamService) overrideSession(ctx context.Context, sessionID uint, action, reason string, allowed []models.SessionStatus, apply func(tx *gorm.DB, session *models.ExamSession, now time.Time) error) (*SessionOverride, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrOverrideReasonRequired
+	}
+
+	// Sessions past their deadline must be EXPIRED before deciding what is allowed
+	s.CheckAndUpdateExpiredSessions(ctx)
+
+	override := &SessionOverride{Action: action, Reason: reason}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		session, err := lockSession(tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(allowed, session.Status) {
+			return fmt.Errorf("%w: %s is %s", ErrInvalidSessionOverride, action, session.Status)
+		}
+
+		before, err := toSessionSnapshot(tx, session)
+		if err != nil {
+			return err
+		}
+
+		if err := apply(tx, session, time.Now()); err != nil {
+			return err
+		}
+
+		var changed models.ExamSession
+		if err := tx.First(&changed, sessionID).Error; err != nil {
+			return fmt.Errorf("failed to get exam session: %w", err)
+		}
+		after, err := toSessionSnapshot(tx, &changed)
+		if err != nil {
+			return err
+		}
+
+		if err := audit.Record(tx, audit.Entry{
+			Action:     action,
+			EntityType: models.ExamSession{}.TableName(),
+			EntityID:   strconv.FormatUint(uint64(changed.ID), 10),
+			Before:     before,
+			After:      after,
+			Reason:     reason,
+		}); err != nil {
+			return err
+		}
+
+		override.PreviousStatus = before.Status
+		override.Session = changed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return override, nil
+}
+
+// toSessionSnapshot captures the audited state of a session with its answer count and summary
+func toSessionSnapshot(tx *gorm.DB, session *models.ExamSession) (*sessionSnapshot, error) {
+	snapshot := &sessionSnapshot{
+		Status:      session.Status,
+		StartedAt:   session.StartedAt,
+		CompletedAt: session.CompletedAt,
+		ExpiresAt:   session.ExpiresAt,
+		Duration:    session.Duration,
+	}
+
+	var answers int64
+	if err := tx.Model(&models.UserAnswer{}).Where("exam_session_id = ?", session.ID).Count(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to count user answers of session %d: %w", session.ID, err)
+	}
+	snapshot.Answers = int(answers)
+
+	var summaries []models.ExamSummary
+	if err := tx.Where("exam_session_id = ?", session.ID).Limit(1).Find(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam summary of session %d: %w", session.ID, err)
+	}
+	if len(summaries) > 0 {
+		snapshot.TotalScore = &summaries[0].TotalScore
+		snapshot.IsPassed = &summaries[0].IsPassed
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,138 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// expectLockedSession expects a session to be selected for update
+func expectLockedSession(mock sqlmock.Sqlmock, examSession models.ExamSession) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions"`)+`.*FOR UPDATE$`).
+		WithArgs(examSession.ID, 1).
+		WillReturnRows(sessionRows(examSession))
+}
+
+// expectSessionSnapshot expects the answers and summary of session 4 to be read for the audit log
+func expectSessionSnapshot(mock sqlmock.Sqlmock, answers int, totalScore *int) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "user_answers" WHERE exam_session_id = $1`)).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(answers))
+	summaries := sqlmock.NewRows([]string{"id", "exam_session_id", "total_score", "is_passed"})
+	if totalScore != nil {
+		summaries.AddRow(50, 4, *totalScore, true)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_summaries" WHERE exam_session_id = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(summaries)
+}
+
+func TestOverrideSessionRequiresReason(t *testing.T) {
+	service, mock := newMockExamService(t)
+
+	if _, err := service.VoidSession(context.Background(), 4, "  "); !errors.Is(err, ErrOverrideReasonRequired) {
+		t.Fatalf("VoidSession() error = %v, want %v", err, ErrOverrideReasonRequired)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOverrideSessionRefusesStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   models.SessionStatus
+		override func(service *ExamService) (*SessionOverride, error)
+	}{
+		{"extend completed", models.SessionCompleted, func(service *ExamService) (*SessionOverride, error) {
+			return service.ExtendSession(context.Background(), 4, 10, "late bus")
+		}},
+		{"force complete voided", models.SessionVoided, func(service *ExamService) (*SessionOverride, error) {
+			return service.ForceCompleteSession(context.Background(), 4, "power cut")
+		}},
+		{"reset voided", models.SessionVoided, func(service *ExamService) (*SessionOverride, error) {
+			return service.ResetSession(context.Background(), 4, "retake")
+		}},
+		{"reopen in progress", models.SessionInProgress, func(service *ExamService) (*SessionOverride, error) {
+			return service.ReopenSession(context.Background(), 4, 10, "power cut")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+
+			expectNoExpiredSessions(mock)
+			mock.ExpectBegin()
+			expectLockedSession(mock, models.ExamSession{ID: 4, UserID: "1234", Status: tt.status, ExpiresAt: time.Now()})
+			mock.ExpectRollback()
+
+			if _, err := tt.override(service); !errors.Is(err, ErrInvalidSessionOverride) {
+				t.Fatalf("error = %v, want %v", err, ErrInvalidSessionOverride)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestResetSessionDiscardsAnswersAndResults(t *testing.T) {
+	service, mock := newMockExamService(t)
+	totalScore := 7
+	var reason, before, after string
+
+	expectNoExpiredSessions(mock)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions"`)+`.*FOR UPDATE$`).
+		WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "duration", "expires_at"}).
+			AddRow(4, "1234", models.SessionCompleted, 90, time.Now().Add(-time.Hour)))
+	expectSessionSnapshot(mock, 2, &totalScore)
+	for _, table := range []string{"user_answers", "exam_results", "exam_tag_results", "exam_summaries"} {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "` + table + `" WHERE exam_session_id = $1`)).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	// Back to NOT_STARTED with the full 90 minutes of the session
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "exam_sessions" SET "completed_at"=$1,"completion_key"=$2,"expires_at"=$3,"started_at"=$4,"status"=$5,"updated_at"=$6 WHERE (id = $7 AND status = $8)`)).
+		WithArgs(nil, nil, sqlmocktest.TimeFromNow(90*time.Minute), nil, models.SessionNotStarted, sqlmock.AnyArg(), 4, models.SessionCompleted).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "session_transitions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions" WHERE "exam_sessions"."id" = $1`)).
+		WithArgs(4, 1).
+		WillReturnRows(sessionRows(models.ExamSession{ID: 4, UserID: "1234", Status: models.SessionNotStarted, ExpiresAt: time.Now()}))
+	expectSessionSnapshot(mock, 0, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), models.AuditActionSessionReset, "exam_sessions", "4",
+			sqlmocktest.Captured(&before), sqlmocktest.Captured(&after), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmocktest.Captured(&reason), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	override, err := service.ResetSession(context.Background(), 4, " retake after power cut ")
+	if err != nil {
+		t.Fatalf("ResetSession() error = %v", err)
+	}
+	if override.PreviousStatus != models.SessionCompleted || override.Session.Status != models.SessionNotStarted {
+		t.Errorf("override = %s -> %s, want COMPLETED -> NOT_STARTED", override.PreviousStatus, override.Session.Status)
+	}
+	if reason != "retake after power cut" {
+		t.Errorf("audited reason = %q, want the trimmed reason", reason)
+	}
+	if !strings.Contains(before, `"answers":2`) || !strings.Contains(before, `"total_score":7`) || !strings.Contains(after, `"answers":0`) {
+		t.Errorf("audited snapshots = %s -> %s, want 2 answers scoring 7 before and none after", before, after)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
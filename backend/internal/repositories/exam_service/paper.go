@@ -0,0 +1,209 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/exampaper"
+	"cutbray/pppk-json/internal/repositories/category_service"
+	"cutbray/pppk-json/internal/repositories/grading_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultPaperTitle is printed on papers whose blueprint has no name
+const defaultPaperTitle = "PPPK Exam"
+
+// ErrAnswerKeyLocked is returned when the answer key of a session that can still be taken is requested
+var ErrAnswerKeyLocked = errors.New("answer key is not available before the session is over")
+
+// GetExamPaper builds the printable paper of a stored exam session, questions in exam order
+// and options ordered by ID. The answer key is refused while the session is NOT_STARTED or IN_PROGRESS.
+func (s *ExamService) GetExamPaper(ctx context.Context, sessionCode string, withAnswerKey bool) (*exampaper.Paper, error) {
+	var examSession models.ExamSession
+	err := s.db.WithContext(ctx).
+		Preload("ExamQuestions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_number ASC")
+		}).
+		Preload("ExamQuestions.Question", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		// Options deleted after the session was drawn are still printed: the candidate was given
+		// them, and offline answers and scoring letter the options of the same unscoped list
+		Preload("ExamQuestions.Question.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Order("id ASC")
+		}).
+		Preload("Blueprint").
+		Where("session_code = ?", sessionCode).
+		First(&examSession).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam session %s: %w", sessionCode, err)
+	}
+	if withAnswerKey && examSession.Status.IsActive() {
+		return nil, fmt.Errorf("%w: session %s is %s", ErrAnswerKeyLocked, sessionCode, examSession.Status)
+	}
+
+	var categories []models.Category
+	if err := s.db.WithContext(ctx).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	paper := &exampaper.Paper{
+		Title:           defaultPaperTitle,
+		SessionCode:     examSession.SessionCode,
+		UserID:          examSession.UserID,
+		DurationMinutes: examSession.Duration,
+		GeneratedAt:     time.Now(),
+	}
+	if examSession.Blueprint != nil {
+		paper.Title = examSession.Blueprint.Name
+		paper.Blueprint = examSession.Blueprint.Code
+	}
+
+	// Number questions like the online exam so offline answers map back to exam questions
+	questions := make([]numberedQuestion, len(examSession.ExamQuestions))
+	for i, eq := range examSession.ExamQuestions {
+		questions[i] = numberedQuestion{Number: eq.OrderNumber, Question: eq.Question}
+		questions[i].Question.Category = eq.Category
+	}
+
+	if err := fillPaperSections(paper, categories, questions); err != nil {
+		return nil, err
+	}
+	return paper, nil
+}
+
+// GenerateSeedPaper draws a paper with the default blueprint and the active categories
+// without storing a session. The same seed gives the same paper while the question bank,
+// categories and tag quotas are unchanged.
+func (s *ExamService) GenerateSeedPaper(ctx context.Context, seed int64) (*exampaper.Paper, error) {
+	paper := &exampaper.Paper{
+		Title:       defaultPaperTitle,
+		SessionCode: fmt.Sprintf("SEED-%d", seed),
+		Seed:        &seed,
+		GeneratedAt: time.Now(),
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		blueprint, err := grading_service.LoadBlueprint(tx, nil)
+		if err != nil {
+			return err
+		}
+		paper.Title = blueprint.Name
+		paper.Blueprint = blueprint.Code
+		paper.DurationMinutes = blueprint.DurationMinutes
+
+		categories, err := category_service.GetActiveCategories(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get categories: %w", err)
+		}
+		if len(categories) == 0 {
+			return fmt.Errorf("no categories configured for exam generation")
+		}
+
+		rng := rand.New(rand.NewSource(seed))
+
+		var questions []models.Question
+		for _, cat := range categories {
+			picked, err := pickCategoryQuestions(tx, cat.Code, cat.QuestionCount, rng)
+			if err != nil {
+				return err
+			}
+			questions = append(questions, picked...)
+		}
+
+		if err := loadQuestionOptions(tx, questions); err != nil {
+			return err
+		}
+
+		numbered := make([]numberedQuestion, len(questions))
+		for i, question := range questions {
+			numbered[i] = numberedQuestion{Number: i + 1, Question: question}
+		}
+
+		return fillPaperSections(paper, categories, numbered)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paper, nil
+}
+
+// loadQuestionOptions loads the options of questions ordered by ID
+func loadQuestionOptions(tx *gorm.DB, questions []models.Question) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID
+	}
+
+	var options []models.QuestionOption
+	if err := tx.Where("question_id IN ?", ids).Order("id ASC").Find(&options).Error; err != nil {
+		return fmt.Errorf("failed to get question options: %w", err)
+	}
+
+	byQuestion := make(map[uint][]models.QuestionOption, len(questions))
+	for _, option := range options {
+		byQuestion[option.QuestionID] = append(byQuestion[option.QuestionID], option)
+	}
+	for i := range questions {
+		questions[i].Options = byQuestion[questions[i].ID]
+	}
+	return nil
+}
+
+// numberedQuestion is a question with the number printed on the paper
+type numberedQuestion struct {
+	Number   int
+	Question models.Question
+}
+
+// fillPaperSections groups the questions by category in order of first appearance,
+// marking the best option of each question with the category scorer
+func fillPaperSections(paper *exampaper.Paper, categories []models.Category, questions []numberedQuestion) error {
+	scorers, err := loadScorers(categories)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.Code] = category.Name
+	}
+
+	sectionIndex := make(map[string]int)
+	for _, numbered := range questions {
+		question := numbered.Question
+		index, ok := sectionIndex[question.Category]
+		if !ok {
+			index = len(paper.Sections)
+			sectionIndex[question.Category] = index
+			paper.Sections = append(paper.Sections, exampaper.Section{
+				Category: question.Category,
+				Name:     names[question.Category],
+			})
+		}
+
+		best := scorerFor(scorers, question.Category).BestOption(question.Options)
+
+		printed := exampaper.Question{Number: numbered.Number, Text: question.QuestionText}
+		for j, option := range question.Options {
+			printed.Options = append(printed.Options, exampaper.Option{
+				Letter: exampaper.OptionLetter(j),
+				Text:   option.OptionText,
+				Score:  option.Score,
+				Best:   option.ID == best.ID,
+			})
+		}
+		paper.Sections[index].Questions = append(paper.Sections[index].Questions, printed)
+	}
+
+	return nil
+}
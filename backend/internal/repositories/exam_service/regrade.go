@@ -0,0 +1,142 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/grading_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidRegrade is returned when a re-grade request selects no rule set
+var ErrInvalidRegrade = errors.New("invalid regrade request")
+
+// RegradeSessions re-applies a grading scale and pass rule to completed sessions.
+// Only grades, verdicts and the recorded rule set change: answers, scores and
+// percentages stay as they were. With dryRun the report is computed without saving.
+func (s *ExamService) RegradeSessions(ctx context.Context, req dto.RegradeRequest) (*dto.RegradeReport, error) {
+	report := &dto.RegradeReport{DryRun: req.DryRun, Sessions: []dto.RegradedSessionEntry{}}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		scale, rule, err := resolveRegradeRuleSet(tx, req)
+		if err != nil {
+			return err
+		}
+		report.GradingScaleID = scale.ID
+		report.PassRuleID = rule.ID
+
+		query := tx.Joins("JOIN exam_sessions ON exam_sessions.id = exam_summaries.exam_session_id AND exam_sessions.deleted_at IS NULL")
+		if req.BlueprintID != nil {
+			query = query.Where("exam_sessions.blueprint_id = ?", *req.BlueprintID)
+		}
+		if len(req.SessionIDs) > 0 {
+			query = query.Where("exam_summaries.exam_session_id IN ?", req.SessionIDs)
+		}
+
+		var summaries []models.ExamSummary
+		if err := query.Order("exam_summaries.exam_session_id ASC").Find(&summaries).Error; err != nil {
+			return fmt.Errorf("failed to get exam summaries: %w", err)
+		}
+
+		for i := range summaries {
+			summary := &summaries[i]
+
+			var results []models.ExamResult
+			if err := tx.Where("exam_session_id = ?", summary.ExamSessionID).Order("id ASC").Find(&results).Error; err != nil {
+				return fmt.Errorf("failed to get exam results for session %d: %w", summary.ExamSessionID, err)
+			}
+
+			entry := dto.RegradedSessionEntry{
+				ExamSessionID:  summary.ExamSessionID,
+				UserID:         summary.UserID,
+				Percentage:     summary.OverallPercentage,
+				PreviousGrade:  summary.OverallGrade,
+				PreviousPassed: summary.IsPassed,
+			}
+
+			scoring.ApplyGrading(scale, rule, summary, results)
+
+			entry.Grade = summary.OverallGrade
+			entry.IsPassed = summary.IsPassed
+			report.Sessions = append(report.Sessions, entry)
+			report.SessionsRegraded++
+			if entry.PreviousPassed != entry.IsPassed {
+				report.VerdictsChanged++
+			}
+
+			if req.DryRun {
+				continue
+			}
+
+			if err := saveGrading(tx, summary, results); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// resolveRegradeRuleSet loads the grading scale and pass rule of a re-grade request,
+// falling back to those of the selected blueprint
+func resolveRegradeRuleSet(tx *gorm.DB, req dto.RegradeRequest) (*models.GradingScale, *models.PassRule, error) {
+	scaleID, ruleID := req.GradingScaleID, req.PassRuleID
+
+	if scaleID == nil || ruleID == nil {
+		if req.BlueprintID == nil {
+			return nil, nil, fmt.Errorf("%w: grading_scale_id and pass_rule_id are required without blueprint_id", ErrInvalidRegrade)
+		}
+
+		blueprint, err := grading_service.LoadBlueprint(tx, req.BlueprintID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if scaleID == nil {
+			scaleID = &blueprint.GradingScaleID
+		}
+		if ruleID == nil {
+			ruleID = &blueprint.PassRuleID
+		}
+	}
+
+	scale, err := grading_service.LoadGradingScale(tx, *scaleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get grading scale %d: %w", *scaleID, err)
+	}
+
+	rule, err := grading_service.LoadPassRule(tx, *ruleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pass rule %d: %w", *ruleID, err)
+	}
+
+	return scale, rule, nil
+}
+
+// saveGrading stores the grades, verdicts and rule set of a summary and its category results
+func saveGrading(tx *gorm.DB, summary *models.ExamSummary, results []models.ExamResult) error {
+	for i := range results {
+		if err := tx.Model(&results[i]).
+			Select("grade", "is_passed", "grading_scale_id", "pass_rule_id").
+			Updates(&results[i]).Error; err != nil {
+			return fmt.Errorf("failed to update exam result %d: %w", results[i].ID, err)
+		}
+	}
+
+	if err := tx.Model(summary).
+		Select("overall_grade", "is_passed", "grading_scale_id", "pass_rule_id").
+		Updates(summary).Error; err != nil {
+		return fmt.Errorf("failed to update exam summary %d: %w", summary.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/grading_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// errDryRun rolls back a re-score transaction once its report has been built
+var errDryRun = errors.New("dry run")
+
+// rescoreSnapshot is the audited state of a session before and after a re-score
+type rescoreSnapshot struct {
+	TotalScore        int            `json:"total_score"`
+	MaxScore          int            `json:"max_score"`
+	OverallPercentage float64        `json:"overall_percentage"`
+	OverallGrade      string         `json:"overall_grade"`
+	IsPassed          bool           `json:"is_passed"`
+	CategoryScores    map[string]int `json:"category_scores"`
+	AnswerScores      map[uint]int   `json:"answer_scores"` // Only answers whose score changed, keyed by user answer ID
+}
+
+// UpdateOptionScore changes the score of a question option and re-scores every
+// completed session that drew the question, in a single transaction.
+// With dryRun nothing is saved and the report previews the effect of the change.
+func (s *ExamService) UpdateOptionScore(ctx context.Context, questionID, optionID uint, score int, dryRun bool) (*models.QuestionOption, *dto.RescoreReport, error) {
+	var option models.QuestionOption
+	report := newRescoreReport(dryRun, questionID)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND question_id = ?", optionID, questionID).First(&option).Error; err != nil {
+			return err
+		}
+
+		var question models.Question
+		if err := tx.First(&question, questionID).Error; err != nil {
+			return err
+		}
+
+		scorer, err := categoryScorer(tx, question.Category)
+		if err != nil {
+			return err
+		}
+		if err := scorer.ValidateOptionScore(score); err != nil {
+			return err
+		}
+
+		option.Score = score
+		if err := tx.Model(&option).Update("score", score).Error; err != nil {
+			return fmt.Errorf("failed to update option score: %w", err)
+		}
+
+		if err := rescoreQuestions(tx, []uint{questionID}, report); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, nil, err
+	}
+
+	return &option, report, nil
+}
+
+// RescoreQuestion re-scores every completed session that drew a question with the
+// current option scores, e.g. after keys were corrected outside the API
+func (s *ExamService) RescoreQuestion(ctx context.Context, questionID uint, dryRun bool) (*dto.RescoreReport, error) {
+	report := newRescoreReport(dryRun, questionID)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Question{}, questionID).Error; err != nil {
+			return err
+		}
+
+		if err := rescoreQuestions(tx, []uint{questionID}, report); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func newRescoreReport(dryRun bool, questionIDs ...uint) *dto.RescoreReport {
+	return &dto.RescoreReport{
+		DryRun:      dryRun,
+		QuestionIDs: questionIDs,
+		Sessions:    []dto.RescoredSessionEntry{},
+	}
+}
+
+// rescoreQuestions recomputes answer scores, category results, tag results and the
+// summary of every completed session that drew one of the questions, once per session.
+// Every session is considered, not only those that picked a changed option, because a
+// scheme such as right/wrong or a new maximum affects the other options of a question too.
+// Each re-scored session is recorded in the audit log with its state before and after,
+// attributed to the actor of the transaction context.
+func rescoreQuestions(tx *gorm.DB, questionIDs []uint, report *dto.RescoreReport) error {
+	if len(questionIDs) == 0 {
+		return nil
+	}
+
+	var summaries []models.ExamSummary
+	if err := tx.Where("exam_session_id IN (?)",
+		tx.Model(&models.ExamQuestion{}).Select("exam_session_id").Where("question_id IN ?", questionIDs)).
+		Order("exam_session_id ASC").
+		Find(&summaries).Error; err != nil {
+		return fmt.Errorf("failed to get affected exam summaries: %w", err)
+	}
+
+	for i := range summaries {
+		entry, err := rescoreSession(tx, &summaries[i])
+		if err != nil {
+			return err
+		}
+
+		report.SessionsRescored++
+		report.AnswersChanged += entry.AnswersChanged
+		if entry.PreviousPassed != entry.IsPassed {
+			report.VerdictsChanged++
+		}
+		report.Sessions = append(report.Sessions, *entry)
+	}
+
+	return nil
+}
+
+// rescoreSession recomputes the results of one completed session, keeping the grading
+// scale and pass rule that produced its verdict, and replaces the stored results
+func rescoreSession(tx *gorm.DB, previous *models.ExamSummary) (*dto.RescoredSessionEntry, error) {
+	var examSession models.ExamSession
+	if err := tx.First(&examSession, previous.ExamSessionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam session %d: %w", previous.ExamSessionID, err)
+	}
+
+	var previousResults []models.ExamResult
+	if err := tx.Where("exam_session_id = ?", examSession.ID).Find(&previousResults).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam results of session %d: %w", examSession.ID, err)
+	}
+
+	var previousAnswers []models.UserAnswer
+	if err := tx.Where("exam_session_id = ?", examSession.ID).Find(&previousAnswers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user answers of session %d: %w", examSession.ID, err)
+	}
+	previousScores := make(map[uint]int, len(previousAnswers))
+	for _, answer := range previousAnswers {
+		previousScores[answer.ID] = answer.Score
+	}
+
+	categories, err := sessionCategories(tx, previousResults)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := calculateSessionResults(tx, &examSession, categories, previous.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-scoring corrects scores, it must not silently switch to another rule set
+	if previous.GradingScaleID != nil && previous.PassRuleID != nil {
+		scale, err := grading_service.LoadGradingScale(tx, *previous.GradingScaleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get grading scale %d: %w", *previous.GradingScaleID, err)
+		}
+		rule, err := grading_service.LoadPassRule(tx, *previous.PassRuleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pass rule %d: %w", *previous.PassRuleID, err)
+		}
+		scoring.ApplyGrading(scale, rule, &results.Summary, results.CategoryResults)
+	}
+
+	before := toRescoreSnapshot(previous, previousResults)
+	after := toRescoreSnapshot(&results.Summary, results.CategoryResults)
+	for _, answer := range results.ChangedAnswers {
+		before.AnswerScores[answer.ID] = previousScores[answer.ID]
+		after.AnswerScores[answer.ID] = answer.Score
+	}
+
+	if err := replaceSessionResults(tx, previous, results); err != nil {
+		return nil, err
+	}
+
+	if err := audit.Record(tx, audit.Entry{
+		Action:     models.AuditActionRescore,
+		EntityType: models.ExamSession{}.TableName(),
+		EntityID:   strconv.FormatUint(uint64(examSession.ID), 10),
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RescoredSessionEntry{
+		ExamSessionID:      examSession.ID,
+		UserID:             examSession.UserID,
+		AnswersChanged:     len(results.ChangedAnswers),
+		PreviousScore:      previous.TotalScore,
+		Score:              results.Summary.TotalScore,
+		PreviousPercentage: previous.OverallPercentage,
+		Percentage:         results.Summary.OverallPercentage,
+		PreviousGrade:      previous.OverallGrade,
+		Grade:              results.Summary.OverallGrade,
+		PreviousPassed:     previous.IsPassed,
+		IsPassed:           results.Summary.IsPassed,
+	}, nil
+}
+
+// replaceSessionResults removes the stored results of a session and inserts the
+// recomputed ones. The summary keeps its ID so references to it stay valid.
+func replaceSessionResults(tx *gorm.DB, previous *models.ExamSummary, results *sessionResults) error {
+	sessionID := previous.ExamSessionID
+
+	if err := tx.Unscoped().Where("exam_session_id = ?", sessionID).Delete(&models.ExamResult{}).Error; err != nil {
+		return fmt.Errorf("failed to remove exam results of session %d: %w", sessionID, err)
+	}
+	if err := tx.Unscoped().Where("exam_session_id = ?", sessionID).Delete(&models.ExamTagResult{}).Error; err != nil {
+		return fmt.Errorf("failed to remove exam tag results of session %d: %w", sessionID, err)
+	}
+	if err := tx.Unscoped().Delete(previous).Error; err != nil {
+		return fmt.Errorf("failed to remove exam summary of session %d: %w", sessionID, err)
+	}
+
+	results.Summary.ID = previous.ID
+	results.Summary.CreatedAt = previous.CreatedAt
+
+	return createSessionResults(tx, results)
+}
+
+// sessionCategories returns the categories a session was scored with, in display order, also
+// when deactivated or removed since, with the section maximum configured when it was scored
+func sessionCategories(tx *gorm.DB, results []models.ExamResult) ([]models.Category, error) {
+	codes := make([]string, len(results))
+	maxScores := make(map[string]int, len(results))
+	for i, result := range results {
+		codes[i] = result.Category
+		maxScores[result.Category] = result.CategoryMaxScore
+	}
+
+	var categories []models.Category
+	if err := tx.Unscoped().Where("code IN ?", codes).Order("display_order ASC, id ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	for i := range categories {
+		categories[i].MaxScore = maxScores[categories[i].Code]
+	}
+	return categories, nil
+}
+
+// toRescoreSnapshot captures the audited state of a summary and its category results
+func toRescoreSnapshot(summary *models.ExamSummary, results []models.ExamResult) *rescoreSnapshot {
+	snapshot := &rescoreSnapshot{
+		TotalScore:        summary.TotalScore,
+		MaxScore:          summary.MaxScore,
+		OverallPercentage: summary.OverallPercentage,
+		OverallGrade:      summary.OverallGrade,
+		IsPassed:          summary.IsPassed,
+		CategoryScores:    make(map[string]int, len(results)),
+		AnswerScores:      make(map[uint]int),
+	}
+	for _, result := range results {
+		snapshot.CategoryScores[result.Category] = result.TotalScore
+	}
+	return snapshot
+}
@@ -0,0 +1,170 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// expectRescoreSession expects the re-score of session 9, which answered option 11 of
+// question 5 and option 13 of question 6, both TEKNIS questions graded 1-4. It was scored
+// 5 of 8 and failed; with option 11 now scoring 3 it scores 7 of 8 (87.5%) and passes the
+// 80% rule of the default blueprint.
+func expectRescoreSession(mock sqlmock.Sqlmock) {
+	completedAt := time.Date(2026, 1, 28, 11, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_summaries" WHERE exam_session_id IN (SELECT "exam_session_id" FROM "exam_questions" WHERE question_id IN ($1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "user_id", "total_score", "max_score", "overall_percentage", "overall_grade", "is_passed", "completed_at"}).
+			AddRow(50, 9, "user-1", 5, 8, 62.5, "C", false, completedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions" WHERE "exam_sessions"."id" = $1`)).
+		WithArgs(9, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow(9, "user-1", models.SessionCompleted))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_results" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "category", "category_max_score", "total_score", "max_score"}).
+			AddRow(70, 9, "TEKNIS", 0, 5, 8))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_answers" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(userAnswerRows())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE code IN ($1)`)).
+		WithArgs("TEKNIS").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "scoring_scheme"}).AddRow(1, "TEKNIS", models.ScoringSchemeGraded))
+
+	// Answer sheet with the current option scores
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_questions" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "exam_session_id", "question_id", "category", "order_number"}).
+			AddRow(31, 9, 5, "TEKNIS", 1).
+			AddRow(32, 9, 6, "TEKNIS", 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "questions" WHERE "questions"."id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(5, "TEKNIS").AddRow(6, "TEKNIS"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_options" WHERE "question_options"."question_id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "question_id", "score"}).
+			AddRow(11, 5, 3).
+			AddRow(12, 5, 4).
+			AddRow(13, 6, 4).
+			AddRow(14, 6, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_tags" WHERE "question_tags"."question_id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "tag_id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_answers" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnRows(userAnswerRows())
+
+	expectDefaultBlueprint(mock)
+
+	// Stored results are replaced, the summary keeps its ID
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "exam_results" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "exam_tag_results" WHERE exam_session_id = $1`)).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "exam_summaries" WHERE "exam_summaries"."id" = $1`)).
+		WithArgs(50).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_answers" SET "score"=$1,"updated_at"=$2 WHERE "user_answers"."deleted_at" IS NULL AND "id" = $3`)).
+		WithArgs(3, sqlmock.AnyArg(), 81).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "exam_results"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(71))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "exam_summaries"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(50))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+// expectDefaultBlueprint expects the default blueprint to be loaded: no grade bands, passed from 80%
+func expectDefaultBlueprint(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_blueprints" WHERE is_default = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "grading_scale_id", "pass_rule_id", "is_default"}).AddRow(1, 2, 3, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "grading_scales" WHERE "grading_scales"."id" = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code"}).AddRow(2, "DEFAULT"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "grading_bands" WHERE "grading_bands"."grading_scale_id" = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "pass_rules" WHERE "pass_rules"."id" = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "overall_min_percentage"}).AddRow(3, "DEFAULT", 80.0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "pass_rule_category_minimums" WHERE "pass_rule_category_minimums"."pass_rule_id" = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+}
+
+func userAnswerRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "exam_session_id", "exam_question_id", "question_option_id", "score"}).
+		AddRow(81, 9, 31, 11, 1).
+		AddRow(82, 9, 32, 13, 4)
+}
+
+func TestRescoreQuestionRecomputesTotals(t *testing.T) {
+	service, mock := newMockExamService(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "questions" WHERE "questions"."id" = $1`)).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(5, "TEKNIS"))
+	expectRescoreSession(mock)
+	mock.ExpectCommit()
+
+	report, err := service.RescoreQuestion(context.Background(), 5, false)
+	if err != nil {
+		t.Fatalf("RescoreQuestion() error = %v", err)
+	}
+	if report.SessionsRescored != 1 || report.AnswersChanged != 1 || report.VerdictsChanged != 1 {
+		t.Errorf("report = %d sessions, %d answers, %d verdicts changed, want 1 each",
+			report.SessionsRescored, report.AnswersChanged, report.VerdictsChanged)
+	}
+	entry := report.Sessions[0]
+	if entry.PreviousScore != 5 || entry.Score != 7 || entry.Percentage != 87.5 || entry.PreviousPassed || !entry.IsPassed {
+		t.Errorf("session = %+v, want 5 -> 7 points at 87.5%% and passed", entry)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectOptionScoreChange expects option 11 of question 5 to be loaded with score 1 and
+// validated against the TEKNIS scheme
+func expectOptionScoreChange(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "question_options" WHERE (id = $1 AND question_id = $2)`)).
+		WithArgs(11, 5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "question_id", "score"}).AddRow(11, 5, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "questions" WHERE "questions"."id" = $1`)).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(5, "TEKNIS"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE code = $1`)).
+		WithArgs("TEKNIS", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "scoring_scheme"}).AddRow(1, "TEKNIS", models.ScoringSchemeGraded))
+}
+
+func TestUpdateOptionScoreDryRunRollsBack(t *testing.T) {
+	service, mock := newMockExamService(t)
+
+	mock.ExpectBegin()
+	expectOptionScoreChange(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "question_options" SET "score"=$1,"updated_at"=$2 WHERE "question_options"."deleted_at" IS NULL AND "id" = $3`)).
+		WithArgs(3, sqlmock.AnyArg(), 11).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRescoreSession(mock)
+	mock.ExpectRollback()
+
+	option, report, err := service.UpdateOptionScore(context.Background(), 5, 11, 3, true)
+	if err != nil {
+		t.Fatalf("UpdateOptionScore() error = %v", err)
+	}
+	if option.Score != 3 || !report.DryRun {
+		t.Errorf("option score = %d, dry run = %v, want the previewed score 3 of a dry run", option.Score, report.DryRun)
+	}
+	if report.SessionsRescored != 1 || report.Sessions[0].Score != 7 {
+		t.Errorf("report = %+v, want session 9 previewed at 7 points", report)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,213 @@
+package exam_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// answerSheet holds every question of an exam session together with the answer given, if any
+type answerSheet struct {
+	questions []models.ExamQuestion
+	answers   map[uint]*models.UserAnswer // keyed by exam question ID
+}
+
+// scoreTally accumulates scores of a group of exam questions (a category or a tag)
+type scoreTally struct {
+	TotalQuestions int
+	TotalAnswered  int
+	TotalScore     int
+	MaxScore       int
+}
+
+// percentage returns the score as a percentage of maxScore, 0 when maxScore is not positive
+func percentage(score, maxScore int) float64 {
+	if maxScore <= 0 {
+		return 0
+	}
+	return float64(score) / float64(maxScore) * 100.0
+}
+
+// tagTally is a scoreTally for a single tag
+type tagTally struct {
+	scoreTally
+	TagID   uint
+	TagName string
+}
+
+// loadAnswerSheet loads the questions, options, tags and answers of an exam session
+func loadAnswerSheet(tx *gorm.DB, examSessionID uint) (*answerSheet, error) {
+	var examQuestions []models.ExamQuestion
+	if err := tx.Preload("Question", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).
+		Preload("Question.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Order("id ASC")
+		}).
+		Preload("Question.Tags").
+		Where("exam_session_id = ?", examSessionID).
+		Order("order_number ASC").
+		Find(&examQuestions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load exam questions: %w", err)
+	}
+
+	var userAnswers []models.UserAnswer
+	if err := tx.Where("exam_session_id = ?", examSessionID).Find(&userAnswers).Error; err != nil {
+		return nil, fmt.Errorf("failed to load user answers: %w", err)
+	}
+
+	answers := make(map[uint]*models.UserAnswer, len(userAnswers))
+	for i := range userAnswers {
+		answers[userAnswers[i].ExamQuestionID] = &userAnswers[i]
+	}
+
+	return &answerSheet{questions: examQuestions, answers: answers}, nil
+}
+
+// loadScorers returns the scorer of every category keyed by category code
+func loadScorers(categories []models.Category) (map[string]scoring.Scorer, error) {
+	scorers := make(map[string]scoring.Scorer, len(categories))
+	for _, category := range categories {
+		scorer, err := scoring.ForCategory(&category)
+		if err != nil {
+			return nil, fmt.Errorf("category %s: %w", category.Code, err)
+		}
+		scorers[category.Code] = scorer
+	}
+	return scorers, nil
+}
+
+// scorerFor returns the scorer of a category, falling back to graded scoring for unknown categories
+func scorerFor(scorers map[string]scoring.Scorer, category string) scoring.Scorer {
+	if scorer, ok := scorers[category]; ok {
+		return scorer
+	}
+	scorer, _ := scoring.ForScheme(models.ScoringSchemeGraded)
+	return scorer
+}
+
+// categoryScorer returns the scorer of a category, unknown categories use the graded scorer
+func categoryScorer(tx *gorm.DB, category string) (scoring.Scorer, error) {
+	var cat models.Category
+	err := tx.Where("code = ?", category).First(&cat).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get category %s: %w", category, err)
+	}
+
+	scorer, err := scoring.ForCategory(&cat)
+	if err != nil {
+		return nil, fmt.Errorf("category %s: %w", category, err)
+	}
+	return scorer, nil
+}
+
+// scoreAnswer scores a selected option with the scorer of the given category
+func scoreAnswer(tx *gorm.DB, category string, selected models.QuestionOption, options []models.QuestionOption) (int, error) {
+	scorer, err := categoryScorer(tx, category)
+	if err != nil {
+		return 0, err
+	}
+
+	return scorer.ScoreAnswer(selected, options), nil
+}
+
+// selectedOption finds the option chosen in an answer among the question options
+func selectedOption(answer *models.UserAnswer, options []models.QuestionOption) (models.QuestionOption, bool) {
+	for _, option := range options {
+		if option.ID == answer.QuestionOptionID {
+			return option, true
+		}
+	}
+	return models.QuestionOption{}, false
+}
+
+// tally scores the answer sheet with the category scorers, grouped by category code and by tag.
+// Answers whose stored score disagrees with the scorer are corrected in place and returned
+// so the caller can persist them.
+func (sheet *answerSheet) tally(scorers map[string]scoring.Scorer) (map[string]*scoreTally, []*tagTally, []*models.UserAnswer) {
+	byCategory := make(map[string]*scoreTally)
+	byTag := make(map[uint]*tagTally)
+	var changed []*models.UserAnswer
+
+	for _, eq := range sheet.questions {
+		scorer := scorerFor(scorers, eq.Category)
+		options := eq.Question.Options
+		maxScore := scorer.MaxScore(options)
+
+		score := 0
+		answered := false
+		if answer, ok := sheet.answers[eq.ID]; ok {
+			if option, found := selectedOption(answer, options); found {
+				answered = true
+				score = scorer.ScoreAnswer(option, options)
+				if answer.Score != score {
+					answer.Score = score
+					changed = append(changed, answer)
+				}
+			}
+		}
+
+		tallies := []*scoreTally{}
+		if byCategory[eq.Category] == nil {
+			byCategory[eq.Category] = &scoreTally{}
+		}
+		tallies = append(tallies, byCategory[eq.Category])
+
+		for _, tag := range eq.Question.Tags {
+			if byTag[tag.ID] == nil {
+				byTag[tag.ID] = &tagTally{TagID: tag.ID, TagName: tag.Name}
+			}
+			tallies = append(tallies, &byTag[tag.ID].scoreTally)
+		}
+
+		for _, t := range tallies {
+			t.TotalQuestions++
+			t.MaxScore += maxScore
+			t.TotalScore += score
+			if answered {
+				t.TotalAnswered++
+			}
+		}
+	}
+
+	tags := make([]*tagTally, 0, len(byTag))
+	for _, t := range byTag {
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].TagName < tags[j].TagName
+	})
+
+	return byCategory, tags, changed
+}
+
+// saveAnswerScores persists answers whose score was corrected by a scorer
+func saveAnswerScores(tx *gorm.DB, answers []*models.UserAnswer) error {
+	for _, answer := range answers {
+		if err := tx.Model(answer).Update("score", answer.Score).Error; err != nil {
+			return fmt.Errorf("failed to update score of answer %d: %w", answer.ID, err)
+		}
+	}
+	return nil
+}
+
+// toTagResults converts tag tallies into exam tag result rows
+func toTagResults(examSessionID uint, tags []*tagTally) []models.ExamTagResult {
+	results := make([]models.ExamTagResult, len(tags))
+	for i, t := range tags {
+		results[i] = models.ExamTagResult{
+			ExamSessionID:  examSessionID,
+			TagID:          t.TagID,
+			TagName:        t.TagName,
+			TotalQuestions: t.TotalQuestions,
+			TotalAnswered:  t.TotalAnswered,
+			TotalScore:     t.TotalScore,
+			MaxScore:       t.MaxScore,
+			Percentage:     percentage(t.TotalScore, t.MaxScore),
+		}
+	}
+	return results
+}
@@ -0,0 +1,100 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/audit"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrIllegalTransition is returned when the transition table does not allow a status change,
+// or the session changed status in the meantime
+var ErrIllegalTransition = errors.New("illegal exam session transition")
+
+// Errors returned when the status of a session does not allow what a candidate asked for
+var (
+	ErrSessionNotStarted = errors.New("exam session has not been started")
+	ErrSessionExpired    = errors.New("exam session has expired")
+	ErrSessionVoided     = errors.New("exam session has been voided")
+	ErrSessionCompleted  = errors.New("exam session has already been completed")
+)
+
+// requireInProgress returns the error telling why a candidate cannot work on a session
+// that is not in progress
+func requireInProgress(session *models.ExamSession) error {
+	switch session.Status {
+	case models.SessionInProgress:
+		return nil
+	case models.SessionNotStarted:
+		return fmt.Errorf("%w: session %d", ErrSessionNotStarted, session.ID)
+	case models.SessionCompleted:
+		return fmt.Errorf("%w: session %d", ErrSessionCompleted, session.ID)
+	case models.SessionExpired:
+		return fmt.Errorf("%w: session %d expired at %s", ErrSessionExpired, session.ID, session.ExpiresAt.Format(time.RFC3339))
+	case models.SessionVoided:
+		return fmt.Errorf("%w: session %d", ErrSessionVoided, session.ID)
+	}
+	return fmt.Errorf("%w: session %d has unknown status %s", ErrIllegalTransition, session.ID, session.Status)
+}
+
+// transitionSession moves a session to status to with the other column updates, when the
+// transition table allows it, and records the transition with its trigger and the actor
+// of the context of tx. The update only applies while the session still has the status it
+// was loaded with, so a concurrent change fails instead of being overwritten.
+func transitionSession(tx *gorm.DB, session *models.ExamSession, to models.SessionStatus, trigger string, updates map[string]interface{}) error {
+	from := session.Status
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: session %d cannot go from %s to %s", ErrIllegalTransition, session.ID, from, to)
+	}
+
+	if updates == nil {
+		updates = make(map[string]interface{})
+	}
+	updates["status"] = to
+
+	result := tx.Model(&models.ExamSession{}).
+		Where("id = ? AND status = ?", session.ID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update exam session %d: %w", session.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: session %d is no longer %s", ErrIllegalTransition, session.ID, from)
+	}
+	session.Status = to
+
+	transition := models.SessionTransition{
+		ExamSessionID: session.ID,
+		FromStatus:    from,
+		ToStatus:      to,
+		Trigger:       trigger,
+		Actor:         audit.ActorFromContext(tx.Statement.Context),
+		RequestID:     audit.RequestIDFromContext(tx.Statement.Context),
+		CreatedAt:     time.Now(),
+	}
+	if err := tx.Create(&transition).Error; err != nil {
+		return fmt.Errorf("failed to record transition of exam session %d: %w", session.ID, err)
+	}
+	return nil
+}
+
+// GetSessionTransitions returns the status changes of a session, oldest first
+func (s *ExamService) GetSessionTransitions(ctx context.Context, sessionID uint) ([]models.SessionTransition, error) {
+	var session models.ExamSession
+	if err := s.db.WithContext(ctx).Select("id").First(&session, sessionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exam session: %w", err)
+	}
+
+	var transitions []models.SessionTransition
+	if err := s.db.WithContext(ctx).
+		Where("exam_session_id = ?", sessionID).
+		Order("created_at ASC, id ASC").
+		Find(&transitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get transitions of exam session %d: %w", sessionID, err)
+	}
+	return transitions, nil
+}
@@ -0,0 +1,149 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRequireInProgress(t *testing.T) {
+	tests := []struct {
+		status models.SessionStatus
+		want   error
+	}{
+		{models.SessionInProgress, nil},
+		{models.SessionNotStarted, ErrSessionNotStarted},
+		{models.SessionCompleted, ErrSessionCompleted},
+		{models.SessionExpired, ErrSessionExpired},
+		{models.SessionVoided, ErrSessionVoided},
+		{models.SessionStatus("UNKNOWN"), ErrIllegalTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			err := requireInProgress(&models.ExamSession{ID: 1, Status: tt.status})
+			if tt.want == nil && err != nil {
+				t.Fatalf("requireInProgress() error = %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("requireInProgress() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		from         models.SessionStatus
+		to           models.SessionStatus
+		rowsAffected int64 // -1 when the transition table refuses it before any query
+		wantErr      error
+	}{
+		{name: "start", from: models.SessionNotStarted, to: models.SessionInProgress, rowsAffected: 1},
+		{name: "void completed", from: models.SessionCompleted, to: models.SessionVoided, rowsAffected: 1},
+		{name: "changed meanwhile", from: models.SessionInProgress, to: models.SessionCompleted, rowsAffected: 0, wantErr: ErrIllegalTransition},
+		{name: "restart completed", from: models.SessionCompleted, to: models.SessionInProgress, rowsAffected: -1, wantErr: ErrIllegalTransition},
+		{name: "leave voided", from: models.SessionVoided, to: models.SessionNotStarted, rowsAffected: -1, wantErr: ErrIllegalTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+			session := &models.ExamSession{ID: 9, Status: tt.from}
+
+			if tt.rowsAffected >= 0 {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE "exam_sessions" SET`) + `.*` + regexp.QuoteMeta(`WHERE (id = $`)).
+					WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+				mock.ExpectCommit()
+				if tt.rowsAffected > 0 {
+					mock.ExpectBegin()
+					mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "session_transitions"`)).
+						WithArgs(uint(9), tt.from, tt.to, "TEST", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+					mock.ExpectCommit()
+				}
+			}
+
+			err := transitionSession(service.db, session, tt.to, "TEST", nil)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("transitionSession() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("transitionSession() error = %v, want %v", err, tt.wantErr)
+			}
+
+			wantStatus := tt.from
+			if tt.wantErr == nil {
+				wantStatus = tt.to
+			}
+			if session.Status != wantStatus {
+				t.Fatalf("session status = %s, want %s", session.Status, wantStatus)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestStartExamRefusesFinishedSessions(t *testing.T) {
+	for _, status := range []models.SessionStatus{models.SessionCompleted, models.SessionExpired, models.SessionVoided} {
+		t.Run(string(status), func(t *testing.T) {
+			service, mock := newMockExamService(t)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions"`) + `.*FOR UPDATE`).
+				WillReturnRows(sessionRows(models.ExamSession{ID: 4, UserID: "1234", Status: status, ExpiresAt: time.Now()}))
+			mock.ExpectRollback()
+
+			err := service.StartExam(context.Background(), 4, "")
+			if !errors.Is(err, ErrIllegalTransition) {
+				t.Fatalf("StartExam() error = %v, want ErrIllegalTransition", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSubmitAnswerRefusesSessionsNotInProgress(t *testing.T) {
+	tests := []struct {
+		status  models.SessionStatus
+		wantErr error
+	}{
+		{models.SessionCompleted, ErrSessionCompleted},
+		{models.SessionNotStarted, ErrSessionNotStarted},
+		{models.SessionExpired, ErrSessionExpired},
+		{models.SessionVoided, ErrSessionVoided},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			service, mock := newMockExamService(t)
+
+			// The session is locked before its status is checked, so a completion running at the
+			// same time either sees the answer or makes it fail; nothing is written afterwards
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exam_sessions" WHERE "exam_sessions"."id" = $1`)+`.*FOR UPDATE$`).
+				WithArgs(4, 1).
+				WillReturnRows(sessionRows(models.ExamSession{ID: 4, UserID: "1234", Status: tt.status, ExpiresAt: time.Now().Add(time.Hour)}))
+			mock.ExpectRollback()
+
+			err := service.SubmitAnswer(context.Background(), 4, 10, 20)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SubmitAnswer() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidStatsRange is returned when the statistics range is empty or too long
+var ErrInvalidStatsRange = errors.New("invalid statistics range")
+
+// MaxStatsDays is the longest range of the statistics, in days
+const MaxStatsDays = 366
+
+// StatsFilter selects the sessions aggregated by GetDashboardStats. Sessions count when they
+// were created in [From, To); results count when they were completed in it.
+type StatsFilter struct {
+	From        time.Time
+	To          time.Time
+	Location    *time.Location // Time zone of the daily series, UTC when nil
+	BlueprintID *uint
+	CohortID    *uint
+}
+
+// sessionConditions returns the SQL conditions on the exam_sessions alias es, without the time range
+func (filter StatsFilter) sessionConditions() (string, []interface{}) {
+	var conditions strings.Builder
+	var args []interface{}
+
+	conditions.WriteString("es.deleted_at IS NULL")
+	if filter.BlueprintID != nil {
+		conditions.WriteString(" AND es.blueprint_id = ?")
+		args = append(args, *filter.BlueprintID)
+	}
+	if filter.CohortID != nil {
+		conditions.WriteString(` AND es.user_id IN (
+			SELECT cm.user_id FROM cohort_members cm
+			WHERE cm.cohort_id = ? AND cm.role = ? AND cm.deleted_at IS NULL
+		)`)
+		args = append(args, *filter.CohortID, models.CohortRoleMember)
+	}
+	return conditions.String(), args
+}
+
+// summariesQuery returns the summaries completed in range by sessions that are not voided
+func (filter StatsFilter) summariesQuery() (string, []interface{}) {
+	conditions, args := filter.sessionConditions()
+	query := `
+		SELECT esm.*
+		FROM exam_summaries esm
+		JOIN exam_sessions es ON es.id = esm.exam_session_id AND es.status <> 'VOIDED'
+		WHERE esm.deleted_at IS NULL AND esm.completed_at >= ? AND esm.completed_at < ? AND ` + conditions
+	return query, append([]interface{}{filter.From, filter.To}, args...)
+}
+
+// scoreStatsRow is a row of the score statistics queries
+type scoreStatsRow struct {
+	Category          string
+	Count             int
+	Passed            int
+	AverageScore      float64
+	MedianScore       float64
+	StdDevScore       float64
+	MinScore          int
+	MaxScore          int
+	AveragePercentage float64
+}
+
+func (r *scoreStatsRow) toScoreStats() dto.ScoreStats {
+	stats := dto.ScoreStats{
+		Count:             r.Count,
+		Passed:            r.Passed,
+		AverageScore:      r.AverageScore,
+		MedianScore:       r.MedianScore,
+		StdDevScore:       r.StdDevScore,
+		MinScore:          r.MinScore,
+		MaxScore:          r.MaxScore,
+		AveragePercentage: r.AveragePercentage,
+	}
+	if r.Count > 0 {
+		stats.PassRate = 100 * float64(r.Passed) / float64(r.Count)
+	}
+	return stats
+}
+
+// gradeCountRow is a row of the grade distribution queries
+type gradeCountRow struct {
+	Category string
+	Grade    string
+	Count    int
+}
+
+// dailyRow is a row of the daily completions query
+type dailyRow struct {
+	Day       time.Time
+	Completed int
+	Passed    int
+}
+
+// GetDashboardStats aggregates the sessions and results in range: counts by status, pass rate,
+// score statistics and grade distribution overall and per category, and daily completions.
+// Voided sessions are counted by status but left out of every result aggregate.
+func (s *ExamService) GetDashboardStats(ctx context.Context, filter StatsFilter) (*dto.DashboardStatsResponse, error) {
+	if !filter.To.After(filter.From) || filter.To.Sub(filter.From) > MaxStatsDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: to must be after from and at most %d days later", ErrInvalidStatsRange, MaxStatsDays)
+	}
+	if filter.Location == nil {
+		filter.Location = time.UTC
+	}
+
+	// First check and update any expired sessions
+	s.CheckAndUpdateExpiredSessions(ctx)
+
+	db := s.db.WithContext(ctx)
+	response := &dto.DashboardStatsResponse{
+		From:         filter.From,
+		To:           filter.To,
+		BlueprintID:  filter.BlueprintID,
+		CohortID:     filter.CohortID,
+		StatusCounts: map[string]int{},
+	}
+
+	// Sessions by status
+	conditions, args := filter.sessionConditions()
+	var statusRows []struct {
+		Status string
+		Count  int
+	}
+	if err := db.Raw(`
+		SELECT es.status, COUNT(*) AS count
+		FROM exam_sessions es
+		WHERE es.created_at >= ? AND es.created_at < ? AND `+conditions+`
+		GROUP BY es.status
+	`, append([]interface{}{filter.From, filter.To}, args...)...).Scan(&statusRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count sessions by status: %w", err)
+	}
+	for _, row := range statusRows {
+		response.StatusCounts[row.Status] = row.Count
+		response.TotalSessions += row.Count
+	}
+
+	summaries, summaryArgs := filter.summariesQuery()
+
+	// Overall score statistics and grades
+	var overall scoreStatsRow
+	if err := db.Raw(`
+		WITH scoped AS (`+summaries+`)
+		SELECT
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE is_passed) AS passed,
+			COALESCE(AVG(total_score), 0) AS average_score,
+			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY total_score), 0) AS median_score,
+			COALESCE(STDDEV_POP(total_score), 0) AS std_dev_score,
+			COALESCE(MIN(total_score), 0) AS min_score,
+			COALESCE(MAX(total_score), 0) AS max_score,
+			COALESCE(AVG(overall_percentage), 0) AS average_percentage
+		FROM scoped
+	`, summaryArgs...).Scan(&overall).Error; err != nil {
+		return nil, fmt.Errorf("failed to get score statistics: %w", err)
+	}
+	response.Overall = overall.toScoreStats()
+
+	var overallGrades []gradeCountRow
+	if err := db.Raw(`
+		WITH scoped AS (`+summaries+`)
+		SELECT COALESCE(overall_grade, '') AS grade, COUNT(*) AS count
+		FROM scoped
+		GROUP BY overall_grade
+		ORDER BY overall_grade
+	`, summaryArgs...).Scan(&overallGrades).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grade distribution: %w", err)
+	}
+	response.Overall.Grades = toGradeCounts(overallGrades)
+
+	// Per category score statistics and grades, in display order
+	var categoryRows []scoreStatsRow
+	if err := db.Raw(`
+		WITH scoped AS (`+summaries+`)
+		SELECT
+			er.category,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE er.is_passed) AS passed,
+			AVG(er.total_score) AS average_score,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY er.total_score) AS median_score,
+			STDDEV_POP(er.total_score) AS std_dev_score,
+			MIN(er.total_score) AS min_score,
+			MAX(er.total_score) AS max_score,
+			AVG(er.percentage) AS average_percentage
+		FROM scoped
+		JOIN exam_results er ON er.exam_session_id = scoped.exam_session_id AND er.deleted_at IS NULL
+		LEFT JOIN categories c ON c.code = er.category AND c.deleted_at IS NULL
+		GROUP BY er.category
+		ORDER BY MIN(c.display_order), er.category
+	`, summaryArgs...).Scan(&categoryRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category statistics: %w", err)
+	}
+
+	var categoryGrades []gradeCountRow
+	if err := db.Raw(`
+		WITH scoped AS (`+summaries+`)
+		SELECT er.category, COALESCE(er.grade, '') AS grade, COUNT(*) AS count
+		FROM scoped
+		JOIN exam_results er ON er.exam_session_id = scoped.exam_session_id AND er.deleted_at IS NULL
+		GROUP BY er.category, er.grade
+		ORDER BY er.category, er.grade
+	`, summaryArgs...).Scan(&categoryGrades).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category grade distribution: %w", err)
+	}
+
+	gradesByCategory := make(map[string][]gradeCountRow)
+	for _, row := range categoryGrades {
+		gradesByCategory[row.Category] = append(gradesByCategory[row.Category], row)
+	}
+
+	response.Categories = make([]dto.CategoryScoreStats, len(categoryRows))
+	for i := range categoryRows {
+		stats := categoryRows[i].toScoreStats()
+		stats.Grades = toGradeCounts(gradesByCategory[categoryRows[i].Category])
+		response.Categories[i] = dto.CategoryScoreStats{
+			Category:   categoryRows[i].Category,
+			ScoreStats: stats,
+		}
+	}
+
+	// Daily completions in the requested time zone, with empty days filled in
+	var dailyRows []dailyRow
+	if err := db.Raw(`
+		WITH scoped AS (`+summaries+`)
+		SELECT
+			CAST(completed_at AT TIME ZONE ? AS date) AS day,
+			COUNT(*) AS completed,
+			COUNT(*) FILTER (WHERE is_passed) AS passed
+		FROM scoped
+		GROUP BY 1
+		ORDER BY 1
+	`, append(append([]interface{}{}, summaryArgs...), filter.Location.String())...).Scan(&dailyRows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get daily completions: %w", err)
+	}
+
+	response.Daily = dailyCompletions(dailyRows, filter.From.In(filter.Location), filter.To.In(filter.Location))
+
+	return response, nil
+}
+
+// toGradeCounts converts grade rows to the grade distribution of the response
+func toGradeCounts(rows []gradeCountRow) []dto.GradeCount {
+	grades := make([]dto.GradeCount, len(rows))
+	for i, row := range rows {
+		grades[i] = dto.GradeCount{Grade: row.Grade, Count: row.Count}
+	}
+	return grades
+}
+
+// dailyCompletions returns one point per calendar day from the day of from up to the day
+// before to, or the day of to when it is not midnight
+func dailyCompletions(rows []dailyRow, from, to time.Time) []dto.DailyCompletion {
+	byDay := make(map[string]dailyRow, len(rows))
+	for _, row := range rows {
+		byDay[row.Day.Format("2006-01-02")] = row
+	}
+
+	series := []dto.DailyCompletion{}
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		row := byDay[key]
+		series = append(series, dto.DailyCompletion{
+			Date:      key,
+			Completed: row.Completed,
+			Passed:    row.Passed,
+		})
+	}
+	return series
+}
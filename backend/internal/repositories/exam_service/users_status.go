@@ -0,0 +1,227 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Errors returned for invalid users dashboard queries
+var (
+	ErrInvalidUsersFilter = errors.New("invalid users filter")
+	ErrInvalidUsersCursor = errors.New("invalid users cursor")
+)
+
+// UsersExamStatusFilter narrows the users dashboard. Empty fields do not filter.
+type UsersExamStatusFilter struct {
+	Status       string     // Status of the latest session
+	Passed       *bool      // Pass or fail of the latest session, only completed sessions match
+	Grade        string     // Overall grade of the latest session
+	From         *time.Time // Latest activity at or after, completion or else start or creation time
+	To           *time.Time // Latest activity before
+	CohortID     *uint      // Members of a cohort
+	UserIDPrefix string     // User IDs starting with this prefix
+}
+
+// UsersExamStatusPage selects a page of the users dashboard. Cursor is the next cursor
+// of the previous page, empty for the first page, and a limit of 0 returns every user.
+type UsersExamStatusPage struct {
+	Sort       string // created_at (default), completed_at, score or percentage
+	Descending bool
+	Cursor     string
+	Limit      int
+}
+
+// usersSortColumns are the sortable expressions of the users dashboard. Missing values sort
+// as the lowest value so that keyset comparisons never see NULL.
+var usersSortColumns = map[string]struct{ expr, cast string }{
+	"created_at":   {"es.created_at", "timestamptz"},
+	"completed_at": {"COALESCE(es.completed_at, 'epoch'::timestamptz)", "timestamptz"},
+	"score":        {"COALESCE(esm.total_score, -1)", "integer"},
+	"percentage":   {"COALESCE(esm.overall_percentage, -1)", "numeric"},
+}
+
+// usersCursor is the position after the last row of a page, tied to the sort it was made for
+type usersCursor struct {
+	Sort       string `json:"s"`
+	Descending bool   `json:"d"`
+	Key        string `json:"k"`
+	ID         uint   `json:"i"`
+}
+
+// GetAllUsersExamStatus gets the exam status of every user from their latest session, with
+// filters, sorting and keyset pagination on the sort value and session ID. The total counts
+// every user matching the filter.
+func (s *ExamService) GetAllUsersExamStatus(ctx context.Context, filter UsersExamStatusFilter, page UsersExamStatusPage) (*dto.UserListDashboardResponse, error) {
+	// First check and update any expired sessions
+	s.CheckAndUpdateExpiredSessions(ctx)
+
+	if page.Sort == "" {
+		page.Sort = "created_at"
+	}
+	sort, ok := usersSortColumns[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidUsersFilter, page.Sort)
+	}
+
+	where, args, err := filter.conditions()
+	if err != nil {
+		return nil, err
+	}
+
+	from := `
+		FROM exam_sessions es
+		LEFT JOIN exam_summaries esm ON es.id = esm.exam_session_id
+		LEFT JOIN accommodations acc ON acc.user_id = es.user_id AND acc.deleted_at IS NULL
+		WHERE es.id IN (
+			SELECT MAX(id)
+			FROM exam_sessions
+			GROUP BY user_id
+		)` + where
+
+	var total int64
+	if err := s.db.WithContext(ctx).Raw("SELECT COUNT(*) "+from, args...).Scan(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count users exam status: %w", err)
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	pageArgs := append([]interface{}{}, args...)
+	if page.Cursor != "" {
+		cursor, err := decodeUsersCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != page.Sort || cursor.Descending != page.Descending {
+			return nil, fmt.Errorf("%w: cursor was made for another sort", ErrInvalidUsersCursor)
+		}
+		from += fmt.Sprintf(" AND (%s, es.id) %s (CAST(? AS %s), ?)", sort.expr, comparison, sort.cast)
+		pageArgs = append(pageArgs, cursor.Key, cursor.ID)
+	}
+
+	query := `
+		SELECT
+			es.user_id,
+			es.status as exam_status,
+			es.session_code,
+			es.started_at,
+			es.completed_at,
+			esm.total_score,
+			esm.max_score,
+			esm.overall_percentage,
+			esm.overall_grade,
+			esm.is_passed,
+			acc.time_multiplier,
+			acc.large_font,
+			acc.extra_breaks,
+			NULLIF(es.accommodation_minutes, 0),
+			CAST(` + sort.expr + ` AS text),
+			es.id` + from + `
+		ORDER BY ` + sort.expr + ` ` + direction + `, es.id ` + direction
+
+	// One row more than the page tells whether there is a next page
+	if page.Limit > 0 {
+		query += " LIMIT ?"
+		pageArgs = append(pageArgs, page.Limit+1)
+	}
+
+	rows, err := s.db.WithContext(ctx).Raw(query, pageArgs...).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users exam status: %w", err)
+	}
+	defer rows.Close()
+
+	var cursors []*usersCursor
+	users, err := scanUserDashboardSummaries(rows, func() []interface{} {
+		cursor := &usersCursor{Sort: page.Sort, Descending: page.Descending}
+		cursors = append(cursors, cursor)
+		return []interface{}{&cursor.Key, &cursor.ID}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.UserListDashboardResponse{
+		TotalUsers: int(total),
+		Users:      users,
+	}
+	if page.Limit > 0 && len(users) > page.Limit {
+		response.Users = users[:page.Limit]
+		response.NextCursor = encodeUsersCursor(cursors[page.Limit-1])
+	}
+
+	return response, nil
+}
+
+// conditions returns the SQL conditions of the filter, each starting with AND
+func (filter UsersExamStatusFilter) conditions() (string, []interface{}, error) {
+	var conditions strings.Builder
+	var args []interface{}
+
+	if filter.Status != "" {
+		if !slices.Contains(models.SessionStatuses, models.SessionStatus(filter.Status)) {
+			return "", nil, fmt.Errorf("%w: unknown status %q", ErrInvalidUsersFilter, filter.Status)
+		}
+		conditions.WriteString(" AND es.status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Passed != nil {
+		conditions.WriteString(" AND esm.is_passed = ?")
+		args = append(args, *filter.Passed)
+	}
+	if filter.Grade != "" {
+		conditions.WriteString(" AND esm.overall_grade = ?")
+		args = append(args, filter.Grade)
+	}
+	if filter.From != nil {
+		conditions.WriteString(" AND COALESCE(es.completed_at, es.started_at, es.created_at) >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions.WriteString(" AND COALESCE(es.completed_at, es.started_at, es.created_at) < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.CohortID != nil {
+		conditions.WriteString(` AND es.user_id IN (
+			SELECT cm.user_id FROM cohort_members cm
+			WHERE cm.cohort_id = ? AND cm.role = ? AND cm.deleted_at IS NULL
+		)`)
+		args = append(args, *filter.CohortID, models.CohortRoleMember)
+	}
+	if filter.UserIDPrefix != "" {
+		conditions.WriteString(` AND es.user_id LIKE ? ESCAPE '\'`)
+		args = append(args, likeEscaper.Replace(filter.UserIDPrefix)+"%")
+	}
+
+	return conditions.String(), args, nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func encodeUsersCursor(cursor *usersCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUsersCursor(value string) (*usersCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUsersCursor, err)
+	}
+	var cursor usersCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUsersCursor, err)
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,269 @@
+package exam_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestUsersExamStatusFilterConditions(t *testing.T) {
+	passed := false
+	cohortID := uint(3)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   UsersExamStatusFilter
+		want     []string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{name: "no filter"},
+		{
+			name:     "status and verdict",
+			filter:   UsersExamStatusFilter{Status: "COMPLETED", Passed: &passed, Grade: "B"},
+			want:     []string{"AND es.status = ?", "AND esm.is_passed = ?", "AND esm.overall_grade = ?"},
+			wantArgs: []interface{}{"COMPLETED", false, "B"},
+		},
+		{
+			name:     "activity window and cohort",
+			filter:   UsersExamStatusFilter{From: &from, To: &from, CohortID: &cohortID},
+			want:     []string{"COALESCE(es.completed_at, es.started_at, es.created_at) >= ?", "COALESCE(es.completed_at, es.started_at, es.created_at) < ?", "cm.cohort_id = ? AND cm.role = ?"},
+			wantArgs: []interface{}{from, from, uint(3), models.CohortRoleMember},
+		},
+		{
+			name:     "prefix wildcards are escaped",
+			filter:   UsersExamStatusFilter{UserIDPrefix: `nip_10%\`},
+			want:     []string{`AND es.user_id LIKE ? ESCAPE '\'`},
+			wantArgs: []interface{}{`nip\_10\%\\%`},
+		},
+		{
+			name:    "unknown status",
+			filter:  UsersExamStatusFilter{Status: "PAUSED"},
+			wantErr: ErrInvalidUsersFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := tt.filter.conditions()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("conditions() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(where, want) {
+					t.Errorf("conditions() = %q, want it to contain %q", where, want)
+				}
+			}
+			if tt.want == nil && where != "" {
+				t.Errorf("conditions() = %q, want none", where)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Fatalf("conditions() args = %v, want %v", args, tt.wantArgs)
+			}
+			for i := range args {
+				if args[i] != tt.wantArgs[i] {
+					t.Errorf("conditions() arg %d = %v, want %v", i, args[i], tt.wantArgs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeUsersCursor(t *testing.T) {
+	cursor := &usersCursor{Sort: "score", Descending: true, Key: "480", ID: 12}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    *usersCursor
+		wantErr bool
+	}{
+		{name: "round trip", value: encodeUsersCursor(cursor), want: cursor},
+		{name: "not base64", value: "***", wantErr: true},
+		{name: "padded base64", value: encodeUsersCursor(cursor) + "==", wantErr: true},
+		{name: "not JSON", value: "bm90IGpzb24", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := decodeUsersCursor(tt.value)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidUsersCursor) {
+				t.Errorf("%s: decodeUsersCursor() error = %v, want %v", tt.name, err, ErrInvalidUsersCursor)
+			}
+			continue
+		}
+		if err != nil || *got != *tt.want {
+			t.Errorf("%s: decodeUsersCursor() = %+v, %v, want %+v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+// expectNoExpiredSessions expects the expiry check that precedes the dashboard queries
+func expectNoExpiredSessions(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "exam_sessions" WHERE .*FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+}
+
+// dashboardRows returns dashboard rows of completed sessions with their sort key and ID
+func dashboardRows(scores ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"user_id", "exam_status", "session_code", "started_at", "completed_at",
+		"total_score", "max_score", "overall_percentage", "overall_grade", "is_passed",
+		"time_multiplier", "large_font", "extra_breaks", "accommodation_minutes", "sort_key", "id"})
+	for i, score := range scores {
+		rows.AddRow("user-"+strconv.Itoa(i+1), "COMPLETED", "S", nil, nil, score, 600, float64(score)/6, "B", true,
+			nil, nil, nil, nil, strconv.Itoa(score), uint(10+i))
+	}
+	return rows
+}
+
+func TestGetAllUsersExamStatusKeysetPages(t *testing.T) {
+	service, mock := newMockExamService(t)
+	ctx := context.Background()
+	page := UsersExamStatusPage{Sort: "score", Descending: true, Limit: 2}
+
+	// First page: one row more than the limit means there is a next page
+	expectNoExpiredSessions(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY COALESCE(esm.total_score, -1) DESC, es.id DESC LIMIT $1")).
+		WithArgs(3).
+		WillReturnRows(dashboardRows(590, 480, 480))
+
+	first, err := service.GetAllUsersExamStatus(ctx, UsersExamStatusFilter{}, page)
+	if err != nil {
+		t.Fatalf("GetAllUsersExamStatus() error = %v", err)
+	}
+	if first.TotalUsers != 5 || len(first.Users) != 2 || first.NextCursor == "" {
+		t.Fatalf("first page = %d of %d users, next cursor %q, want 2 of 5 and a cursor", len(first.Users), first.TotalUsers, first.NextCursor)
+	}
+	cursor, err := decodeUsersCursor(first.NextCursor)
+	if err != nil || *cursor != (usersCursor{Sort: "score", Descending: true, Key: "480", ID: 11}) {
+		t.Fatalf("next cursor = %+v, %v, want the last row of the page", cursor, err)
+	}
+
+	// Last page: the cursor continues after the last row, ties broken by the session ID
+	page.Cursor = first.NextCursor
+	expectNoExpiredSessions(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta("AND (COALESCE(esm.total_score, -1), es.id) < (CAST($1 AS integer), $2)")+`.*`+
+		regexp.QuoteMeta("ORDER BY COALESCE(esm.total_score, -1) DESC, es.id DESC LIMIT $3")).
+		WithArgs("480", 11, 3).
+		WillReturnRows(dashboardRows(480))
+
+	last, err := service.GetAllUsersExamStatus(ctx, UsersExamStatusFilter{}, page)
+	if err != nil {
+		t.Fatalf("GetAllUsersExamStatus() error = %v", err)
+	}
+	if len(last.Users) != 1 || last.NextCursor != "" {
+		t.Fatalf("last page = %d users, next cursor %q, want 1 user and no cursor", len(last.Users), last.NextCursor)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetAllUsersExamStatusRejectsInvalidPages(t *testing.T) {
+	ascending := encodeUsersCursor(&usersCursor{Sort: "score", Key: "480", ID: 11})
+
+	tests := []struct {
+		name    string
+		page    UsersExamStatusPage
+		counted bool // whether the total is counted before the page is refused
+		wantErr error
+	}{
+		{name: "unknown sort", page: UsersExamStatusPage{Sort: "name"}, wantErr: ErrInvalidUsersFilter},
+		{name: "cursor of another direction", page: UsersExamStatusPage{Sort: "score", Descending: true, Cursor: ascending}, counted: true, wantErr: ErrInvalidUsersCursor},
+		{name: "cursor of another sort", page: UsersExamStatusPage{Sort: "percentage", Cursor: ascending}, counted: true, wantErr: ErrInvalidUsersCursor},
+		{name: "malformed cursor", page: UsersExamStatusPage{Cursor: "%%"}, counted: true, wantErr: ErrInvalidUsersCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+			expectNoExpiredSessions(mock)
+			if tt.counted {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			}
+
+			_, err := service.GetAllUsersExamStatus(context.Background(), UsersExamStatusFilter{}, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetAllUsersExamStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestGetCohortExamStatus(t *testing.T) {
+	assignmentID := uint(4)
+
+	tests := []struct {
+		name         string
+		assignmentID *uint
+		session      string // the subquery choosing the latest session of each member
+		members      string // the filter of the cohort members
+		args         []driver.Value
+	}{
+		{
+			name:    "latest session",
+			session: "WHERE s.user_id = cm.user_id AND s.deleted_at IS NULL \n",
+			members: "WHERE cm.cohort_id = $1 AND cm.role = $2 AND cm.deleted_at IS NULL",
+			args:    []driver.Value{uint(3), models.CohortRoleMember},
+		},
+		{
+			name:         "latest session of an assignment",
+			assignmentID: &assignmentID,
+			session:      "WHERE s.user_id = cm.user_id AND s.deleted_at IS NULL AND s.assignment_id = $1\n",
+			members:      "WHERE cm.cohort_id = $2 AND cm.role = $3 AND cm.deleted_at IS NULL",
+			args:         []driver.Value{uint(4), uint(3), models.CohortRoleMember},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newMockExamService(t)
+			expectNoExpiredSessions(mock)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.session) + `.*` + regexp.QuoteMeta(tt.members)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"user_id", "exam_status", "session_code", "started_at", "completed_at",
+					"total_score", "max_score", "overall_percentage", "overall_grade", "is_passed",
+					"time_multiplier", "large_font", "extra_breaks", "accommodation_minutes"}).
+					AddRow("user-1", "COMPLETED", "S1", nil, nil, 480, 600, 80.0, "B", true, nil, nil, nil, nil).
+					AddRow("user-2", "NO_EXAM", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+
+			summaries, err := service.GetCohortExamStatus(context.Background(), 3, tt.assignmentID)
+			if err != nil {
+				t.Fatalf("GetCohortExamStatus() error = %v", err)
+			}
+			if len(summaries) != 2 {
+				t.Fatalf("GetCohortExamStatus() = %d members, want 2", len(summaries))
+			}
+			if got := summaries[0]; got.ExamStatus != "COMPLETED" || got.TotalScore == nil || *got.TotalScore != 480 {
+				t.Errorf("first member = %+v, want the completed session scoring 480", got)
+			}
+			if got := summaries[1]; got.ExamStatus != "NO_EXAM" || got.TotalScore != nil || got.SessionCode != "" {
+				t.Errorf("second member = %+v, want NO_EXAM without a score", got)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package grading_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/category_service"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidPassRule is returned when a pass rule references an unknown category or sets no minimum
+	ErrInvalidPassRule = errors.New("invalid pass rule")
+	// ErrNoDefaultBlueprint is returned when a session has no blueprint and none is marked default
+	ErrNoDefaultBlueprint = errors.New("no default exam blueprint configured")
+)
+
+type GradingService interface {
+	GetGradingScales(ctx context.Context) ([]models.GradingScale, error)
+	GetGradingScaleByID(ctx context.Context, scaleID uint) (*models.GradingScale, error)
+	CreateGradingScale(ctx context.Context, scale *models.GradingScale) error
+	GetPassRules(ctx context.Context) ([]models.PassRule, error)
+	GetPassRuleByID(ctx context.Context, ruleID uint) (*models.PassRule, error)
+	CreatePassRule(ctx context.Context, rule *models.PassRule) error
+	GetBlueprints(ctx context.Context) ([]models.ExamBlueprint, error)
+	GetBlueprintByID(ctx context.Context, blueprintID uint) (*models.ExamBlueprint, error)
+	CreateBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error
+	UpdateBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error
+}
+
+type gradingService struct {
+	db *gorm.DB
+}
+
+func NewGradingService(db *gorm.DB) GradingService {
+	return &gradingService{
+		db: db,
+	}
+}
+
+// preloadScaleBands orders bands from the highest minimum so scales read top-down
+func preloadScaleBands(db *gorm.DB) *gorm.DB {
+	return db.Order("min_percentage DESC")
+}
+
+func (r *gradingService) GetGradingScales(ctx context.Context) ([]models.GradingScale, error) {
+	var scales []models.GradingScale
+	err := r.db.WithContext(ctx).Preload("Bands", preloadScaleBands).Order("id ASC").Find(&scales).Error
+	return scales, err
+}
+
+func (r *gradingService) GetGradingScaleByID(ctx context.Context, scaleID uint) (*models.GradingScale, error) {
+	return LoadGradingScale(r.db.WithContext(ctx), scaleID)
+}
+
+// CreateGradingScale validates the bands and creates the scale with them
+func (r *gradingService) CreateGradingScale(ctx context.Context, scale *models.GradingScale) error {
+	if err := scoring.ValidateGradingScale(scale); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Create(scale).Error
+}
+
+func (r *gradingService) GetPassRules(ctx context.Context) ([]models.PassRule, error) {
+	var rules []models.PassRule
+	err := r.db.WithContext(ctx).Preload("CategoryMinimums").Order("id ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *gradingService) GetPassRuleByID(ctx context.Context, ruleID uint) (*models.PassRule, error) {
+	return LoadPassRule(r.db.WithContext(ctx), ruleID)
+}
+
+// CreatePassRule validates the category minimums and creates the rule with them
+func (r *gradingService) CreatePassRule(ctx context.Context, rule *models.PassRule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seen := make(map[string]bool, len(rule.CategoryMinimums))
+		for _, minimum := range rule.CategoryMinimums {
+			if seen[minimum.Category] {
+				return fmt.Errorf("%w: category %s has more than one minimum", ErrInvalidPassRule, minimum.Category)
+			}
+			seen[minimum.Category] = true
+
+			if minimum.MinScore == nil && minimum.MinPercentage == nil {
+				return fmt.Errorf("%w: category %s needs a minimum score or percentage", ErrInvalidPassRule, minimum.Category)
+			}
+
+			exists, err := category_service.CategoryExists(tx, minimum.Category)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("%w: unknown category %s", ErrInvalidPassRule, minimum.Category)
+			}
+		}
+
+		return tx.Create(rule).Error
+	})
+}
+
+func (r *gradingService) GetBlueprints(ctx context.Context) ([]models.ExamBlueprint, error) {
+	var blueprints []models.ExamBlueprint
+	err := r.db.WithContext(ctx).Preload("GradingScale").Preload("PassRule").Order("id ASC").Find(&blueprints).Error
+	return blueprints, err
+}
+
+func (r *gradingService) GetBlueprintByID(ctx context.Context, blueprintID uint) (*models.ExamBlueprint, error) {
+	var blueprint models.ExamBlueprint
+	err := r.db.WithContext(ctx).Preload("GradingScale").Preload("PassRule").First(&blueprint, blueprintID).Error
+	return &blueprint, err
+}
+
+// CreateBlueprint creates a blueprint, taking over the default flag when requested
+func (r *gradingService) CreateBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := prepareBlueprint(tx, blueprint); err != nil {
+			return err
+		}
+		if err := tx.Omit("GradingScale", "PassRule").Create(blueprint).Error; err != nil {
+			return err
+		}
+		return tx.Preload("GradingScale").Preload("PassRule").First(blueprint, blueprint.ID).Error
+	})
+}
+
+// UpdateBlueprint saves a blueprint, taking over the default flag when requested.
+// Sessions already generated keep their blueprint; their grades only change through a re-grade.
+func (r *gradingService) UpdateBlueprint(ctx context.Context, blueprint *models.ExamBlueprint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := prepareBlueprint(tx, blueprint); err != nil {
+			return err
+		}
+		if err := tx.Omit("GradingScale", "PassRule").Save(blueprint).Error; err != nil {
+			return err
+		}
+		return tx.Preload("GradingScale").Preload("PassRule").First(blueprint, blueprint.ID).Error
+	})
+}
+
+// prepareBlueprint checks the referenced scale and rule exist and clears the
+// default flag of other blueprints when this one becomes the default
+func prepareBlueprint(tx *gorm.DB, blueprint *models.ExamBlueprint) error {
+	if err := tx.First(&models.GradingScale{}, blueprint.GradingScaleID).Error; err != nil {
+		return fmt.Errorf("grading scale %d: %w", blueprint.GradingScaleID, err)
+	}
+	if err := tx.First(&models.PassRule{}, blueprint.PassRuleID).Error; err != nil {
+		return fmt.Errorf("pass rule %d: %w", blueprint.PassRuleID, err)
+	}
+
+	if blueprint.IsDefault {
+		return tx.Model(&models.ExamBlueprint{}).
+			Where("is_default = ? AND id <> ?", true, blueprint.ID).
+			Update("is_default", false).Error
+	}
+	return nil
+}
+
+// LoadGradingScale returns a grading scale with its bands
+func LoadGradingScale(tx *gorm.DB, scaleID uint) (*models.GradingScale, error) {
+	var scale models.GradingScale
+	err := tx.Preload("Bands", preloadScaleBands).First(&scale, scaleID).Error
+	return &scale, err
+}
+
+// LoadPassRule returns a pass rule with its category minimums
+func LoadPassRule(tx *gorm.DB, ruleID uint) (*models.PassRule, error) {
+	var rule models.PassRule
+	err := tx.Preload("CategoryMinimums").First(&rule, ruleID).Error
+	return &rule, err
+}
+
+// LoadBlueprint returns a blueprint with its grading scale bands and pass rule minimums.
+// A nil ID loads the default blueprint.
+func LoadBlueprint(tx *gorm.DB, blueprintID *uint) (*models.ExamBlueprint, error) {
+	query := tx.Preload("GradingScale.Bands", preloadScaleBands).Preload("PassRule.CategoryMinimums")
+
+	var blueprint models.ExamBlueprint
+	if blueprintID != nil {
+		if err := query.First(&blueprint, *blueprintID).Error; err != nil {
+			return nil, fmt.Errorf("failed to get exam blueprint %d: %w", *blueprintID, err)
+		}
+		return &blueprint, nil
+	}
+
+	if err := query.Where("is_default = ?", true).First(&blueprint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoDefaultBlueprint
+		}
+		return nil, fmt.Errorf("failed to get default exam blueprint: %w", err)
+	}
+	return &blueprint, nil
+}
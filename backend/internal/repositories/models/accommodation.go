@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Accommodation is the exam accommodation a candidate is entitled to. It is applied to the
+// deadline of every session the candidate starts.
+type Accommodation struct {
+	ID             uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID         string         `gorm:"column:user_id;type:varchar(50);not null" json:"user_id"`
+	TimeMultiplier float64        `gorm:"column:time_multiplier;not null;default:1" json:"time_multiplier"` // 1.5 gives 50% extra time
+	LargeFont      bool           `gorm:"column:large_font;default:false" json:"large_font"`                // Shown to the frontend only
+	ExtraBreaks    int            `gorm:"column:extra_breaks;not null;default:0" json:"extra_breaks"`
+	BreakMinutes   int            `gorm:"column:break_minutes;not null;default:0" json:"break_minutes"` // Length of each extra break
+	Notes          string         `gorm:"column:notes;type:text" json:"notes"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for Accommodation model
+func (Accommodation) TableName() string {
+	return "accommodations"
+}
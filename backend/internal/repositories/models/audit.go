@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit actions
+const (
+	AuditActionCreate  = "CREATE"
+	AuditActionUpdate  = "UPDATE"
+	AuditActionDelete  = "DELETE"
+	AuditActionRescore = "RESCORE"
+
+	// Admin overrides of an exam session, recorded with a reason
+	AuditActionSessionExtend        = "SESSION_EXTEND"
+	AuditActionSessionForceComplete = "SESSION_FORCE_COMPLETE"
+	AuditActionSessionReset         = "SESSION_RESET"
+	AuditActionSessionVoid          = "SESSION_VOID"
+	AuditActionSessionReopen        = "SESSION_REOPEN"
+)
+
+// AuditLog records a change made to an entity with its state before and after the change.
+// Audit rows are append-only and never soft deleted.
+type AuditLog struct {
+	ID           uint      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Actor        string    `gorm:"column:actor;type:varchar(100);not null;index" json:"actor"`
+	ActorClaimed bool      `gorm:"column:actor_claimed;not null;default:false" json:"actor_claimed"` // Actor named by the client in X-Actor, not verified
+	Action       string    `gorm:"column:action;type:varchar(30);not null;index" json:"action"`
+	EntityType   string    `gorm:"column:entity_type;type:varchar(100);not null;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID     string    `gorm:"column:entity_id;type:varchar(100);not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Before       *string   `gorm:"column:before;type:jsonb" json:"before"` // JSON snapshot, nil on create
+	After        *string   `gorm:"column:after;type:jsonb" json:"after"`   // JSON snapshot, nil on delete
+	RequestID    string    `gorm:"column:request_id;type:varchar(100);index" json:"request_id"`
+	RemoteAddr   string    `gorm:"column:remote_addr;type:varchar(64)" json:"remote_addr"` // Address the request came from
+	Reason       *string   `gorm:"column:reason;type:text" json:"reason"`                  // Why an admin overrode the entity
+	CreatedAt    time.Time `gorm:"column:created_at;index" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
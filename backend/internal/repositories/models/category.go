@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Scoring schemes a category can use to score answers
+const (
+	ScoringSchemeGraded          = "GRADED"
+	ScoringSchemeRightWrong      = "RIGHT_WRONG"
+	ScoringSchemeNegativeMarking = "NEGATIVE_MARKING"
+)
+
+// Category represents an exam section (e.g. TEKNIS, MANAJERIAL) and how it is drawn and scored
+type Category struct {
+	ID            uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code          string         `gorm:"column:code;type:varchar(100);not null;uniqueIndex" json:"code"` // Referenced by questions.category
+	Name          string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Description   string         `gorm:"column:description;type:text" json:"description"`
+	DisplayOrder  int            `gorm:"column:display_order;not null;default:0" json:"display_order"`
+	ScoringScheme string         `gorm:"column:scoring_scheme;type:varchar(30);not null;default:'GRADED'" json:"scoring_scheme"`
+	QuestionCount int            `gorm:"column:question_count;not null;default:0" json:"question_count"` // Questions drawn per exam session
+	MaxScore      int            `gorm:"column:max_score;not null;default:0" json:"max_score"`           // Maximum score of the section in one exam
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for Category model
+func (Category) TableName() string {
+	return "categories"
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Roles a user can have inside a cohort
+const (
+	CohortRoleMember     = "MEMBER"
+	CohortRoleInstructor = "INSTRUCTOR"
+)
+
+// Cohort is a tryout class grouping candidates with the instructors running it
+type Cohort struct {
+	ID          uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	Name        string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Description string         `gorm:"column:description;type:text" json:"description"`
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Members     []CohortMember     `gorm:"foreignKey:CohortID;constraint:OnDelete:CASCADE" json:"members,omitempty"`
+	Assignments []CohortAssignment `gorm:"foreignKey:CohortID;constraint:OnDelete:CASCADE" json:"assignments,omitempty"`
+}
+
+// TableName specifies the table name for Cohort model
+func (Cohort) TableName() string {
+	return "cohorts"
+}
+
+// CohortMember places a user in a cohort as a candidate or an instructor
+type CohortMember struct {
+	ID        uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	CohortID  uint           `gorm:"column:cohort_id;not null;index" json:"cohort_id"`
+	UserID    string         `gorm:"column:user_id;type:varchar(50);not null;index" json:"user_id"`      // Same free-form user ID as exam sessions
+	Role      string         `gorm:"column:role;type:varchar(20);not null;default:'MEMBER'" json:"role"` // MEMBER, INSTRUCTOR
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for CohortMember model
+func (CohortMember) TableName() string {
+	return "cohort_members"
+}
+
+// CohortAssignment lets the members of a cohort sit a blueprint inside an availability window
+type CohortAssignment struct {
+	ID             uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	CohortID       uint           `gorm:"column:cohort_id;not null;index" json:"cohort_id"`
+	BlueprintID    uint           `gorm:"column:blueprint_id;not null;index" json:"blueprint_id"`
+	AvailableFrom  time.Time      `gorm:"column:available_from;not null" json:"available_from"`
+	AvailableUntil *time.Time     `gorm:"column:available_until" json:"available_until"` // Open-ended when nil
+	AssignedBy     string         `gorm:"column:assigned_by;type:varchar(100);not null" json:"assigned_by"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Cohort    Cohort        `gorm:"foreignKey:CohortID" json:"cohort,omitempty"`
+	Blueprint ExamBlueprint `gorm:"foreignKey:BlueprintID" json:"blueprint,omitempty"`
+}
+
+// TableName specifies the table name for CohortAssignment model
+func (CohortAssignment) TableName() string {
+	return "cohort_assignments"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// Domain events published by the exam service
+const (
+	EventExamSessionCreated = "ExamSessionCreated"
+	EventExamStarted        = "ExamStarted"
+	EventAnswerSubmitted    = "AnswerSubmitted"
+	EventExamCompleted      = "ExamCompleted"
+	EventExamExpired        = "ExamExpired"
+)
+
+// Aggregate types of domain events
+const (
+	AggregateExamSession = "exam_session"
+)
+
+// Domain event statuses
+const (
+	DomainEventPending   = "PENDING"
+	DomainEventProcessed = "PROCESSED" // Handled by every subscriber
+	DomainEventFailed    = "FAILED"    // Gave up after the last retry
+)
+
+// DomainEvent is a change recorded in the transaction that made it. Pending events form the
+// outbox the event dispatcher passes on to the subscribers, at least once each.
+type DomainEvent struct {
+	ID            uint       `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	EventID       string     `gorm:"column:event_id;type:varchar(64);not null;uniqueIndex" json:"event_id"`
+	EventType     string     `gorm:"column:event_type;type:varchar(100);not null" json:"event_type"`
+	AggregateType string     `gorm:"column:aggregate_type;type:varchar(50);not null" json:"aggregate_type"`
+	AggregateID   string     `gorm:"column:aggregate_id;type:varchar(50);not null" json:"aggregate_id"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null" json:"payload"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at;not null" json:"occurred_at"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'PENDING'" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text" json:"last_error"`
+	ProcessedAt   *time.Time `gorm:"column:processed_at" json:"processed_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updated_at"`
+
+	// Relationships
+	Receipts []DomainEventReceipt `gorm:"foreignKey:DomainEventID;constraint:OnDelete:CASCADE" json:"receipts,omitempty"`
+}
+
+// TableName specifies the table name for DomainEvent model
+func (DomainEvent) TableName() string {
+	return "domain_events"
+}
+
+// DomainEventReceipt records that a subscriber handled a domain event
+type DomainEventReceipt struct {
+	ID            uint      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	DomainEventID uint      `gorm:"column:domain_event_id;not null" json:"domain_event_id"`
+	Subscriber    string    `gorm:"column:subscriber;type:varchar(100);not null" json:"subscriber"`
+	CreatedAt     time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName specifies the table name for DomainEventReceipt model
+func (DomainEventReceipt) TableName() string {
+	return "domain_event_receipts"
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// EventCount is the number of domain events of a type that occurred on a day (UTC)
+type EventCount struct {
+	Day       time.Time `gorm:"column:day;type:date;primaryKey" json:"day"`
+	EventType string    `gorm:"column:event_type;type:varchar(100);primaryKey" json:"event_type"`
+	Count     int64     `gorm:"column:count;not null;default:0" json:"count"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName specifies the table name for EventCount model
+func (EventCount) TableName() string {
+	return "event_counts"
+}
@@ -8,22 +8,32 @@ import (
 
 // ExamSession represents an exam session for a user
 type ExamSession struct {
-	ID          uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	UserID      string         `gorm:"column:user_id;type:varchar(50);not null;index" json:"user_id"` // Hardcoded user ID from URL
-	SessionCode string         `gorm:"column:session_code;type:varchar(100);uniqueIndex;not null" json:"session_code"`
-	Status      string         `gorm:"column:status;type:varchar(20);default:'NOT_STARTED'" json:"status"` // NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED
-	StartedAt   *time.Time     `gorm:"column:started_at" json:"started_at"`
-	CompletedAt *time.Time     `gorm:"column:completed_at" json:"completed_at"`
-	ExpiresAt   time.Time      `gorm:"column:expires_at;not null" json:"expires_at"`
-	Duration    int            `gorm:"column:duration;default:120" json:"duration"` // Duration in minutes (default 2 hours)
-	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	ID                   uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID               string         `gorm:"column:user_id;type:varchar(50);not null;index" json:"user_id"` // Hardcoded user ID from URL
+	SessionCode          string         `gorm:"column:session_code;type:varchar(100);uniqueIndex;not null" json:"session_code"`
+	BlueprintID          *uint          `gorm:"column:blueprint_id;index" json:"blueprint_id"`                      // Blueprint deciding duration, grading scale and pass rule
+	AssignmentID         *uint          `gorm:"column:assignment_id;index" json:"assignment_id"`                    // Cohort assignment the session was started for
+	SittingID            *uint          `gorm:"column:sitting_id;index" json:"sitting_id"`                          // Scheduled sitting deciding when it can start and its deadline
+	Status               SessionStatus  `gorm:"column:status;type:varchar(20);default:'NOT_STARTED'" json:"status"` // NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED, VOIDED
+	StartedAt            *time.Time     `gorm:"column:started_at" json:"started_at"`
+	CompletedAt          *time.Time     `gorm:"column:completed_at" json:"completed_at"`
+	ExpiresAt            time.Time      `gorm:"column:expires_at;not null" json:"expires_at"`
+	Duration             int            `gorm:"column:duration;default:120" json:"duration"`                                  // Duration in minutes (default 2 hours)
+	AccommodationMinutes int            `gorm:"column:accommodation_minutes;not null;default:0" json:"accommodation_minutes"` // Part of Duration added by the user's accommodation
+	CompletionKey        *string        `gorm:"column:completion_key;type:varchar(255)" json:"-"`                             // Idempotency-Key of the completion request
+	CreatedAt            time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt            time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// Relationships
-	ExamQuestions []ExamQuestion `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_questions,omitempty"`
-	UserAnswers   []UserAnswer   `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"user_answers,omitempty"`
-	ExamResults   []ExamResult   `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_results,omitempty"`
+	ExamQuestions []ExamQuestion    `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_questions,omitempty"`
+	UserAnswers   []UserAnswer      `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"user_answers,omitempty"`
+	ExamResults   []ExamResult      `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_results,omitempty"`
+	TagResults    []ExamTagResult   `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"tag_results,omitempty"`
+	Blueprint     *ExamBlueprint    `gorm:"foreignKey:BlueprintID" json:"blueprint,omitempty"`
+	Assignment    *CohortAssignment `gorm:"foreignKey:AssignmentID" json:"assignment,omitempty"`
+	Sitting       *Sitting          `gorm:"foreignKey:SittingID" json:"sitting,omitempty"`
+	Accommodation *Accommodation    `gorm:"foreignKey:UserID;references:UserID" json:"accommodation,omitempty"`
 }
 
 // TableName specifies the table name for ExamSession model
@@ -80,19 +90,22 @@ func (UserAnswer) TableName() string {
 
 // ExamResult represents the result of an exam session per category
 type ExamResult struct {
-	ID             uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	ExamSessionID  uint           `gorm:"column:exam_session_id;not null;index" json:"exam_session_id"`
-	Category       string         `gorm:"column:category;type:varchar(50);not null" json:"category"` // MANAJERIAL, SOSIAL_KULTURAL, TEKNIS, WAWANCARA
-	TotalQuestions int            `gorm:"column:total_questions;default:5" json:"total_questions"`   // Always 5 per category
-	TotalAnswered  int            `gorm:"column:total_answered;not null" json:"total_answered"`
-	TotalScore     int            `gorm:"column:total_score;not null" json:"total_score"`
-	MaxScore       int            `gorm:"column:max_score;default:20" json:"max_score"` // 5 questions * 4 max score = 20
-	Percentage     float64        `gorm:"column:percentage;not null" json:"percentage"`
-	Grade          string         `gorm:"column:grade;type:varchar(5)" json:"grade"` // A, B, C, D, E
-	IsPassed       bool           `gorm:"column:is_passed;default:false" json:"is_passed"`
-	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
-	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	ID               uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ExamSessionID    uint           `gorm:"column:exam_session_id;not null;index" json:"exam_session_id"`
+	Category         string         `gorm:"column:category;type:varchar(50);not null" json:"category"` // MANAJERIAL, SOSIAL_KULTURAL, TEKNIS, WAWANCARA
+	TotalQuestions   int            `gorm:"column:total_questions;default:5" json:"total_questions"`   // Always 5 per category
+	TotalAnswered    int            `gorm:"column:total_answered;not null" json:"total_answered"`
+	TotalScore       int            `gorm:"column:total_score;not null" json:"total_score"`
+	MaxScore         int            `gorm:"column:max_score;default:20" json:"max_score"`                           // 5 questions * 4 max score = 20
+	CategoryMaxScore int            `gorm:"column:category_max_score;not null;default:0" json:"category_max_score"` // Section maximum configured on the category when scored, 0 for the sum of question maximums
+	Percentage       float64        `gorm:"column:percentage;not null" json:"percentage"`
+	Grade            string         `gorm:"column:grade;type:varchar(5)" json:"grade"` // A, B, C, D, E
+	IsPassed         bool           `gorm:"column:is_passed;default:false" json:"is_passed"`
+	GradingScaleID   *uint          `gorm:"column:grading_scale_id" json:"grading_scale_id"` // Scale that produced Grade
+	PassRuleID       *uint          `gorm:"column:pass_rule_id" json:"pass_rule_id"`         // Rule that produced IsPassed
+	CreatedAt        time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt        time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 
 	// Relationships
 	ExamSession ExamSession `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_session,omitempty"`
@@ -115,6 +128,8 @@ type ExamSummary struct {
 	OverallPercentage float64        `gorm:"column:overall_percentage;not null" json:"overall_percentage"`
 	OverallGrade      string         `gorm:"column:overall_grade;type:varchar(5)" json:"overall_grade"`
 	IsPassed          bool           `gorm:"column:is_passed;default:false" json:"is_passed"`
+	GradingScaleID    *uint          `gorm:"column:grading_scale_id" json:"grading_scale_id"` // Scale that produced OverallGrade
+	PassRuleID        *uint          `gorm:"column:pass_rule_id" json:"pass_rule_id"`         // Rule that produced IsPassed
 	CompletedAt       time.Time      `gorm:"column:completed_at;not null" json:"completed_at"`
 	CreatedAt         time.Time      `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt         time.Time      `gorm:"column:updated_at" json:"updated_at"`
@@ -128,3 +143,27 @@ type ExamSummary struct {
 func (ExamSummary) TableName() string {
 	return "exam_summaries"
 }
+
+// ExamTagResult represents the result of an exam session per tag (sub-topic)
+type ExamTagResult struct {
+	ID             uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ExamSessionID  uint           `gorm:"column:exam_session_id;not null;index" json:"exam_session_id"`
+	TagID          uint           `gorm:"column:tag_id;not null;index" json:"tag_id"`
+	TagName        string         `gorm:"column:tag_name;type:varchar(100);not null" json:"tag_name"`
+	TotalQuestions int            `gorm:"column:total_questions;not null" json:"total_questions"`
+	TotalAnswered  int            `gorm:"column:total_answered;not null" json:"total_answered"`
+	TotalScore     int            `gorm:"column:total_score;not null" json:"total_score"`
+	MaxScore       int            `gorm:"column:max_score;not null" json:"max_score"`
+	Percentage     float64        `gorm:"column:percentage;not null" json:"percentage"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	ExamSession ExamSession `gorm:"foreignKey:ExamSessionID;constraint:OnDelete:CASCADE" json:"exam_session,omitempty"`
+}
+
+// TableName specifies the table name for ExamTagResult model
+func (ExamTagResult) TableName() string {
+	return "exam_tag_results"
+}
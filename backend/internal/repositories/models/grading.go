@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GradingScale is a named set of grade bands used to turn a percentage into a grade
+type GradingScale struct {
+	ID          uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	Name        string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Description string         `gorm:"column:description;type:text" json:"description"`
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Bands []GradingBand `gorm:"foreignKey:GradingScaleID;constraint:OnDelete:CASCADE" json:"bands"`
+}
+
+// TableName specifies the table name for GradingScale model
+func (GradingScale) TableName() string {
+	return "grading_scales"
+}
+
+// GradingBand awards a grade when the percentage reaches MinPercentage
+type GradingBand struct {
+	ID             uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	GradingScaleID uint           `gorm:"column:grading_scale_id;not null;index" json:"grading_scale_id"`
+	Grade          string         `gorm:"column:grade;type:varchar(5);not null" json:"grade"`
+	MinPercentage  float64        `gorm:"column:min_percentage;not null" json:"min_percentage"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for GradingBand model
+func (GradingBand) TableName() string {
+	return "grading_bands"
+}
+
+// PassRule decides whether a candidate passes a category and the exam overall
+type PassRule struct {
+	ID                   uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code                 string         `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	Name                 string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Description          string         `gorm:"column:description;type:text" json:"description"`
+	OverallMinPercentage *float64       `gorm:"column:overall_min_percentage" json:"overall_min_percentage"`
+	OverallMinScore      *int           `gorm:"column:overall_min_score" json:"overall_min_score"`
+	RequireAllCategories bool           `gorm:"column:require_all_categories;default:false" json:"require_all_categories"` // Must pass every category to pass overall
+	CreatedAt            time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt            time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	CategoryMinimums []PassRuleCategoryMinimum `gorm:"foreignKey:PassRuleID;constraint:OnDelete:CASCADE" json:"category_minimums"`
+}
+
+// TableName specifies the table name for PassRule model
+func (PassRule) TableName() string {
+	return "pass_rules"
+}
+
+// PassRuleCategoryMinimum is the minimum raw score and/or percentage to pass a category
+type PassRuleCategoryMinimum struct {
+	ID            uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	PassRuleID    uint           `gorm:"column:pass_rule_id;not null;index" json:"pass_rule_id"`
+	Category      string         `gorm:"column:category;type:varchar(100);not null" json:"category"`
+	MinScore      *int           `gorm:"column:min_score" json:"min_score"`
+	MinPercentage *float64       `gorm:"column:min_percentage" json:"min_percentage"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for PassRuleCategoryMinimum model
+func (PassRuleCategoryMinimum) TableName() string {
+	return "pass_rule_category_minimums"
+}
+
+// ExamBlueprint describes how an exam is run and judged: duration, grading scale and pass rule
+type ExamBlueprint struct {
+	ID              uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code            string         `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	Name            string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Description     string         `gorm:"column:description;type:text" json:"description"`
+	DurationMinutes int            `gorm:"column:duration_minutes;not null;default:130" json:"duration_minutes"`
+	GradingScaleID  uint           `gorm:"column:grading_scale_id;not null;index" json:"grading_scale_id"`
+	PassRuleID      uint           `gorm:"column:pass_rule_id;not null;index" json:"pass_rule_id"`
+	IsDefault       bool           `gorm:"column:is_default;default:false" json:"is_default"` // Used when no blueprint is assigned
+	CreatedAt       time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	GradingScale GradingScale `gorm:"foreignKey:GradingScaleID" json:"grading_scale,omitempty"`
+	PassRule     PassRule     `gorm:"foreignKey:PassRuleID" json:"pass_rule,omitempty"`
+}
+
+// TableName specifies the table name for ExamBlueprint model
+func (ExamBlueprint) TableName() string {
+	return "exam_blueprints"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification locales
+const (
+	LocaleIndonesian = "id"
+	LocaleEnglish    = "en"
+)
+
+// Notification kinds
+const (
+	NotificationScoreReport     = "SCORE_REPORT"     // Sent after an exam is completed
+	NotificationSittingReminder = "SITTING_REMINDER" // Sent before a booked sitting opens
+)
+
+// Notification statuses
+const (
+	NotificationPending = "PENDING"
+	NotificationSent    = "SENT"
+	NotificationFailed  = "FAILED"  // Gave up after the last retry
+	NotificationSkipped = "SKIPPED" // The candidate opted out after it was queued
+)
+
+// NotificationContact is where and in which language a candidate is notified
+type NotificationContact struct {
+	ID        uint      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID    string    `gorm:"column:user_id;type:varchar(50);not null;uniqueIndex" json:"user_id"`
+	Email     string    `gorm:"column:email;type:varchar(255);not null" json:"email"`
+	Name      string    `gorm:"column:name;type:varchar(150)" json:"name"`
+	Locale    string    `gorm:"column:locale;type:varchar(5);not null;default:'id'" json:"locale"`
+	OptedOut  bool      `gorm:"column:opted_out;not null;default:false" json:"opted_out"` // No notifications are queued or sent
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName specifies the table name for NotificationContact model
+func (NotificationContact) TableName() string {
+	return "notification_contacts"
+}
+
+// Notification is a rendered email in the send queue
+type Notification struct {
+	ID            uint       `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID        string     `gorm:"column:user_id;type:varchar(50);not null;index" json:"user_id"`
+	Kind          string     `gorm:"column:kind;type:varchar(30);not null" json:"kind"`
+	DedupeKey     string     `gorm:"column:dedupe_key;type:varchar(150);not null;uniqueIndex" json:"dedupe_key"` // Queues each notification once
+	Recipient     string     `gorm:"column:recipient;type:varchar(255);not null" json:"recipient"`
+	Locale        string     `gorm:"column:locale;type:varchar(5);not null" json:"locale"`
+	Subject       string     `gorm:"column:subject;type:varchar(255);not null" json:"subject"`
+	Body          string     `gorm:"column:body;type:text;not null" json:"body"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'PENDING'" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text" json:"last_error"`
+	SentAt        *time.Time `gorm:"column:sent_at" json:"sent_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName specifies the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -12,6 +12,7 @@ type Question struct {
 	Category     string           `gorm:"column:category;type:varchar(100);not null" json:"category"`
 	QuestionText string           `gorm:"column:question_text;type:text;not null" json:"question_text"`
 	Options      []QuestionOption `gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE" json:"options"`
+	Tags         []Tag            `gorm:"many2many:question_tags;constraint:OnDelete:CASCADE" json:"tags"`
 	CreatedAt    time.Time        `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt    time.Time        `gorm:"column:updated_at" json:"updated_at"`
 	DeletedAt    gorm.DeletedAt   `gorm:"index" json:"deleted_at"`
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// SessionStatus is the state of an exam session
+type SessionStatus string
+
+// Exam session statuses
+const (
+	SessionNotStarted SessionStatus = "NOT_STARTED"
+	SessionInProgress SessionStatus = "IN_PROGRESS"
+	SessionCompleted  SessionStatus = "COMPLETED"
+	SessionExpired    SessionStatus = "EXPIRED"
+	SessionVoided     SessionStatus = "VOIDED" // Excluded from leaderboards and statistics, final
+)
+
+// SessionStatuses lists every exam session status
+var SessionStatuses = []SessionStatus{SessionNotStarted, SessionInProgress, SessionCompleted, SessionExpired, SessionVoided}
+
+// ActiveSessionStatuses are the statuses of sessions a candidate can still work on
+var ActiveSessionStatuses = []SessionStatus{SessionNotStarted, SessionInProgress}
+
+// sessionTransitions lists the statuses each status may move to. Moving to NOT_STARTED is an
+// admin reset, which is also allowed from NOT_STARTED itself.
+var sessionTransitions = map[SessionStatus][]SessionStatus{
+	SessionNotStarted: {SessionNotStarted, SessionInProgress, SessionCompleted, SessionExpired, SessionVoided},
+	SessionInProgress: {SessionNotStarted, SessionCompleted, SessionExpired, SessionVoided},
+	SessionCompleted:  {SessionNotStarted, SessionVoided},
+	SessionExpired:    {SessionNotStarted, SessionInProgress, SessionCompleted, SessionVoided},
+	SessionVoided:     {},
+}
+
+// CanTransitionTo tells whether a session may move from status s to status to
+func (s SessionStatus) CanTransitionTo(to SessionStatus) bool {
+	return slices.Contains(sessionTransitions[s], to)
+}
+
+// IsActive tells whether a candidate can still work on a session with status s
+func (s SessionStatus) IsActive() bool {
+	return slices.Contains(ActiveSessionStatuses, s)
+}
+
+// Triggers of session transitions besides the admin overrides, which use their audit action
+const (
+	TransitionStart           = "START"
+	TransitionComplete        = "COMPLETE"
+	TransitionExpire          = "EXPIRE"
+	TransitionOfflineComplete = "OFFLINE_COMPLETE"
+)
+
+// SessionTransition records a status change of an exam session
+type SessionTransition struct {
+	ID            uint          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ExamSessionID uint          `gorm:"column:exam_session_id;not null;index" json:"exam_session_id"`
+	FromStatus    SessionStatus `gorm:"column:from_status;type:varchar(20);not null" json:"from_status"`
+	ToStatus      SessionStatus `gorm:"column:to_status;type:varchar(20);not null" json:"to_status"`
+	Trigger       string        `gorm:"column:trigger;type:varchar(50);not null" json:"trigger"` // START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an override
+	Actor         string        `gorm:"column:actor;type:varchar(100)" json:"actor"`
+	RequestID     string        `gorm:"column:request_id;type:varchar(100)" json:"request_id"`
+	CreatedAt     time.Time     `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName specifies the table name for SessionTransition model
+func (SessionTransition) TableName() string {
+	return "session_transitions"
+}
@@ -0,0 +1,84 @@
+package models
+
+import "testing"
+
+func TestSessionStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from SessionStatus
+		to   SessionStatus
+		want bool
+	}{
+		{SessionNotStarted, SessionNotStarted, true},
+		{SessionNotStarted, SessionInProgress, true},
+		{SessionNotStarted, SessionCompleted, true},
+		{SessionNotStarted, SessionExpired, true},
+		{SessionNotStarted, SessionVoided, true},
+
+		{SessionInProgress, SessionNotStarted, true},
+		{SessionInProgress, SessionInProgress, false},
+		{SessionInProgress, SessionCompleted, true},
+		{SessionInProgress, SessionExpired, true},
+		{SessionInProgress, SessionVoided, true},
+
+		{SessionCompleted, SessionNotStarted, true},
+		{SessionCompleted, SessionInProgress, false},
+		{SessionCompleted, SessionCompleted, false},
+		{SessionCompleted, SessionExpired, false},
+		{SessionCompleted, SessionVoided, true},
+
+		{SessionExpired, SessionNotStarted, true},
+		{SessionExpired, SessionInProgress, true},
+		{SessionExpired, SessionCompleted, true},
+		{SessionExpired, SessionExpired, false},
+		{SessionExpired, SessionVoided, true},
+
+		{SessionVoided, SessionNotStarted, false},
+		{SessionVoided, SessionInProgress, false},
+		{SessionVoided, SessionCompleted, false},
+		{SessionVoided, SessionExpired, false},
+		{SessionVoided, SessionVoided, false},
+
+		{SessionStatus("UNKNOWN"), SessionInProgress, false},
+		{SessionNotStarted, SessionStatus("UNKNOWN"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Fatalf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionStatusesCoverTransitionTable(t *testing.T) {
+	for _, status := range SessionStatuses {
+		if _, ok := sessionTransitions[status]; !ok {
+			t.Errorf("status %s has no entry in the transition table", status)
+		}
+	}
+	if len(sessionTransitions) != len(SessionStatuses) {
+		t.Errorf("transition table has %d statuses, want %d", len(sessionTransitions), len(SessionStatuses))
+	}
+}
+
+func TestSessionStatusIsActive(t *testing.T) {
+	tests := []struct {
+		status SessionStatus
+		want   bool
+	}{
+		{SessionNotStarted, true},
+		{SessionInProgress, true},
+		{SessionCompleted, false},
+		{SessionExpired, false},
+		{SessionVoided, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsActive(); got != tt.want {
+				t.Fatalf("%s.IsActive() = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
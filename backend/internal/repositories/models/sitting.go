@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sitting is a scheduled exam at a test centre. Candidates can only start between OpensAt and
+// ClosesAt, and every session of the sitting expires at EndsAt whenever it was started.
+type Sitting struct {
+	ID          uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"column:code;type:varchar(50);not null;uniqueIndex" json:"code"`
+	Name        string         `gorm:"column:name;type:varchar(150);not null" json:"name"`
+	Location    string         `gorm:"column:location;type:varchar(255)" json:"location"`
+	BlueprintID *uint          `gorm:"column:blueprint_id;index" json:"blueprint_id"` // Falls back to the cohort assignment or default blueprint
+	OpensAt     time.Time      `gorm:"column:opens_at;not null" json:"opens_at"`
+	ClosesAt    time.Time      `gorm:"column:closes_at;not null" json:"closes_at"`
+	EndsAt      time.Time      `gorm:"column:ends_at;not null;index" json:"ends_at"` // Hard deadline shared by every session
+	Capacity    int            `gorm:"column:capacity;not null" json:"capacity"`
+	AccessCode  string         `gorm:"column:access_code;type:varchar(50)" json:"access_code"` // Required to start when set
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Blueprint  *ExamBlueprint     `gorm:"foreignKey:BlueprintID" json:"blueprint,omitempty"`
+	Candidates []SittingCandidate `gorm:"foreignKey:SittingID;constraint:OnDelete:CASCADE" json:"candidates,omitempty"`
+}
+
+// TableName specifies the table name for Sitting model
+func (Sitting) TableName() string {
+	return "sittings"
+}
+
+// SittingCandidate books a user into a sitting
+type SittingCandidate struct {
+	ID        uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	SittingID uint           `gorm:"column:sitting_id;not null;index" json:"sitting_id"`
+	UserID    string         `gorm:"column:user_id;type:varchar(50);not null;index" json:"user_id"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for SittingCandidate model
+func (SittingCandidate) TableName() string {
+	return "sitting_candidates"
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tag represents a sub-topic label (e.g. pedagogi, regulasi) attached to questions
+type Tag struct {
+	ID          uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name        string         `gorm:"column:name;type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description string         `gorm:"column:description;type:text" json:"description"`
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Questions []Question `gorm:"many2many:question_tags;constraint:OnDelete:CASCADE" json:"questions,omitempty"`
+}
+
+// TableName specifies the table name for Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagQuota reserves a number of questions carrying a tag inside a category
+// when a new exam session is generated
+type TagQuota struct {
+	ID            uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Category      string         `gorm:"column:category;type:varchar(100);not null;uniqueIndex:idx_tag_quotas_category_tag" json:"category"`
+	TagID         uint           `gorm:"column:tag_id;not null;uniqueIndex:idx_tag_quotas_category_tag" json:"tag_id"`
+	QuestionCount int            `gorm:"column:question_count;not null" json:"question_count"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Relationships
+	Tag Tag `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE" json:"tag,omitempty"`
+}
+
+// TableName specifies the table name for TagQuota model
+func (TagQuota) TableName() string {
+	return "tag_quotas"
+}
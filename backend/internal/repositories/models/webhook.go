@@ -0,0 +1,102 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Exam lifecycle events sent to webhooks
+const (
+	WebhookEventSessionCreated   = "session.created"
+	WebhookEventSessionStarted   = "session.started"
+	WebhookEventAnswerSubmitted  = "answer.submitted"
+	WebhookEventSessionCompleted = "session.completed"
+	WebhookEventSessionExpired   = "session.expired"
+)
+
+// WebhookEvents lists every event a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookEventSessionCreated,
+	WebhookEventSessionStarted,
+	WebhookEventAnswerSubmitted,
+	WebhookEventSessionCompleted,
+	WebhookEventSessionExpired,
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliveryDelivered = "DELIVERED"
+	WebhookDeliveryFailed    = "FAILED" // Gave up after the last retry
+)
+
+// Webhook is an external endpoint notified of the exam lifecycle events it subscribes to
+type Webhook struct {
+	ID        uint           `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name      string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	URL       string         `gorm:"column:url;type:varchar(2048);not null" json:"url"`
+	Secret    string         `gorm:"column:secret;type:varchar(255);not null" json:"-"`      // Key of the HMAC-SHA256 signature
+	Events    string         `gorm:"column:events;type:varchar(500);not null" json:"events"` // Comma separated subscribed events
+	Active    bool           `gorm:"column:active;not null;default:true" json:"active"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+// TableName specifies the table name for Webhook model
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventList returns the subscribed events
+func (w *Webhook) EventList() []string {
+	if w.Events == "" {
+		return []string{}
+	}
+	return strings.Split(w.Events, ",")
+}
+
+// WebhookDelivery is one event to deliver to one webhook. Pending deliveries form the outbox
+// the dispatcher works through, retrying failures with exponential backoff.
+type WebhookDelivery struct {
+	ID            uint       `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	WebhookID     uint       `gorm:"column:webhook_id;not null;index" json:"webhook_id"`
+	EventID       string     `gorm:"column:event_id;type:varchar(64);not null" json:"event_id"` // Shared by the deliveries of one event
+	Event         string     `gorm:"column:event;type:varchar(50);not null" json:"event"`
+	Payload       string     `gorm:"column:payload;type:jsonb;not null" json:"payload"` // Exact body that is signed and sent
+	Status        string     `gorm:"column:status;type:varchar(20);not null;default:'PENDING'" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null" json:"next_attempt_at"`
+	LastAttemptAt *time.Time `gorm:"column:last_attempt_at" json:"last_attempt_at"`
+	DeliveredAt   *time.Time `gorm:"column:delivered_at" json:"delivered_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updated_at"`
+
+	// Relationships
+	Webhook    *Webhook                 `gorm:"foreignKey:WebhookID" json:"webhook,omitempty"`
+	AttemptLog []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE" json:"attempt_log,omitempty"`
+}
+
+// TableName specifies the table name for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryAttempt records one HTTP request of a delivery
+type WebhookDeliveryAttempt struct {
+	ID             uint      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	DeliveryID     uint      `gorm:"column:delivery_id;not null;index" json:"delivery_id"`
+	Attempt        int       `gorm:"column:attempt;not null" json:"attempt"`
+	ResponseStatus *int      `gorm:"column:response_status" json:"response_status"` // Nil when no response was received
+	ResponseBody   string    `gorm:"column:response_body;type:text" json:"response_body"`
+	Error          string    `gorm:"column:error;type:text" json:"error"`
+	DurationMs     int       `gorm:"column:duration_ms;not null" json:"duration_ms"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName specifies the table name for WebhookDeliveryAttempt model
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
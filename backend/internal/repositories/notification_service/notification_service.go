@@ -0,0 +1,149 @@
+package notification_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidContact is returned for contacts with an invalid email address or locale
+	ErrInvalidContact = errors.New("invalid notification contact")
+	// ErrNotificationNotFailed is returned when retrying a notification that has not failed
+	ErrNotificationNotFailed = errors.New("notification has not failed")
+)
+
+// NotificationFilter narrows down notification queries, zero values are ignored
+type NotificationFilter struct {
+	UserID string
+	Kind   string
+	Status string
+}
+
+type NotificationService interface {
+	GetContacts(ctx context.Context) ([]models.NotificationContact, error)
+	GetContact(ctx context.Context, userID string) (*models.NotificationContact, error)
+	SaveContact(ctx context.Context, contact *models.NotificationContact) error
+	DeleteContact(ctx context.Context, userID string) error
+	GetNotifications(ctx context.Context, filter NotificationFilter, offset, limit int) ([]models.Notification, error)
+	CountNotifications(ctx context.Context, filter NotificationFilter) (int64, error)
+	RetryNotification(ctx context.Context, notificationID uint) (*models.Notification, error)
+}
+
+type notificationService struct {
+	db *gorm.DB
+}
+
+func NewNotificationService(db *gorm.DB) NotificationService {
+	return &notificationService{
+		db: db,
+	}
+}
+
+func (r *notificationService) GetContacts(ctx context.Context) ([]models.NotificationContact, error) {
+	var contacts []models.NotificationContact
+	err := r.db.WithContext(ctx).Order("user_id ASC").Find(&contacts).Error
+	return contacts, err
+}
+
+func (r *notificationService) GetContact(ctx context.Context, userID string) (*models.NotificationContact, error) {
+	var contact models.NotificationContact
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&contact).Error
+	return &contact, err
+}
+
+// SaveContact creates or replaces the contact of a user
+func (r *notificationService) SaveContact(ctx context.Context, contact *models.NotificationContact) error {
+	address, err := mail.ParseAddress(contact.Email)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidContact, err)
+	}
+	contact.Email = address.Address
+
+	if contact.Locale == "" {
+		contact.Locale = models.LocaleIndonesian
+	}
+	if !slices.Contains(Locales, contact.Locale) {
+		return fmt.Errorf("%w: %v: %s", ErrInvalidContact, ErrUnsupportedLocale, contact.Locale)
+	}
+
+	now := time.Now()
+	contact.CreatedAt, contact.UpdatedAt = now, now
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "name", "locale", "opted_out", "updated_at"}),
+	}).Create(contact).Error
+}
+
+func (r *notificationService) DeleteContact(ctx context.Context, userID string) error {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.NotificationContact{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// applyNotificationFilters applies the notification filter to a query
+func applyNotificationFilters(query *gorm.DB, filter NotificationFilter) *gorm.DB {
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
+
+// GetNotifications returns queued notifications matching the filter, newest first
+func (r *notificationService) GetNotifications(ctx context.Context, filter NotificationFilter, offset, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := applyNotificationFilters(r.db.WithContext(ctx), filter)
+
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+
+	err := query.Order("id DESC").Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationService) CountNotifications(ctx context.Context, filter NotificationFilter) (int64, error) {
+	var count int64
+	err := applyNotificationFilters(r.db.WithContext(ctx).Model(&models.Notification{}), filter).Count(&count).Error
+	return count, err
+}
+
+// RetryNotification queues a failed notification again with a fresh set of attempts
+func (r *notificationService) RetryNotification(ctx context.Context, notificationID uint) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&notification, notificationID).Error; err != nil {
+			return err
+		}
+		if notification.Status != models.NotificationFailed {
+			return fmt.Errorf("%w: notification %d is %s", ErrNotificationNotFailed, notification.ID, notification.Status)
+		}
+
+		notification.Status = models.NotificationPending
+		notification.Attempts = 0
+		notification.NextAttemptAt = time.Now()
+		return tx.Save(&notification).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
@@ -0,0 +1,279 @@
+package notification_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/events"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scorereport"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ events.Subscriber = &Notifier{}
+
+// NotifierConfig holds the settings of the queued notifications
+type NotifierConfig struct {
+	BaseURL      string         // Public URL of the server, for the report and verification links
+	SigningKey   string         // Secret of the score report verification codes
+	Location     *time.Location // Time zone of the times in messages
+	ReminderLead time.Duration  // How long before a sitting opens its reminder is queued
+}
+
+// Notifier queues score reports when exams are completed and reminders before sittings open.
+// Users without a contact or who opted out are not queued anything.
+type Notifier struct {
+	db           *gorm.DB
+	renderer     *Renderer
+	signer       *scorereport.Signer
+	baseURL      string
+	reminderLead time.Duration
+}
+
+func NewNotifier(db *gorm.DB, config NotifierConfig) (*Notifier, error) {
+	renderer, err := NewRenderer(config.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ReminderLead <= 0 {
+		config.ReminderLead = 24 * time.Hour
+	}
+
+	return &Notifier{
+		db:           db,
+		renderer:     renderer,
+		signer:       scorereport.NewSigner(config.SigningKey),
+		baseURL:      strings.TrimRight(config.BaseURL, "/"),
+		reminderLead: config.ReminderLead,
+	}, nil
+}
+
+// scoreReportData is the data of the score report templates
+type scoreReportData struct {
+	Name             string
+	SessionCode      string
+	CompletedAt      time.Time
+	TotalScore       int
+	MaxScore         int
+	Percentage       float64
+	Grade            string
+	Passed           bool
+	Categories       []categoryData
+	VerificationCode string
+	VerifyURL        string
+	ReportURL        string
+}
+
+type categoryData struct {
+	Name       string
+	Score      int
+	MaxScore   int
+	Percentage float64
+	Grade      string
+}
+
+// sittingReminderData is the data of the sitting reminder templates
+type sittingReminderData struct {
+	Name               string
+	SittingCode        string
+	SittingName        string
+	Location           string
+	OpensAt            time.Time
+	ClosesAt           time.Time
+	EndsAt             time.Time
+	AccessCodeRequired bool
+}
+
+func (n *Notifier) Name() string {
+	return "notifications"
+}
+
+// Handle queues the score report of a completed exam
+func (n *Notifier) Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error {
+	if event.EventType != models.EventExamCompleted {
+		return nil
+	}
+
+	var payload struct {
+		SessionID uint `json:"session_id"`
+	}
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", event.EventType, err)
+	}
+
+	var summary models.ExamSummary
+	if err := tx.Preload("ExamSession").Where("exam_session_id = ?", payload.SessionID).First(&summary).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Reset or voided since, nothing to report
+			return nil
+		}
+		return fmt.Errorf("failed to get exam summary: %w", err)
+	}
+
+	var results []models.ExamResult
+	if err := tx.Where("exam_session_id = ?", summary.ExamSessionID).Find(&results).Error; err != nil {
+		return fmt.Errorf("failed to get exam results: %w", err)
+	}
+
+	var categories []models.Category
+	if err := tx.Unscoped().Order("display_order ASC, id ASC").Find(&categories).Error; err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	code := n.signer.Code(&summary)
+	data := scoreReportData{
+		SessionCode:      summary.ExamSession.SessionCode,
+		CompletedAt:      summary.CompletedAt,
+		TotalScore:       summary.TotalScore,
+		MaxScore:         summary.MaxScore,
+		Percentage:       summary.OverallPercentage,
+		Grade:            summary.OverallGrade,
+		Passed:           summary.IsPassed,
+		VerificationCode: code,
+		VerifyURL:        n.baseURL + "/verify/" + code,
+		ReportURL:        n.baseURL + "/api/v1/exam/" + summary.UserID + "/results.pdf",
+	}
+
+	// Categories in display order
+	for _, category := range categories {
+		for _, result := range results {
+			if result.Category != category.Code {
+				continue
+			}
+			data.Categories = append(data.Categories, categoryData{
+				Name:       category.Name,
+				Score:      result.TotalScore,
+				MaxScore:   result.MaxScore,
+				Percentage: result.Percentage,
+				Grade:      result.Grade,
+			})
+		}
+	}
+
+	return n.queue(tx, models.NotificationScoreReport, fmt.Sprintf("score_report:%d", summary.ID), summary.UserID,
+		func(contact *models.NotificationContact) interface{} {
+			data.Name = contactName(contact)
+			return data
+		})
+}
+
+// ScheduleReminders queues reminders for up to batch booked candidates of sittings opening
+// within the reminder lead, and returns how many candidates were handled
+func (n *Notifier) ScheduleReminders(ctx context.Context, batch int) (int, error) {
+	db := n.db.WithContext(ctx)
+	now := time.Now()
+
+	var due []struct {
+		SittingID uint
+		UserID    string
+	}
+	if err := db.Raw(`
+		SELECT sc.sitting_id, sc.user_id
+		FROM sitting_candidates sc
+		JOIN sittings s ON s.id = sc.sitting_id AND s.deleted_at IS NULL
+		JOIN notification_contacts nc ON nc.user_id = sc.user_id AND NOT nc.opted_out
+		WHERE sc.deleted_at IS NULL
+			AND s.opens_at > ? AND s.opens_at <= ?
+			AND NOT EXISTS (
+				SELECT 1 FROM notifications n
+				WHERE n.dedupe_key = 'sitting_reminder:' || sc.sitting_id || ':' || sc.user_id
+			)
+		ORDER BY s.opens_at ASC, sc.id ASC
+		LIMIT ?
+	`, now, now.Add(n.reminderLead), batch).Scan(&due).Error; err != nil {
+		return 0, fmt.Errorf("failed to get due sitting reminders: %w", err)
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	sittingIDs := make([]uint, len(due))
+	for i, reminder := range due {
+		sittingIDs[i] = reminder.SittingID
+	}
+	var sittings []models.Sitting
+	if err := db.Where("id IN ?", sittingIDs).Find(&sittings).Error; err != nil {
+		return 0, fmt.Errorf("failed to get sittings: %w", err)
+	}
+	sittingsByID := make(map[uint]*models.Sitting, len(sittings))
+	for i := range sittings {
+		sittingsByID[sittings[i].ID] = &sittings[i]
+	}
+
+	for _, reminder := range due {
+		sitting := sittingsByID[reminder.SittingID]
+		if sitting == nil {
+			continue
+		}
+
+		data := sittingReminderData{
+			SittingCode:        sitting.Code,
+			SittingName:        sitting.Name,
+			Location:           sitting.Location,
+			OpensAt:            sitting.OpensAt,
+			ClosesAt:           sitting.ClosesAt,
+			EndsAt:             sitting.EndsAt,
+			AccessCodeRequired: sitting.AccessCode != "",
+		}
+		if err := n.queue(db, models.NotificationSittingReminder,
+			fmt.Sprintf("sitting_reminder:%d:%s", sitting.ID, reminder.UserID), reminder.UserID,
+			func(contact *models.NotificationContact) interface{} {
+				data.Name = contactName(contact)
+				return data
+			}); err != nil {
+			return 0, err
+		}
+	}
+	return len(due), nil
+}
+
+// queue renders a notification in the locale of the user and stores it using db. Nothing is
+// queued for users without a contact, who opted out, or who were queued the same key before.
+func (n *Notifier) queue(db *gorm.DB, kind, dedupeKey, userID string, data func(contact *models.NotificationContact) interface{}) error {
+	var contact models.NotificationContact
+	if err := db.Where("user_id = ?", userID).First(&contact).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get notification contact: %w", err)
+	}
+	if contact.OptedOut {
+		return nil
+	}
+
+	subject, body, err := n.renderer.Render(kind, contact.Locale, data(&contact))
+	if err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		UserID:        userID,
+		Kind:          kind,
+		DedupeKey:     dedupeKey,
+		Recipient:     contact.Email,
+		Locale:        contact.Locale,
+		Subject:       subject,
+		Body:          body,
+		Status:        models.NotificationPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedupe_key"}}, DoNothing: true}).
+		Create(&notification).Error; err != nil {
+		return fmt.Errorf("failed to queue %s notification: %w", kind, err)
+	}
+	return nil
+}
+
+// contactName returns the name to greet a contact with
+func contactName(contact *models.NotificationContact) string {
+	if contact.Name != "" {
+		return contact.Name
+	}
+	return contact.UserID
+}
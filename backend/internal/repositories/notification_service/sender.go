@@ -0,0 +1,199 @@
+package notification_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/ports"
+	"cutbray/pppk-json/internal/repositories/models"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxSendAttempts is the number of sends tried before a notification fails
+	MaxSendAttempts = 6
+	// sendLease keeps claimed notifications from being claimed again while they are sent
+	sendLease = 5 * time.Minute
+	// baseBackoff is the wait after the first failed send, doubled after every later one
+	baseBackoff = 1 * time.Minute
+	// maxBackoff caps the wait between sends
+	maxBackoff = 2 * time.Hour
+)
+
+// Backoff returns the wait after the given number of failed sends
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
+
+// Sender sends queued notifications with a mailer. Several senders may share a database,
+// claimed notifications are skipped by the others.
+type Sender struct {
+	db     *gorm.DB
+	mailer ports.MailerPort
+}
+
+func NewSender(db *gorm.DB, mailer ports.MailerPort) *Sender {
+	return &Sender{
+		db:     db,
+		mailer: mailer,
+	}
+}
+
+// SendDue sends up to batch notifications that are due and returns how many were attempted
+func (s *Sender) SendDue(ctx context.Context, batch int) (int, error) {
+	notifications, optedOut, err := s.claim(ctx, batch)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range notifications {
+		if ctx.Err() != nil {
+			// Unsent notifications are claimed again once their lease runs out
+			break
+		}
+		renewed, err := s.renewLease(ctx, &notifications[i])
+		if err != nil {
+			log.Printf("[Error] Failed to renew lease of notification %d: %v", notifications[i].ID, err)
+			continue
+		}
+		if !renewed {
+			// The lease ran out while earlier notifications of the batch were sent and another
+			// sender took this one over
+			continue
+		}
+		if err := s.send(ctx, &notifications[i], optedOut[notifications[i].UserID]); err != nil {
+			log.Printf("[Error] Failed to record notification %d: %v", notifications[i].ID, err)
+		}
+	}
+	return len(notifications), nil
+}
+
+// claim leases due notifications and tells which of their users opted out since
+func (s *Sender) claim(ctx context.Context, batch int) ([]models.Notification, map[string]bool, error) {
+	var notifications []models.Notification
+	optedOut := make(map[string]bool)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationPending, time.Now()).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(batch).
+			Find(&notifications).Error; err != nil {
+			return fmt.Errorf("failed to get due notifications: %w", err)
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		leasedUntil := leaseEnd()
+		ids := make([]uint, len(notifications))
+		userIDs := make([]string, len(notifications))
+		for i := range notifications {
+			ids[i] = notifications[i].ID
+			userIDs[i] = notifications[i].UserID
+			notifications[i].NextAttemptAt = leasedUntil
+		}
+		if err := tx.Model(&models.Notification{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leasedUntil).Error; err != nil {
+			return fmt.Errorf("failed to claim notifications: %w", err)
+		}
+
+		// A deleted contact counts as opted out
+		var contacts []models.NotificationContact
+		if err := tx.Where("user_id IN ?", userIDs).Find(&contacts).Error; err != nil {
+			return fmt.Errorf("failed to get notification contacts: %w", err)
+		}
+		for _, userID := range userIDs {
+			optedOut[userID] = true
+		}
+		for _, contact := range contacts {
+			optedOut[contact.UserID] = contact.OptedOut
+		}
+		return nil
+	})
+	return notifications, optedOut, err
+}
+
+// renewLease moves the next attempt of a claimed notification past a fresh lease before it is
+// sent. It reports false when the notification changed since it was claimed, i.e. another
+// sender claimed it after the lease ran out.
+func (s *Sender) renewLease(ctx context.Context, notification *models.Notification) (bool, error) {
+	leasedUntil := leaseEnd()
+	result := s.leased(ctx, notification).Update("next_attempt_at", leasedUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	notification.NextAttemptAt = leasedUntil
+	return true, nil
+}
+
+// leased scopes an update to a notification as long as this sender still holds its lease
+func (s *Sender) leased(ctx context.Context, notification *models.Notification) *gorm.DB {
+	return s.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_attempt_at = ?",
+			notification.ID, models.NotificationPending, notification.Attempts, notification.NextAttemptAt)
+}
+
+// leaseEnd returns the end of a lease taken now, at the microsecond precision of Postgres
+// timestamps so the stored value can be compared with the one kept in memory
+func leaseEnd() time.Time {
+	return time.Now().Add(sendLease).Truncate(time.Microsecond)
+}
+
+// send makes one attempt of a claimed notification and records its outcome, unless another
+// sender took the notification over in the meantime
+func (s *Sender) send(ctx context.Context, notification *models.Notification, optedOut bool) error {
+	if optedOut {
+		return s.leased(ctx, notification).Updates(map[string]interface{}{
+			"status":     models.NotificationSkipped,
+			"last_error": "user opted out",
+		}).Error
+	}
+
+	err := s.mailer.SendMail(ctx, ports.MailMessage{
+		To:      notification.Recipient,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by shutdown, not by the server; retried after the lease
+		return nil
+	}
+
+	attempts := notification.Attempts + 1
+	updates := map[string]interface{}{
+		"attempts": attempts,
+	}
+	switch {
+	case err == nil:
+		updates["status"] = models.NotificationSent
+		updates["sent_at"] = time.Now()
+		updates["last_error"] = ""
+	case attempts >= MaxSendAttempts:
+		updates["status"] = models.NotificationFailed
+		updates["last_error"] = err.Error()
+	default:
+		updates["next_attempt_at"] = time.Now().Add(Backoff(attempts))
+		updates["last_error"] = err.Error()
+	}
+
+	result := s.leased(ctx, notification).Updates(updates)
+	if result.Error == nil && result.RowsAffected == 0 {
+		log.Printf("[Warning] Notification %d was taken over by another sender while it was sent", notification.ID)
+	}
+	return result.Error
+}
@@ -0,0 +1,272 @@
+package notification_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/adapters/smtp_adapter"
+	"cutbray/pppk-json/internal/adapters/smtp_adapter/smtptest"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"database/sql/driver"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+// newSinkSender returns a sender mailing through an in-process SMTP server
+func newSinkSender(t *testing.T, db *gorm.DB) (*Sender, *smtptest.Server) {
+	t.Helper()
+
+	sink, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatalf("failed to start SMTP sink: %v", err)
+	}
+	t.Cleanup(sink.Close)
+
+	mailer := smtp_adapter.New(smtp_adapter.SMTPConfig{
+		Host:    sink.Host,
+		Port:    sink.Port,
+		From:    "PPPK Exam <no-reply@example.com>",
+		TLS:     smtp_adapter.TLSNone,
+		Timeout: 5 * time.Second,
+	})
+	if err := mailer.Connect(context.Background()); err != nil {
+		t.Fatalf("failed to connect to SMTP sink: %v", err)
+	}
+	return NewSender(db, mailer), sink
+}
+
+// expectClaim expects the claim of one due notification and the contact lookup of its user
+func expectClaim(mock sqlmock.Sqlmock, notification models.Notification, optedOut bool) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notifications" WHERE status = $1 AND next_attempt_at <= $2`) + `.*` + regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "kind", "recipient", "locale", "subject", "body", "status", "attempts", "next_attempt_at"}).
+			AddRow(notification.ID, notification.UserID, notification.Kind, notification.Recipient, notification.Locale,
+				notification.Subject, notification.Body, models.NotificationPending, notification.Attempts, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "notifications" SET "next_attempt_at"=$1,"updated_at"=$2 WHERE id IN ($3)`)).
+		WithArgs(sqlmocktest.TimeFromNow(sendLease), sqlmock.AnyArg(), notification.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notification_contacts" WHERE user_id IN ($1)`)).
+		WithArgs(notification.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email", "locale", "opted_out"}).
+			AddRow(1, notification.UserID, notification.Recipient, notification.Locale, optedOut))
+	mock.ExpectCommit()
+}
+
+// expectRenew expects the lease of a claimed notification to be renewed right before it is sent
+func expectRenew(mock sqlmock.Sqlmock, id uint, attempts int, renewed bool) {
+	rows := int64(0)
+	if renewed {
+		rows = 1
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "notifications" SET "next_attempt_at"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4 AND attempts = $5 AND next_attempt_at = $6`)).
+		WithArgs(sqlmocktest.TimeFromNow(sendLease), sqlmock.AnyArg(), id, models.NotificationPending, attempts, sqlmocktest.TimeFromNow(sendLease)).
+		WillReturnResult(sqlmock.NewResult(0, rows))
+	mock.ExpectCommit()
+}
+
+// decodeMessage returns the subject and body of a message accepted by the sink
+func decodeMessage(t *testing.T, message smtptest.Message) (string, string) {
+	t.Helper()
+
+	parsed, err := mail.ReadMessage(strings.NewReader(message.Data))
+	if err != nil {
+		t.Fatalf("failed to parse sent message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	return subject, strings.ReplaceAll(string(body), "\r\n", "\n")
+}
+
+func TestNotifierQueueThenSend(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	notifier, err := NewNotifier(db, NotifierConfig{Location: time.UTC})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+	sender, sink := newSinkSender(t, db)
+
+	// Queued in the locale of the contact
+	var subject, body string
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notification_contacts" WHERE user_id = $1`)).
+		WithArgs("1234", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email", "name", "locale", "opted_out"}).
+			AddRow(1, "1234", "budi@example.com", "Budi", models.LocaleIndonesian, false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "notifications" ("user_id","kind","dedupe_key","recipient","locale","subject","body","status"`)+`.*`+regexp.QuoteMeta(`ON CONFLICT ("dedupe_key") DO NOTHING`)).
+		WithArgs("1234", models.NotificationSittingReminder, "sitting_reminder:5:1234", "budi@example.com", models.LocaleIndonesian,
+			sqlmocktest.Captured(&subject), sqlmocktest.Captured(&body), models.NotificationPending, 0, sqlmocktest.TimeFromNow(0), "", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+	mock.ExpectCommit()
+
+	err = notifier.queue(db, models.NotificationSittingReminder, "sitting_reminder:5:1234", "1234",
+		func(contact *models.NotificationContact) interface{} {
+			return sittingReminderData{
+				Name:        contactName(contact),
+				SittingCode: "JKT-2026-02-01-A",
+				SittingName: "Sesi Pagi Jakarta",
+				OpensAt:     time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC),
+			}
+		})
+	if err != nil {
+		t.Fatalf("queue() error = %v", err)
+	}
+	if !strings.HasPrefix(subject, "Pengingat ujian: Sesi Pagi Jakarta") || !strings.HasPrefix(body, "Yth. Budi,") {
+		t.Fatalf("queued %q / %q, want the Indonesian reminder", subject, body)
+	}
+
+	// Sent and marked SENT
+	expectClaim(mock, models.Notification{ID: 21, UserID: "1234", Kind: models.NotificationSittingReminder,
+		Recipient: "budi@example.com", Locale: models.LocaleIndonesian, Subject: subject, Body: body}, false)
+	expectRenew(mock, 21, 0, true)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "notifications" SET "attempts"=$1,"last_error"=$2,"sent_at"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6 AND status = $7 AND attempts = $8 AND next_attempt_at = $9`)).
+		WithArgs(1, "", sqlmocktest.TimeFromNow(0), models.NotificationSent, sqlmock.AnyArg(), 21, models.NotificationPending, 0, sqlmocktest.TimeFromNow(sendLease)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if attempted, err := sender.SendDue(context.Background(), 10); err != nil || attempted != 1 {
+		t.Fatalf("SendDue() = %d, %v, want 1 notification attempted", attempted, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := sink.Messages()
+	if len(messages) != 1 || messages[0].To[0] != "budi@example.com" {
+		t.Fatalf("sink received %+v, want one message to budi@example.com", messages)
+	}
+	sentSubject, sentBody := decodeMessage(t, messages[0])
+	if sentSubject != subject || sentBody != body {
+		t.Fatalf("sent %q / %q, want the queued subject and body", sentSubject, sentBody)
+	}
+}
+
+func TestNotifierSkipsOptedOutContact(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	notifier, err := NewNotifier(db, NotifierConfig{})
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notification_contacts" WHERE user_id = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "email", "locale", "opted_out"}).
+			AddRow(1, "1234", "budi@example.com", models.LocaleEnglish, true))
+
+	err = notifier.queue(db, models.NotificationScoreReport, "score_report:3", "1234",
+		func(contact *models.NotificationContact) interface{} { return scoreReportData{} })
+	if err != nil {
+		t.Fatalf("queue() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("an opted out contact was queued a notification: %v", err)
+	}
+}
+
+func TestSenderSendDue(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempts  int  // attempts made before this one
+		optedOut  bool // opted out after the notification was queued
+		failure   string
+		wantSQL   string
+		wantArgs  []driver.Value
+		wantMails int
+	}{
+		{
+			name:      "retried after a transient failure",
+			attempts:  1,
+			failure:   "451 4.3.0 Try again later",
+			wantSQL:   `UPDATE "notifications" SET "attempts"=$1,"last_error"=$2,"next_attempt_at"=$3,"updated_at"=$4 WHERE id = $5 AND status = $6 AND attempts = $7 AND next_attempt_at = $8`,
+			wantArgs:  []driver.Value{2, sqlmocktest.Containing("451"), sqlmocktest.TimeFromNow(Backoff(2)), sqlmock.AnyArg(), 21, models.NotificationPending},
+			wantMails: 0,
+		},
+		{
+			name:      "failed at the last attempt",
+			attempts:  MaxSendAttempts - 1,
+			failure:   "451 4.3.0 Try again later",
+			wantSQL:   `UPDATE "notifications" SET "attempts"=$1,"last_error"=$2,"status"=$3,"updated_at"=$4 WHERE id = $5 AND status = $6 AND attempts = $7 AND next_attempt_at = $8`,
+			wantArgs:  []driver.Value{MaxSendAttempts, sqlmocktest.Containing("451"), models.NotificationFailed, sqlmock.AnyArg(), 21, models.NotificationPending},
+			wantMails: 0,
+		},
+		{
+			name:      "sent after earlier failures",
+			attempts:  2,
+			wantSQL:   `UPDATE "notifications" SET "attempts"=$1,"last_error"=$2,"sent_at"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6 AND status = $7 AND attempts = $8 AND next_attempt_at = $9`,
+			wantArgs:  []driver.Value{3, "", sqlmocktest.TimeFromNow(0), models.NotificationSent, sqlmock.AnyArg(), 21, models.NotificationPending},
+			wantMails: 1,
+		},
+		{
+			name:      "skipped after opting out",
+			optedOut:  true,
+			wantSQL:   `UPDATE "notifications" SET "last_error"=$1,"status"=$2,"updated_at"=$3 WHERE id = $4 AND status = $5 AND attempts = $6 AND next_attempt_at = $7`,
+			wantArgs:  []driver.Value{"user opted out", models.NotificationSkipped, sqlmock.AnyArg(), 21, models.NotificationPending},
+			wantMails: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := sqlmocktest.NewDB(t)
+			sender, sink := newSinkSender(t, db)
+			if tt.failure != "" {
+				sink.FailNext(tt.failure)
+			}
+
+			expectClaim(mock, models.Notification{ID: 21, UserID: "1234", Kind: models.NotificationScoreReport,
+				Recipient: "budi@example.com", Locale: models.LocaleEnglish, Subject: "Exam result", Body: "Dear Budi,",
+				Attempts: tt.attempts}, tt.optedOut)
+			expectRenew(mock, 21, tt.attempts, true)
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.wantSQL)).
+				WithArgs(append(tt.wantArgs, tt.attempts, sqlmocktest.TimeFromNow(sendLease))...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			if attempted, err := sender.SendDue(context.Background(), 10); err != nil || attempted != 1 {
+				t.Fatalf("SendDue() = %d, %v, want 1 notification attempted", attempted, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(sink.Messages()); got != tt.wantMails {
+				t.Fatalf("sink received %d messages, want %d", got, tt.wantMails)
+			}
+		})
+	}
+}
+
+func TestSenderSkipsNotificationTakenOver(t *testing.T) {
+	db, mock := sqlmocktest.NewDB(t)
+	sender, sink := newSinkSender(t, db)
+
+	// The lease ran out while earlier notifications of the batch were sent and another sender
+	// claimed this one, so the renewal matches nothing and the email is not sent twice
+	expectClaim(mock, models.Notification{ID: 21, UserID: "1234", Kind: models.NotificationScoreReport,
+		Recipient: "budi@example.com", Locale: models.LocaleEnglish, Subject: "Exam result", Body: "Dear Budi,"}, false)
+	expectRenew(mock, 21, 0, false)
+
+	if attempted, err := sender.SendDue(context.Background(), 10); err != nil || attempted != 1 {
+		t.Fatalf("SendDue() = %d, %v, want 1 notification attempted", attempted, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(sink.Messages()); got != 0 {
+		t.Fatalf("sink received %d messages, want none", got)
+	}
+}
@@ -0,0 +1,97 @@
+package notification_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ErrUnsupportedLocale is returned for locales without templates
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// Locales lists the locales every notification has a template in
+var Locales = []string{models.LocaleIndonesian, models.LocaleEnglish}
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templateNames maps notification kinds to their template file prefix
+var templateNames = map[string]string{
+	models.NotificationScoreReport:     "score_report",
+	models.NotificationSittingReminder: "sitting_reminder",
+}
+
+var monthNames = map[string][]string{
+	models.LocaleIndonesian: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	models.LocaleEnglish:    {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// Renderer renders notification templates, showing times in one time zone
+type Renderer struct {
+	location  *time.Location
+	templates map[string]*template.Template // By "<kind>.<locale>"
+}
+
+// NewRenderer parses the embedded templates of every kind and locale
+func NewRenderer(location *time.Location) (*Renderer, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	renderer := &Renderer{location: location, templates: make(map[string]*template.Template)}
+	for kind, name := range templateNames {
+		for _, locale := range Locales {
+			file := fmt.Sprintf("templates/%s.%s.tmpl", name, locale)
+			tmpl, err := template.New(file).Funcs(renderer.funcs(locale)).ParseFS(templateFiles, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			renderer.templates[kind+"."+locale] = tmpl
+		}
+	}
+	return renderer, nil
+}
+
+// Render returns the subject and body of a notification
+func (r *Renderer) Render(kind, locale string, data interface{}) (subject, body string, err error) {
+	tmpl, ok := r.templates[kind+"."+locale]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, "subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject: %w", err)
+	}
+	subject = strings.TrimSpace(b.String())
+
+	b.Reset()
+	if err := tmpl.ExecuteTemplate(&b, "body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render body: %w", err)
+	}
+	return subject, b.String(), nil
+}
+
+// funcs returns the template functions of a locale
+func (r *Renderer) funcs(locale string) template.FuncMap {
+	return template.FuncMap{
+		// date formats a time as "2 Februari 2026 08:00 WIB"
+		"date": func(t time.Time) string {
+			t = t.In(r.location)
+			return fmt.Sprintf("%d %s %d %s", t.Day(), monthNames[locale][t.Month()-1], t.Year(), t.Format("15:04 MST"))
+		},
+		// percent formats a percentage with one decimal and the decimal separator of the locale
+		"percent": func(value float64) string {
+			formatted := strconv.FormatFloat(value, 'f', 1, 64)
+			if locale == models.LocaleIndonesian {
+				formatted = strings.Replace(formatted, ".", ",", 1)
+			}
+			return formatted + "%"
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package notification_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRendererRender(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	renderer, err := NewRenderer(wib)
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+
+	report := scoreReportData{
+		Name:        "Budi",
+		SessionCode: "EXAM_1234_1700000000",
+		CompletedAt: time.Date(2026, 2, 1, 3, 5, 0, 0, time.UTC),
+		TotalScore:  410,
+		MaxScore:    480,
+		Percentage:  85.42,
+		Grade:       "B",
+		Passed:      true,
+		Categories: []categoryData{
+			{Name: "Teknis", Score: 150, MaxScore: 175, Percentage: 85.71, Grade: "B"},
+		},
+		VerificationCode: "R42-ABCD-EFGH-IJKL-MNOP",
+		VerifyURL:        "https://exam.example.com/verify/R42-ABCD-EFGH-IJKL-MNOP",
+	}
+	reminder := sittingReminderData{
+		Name:               "Budi",
+		SittingCode:        "JKT-2026-02-01-A",
+		SittingName:        "Sesi Pagi Jakarta",
+		OpensAt:            time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC),
+		ClosesAt:           time.Date(2026, 2, 1, 1, 30, 0, 0, time.UTC),
+		EndsAt:             time.Date(2026, 2, 1, 3, 10, 0, 0, time.UTC),
+		AccessCodeRequired: true,
+	}
+
+	tests := []struct {
+		name        string
+		kind        string
+		locale      string
+		data        interface{}
+		wantSubject string
+		wantBody    []string
+	}{
+		{
+			name:        "score report id",
+			kind:        models.NotificationScoreReport,
+			locale:      models.LocaleIndonesian,
+			data:        report,
+			wantSubject: "Hasil Ujian EXAM_1234_1700000000: LULUS",
+			wantBody:    []string{"Yth. Budi,", "1 Februari 2026 10:05 WIB", "410 dari 480 (85,4%)", "- Teknis: 150 dari 175 (85,7%), predikat B", "R42-ABCD-EFGH-IJKL-MNOP"},
+		},
+		{
+			name:        "score report en",
+			kind:        models.NotificationScoreReport,
+			locale:      models.LocaleEnglish,
+			data:        report,
+			wantSubject: "Exam result EXAM_1234_1700000000: PASSED",
+			wantBody:    []string{"Dear Budi,", "1 February 2026 10:05 WIB", "410 of 480 (85.4%)", "- Teknis: 150 of 175 (85.7%), grade B"},
+		},
+		{
+			name:        "sitting reminder id",
+			kind:        models.NotificationSittingReminder,
+			locale:      models.LocaleIndonesian,
+			data:        reminder,
+			wantSubject: "Pengingat ujian: Sesi Pagi Jakarta, 1 Februari 2026 08:00 WIB",
+			wantBody:    []string{"Sesi    : Sesi Pagi Jakarta (JKT-2026-02-01-A)", "Ditutup : 1 Februari 2026 08:30 WIB", "Kode akses akan diberikan"},
+		},
+		{
+			name:        "sitting reminder en",
+			kind:        models.NotificationSittingReminder,
+			locale:      models.LocaleEnglish,
+			data:        reminder,
+			wantSubject: "Exam reminder: Sesi Pagi Jakarta, 1 February 2026 08:00 WIB",
+			wantBody:    []string{"Closes  : 1 February 2026 08:30 WIB", "ends at 1 February 2026 10:10 WIB", "access code on site"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body, err := renderer.Render(tt.kind, tt.locale, tt.data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if subject != tt.wantSubject {
+				t.Fatalf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body misses %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRendererRenderUnsupportedLocale(t *testing.T) {
+	renderer, err := NewRenderer(nil)
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	if _, _, err := renderer.Render(models.NotificationScoreReport, "fr", scoreReportData{}); !errors.Is(err, ErrUnsupportedLocale) {
+		t.Fatalf("Render() error = %v, want ErrUnsupportedLocale", err)
+	}
+}
@@ -0,0 +1,330 @@
+package question_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/lms"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"cutbray/pppk-json/internal/spreadsheet"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrImportRejected is returned when at least one imported question is invalid.
+// Nothing is imported and the report lists the row errors.
+var ErrImportRejected = errors.New("question import rejected")
+
+// QuestionInput is a question to import, whatever the source format (JSON, CSV or XLSX)
+type QuestionInput struct {
+	Row          int // 1-based position in the JSON list or line number in the spreadsheet
+	Category     string
+	QuestionText string
+	Tags         []string
+	Options      []OptionInput
+}
+
+// OptionInput is an option of a question to import
+type OptionInput struct {
+	OptionText string
+	Score      int
+}
+
+// ToModel converts the input into a question with its options, tags are resolved separately
+func (in *QuestionInput) ToModel() models.Question {
+	question := models.Question{
+		Category:     strings.TrimSpace(in.Category),
+		QuestionText: strings.TrimSpace(in.QuestionText),
+	}
+	for _, opt := range in.Options {
+		question.Options = append(question.Options, models.QuestionOption{
+			OptionText: strings.TrimSpace(opt.OptionText),
+			Score:      opt.Score,
+		})
+	}
+	return question
+}
+
+// ValidateQuestionInputs checks every input against the registered categories and their
+// scoring schemes and returns one error per problem found, nil when all inputs are valid
+func ValidateQuestionInputs(tx *gorm.DB, inputs []QuestionInput) ([]dto.ImportRowError, error) {
+	scorers, err := categoryScorers(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rowErrors []dto.ImportRowError
+	addError := func(row int, field, format string, args ...interface{}) {
+		rowErrors = append(rowErrors, dto.ImportRowError{Row: row, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, in := range inputs {
+		category := strings.TrimSpace(in.Category)
+		scorer, known := scorers[category]
+		switch {
+		case category == "":
+			addError(in.Row, "category", "category is required")
+		case !known:
+			addError(in.Row, "category", "%s: %s", ErrUnknownCategory, category)
+		}
+
+		if strings.TrimSpace(in.QuestionText) == "" {
+			addError(in.Row, "question_text", "question text is required")
+		}
+
+		if len(in.Options) < 2 {
+			addError(in.Row, "options", "at least 2 options are required, got %d", len(in.Options))
+		}
+
+		for i, opt := range in.Options {
+			field := fmt.Sprintf("option_%d", i+1)
+			if strings.TrimSpace(opt.OptionText) == "" {
+				addError(in.Row, field, "option text cannot be empty")
+			}
+			if known {
+				if err := scorer.ValidateOptionScore(opt.Score); err != nil {
+					addError(in.Row, fmt.Sprintf("score_%d", i+1), "%v", err)
+				}
+			}
+		}
+	}
+
+	return rowErrors, nil
+}
+
+// ParseQuestionRows maps spreadsheet rows into question inputs. The first row is the
+// header with category, question_text, optional tags and option_N/score_N column pairs;
+// tags are separated by commas or semicolons. Rows that cannot be parsed are reported as errors.
+func ParseQuestionRows(rows [][]string) ([]QuestionInput, []dto.ImportRowError, error) {
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+
+	columns := make(map[string]int)
+	maxOption := 0
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[name] = i
+
+		if n, ok := pairNumber(name, "option_"); ok && n > maxOption {
+			maxOption = n
+		}
+	}
+
+	for _, required := range []string{"category", "question_text"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+	if maxOption == 0 {
+		return nil, nil, fmt.Errorf("missing option_1/score_1 columns")
+	}
+	for n := 1; n <= maxOption; n++ {
+		if _, ok := columns[fmt.Sprintf("score_%d", n)]; !ok {
+			return nil, nil, fmt.Errorf("missing score_%d column for option_%d", n, n)
+		}
+	}
+
+	cell := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var inputs []QuestionInput
+	var rowErrors []dto.ImportRowError
+
+	for i, row := range rows[1:] {
+		line := i + 2
+		if isBlankRow(row) {
+			continue
+		}
+
+		in := QuestionInput{
+			Row:          line,
+			Category:     cell(row, "category"),
+			QuestionText: cell(row, "question_text"),
+			Tags:         splitTags(cell(row, "tags")),
+		}
+
+		valid := true
+		for n := 1; n <= maxOption; n++ {
+			text := cell(row, fmt.Sprintf("option_%d", n))
+			rawScore := cell(row, fmt.Sprintf("score_%d", n))
+			if text == "" && rawScore == "" {
+				continue
+			}
+
+			score, err := parseScore(rawScore)
+			if err != nil {
+				rowErrors = append(rowErrors, dto.ImportRowError{
+					Row:     line,
+					Field:   fmt.Sprintf("score_%d", n),
+					Message: fmt.Sprintf("invalid score %q", rawScore),
+				})
+				valid = false
+				continue
+			}
+
+			in.Options = append(in.Options, OptionInput{OptionText: text, Score: score})
+		}
+
+		if valid {
+			inputs = append(inputs, in)
+		}
+	}
+
+	return inputs, rowErrors, nil
+}
+
+// ImportQuestions validates the inputs and, unless previewing, appends them to the question
+// bank in one transaction. Any invalid row rejects the whole import with ErrImportRejected.
+func (r *questionService) ImportQuestions(ctx context.Context, inputs []QuestionInput, preview bool) (*dto.QuestionImportReport, error) {
+	report := &dto.QuestionImportReport{
+		Preview:    preview,
+		TotalRows:  len(inputs),
+		ByCategory: make(map[string]int),
+		Errors:     []dto.ImportRowError{},
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		rowErrors, err := ValidateQuestionInputs(tx, inputs)
+		if err != nil {
+			return err
+		}
+		if len(rowErrors) > 0 {
+			report.Errors = rowErrors
+			return ErrImportRejected
+		}
+
+		for _, in := range inputs {
+			report.ByCategory[strings.TrimSpace(in.Category)]++
+		}
+
+		if preview {
+			return nil
+		}
+
+		for _, in := range inputs {
+			question := in.ToModel()
+
+			tags, err := FindOrCreateTags(tx, in.Tags)
+			if err != nil {
+				return fmt.Errorf("row %d: failed to prepare tags: %w", in.Row, err)
+			}
+			question.Tags = tags
+
+			if err := tx.Create(&question).Error; err != nil {
+				return fmt.Errorf("row %d: failed to insert question: %w", in.Row, err)
+			}
+			report.Imported++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, ErrImportRejected) {
+			return report, err
+		}
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ImportFormatFromFilename returns the spreadsheet or LMS format implied by a file extension
+func ImportFormatFromFilename(filename string) (string, error) {
+	if format, err := spreadsheet.FormatFromFilename(filename); err == nil {
+		return format, nil
+	}
+	return lms.FormatFromFilename(filename)
+}
+
+// ParseImportFile reads a CSV, XLSX or LMS exchange file into question inputs. Questions
+// of formats without categories (Aiken, QTI without labels) are filed under defaultCategory.
+func (r *questionService) ParseImportFile(ctx context.Context, file io.ReaderAt, size int64, format, defaultCategory string) ([]QuestionInput, []dto.ImportRowError, error) {
+	if !lms.IsFormat(format) {
+		rows, err := spreadsheet.ReadRows(file, size, format)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ParseQuestionRows(rows)
+	}
+
+	questions, err := lms.Decode(file, size, format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs, err := r.FromLMSQuestions(ctx, questions, defaultCategory)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs, nil, nil
+}
+
+// categoryScorers returns the scorer of every registered category keyed by category code
+func categoryScorers(tx *gorm.DB) (map[string]scoring.Scorer, error) {
+	var categories []models.Category
+	if err := tx.Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+
+	scorers := make(map[string]scoring.Scorer, len(categories))
+	for _, category := range categories {
+		scorer, err := scoring.ForCategory(&category)
+		if err != nil {
+			return nil, fmt.Errorf("category %s: %w", category.Code, err)
+		}
+		scorers[category.Code] = scorer
+	}
+	return scorers, nil
+}
+
+// pairNumber extracts N from a column named prefix+N
+func pairNumber(name, prefix string) (int, bool) {
+	if !strings.HasPrefix(name, prefix) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseScore parses an integer score, accepting the "4.0" form spreadsheets produce
+func parseScore(value string) (int, error) {
+	if score, err := strconv.Atoi(value); err == nil {
+		return score, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != float64(int(f)) {
+		return 0, fmt.Errorf("invalid score %q", value)
+	}
+	return int(f), nil
+}
+
+// splitTags splits a tag cell on commas and semicolons
+func splitTags(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+}
+
+// isBlankRow reports whether every cell of a row is empty
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package question_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/lms"
+	"cutbray/pppk-json/internal/scoring"
+	"strings"
+)
+
+// ExportLMSQuestions returns the filtered questions in exchange form, option scores
+// converted into fractional credit with the scorer of each question's category
+func (r *questionService) ExportLMSQuestions(ctx context.Context, category, searchText string, tags []string) ([]lms.Question, error) {
+	questions, err := r.GetAllQuestionsWithFilters(ctx, category, searchText, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	scorers, err := categoryScorers(r.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]lms.Question, len(questions))
+	for i, question := range questions {
+		scorer, ok := scorers[question.Category]
+		if !ok {
+			scorer, _ = scoring.ForScheme("")
+		}
+
+		result[i] = lms.Question{
+			Category: question.Category,
+			Text:     question.QuestionText,
+			Tags:     dto.ToTagNames(question.Tags),
+		}
+		for _, option := range question.Options {
+			result[i].Options = append(result[i].Options, lms.Option{
+				Text:   option.OptionText,
+				Credit: scoring.Credit(scorer, option, question.Options),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// FromLMSQuestions converts exchanged questions into import inputs, fractional credit
+// converted back into option scores of the category scheme. Questions without a category
+// are filed under defaultCategory; unknown categories are left for validation to report.
+func (r *questionService) FromLMSQuestions(ctx context.Context, questions []lms.Question, defaultCategory string) ([]QuestionInput, error) {
+	scorers, err := categoryScorers(r.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]QuestionInput, len(questions))
+	for i, q := range questions {
+		category := strings.TrimSpace(q.Category)
+		if category == "" {
+			category = defaultCategory
+		}
+
+		inputs[i] = QuestionInput{
+			Row:          i + 1,
+			Category:     category,
+			QuestionText: q.Text,
+			Tags:         q.Tags,
+		}
+
+		scorer, ok := scorers[category]
+		for _, opt := range q.Options {
+			score := 0
+			if ok {
+				score = scoring.ScoreForCredit(scorer, opt.Credit)
+			}
+			inputs[i].Options = append(inputs[i].Options, OptionInput{OptionText: opt.Text, Score: score})
+		}
+	}
+
+	return inputs, nil
+}
@@ -1,18 +1,44 @@
 package question_service
 
 import (
+	"context"
+	"cutbray/pppk-json/internal/dto"
+	"cutbray/pppk-json/internal/lms"
+	"cutbray/pppk-json/internal/repositories/category_service"
 	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/scoring"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
 	"gorm.io/gorm"
 )
 
+// ErrUnknownCategory is returned when a question references a category that is not registered
+var ErrUnknownCategory = errors.New("unknown category")
+
 type QuestionService interface {
-	GetQuestionsWithFilters(category, searchText string, offset, limit int) ([]models.Question, error)
-	CountQuestionsWithFilters(category, searchText string) (int64, error)
-	GetAllQuestionsWithFilters(category, searchText string) ([]models.Question, error)
-	GetQuestionOptionByID(questionID, optionID uint) (*models.QuestionOption, error)
-	UpdateQuestionOption(option *models.QuestionOption) error
-	GetDistinctCategories() ([]string, error)
+	GetQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string, offset, limit int) ([]models.Question, error)
+	CountQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string) (int64, error)
+	GetAllQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string) ([]models.Question, error)
+	GetQuestionByID(ctx context.Context, questionID uint) (*models.Question, error)
+	CreateQuestion(ctx context.Context, question *models.Question, tagNames []string) error
+	UpdateQuestion(ctx context.Context, question *models.Question) error
+	DeleteQuestion(ctx context.Context, questionID uint) error
+	GetQuestionOptionByID(ctx context.Context, questionID, optionID uint) (*models.QuestionOption, error)
+	UpdateQuestionOption(ctx context.Context, option *models.QuestionOption) error
+	GetScorerForQuestion(ctx context.Context, questionID uint) (scoring.Scorer, error)
+	GetCategoryCodes(ctx context.Context) ([]string, error)
+	GetTags(ctx context.Context) ([]models.Tag, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	SetQuestionTags(ctx context.Context, questionID uint, tagNames []string) (*models.Question, error)
+	GetTagQuotas(ctx context.Context) ([]models.TagQuota, error)
+	SetTagQuota(ctx context.Context, category, tagName string, questionCount int) (*models.TagQuota, error)
+	ImportQuestions(ctx context.Context, inputs []QuestionInput, preview bool) (*dto.QuestionImportReport, error)
+	ParseImportFile(ctx context.Context, file io.ReaderAt, size int64, format, defaultCategory string) ([]QuestionInput, []dto.ImportRowError, error)
+	ExportLMSQuestions(ctx context.Context, category, searchText string, tags []string) ([]lms.Question, error)
+	FromLMSQuestions(ctx context.Context, questions []lms.Question, defaultCategory string) ([]QuestionInput, error)
 }
 
 type questionService struct {
@@ -25,10 +51,14 @@ func NewQuestionService(db *gorm.DB) QuestionService {
 	}
 }
 
-func (r *questionService) GetQuestionsWithFilters(category, searchText string, offset, limit int) ([]models.Question, error) {
-	var questions []models.Question
-	query := r.db.Preload("Options")
+// NormalizeTagName trims and lowercases a tag name so "Pedagogi " and "pedagogi" are the same tag
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
 
+// applyFilters applies category, question text and tag filters to a question query.
+// When several tags are given, only questions carrying all of them are returned.
+func applyFilters(query *gorm.DB, category, searchText string, tags []string) *gorm.DB {
 	if category != "" {
 		query = query.Where("category = ?", category)
 	}
@@ -37,6 +67,31 @@ func (r *questionService) GetQuestionsWithFilters(category, searchText string, o
 		query = query.Where("LOWER(question_text) LIKE LOWER(?)", "%"+searchText+"%")
 	}
 
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if name := NormalizeTagName(tag); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) > 0 {
+		query = query.Where(`id IN (
+			SELECT qt.question_id
+			FROM question_tags qt
+			JOIN tags t ON t.id = qt.tag_id AND t.deleted_at IS NULL
+			WHERE t.name IN ?
+			GROUP BY qt.question_id
+			HAVING COUNT(DISTINCT t.id) = ?
+		)`, names, len(names))
+	}
+
+	return query
+}
+
+func (r *questionService) GetQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string, offset, limit int) ([]models.Question, error) {
+	var questions []models.Question
+	query := applyFilters(r.db.WithContext(ctx).Preload("Options").Preload("Tags"), category, searchText, tags)
+
 	if limit > 0 {
 		query = query.Offset(offset).Limit(limit)
 	}
@@ -45,50 +100,217 @@ func (r *questionService) GetQuestionsWithFilters(category, searchText string, o
 	return questions, err
 }
 
-func (r *questionService) CountQuestionsWithFilters(category, searchText string) (int64, error) {
+func (r *questionService) CountQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string) (int64, error) {
 	var count int64
-	query := r.db.Model(&models.Question{})
-
-	if category != "" {
-		query = query.Where("category = ?", category)
-	}
-
-	if searchText != "" {
-		query = query.Where("LOWER(question_text) LIKE LOWER(?)", "%"+searchText+"%")
-	}
+	query := applyFilters(r.db.WithContext(ctx).Model(&models.Question{}), category, searchText, tags)
 
 	err := query.Count(&count).Error
 	return count, err
 }
 
-func (r *questionService) GetAllQuestionsWithFilters(category, searchText string) ([]models.Question, error) {
+func (r *questionService) GetAllQuestionsWithFilters(ctx context.Context, category, searchText string, tags []string) ([]models.Question, error) {
 	var questions []models.Question
-	query := r.db.Preload("Options")
+	query := applyFilters(r.db.WithContext(ctx).Preload("Options").Preload("Tags"), category, searchText, tags)
 
-	if category != "" {
-		query = query.Where("category = ?", category)
-	}
+	err := query.Order("id ASC").Find(&questions).Error
+	return questions, err
+}
 
-	if searchText != "" {
-		query = query.Where("LOWER(question_text) LIKE LOWER(?)", "%"+searchText+"%")
+func (r *questionService) GetQuestionByID(ctx context.Context, questionID uint) (*models.Question, error) {
+	var question models.Question
+	err := r.db.WithContext(ctx).Preload("Options").Preload("Tags").First(&question, questionID).Error
+	return &question, err
+}
+
+// CreateQuestion creates a question with its options after validating the category
+func (r *questionService) CreateQuestion(ctx context.Context, question *models.Question, tagNames []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := validateCategory(tx, question.Category); err != nil {
+			return err
+		}
+
+		tags, err := FindOrCreateTags(tx, tagNames)
+		if err != nil {
+			return err
+		}
+		question.Tags = tags
+
+		return tx.Create(question).Error
+	})
+}
+
+// UpdateQuestion updates the category and text of a question after validating the category
+func (r *questionService) UpdateQuestion(ctx context.Context, question *models.Question) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := validateCategory(tx, question.Category); err != nil {
+			return err
+		}
+
+		return tx.Model(question).
+			Select("category", "question_text").
+			Updates(question).Error
+	})
+}
+
+func (r *questionService) DeleteQuestion(ctx context.Context, questionID uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Question{}, questionID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
+	return nil
+}
 
-	err := query.Order("id ASC").Find(&questions).Error
-	return questions, err
+// validateCategory rejects category codes that are not registered in the categories table
+func validateCategory(tx *gorm.DB, category string) error {
+	exists, err := category_service.CategoryExists(tx, category)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownCategory, category)
+	}
+	return nil
 }
 
-func (r *questionService) GetQuestionOptionByID(questionID, optionID uint) (*models.QuestionOption, error) {
+func (r *questionService) GetQuestionOptionByID(ctx context.Context, questionID, optionID uint) (*models.QuestionOption, error) {
 	var option models.QuestionOption
-	err := r.db.Where("id = ? AND question_id = ?", optionID, questionID).First(&option).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND question_id = ?", optionID, questionID).First(&option).Error
 	return &option, err
 }
 
-func (r *questionService) UpdateQuestionOption(option *models.QuestionOption) error {
-	return r.db.Save(option).Error
+func (r *questionService) UpdateQuestionOption(ctx context.Context, option *models.QuestionOption) error {
+	return r.db.WithContext(ctx).Save(option).Error
+}
+
+// GetScorerForQuestion returns the scorer of the category a question belongs to
+func (r *questionService) GetScorerForQuestion(ctx context.Context, questionID uint) (scoring.Scorer, error) {
+	var question models.Question
+	if err := r.db.WithContext(ctx).First(&question, questionID).Error; err != nil {
+		return nil, err
+	}
+
+	var category models.Category
+	if err := r.db.WithContext(ctx).Where("code = ?", question.Category).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, question.Category)
+		}
+		return nil, err
+	}
+
+	return scoring.ForCategory(&category)
 }
 
-func (r *questionService) GetDistinctCategories() ([]string, error) {
+// GetCategoryCodes returns the registered category codes in display order
+func (r *questionService) GetCategoryCodes(ctx context.Context) ([]string, error) {
 	var categories []string
-	err := r.db.Model(&models.Question{}).Distinct("category").Pluck("category", &categories).Error
+	err := r.db.WithContext(ctx).Model(&models.Category{}).Order("display_order ASC, id ASC").Pluck("code", &categories).Error
 	return categories, err
 }
+
+func (r *questionService) GetTags(ctx context.Context) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+func (r *questionService) CreateTag(ctx context.Context, tag *models.Tag) error {
+	tag.Name = NormalizeTagName(tag.Name)
+	return r.db.WithContext(ctx).Create(tag).Error
+}
+
+// SetQuestionTags replaces the tags of a question, creating tags that do not exist yet
+func (r *questionService) SetQuestionTags(ctx context.Context, questionID uint, tagNames []string) (*models.Question, error) {
+	var question models.Question
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&question, questionID).Error; err != nil {
+			return err
+		}
+
+		tags, err := FindOrCreateTags(tx, tagNames)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&question).Association("Tags").Replace(tags); err != nil {
+			return err
+		}
+
+		return tx.Preload("Options").Preload("Tags").First(&question, questionID).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &question, nil
+}
+
+// FindOrCreateTags returns the tags with the given names, creating the missing ones
+func FindOrCreateTags(tx *gorm.DB, tagNames []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(tagNames))
+	seen := make(map[string]bool)
+
+	for _, tagName := range tagNames {
+		name := NormalizeTagName(tagName)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		var tag models.Tag
+		if err := tx.Where(models.Tag{Name: name}).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (r *questionService) GetTagQuotas(ctx context.Context) ([]models.TagQuota, error) {
+	var quotas []models.TagQuota
+	err := r.db.WithContext(ctx).Preload("Tag").Order("category ASC, id ASC").Find(&quotas).Error
+	return quotas, err
+}
+
+// SetTagQuota creates or updates the quota of a tag inside a category.
+// A question count of zero removes the quota and returns nil.
+func (r *questionService) SetTagQuota(ctx context.Context, category, tagName string, questionCount int) (*models.TagQuota, error) {
+	var quota models.TagQuota
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := validateCategory(tx, category); err != nil {
+			return err
+		}
+
+		var tag models.Tag
+		if err := tx.Where("name = ?", NormalizeTagName(tagName)).First(&tag).Error; err != nil {
+			return err
+		}
+
+		if questionCount == 0 {
+			return tx.Unscoped().
+				Where("category = ? AND tag_id = ?", category, tag.ID).
+				Delete(&models.TagQuota{}).Error
+		}
+
+		if err := tx.Where(models.TagQuota{Category: category, TagID: tag.ID}).
+			Assign(models.TagQuota{QuestionCount: questionCount}).
+			FirstOrCreate(&quota).Error; err != nil {
+			return err
+		}
+
+		quota.Tag = tag
+		return nil
+	})
+
+	if err != nil || questionCount == 0 {
+		return nil, err
+	}
+
+	return &quota, nil
+}
@@ -0,0 +1,249 @@
+package sitting_service
+
+import (
+	"context"
+	"crypto/subtle"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidSittingWindow is returned when a sitting does not open, close and end in that order
+	ErrInvalidSittingWindow = errors.New("invalid sitting window")
+	// ErrSittingFull is returned when booking more candidates than the sitting has seats
+	ErrSittingFull = errors.New("sitting is full")
+	// ErrSittingNotOpen is returned when starting a sitting session before the sitting opens
+	ErrSittingNotOpen = errors.New("sitting is not open yet")
+	// ErrSittingClosed is returned when starting a sitting session after the sitting closed
+	ErrSittingClosed = errors.New("sitting is closed")
+	// ErrInvalidAccessCode is returned when starting a sitting session with a wrong access code
+	ErrInvalidAccessCode = errors.New("invalid sitting access code")
+)
+
+type SittingService interface {
+	GetSittings(ctx context.Context, from *time.Time) ([]models.Sitting, error)
+	GetSittingByID(ctx context.Context, sittingID uint) (*models.Sitting, error)
+	CreateSitting(ctx context.Context, sitting *models.Sitting) error
+	UpdateSitting(ctx context.Context, sitting *models.Sitting) error
+	DeleteSitting(ctx context.Context, sittingID uint) error
+	BookCandidates(ctx context.Context, sittingID uint, userIDs []string) (*models.Sitting, error)
+	RemoveCandidate(ctx context.Context, sittingID uint, userID string) error
+}
+
+type sittingService struct {
+	db *gorm.DB
+}
+
+func NewSittingService(db *gorm.DB) SittingService {
+	return &sittingService{
+		db: db,
+	}
+}
+
+// preloadCandidates lists candidates in booking order
+func preloadCandidates(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
+
+// GetSittings returns sittings by opening time, only those not ended at from when set
+func (r *sittingService) GetSittings(ctx context.Context, from *time.Time) ([]models.Sitting, error) {
+	query := r.db.WithContext(ctx).Preload("Blueprint").Preload("Candidates", preloadCandidates)
+	if from != nil {
+		query = query.Where("ends_at > ?", *from)
+	}
+
+	var sittings []models.Sitting
+	err := query.Order("opens_at ASC, id ASC").Find(&sittings).Error
+	return sittings, err
+}
+
+func (r *sittingService) GetSittingByID(ctx context.Context, sittingID uint) (*models.Sitting, error) {
+	var sitting models.Sitting
+	err := r.db.WithContext(ctx).Preload("Blueprint").Preload("Candidates", preloadCandidates).First(&sitting, sittingID).Error
+	return &sitting, err
+}
+
+func (r *sittingService) CreateSitting(ctx context.Context, sitting *models.Sitting) error {
+	if err := validateSitting(sitting); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if sitting.BlueprintID != nil {
+			if err := tx.First(&models.ExamBlueprint{}, *sitting.BlueprintID).Error; err != nil {
+				return fmt.Errorf("failed to get exam blueprint %d: %w", *sitting.BlueprintID, err)
+			}
+		}
+		return tx.Omit("Blueprint", "Candidates").Create(sitting).Error
+	})
+}
+
+// UpdateSitting saves the sitting and moves the deadline of its unfinished sessions along with its end.
+// The capacity cannot drop below the candidates already booked.
+func (r *sittingService) UpdateSitting(ctx context.Context, sitting *models.Sitting) error {
+	if err := validateSitting(sitting); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if sitting.BlueprintID != nil {
+			if err := tx.First(&models.ExamBlueprint{}, *sitting.BlueprintID).Error; err != nil {
+				return fmt.Errorf("failed to get exam blueprint %d: %w", *sitting.BlueprintID, err)
+			}
+		}
+
+		var booked int64
+		if err := tx.Model(&models.SittingCandidate{}).Where("sitting_id = ?", sitting.ID).Count(&booked).Error; err != nil {
+			return fmt.Errorf("failed to count sitting candidates: %w", err)
+		}
+		if int64(sitting.Capacity) < booked {
+			return fmt.Errorf("%w: %d candidates already booked", ErrSittingFull, booked)
+		}
+
+		var previous models.Sitting
+		if err := tx.Select("id", "ends_at").First(&previous, sitting.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Blueprint", "Candidates").Save(sitting).Error; err != nil {
+			return err
+		}
+
+		// Shift rather than overwrite so extensions granted to single sessions are kept
+		shift := sitting.EndsAt.Sub(previous.EndsAt)
+		if shift == 0 {
+			return nil
+		}
+		err := tx.Model(&models.ExamSession{}).
+			Where("sitting_id = ? AND status IN ?", sitting.ID, models.ActiveSessionStatuses).
+			Update("expires_at", gorm.Expr("expires_at + ? * INTERVAL '1 second'", shift.Seconds())).Error
+		if err != nil {
+			return fmt.Errorf("failed to move sitting session deadlines: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteSitting deletes a sitting and its bookings. Sessions already created keep their deadline.
+func (r *sittingService) DeleteSitting(ctx context.Context, sittingID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sitting models.Sitting
+		if err := tx.First(&sitting, sittingID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("sitting_id = ?", sittingID).Delete(&models.SittingCandidate{}).Error; err != nil {
+			return fmt.Errorf("failed to delete sitting candidates: %w", err)
+		}
+		return tx.Delete(&sitting).Error
+	})
+}
+
+// BookCandidates books users into a sitting. Users already booked are skipped; the booking is
+// rejected as a whole when the remaining seats do not fit the new candidates.
+func (r *sittingService) BookCandidates(ctx context.Context, sittingID uint, userIDs []string) (*models.Sitting, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the sitting so concurrent bookings cannot overfill it
+		var sitting models.Sitting
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sitting, sittingID).Error; err != nil {
+			return err
+		}
+
+		var booked []string
+		if err := tx.Model(&models.SittingCandidate{}).Where("sitting_id = ?", sittingID).Pluck("user_id", &booked).Error; err != nil {
+			return fmt.Errorf("failed to get sitting candidates: %w", err)
+		}
+
+		seen := make(map[string]bool, len(booked)+len(userIDs))
+		for _, userID := range booked {
+			seen[userID] = true
+		}
+
+		var candidates []models.SittingCandidate
+		for _, userID := range userIDs {
+			if seen[userID] {
+				continue
+			}
+			seen[userID] = true
+			candidates = append(candidates, models.SittingCandidate{SittingID: sittingID, UserID: userID})
+		}
+
+		if len(booked)+len(candidates) > sitting.Capacity {
+			return fmt.Errorf("%w: %d of %d seats left", ErrSittingFull, sitting.Capacity-len(booked), sitting.Capacity)
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		if err := tx.Create(&candidates).Error; err != nil {
+			return fmt.Errorf("failed to book sitting candidates: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetSittingByID(ctx, sittingID)
+}
+
+func (r *sittingService) RemoveCandidate(ctx context.Context, sittingID uint, userID string) error {
+	result := r.db.WithContext(ctx).Where("sitting_id = ? AND user_id = ?", sittingID, userID).Delete(&models.SittingCandidate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// validateSitting checks the sitting opens before it closes, ends no earlier than it closes and has seats
+func validateSitting(sitting *models.Sitting) error {
+	if !sitting.ClosesAt.After(sitting.OpensAt) {
+		return fmt.Errorf("%w: closes_at must be after opens_at", ErrInvalidSittingWindow)
+	}
+	if sitting.EndsAt.Before(sitting.ClosesAt) {
+		return fmt.Errorf("%w: ends_at must not be before closes_at", ErrInvalidSittingWindow)
+	}
+	if sitting.Capacity < 1 {
+		return fmt.Errorf("%w: capacity must be at least 1", ErrInvalidSittingWindow)
+	}
+	return nil
+}
+
+// FindBookedSitting returns the earliest sitting the user is booked into that has not ended at at.
+// It returns nil when there is none.
+func FindBookedSitting(tx *gorm.DB, userID string, at time.Time) (*models.Sitting, error) {
+	var sittings []models.Sitting
+	err := tx.
+		Joins("JOIN sitting_candidates sc ON sc.sitting_id = sittings.id AND sc.deleted_at IS NULL").
+		Where("sc.user_id = ? AND sittings.ends_at > ?", userID, at).
+		Order("sittings.opens_at ASC, sittings.id ASC").
+		Limit(1).
+		Find(&sittings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find booked sitting: %w", err)
+	}
+	if len(sittings) == 0 {
+		return nil, nil
+	}
+	return &sittings[0], nil
+}
+
+// CheckStart returns why a session of the sitting cannot be started at at with the access code, nil when it can
+func CheckStart(sitting *models.Sitting, at time.Time, accessCode string) error {
+	if at.Before(sitting.OpensAt) {
+		return fmt.Errorf("%w: opens at %s", ErrSittingNotOpen, sitting.OpensAt.Format(time.RFC3339))
+	}
+	if !at.Before(sitting.ClosesAt) {
+		return fmt.Errorf("%w: closed at %s", ErrSittingClosed, sitting.ClosesAt.Format(time.RFC3339))
+	}
+	if sitting.AccessCode != "" && subtle.ConstantTimeCompare([]byte(sitting.AccessCode), []byte(accessCode)) != 1 {
+		return ErrInvalidAccessCode
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package webhook_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxDeliveryAttempts is the number of requests made before a delivery fails
+	MaxDeliveryAttempts = 8
+	// deliveryTimeout bounds one webhook request
+	deliveryTimeout = 10 * time.Second
+	// deliveryLease keeps claimed deliveries from being claimed again while they are sent. It is
+	// renewed before every request, so it only has to outlast one delivery, not the whole batch.
+	deliveryLease = 2 * time.Minute
+	// baseBackoff is the wait after the first failed attempt, doubled after every later one
+	baseBackoff = 30 * time.Second
+	// maxBackoff caps the wait between attempts
+	maxBackoff = 6 * time.Hour
+	// maxResponseBody is the number of response bytes kept in the delivery log
+	maxResponseBody = 1024
+)
+
+// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the webhook secret, where timestamp is the TimestampHeader value.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+)
+
+// Sign returns the signature header value of a request body sent at the Unix timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the wait after the given number of failed attempts
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
+
+// Dispatcher sends pending webhook deliveries. Several dispatchers may share a database,
+// claimed deliveries are skipped by the others.
+type Dispatcher struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		client: &http.Client{Timeout: deliveryTimeout},
+	}
+}
+
+// DeliverDue sends up to batch deliveries that are due and returns how many were attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context, batch int) (int, error) {
+	deliveries, err := d.claim(ctx, batch)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			// Unsent deliveries are claimed again once their lease runs out
+			break
+		}
+		renewed, err := d.renewLease(ctx, &deliveries[i])
+		if err != nil {
+			log.Printf("[Error] Failed to renew lease of webhook delivery %d: %v", deliveries[i].ID, err)
+			continue
+		}
+		if !renewed {
+			// The lease ran out while earlier deliveries of the batch were sent and another
+			// dispatcher took this one over
+			continue
+		}
+		if err := d.deliver(ctx, &deliveries[i]); err != nil {
+			log.Printf("[Error] Failed to record webhook delivery %d: %v", deliveries[i].ID, err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// claim leases due deliveries by moving their next attempt past the lease
+func (d *Dispatcher) claim(ctx context.Context, batch int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(batch).
+			Find(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to get due webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		leasedUntil := leaseEnd()
+		ids := make([]uint, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+			deliveries[i].NextAttemptAt = leasedUntil
+		}
+		if err := tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leasedUntil).Error; err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+
+		// Deleted webhooks are loaded too, so their deliveries can be failed
+		webhookIDs := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			webhookIDs[i] = delivery.WebhookID
+		}
+		var webhooks []models.Webhook
+		if err := tx.Unscoped().Where("id IN ?", webhookIDs).Find(&webhooks).Error; err != nil {
+			return fmt.Errorf("failed to get webhooks: %w", err)
+		}
+		byID := make(map[uint]*models.Webhook, len(webhooks))
+		for i := range webhooks {
+			byID[webhooks[i].ID] = &webhooks[i]
+		}
+		for i := range deliveries {
+			deliveries[i].Webhook = byID[deliveries[i].WebhookID]
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+// renewLease moves the next attempt of a claimed delivery past a fresh lease before it is sent.
+// It reports false when the delivery changed since it was claimed, i.e. another dispatcher
+// claimed it after the lease ran out.
+func (d *Dispatcher) renewLease(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	leasedUntil := leaseEnd()
+	result := d.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_attempt_at = ?",
+			delivery.ID, models.WebhookDeliveryPending, delivery.Attempts, delivery.NextAttemptAt).
+		Update("next_attempt_at", leasedUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	delivery.NextAttemptAt = leasedUntil
+	return true, nil
+}
+
+// leaseEnd returns the end of a lease taken now, at the microsecond precision of Postgres
+// timestamps so the stored value can be compared with the one kept in memory
+func leaseEnd() time.Time {
+	return time.Now().Add(deliveryLease).Truncate(time.Microsecond)
+}
+
+// deliver makes one attempt of a claimed delivery and records its outcome
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	webhook := delivery.Webhook
+	now := time.Now()
+	attempt := models.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts + 1,
+	}
+
+	updates := map[string]interface{}{
+		"attempts":        attempt.Attempt,
+		"last_attempt_at": now,
+	}
+
+	if webhook == nil || webhook.DeletedAt.Valid || !webhook.Active {
+		// Nobody is listening any more, stop retrying
+		attempt.Error = "webhook is deleted or inactive"
+		updates["status"] = models.WebhookDeliveryFailed
+	} else {
+		d.send(ctx, webhook, delivery, &attempt)
+		if ctx.Err() != nil {
+			// Interrupted by shutdown, not by the webhook; retried after the lease
+			return nil
+		}
+
+		switch {
+		case attempt.Error == "":
+			updates["status"] = models.WebhookDeliveryDelivered
+			updates["delivered_at"] = time.Now()
+		case attempt.Attempt >= MaxDeliveryAttempts:
+			updates["status"] = models.WebhookDeliveryFailed
+		default:
+			updates["next_attempt_at"] = time.Now().Add(Backoff(attempt.Attempt))
+		}
+	}
+
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error
+	})
+}
+
+// send posts the signed payload, filling the attempt with the response or error
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	started := time.Now()
+	defer func() {
+		attempt.DurationMs = int(time.Since(started).Milliseconds())
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PPPKJson-Webhook/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Event-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	attempt.ResponseStatus = &status
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// Postgres text holds neither invalid UTF-8 nor NUL bytes
+	attempt.ResponseBody = string(bytes.ReplaceAll(bytes.ToValidUTF8(responseBody, nil), []byte{0}, nil))
+
+	if status < 200 || status > 299 {
+		attempt.Error = fmt.Sprintf("unexpected response status %d", status)
+	}
+}
@@ -0,0 +1,194 @@
+package webhook_service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"cutbray/pppk-json/internal/repositories/models"
+	"cutbray/pppk-json/internal/testutil/sqlmocktest"
+	"database/sql/driver"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("whsec_test", 1700000000, []byte(`{"event":"session.completed"}`))
+	want := "sha256=5b523e1a8a28de4699ae43a4254a1d23dd64c372b3b489f16cca0ebb5f4a5094"
+	if got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{7, 32 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, maxBackoff},
+		{50, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			if got := Backoff(tt.attempts); got != tt.want {
+				t.Fatalf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherDeliverDue(t *testing.T) {
+	const secret = "whsec_test"
+	payload := `{"id":"ev-1","event":"session.completed","data":{"session_id":7}}`
+
+	tests := []struct {
+		name           string
+		responseStatus int
+		attempts       int // attempts made before this one
+		// outcome columns of the delivery update besides attempts and last_attempt_at, in column order
+		outcome []driver.Value
+	}{
+		{
+			name:           "acknowledged",
+			responseStatus: http.StatusOK,
+			attempts:       0,
+			outcome:        []driver.Value{sqlmocktest.TimeFromNow(0), nil, models.WebhookDeliveryDelivered},
+		},
+		{
+			name:           "retried after a server error",
+			responseStatus: http.StatusServiceUnavailable,
+			attempts:       2,
+			outcome:        []driver.Value{nil, sqlmocktest.TimeFromNow(Backoff(3))},
+		},
+		{
+			name:           "failed at the last attempt",
+			responseStatus: http.StatusInternalServerError,
+			attempts:       MaxDeliveryAttempts - 1,
+			outcome:        []driver.Value{nil, models.WebhookDeliveryFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.responseStatus)
+				io.WriteString(w, "ok")
+			}))
+			defer server.Close()
+
+			db, mock := sqlmocktest.NewDB(t)
+			claimedAt := time.Now().Add(-time.Minute)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE status = $1 AND next_attempt_at <= $2`) + `.*` + regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event", "payload", "status", "attempts", "next_attempt_at"}).
+					AddRow(11, 3, "ev-1", "session.completed", payload, models.WebhookDeliveryPending, tt.attempts, claimedAt))
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "next_attempt_at"=$1,"updated_at"=$2 WHERE id IN ($3)`)).
+				WithArgs(sqlmocktest.TimeFromNow(deliveryLease), sqlmock.AnyArg(), 11).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE id IN ($1)`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "url", "secret", "events", "active"}).
+					AddRow(3, "Proctoring", server.URL+"/hooks", secret, "session.completed", true))
+			mock.ExpectCommit()
+
+			// Lease renewed right before the request
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "next_attempt_at"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4 AND attempts = $5 AND next_attempt_at = $6`)).
+				WithArgs(sqlmocktest.TimeFromNow(deliveryLease), sqlmock.AnyArg(), 11, models.WebhookDeliveryPending, tt.attempts, sqlmocktest.TimeFromNow(deliveryLease)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			// Delivery log and outcome
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "webhook_delivery_attempts" ("delivery_id","attempt","response_status","response_body","error","duration_ms","created_at")`)).
+				WithArgs(11, tt.attempts+1, tt.responseStatus, "ok", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			args := []driver.Value{tt.attempts + 1}
+			for _, value := range tt.outcome {
+				if value == nil {
+					// last_attempt_at sorts between the outcome columns
+					value = sqlmocktest.TimeFromNow(0)
+				}
+				args = append(args, value)
+			}
+			args = append(args, sqlmock.AnyArg(), 11)
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "attempts"=$1`)).
+				WithArgs(args...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			attempted, err := NewDispatcher(db).DeliverDue(context.Background(), 20)
+			if err != nil || attempted != 1 {
+				t.Fatalf("DeliverDue() = %d, %v, want 1 delivery attempted", attempted, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+
+			if received == nil {
+				t.Fatal("webhook received no request")
+			}
+			if string(receivedBody) != payload {
+				t.Fatalf("body = %s, want the stored payload %s", receivedBody, payload)
+			}
+			timestamp := received.Header.Get(TimestampHeader)
+			mac := hmac.New(sha256.New, []byte(secret))
+			io.WriteString(mac, timestamp+"."+payload)
+			if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); received.Header.Get(SignatureHeader) != want {
+				t.Fatalf("signature = %s, want %s", received.Header.Get(SignatureHeader), want)
+			}
+			if got := received.Header.Get("X-Webhook-Event-ID"); got != "ev-1" {
+				t.Fatalf("event ID header = %s, want ev-1", got)
+			}
+			if got := received.Header.Get("X-Webhook-Delivery"); got != "11" {
+				t.Fatalf("delivery header = %s, want 11", got)
+			}
+		})
+	}
+}
+
+func TestDispatcherSkipsDeliveryTakenOver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a delivery claimed by another dispatcher was sent")
+	}))
+	defer server.Close()
+
+	db, mock := sqlmocktest.NewDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event", "payload", "status", "attempts", "next_attempt_at"}).
+			AddRow(11, 3, "ev-1", "session.completed", `{}`, models.WebhookDeliveryPending, 0, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhooks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "active"}).AddRow(3, server.URL, "s", true))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if _, err := NewDispatcher(db).DeliverDue(context.Background(), 20); err != nil {
+		t.Fatalf("DeliverDue() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,63 @@
+package webhook_service
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Envelope is the JSON body of every webhook request
+type Envelope struct {
+	ID         string      `json:"id"` // Event ID, the same for every webhook receiving the event
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Enqueue stores a pending delivery of the event for every active webhook subscribed to it.
+// Deliveries exist exactly when tx is committed.
+func Enqueue(tx *gorm.DB, eventID, event string, occurredAt time.Time, data interface{}) error {
+	var webhooks []models.Webhook
+	if err := tx.Where("active = ?", true).Find(&webhooks).Error; err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	var deliveries []models.WebhookDelivery
+	now := time.Now()
+	var envelope []byte
+
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.EventList(), event) {
+			continue
+		}
+
+		if envelope == nil {
+			body, err := json.Marshal(Envelope{ID: eventID, Event: event, OccurredAt: occurredAt, Data: data})
+			if err != nil {
+				return fmt.Errorf("failed to encode %s event: %w", event, err)
+			}
+			envelope = body
+		}
+
+		deliveries = append(deliveries, models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       string(envelope),
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := tx.Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to queue %s webhook deliveries: %w", event, err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package webhook_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/events"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+var _ events.Subscriber = &subscriber{}
+
+// webhookEvents maps the domain events to the webhook events they are sent as
+var webhookEvents = map[string]string{
+	models.EventExamSessionCreated: models.WebhookEventSessionCreated,
+	models.EventExamStarted:        models.WebhookEventSessionStarted,
+	models.EventAnswerSubmitted:    models.WebhookEventAnswerSubmitted,
+	models.EventExamCompleted:      models.WebhookEventSessionCompleted,
+	models.EventExamExpired:        models.WebhookEventSessionExpired,
+}
+
+type subscriber struct{}
+
+// NewSubscriber returns the domain event subscriber that queues webhook deliveries. Events
+// keep their ID and payload, so a receiver can match webhook events to domain events.
+func NewSubscriber() events.Subscriber {
+	return &subscriber{}
+}
+
+func (s *subscriber) Name() string {
+	return "webhooks"
+}
+
+func (s *subscriber) Handle(ctx context.Context, tx *gorm.DB, event *models.DomainEvent) error {
+	webhookEvent, ok := webhookEvents[event.EventType]
+	if !ok {
+		return nil
+	}
+	return Enqueue(tx, event.EventID, webhookEvent, event.OccurredAt, json.RawMessage(event.Payload))
+}
@@ -0,0 +1,162 @@
+package webhook_service
+
+import (
+	"context"
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidWebhookURL is returned for webhook URLs that are not absolute http(s) URLs
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+	// ErrUnknownWebhookEvent is returned when subscribing to an event that does not exist
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event")
+	// ErrDeliveryNotFailed is returned when retrying a delivery that has not failed
+	ErrDeliveryNotFailed = errors.New("webhook delivery has not failed")
+)
+
+type WebhookService interface {
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, webhookID uint) (*models.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID uint) error
+	CountDeliveries(ctx context.Context, webhookID uint, status string) (int64, error)
+	GetDeliveries(ctx context.Context, webhookID uint, status string, offset, limit int) ([]models.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	db *gorm.DB
+}
+
+func NewWebhookService(db *gorm.DB) WebhookService {
+	return &webhookService{
+		db: db,
+	}
+}
+
+func (r *webhookService) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.WithContext(ctx).Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookService) GetWebhookByID(ctx context.Context, webhookID uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.WithContext(ctx).First(&webhook, webhookID).Error
+	return &webhook, err
+}
+
+func (r *webhookService) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookService) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Save(webhook).Error
+}
+
+// DeleteWebhook removes a webhook; its pending deliveries are dropped by the dispatcher
+func (r *webhookService) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Webhook{}, webhookID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// deliveriesQuery selects the deliveries of a webhook, optionally with one status
+func (r *webhookService) deliveriesQuery(ctx context.Context, webhookID uint, status string) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
+
+func (r *webhookService) CountDeliveries(ctx context.Context, webhookID uint, status string) (int64, error) {
+	var count int64
+	err := r.deliveriesQuery(ctx, webhookID, status).Count(&count).Error
+	return count, err
+}
+
+// GetDeliveries returns the deliveries of a webhook newest first with their attempts.
+// A limit of 0 returns every delivery.
+func (r *webhookService) GetDeliveries(ctx context.Context, webhookID uint, status string, offset, limit int) ([]models.WebhookDelivery, error) {
+	query := r.deliveriesQuery(ctx, webhookID, status).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("attempt ASC")
+		}).
+		Order("id DESC")
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.Find(&deliveries).Error
+	return deliveries, err
+}
+
+// RetryDelivery queues a failed delivery again with a fresh set of attempts
+func (r *webhookService) RetryDelivery(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhookID).First(&delivery, deliveryID).Error; err != nil {
+			return err
+		}
+		if delivery.Status != models.WebhookDeliveryFailed {
+			return fmt.Errorf("%w: delivery %d is %s", ErrDeliveryNotFailed, delivery.ID, delivery.Status)
+		}
+
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = time.Now()
+		return tx.Omit("Webhook", "AttemptLog").Save(&delivery).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// validateWebhook checks the URL and normalises the subscribed events
+func validateWebhook(webhook *models.Webhook) error {
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookURL, webhook.URL)
+	}
+
+	var events []string
+	for _, event := range strings.Split(webhook.Events, ",") {
+		event = strings.TrimSpace(event)
+		if event == "" || slices.Contains(events, event) {
+			continue
+		}
+		if !slices.Contains(models.WebhookEvents, event) {
+			return fmt.Errorf("%w: %s", ErrUnknownWebhookEvent, event)
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("%w: no events subscribed", ErrUnknownWebhookEvent)
+	}
+
+	webhook.Events = strings.Join(events, ",")
+	return nil
+}
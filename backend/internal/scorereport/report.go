@@ -0,0 +1,150 @@
+package scorereport
+
+import (
+	"cutbray/pppk-json/internal/pdf"
+	"cutbray/pppk-json/internal/repositories/models"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Brand is printed in the header of every report
+const Brand = "PPPKJson Exam"
+
+// Report is the content of a score report
+type Report struct {
+	Summary          models.ExamSummary
+	Results          []models.ExamResult
+	SessionCode      string
+	Blueprint        string
+	VerificationCode string
+	VerifyURL        string // where the code can be checked, empty to omit
+	GeneratedAt      time.Time
+}
+
+const (
+	margin = 50.0
+	width  = pdf.PageWidth - 2*margin
+)
+
+// brand colours
+var (
+	brandBlue = [3]float64{0.11, 0.25, 0.49}
+	passGreen = [3]float64{0.13, 0.55, 0.27}
+	failRed   = [3]float64{0.75, 0.16, 0.16}
+	lightGray = [3]float64{0.93, 0.94, 0.96}
+)
+
+// Render writes the score report as a one page PDF
+func Render(w io.Writer, report *Report) error {
+	doc := pdf.New("Score report " + report.SessionCode)
+	page := doc.AddPage()
+	summary := report.Summary
+
+	// Header band
+	setColor(page, brandBlue)
+	page.FillRect(0, 0, pdf.PageWidth, 90)
+	page.Color(1, 1, 1)
+	page.Text(margin, 45, pdf.HelveticaBold, 22, Brand)
+	page.Text(margin, 68, pdf.Helvetica, 12, "Score Report")
+
+	// Candidate details
+	page.Color(0, 0, 0)
+	y := 125.0
+	for _, row := range [][2]string{
+		{"Candidate ID", summary.UserID},
+		{"Session", report.SessionCode},
+		{"Blueprint", report.Blueprint},
+		{"Completed", summary.CompletedAt.UTC().Format("2 January 2006 15:04 MST")},
+	} {
+		if row[1] == "" {
+			continue
+		}
+		page.Text(margin, y, pdf.Helvetica, 10, row[0])
+		page.Text(margin+110, y, pdf.HelveticaBold, 10, row[1])
+		y += 18
+	}
+
+	// Verdict box
+	y += 10
+	verdict, verdictColor := "NOT PASSED", failRed
+	if summary.IsPassed {
+		verdict, verdictColor = "PASSED", passGreen
+	}
+	setColor(page, verdictColor)
+	page.FillRect(margin, y, width, 70)
+	page.Color(1, 1, 1)
+	page.Text(margin+20, y+30, pdf.HelveticaBold, 24, verdict)
+	page.Text(margin+20, y+52, pdf.Helvetica, 11,
+		fmt.Sprintf("Score %d of %d  ·  %.2f%%  ·  Grade %s", summary.TotalScore, summary.MaxScore, summary.OverallPercentage, summary.OverallGrade))
+	page.Text(margin+width-150, y+30, pdf.Helvetica, 10,
+		fmt.Sprintf("Answered %d of %d", summary.TotalAnswered, summary.TotalQuestions))
+	y += 100
+
+	// Category results table
+	page.Color(0, 0, 0)
+	page.Text(margin, y, pdf.HelveticaBold, 13, "Results by category")
+	y += 14
+
+	columns := []struct {
+		title string
+		x     float64
+	}{
+		{"Category", 0}, {"Answered", 170}, {"Score", 245}, {"Percentage", 320}, {"Grade", 400}, {"Result", 445},
+	}
+
+	setColor(page, lightGray)
+	page.FillRect(margin, y, width, 20)
+	page.Color(0, 0, 0)
+	for _, column := range columns {
+		page.Text(margin+6+column.x, y+14, pdf.HelveticaBold, 9, column.title)
+	}
+	y += 20
+
+	for _, result := range report.Results {
+		outcome, outcomeColor := "Not passed", failRed
+		if result.IsPassed {
+			outcome, outcomeColor = "Passed", passGreen
+		}
+
+		values := []string{
+			result.Category,
+			fmt.Sprintf("%d / %d", result.TotalAnswered, result.TotalQuestions),
+			fmt.Sprintf("%d / %d", result.TotalScore, result.MaxScore),
+			fmt.Sprintf("%.2f%%", result.Percentage),
+			result.Grade,
+		}
+		for i, value := range values {
+			page.Text(margin+6+columns[i].x, y+14, pdf.Helvetica, 9, value)
+		}
+		setColor(page, outcomeColor)
+		page.Text(margin+6+columns[5].x, y+14, pdf.HelveticaBold, 9, outcome)
+		page.Color(0.8, 0.8, 0.8)
+		page.Line(margin, y+20, margin+width, y+20, 0.5)
+		page.Color(0, 0, 0)
+		y += 20
+	}
+
+	// Verification block
+	y += 30
+	page.Color(0.6, 0.6, 0.6)
+	page.Rect(margin, y, width, 62, 0.75)
+	page.Color(0, 0, 0)
+	page.Text(margin+14, y+20, pdf.Helvetica, 9, "Verification code")
+	page.Text(margin+14, y+40, pdf.HelveticaBold, 14, report.VerificationCode)
+	if report.VerifyURL != "" {
+		page.Text(margin+14, y+54, pdf.Helvetica, 8, "Check this report at "+report.VerifyURL)
+	}
+
+	page.Color(0.4, 0.4, 0.4)
+	page.Text(margin, pdf.PageHeight-30, pdf.Helvetica, 8,
+		fmt.Sprintf("Generated %s. The code only verifies the result as it is currently recorded.",
+			report.GeneratedAt.UTC().Format("2006-01-02 15:04 MST")))
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func setColor(page *pdf.Page, rgb [3]float64) {
+	page.Color(rgb[0], rgb[1], rgb[2])
+}
@@ -0,0 +1,88 @@
+// Package scorereport renders the score report candidates download after an exam and
+// signs it with a verification code anyone can check against the stored result.
+package scorereport
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"cutbray/pppk-json/internal/repositories/models"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCode is returned for verification codes that are not well formed
+var ErrInvalidCode = errors.New("invalid verification code")
+
+// codeEncoding writes the MAC with unambiguous upper case letters and digits
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// macBytes is the number of HMAC bytes kept in a code, 80 bits
+const macBytes = 10
+
+// Signer issues and checks verification codes: the summary ID followed by an HMAC-SHA256
+// over the result fields, so a code stops verifying once the result is re-scored or re-graded
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a signer with a secret key
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Code returns the verification code of a summary, formatted as R<id>-XXXX-XXXX-XXXX-XXXX
+func (s *Signer) Code(summary *models.ExamSummary) string {
+	mac := codeEncoding.EncodeToString(s.mac(summary))
+
+	groups := make([]string, 0, len(mac)/4)
+	for i := 0; i < len(mac); i += 4 {
+		groups = append(groups, mac[i:min(i+4, len(mac))])
+	}
+	return fmt.Sprintf("R%d-%s", summary.ID, strings.Join(groups, "-"))
+}
+
+// SummaryID extracts the summary ID a code claims to verify
+func SummaryID(code string) (uint, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	prefix, _, found := strings.Cut(code, "-")
+	if !found || !strings.HasPrefix(prefix, "R") {
+		return 0, ErrInvalidCode
+	}
+
+	id, err := strconv.ParseUint(prefix[1:], 10, 32)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidCode
+	}
+	return uint(id), nil
+}
+
+// Verify reports whether a code was issued for the summary as it is stored now
+func (s *Signer) Verify(summary *models.ExamSummary, code string) bool {
+	expected := s.Code(summary)
+	given := strings.ToUpper(strings.TrimSpace(code))
+	return hmac.Equal([]byte(expected), []byte(given))
+}
+
+// mac signs the canonical form of the summary
+func (s *Signer) mac(summary *models.ExamSummary) []byte {
+	message := strings.Join([]string{
+		"v1",
+		strconv.FormatUint(uint64(summary.ID), 10),
+		strconv.FormatUint(uint64(summary.ExamSessionID), 10),
+		summary.UserID,
+		strconv.Itoa(summary.TotalAnswered),
+		strconv.Itoa(summary.TotalScore),
+		strconv.Itoa(summary.MaxScore),
+		strconv.FormatFloat(summary.OverallPercentage, 'f', 2, 64),
+		summary.OverallGrade,
+		strconv.FormatBool(summary.IsPassed),
+		strconv.FormatInt(summary.CompletedAt.UTC().Unix(), 10),
+	}, "|")
+
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(message))
+	return h.Sum(nil)[:macBytes]
+}
@@ -0,0 +1,115 @@
+package scorereport
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedSummary is the summary the tests sign
+func signedSummary() models.ExamSummary {
+	return models.ExamSummary{
+		ID:                42,
+		ExamSessionID:     7,
+		UserID:            "user-1",
+		TotalAnswered:     150,
+		TotalScore:        480,
+		MaxScore:          600,
+		OverallPercentage: 80,
+		OverallGrade:      "B",
+		IsPassed:          true,
+		CompletedAt:       time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestSignerCode(t *testing.T) {
+	summary := signedSummary()
+	signer := NewSigner("secret")
+
+	code := signer.Code(&summary)
+	if !regexp.MustCompile(`^R42-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`).MatchString(code) {
+		t.Fatalf("Code() = %s, want R42 followed by four groups of four", code)
+	}
+	if again := signer.Code(&summary); again != code {
+		t.Fatalf("Code() = %s then %s, want a stable code", code, again)
+	}
+
+	// The completion time is signed in UTC, so the zone it is loaded in does not matter
+	local := summary
+	local.CompletedAt = summary.CompletedAt.In(time.FixedZone("WIB", 7*60*60))
+	if got := signer.Code(&local); got != code {
+		t.Fatalf("Code() in another zone = %s, want %s", got, code)
+	}
+
+	if other := NewSigner("another secret").Code(&summary); other == code {
+		t.Fatalf("Code() is the same under another key")
+	}
+}
+
+func TestSignerVerify(t *testing.T) {
+	signer := NewSigner("secret")
+	original := signedSummary()
+	code := signer.Code(&original)
+
+	tests := []struct {
+		name   string
+		change func(*models.ExamSummary)
+		code   string
+		want   bool
+	}{
+		{"unchanged", func(*models.ExamSummary) {}, code, true},
+		{"lower case with spaces", func(*models.ExamSummary) {}, "  " + strings.ToLower(code) + "\n", true},
+		{"re-scored", func(s *models.ExamSummary) { s.TotalScore = 481 }, code, false},
+		{"re-graded", func(s *models.ExamSummary) { s.OverallGrade = "A" }, code, false},
+		{"verdict changed", func(s *models.ExamSummary) { s.IsPassed = false }, code, false},
+		{"percentage changed", func(s *models.ExamSummary) { s.OverallPercentage = 80.01 }, code, false},
+		{"other candidate", func(s *models.ExamSummary) { s.UserID = "user-2" }, code, false},
+		{"completed later", func(s *models.ExamSummary) { s.CompletedAt = s.CompletedAt.Add(time.Second) }, code, false},
+		{"other summary", func(s *models.ExamSummary) { s.ID = 43 }, code, false},
+		{"truncated code", func(*models.ExamSummary) {}, code[:len(code)-1], false},
+		{"empty code", func(*models.ExamSummary) {}, "", false},
+	}
+
+	for _, tt := range tests {
+		summary := signedSummary()
+		tt.change(&summary)
+		if got := signer.Verify(&summary, tt.code); got != tt.want {
+			t.Errorf("%s: Verify() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryID(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    uint
+		wantErr bool
+	}{
+		{"R42-ABCD-EFGH-IJKL-MNOP", 42, false},
+		{" r7-abcd ", 7, false},
+		{"R4294967295-ABCD", 4294967295, false},
+		{"R4294967296-ABCD", 0, true},
+		{"R0-ABCD", 0, true},
+		{"R-ABCD", 0, true},
+		{"X42-ABCD", 0, true},
+		{"R42", 0, true},
+		{"R4a-ABCD", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := SummaryID(tt.code)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidCode) {
+				t.Errorf("SummaryID(%q) error = %v, want %v", tt.code, err, ErrInvalidCode)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("SummaryID(%q) = %d, %v, want %d", tt.code, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"math"
+)
+
+// Credit returns the points earned by selecting an option as a percentage of the highest
+// option score of the scheme, the fractional credit used by LMS formats such as Moodle.
+// Penalised options give a negative credit.
+func Credit(s Scorer, selected models.QuestionOption, options []models.QuestionOption) float64 {
+	maxScore := s.MaxOptionScore()
+	if maxScore <= 0 {
+		return 0
+	}
+	credit := float64(s.ScoreAnswer(selected, options)) / float64(maxScore) * 100
+	return math.Round(credit*100000) / 100000
+}
+
+// ScoreForCredit converts a fractional credit back into an option score of the scheme.
+// Negative credits are penalties the scheme applies by itself and map to 0; the result
+// still has to pass ValidateOptionScore.
+func ScoreForCredit(s Scorer, credit float64) int {
+	if credit <= 0 {
+		return 0
+	}
+	return int(math.Round(credit / 100 * float64(s.MaxOptionScore())))
+}
@@ -0,0 +1,70 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"testing"
+)
+
+func TestCredit(t *testing.T) {
+	tests := []struct {
+		name     string
+		scorer   Scorer
+		options  []models.QuestionOption
+		selected int
+		want     float64
+	}{
+		{"graded best option", NewGradedScorer(1, 4), gradedOptions, 3, 100},
+		{"graded third option", NewGradedScorer(1, 4), gradedOptions, 2, 75},
+		{"graded lowest option", NewGradedScorer(1, 4), gradedOptions, 1, 25},
+		{"graded fraction", NewGradedScorer(1, 3), []models.QuestionOption{{Score: 1}, {Score: 3}}, 0, 33.33333},
+		{"right/wrong correct", NewRightWrongScorer(5), keyedOptions(5), 1, 100},
+		{"right/wrong wrong", NewRightWrongScorer(5), keyedOptions(5), 0, 0},
+		{"negative marking wrong", NewNegativeMarkingScorer(4, 1), keyedOptions(4), 0, -25},
+		{"scheme without points", NewRightWrongScorer(0), keyedOptions(0), 1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := Credit(tt.scorer, tt.options[tt.selected], tt.options); got != tt.want {
+			t.Errorf("%s: Credit() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScoreForCredit(t *testing.T) {
+	tests := []struct {
+		name   string
+		scorer Scorer
+		credit float64
+		want   int
+	}{
+		{"graded full credit", NewGradedScorer(1, 4), 100, 4},
+		{"graded partial credit", NewGradedScorer(1, 4), 75, 3},
+		{"graded rounded credit", NewGradedScorer(1, 4), 33.33333, 1},
+		{"right/wrong full credit", NewRightWrongScorer(5), 100, 5},
+		{"right/wrong no credit", NewRightWrongScorer(5), 0, 0},
+		{"negative credit", NewNegativeMarkingScorer(4, 1), -25, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ScoreForCredit(tt.scorer, tt.credit); got != tt.want {
+			t.Errorf("%s: ScoreForCredit(%v) = %d, want %d", tt.name, tt.credit, got, tt.want)
+		}
+	}
+}
+
+func TestCreditRoundTrip(t *testing.T) {
+	// Every valid option score survives an export as credit and an import back
+	for _, scheme := range []string{models.ScoringSchemeGraded, models.ScoringSchemeRightWrong, models.ScoringSchemeNegativeMarking} {
+		s, _ := ForScheme(scheme)
+		for score := 0; score <= s.MaxOptionScore(); score++ {
+			if s.ValidateOptionScore(score) != nil {
+				continue
+			}
+			options := []models.QuestionOption{{Score: score}, {Score: s.MaxOptionScore()}}
+			credit := Credit(s, options[0], options)
+			if got := ScoreForCredit(s, credit); got != score {
+				t.Errorf("%s: score %d exported as credit %v imports as %d", scheme, score, credit, got)
+			}
+		}
+	}
+}
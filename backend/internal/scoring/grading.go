@@ -0,0 +1,111 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidGradingScale is returned when a grading scale cannot grade every percentage
+var ErrInvalidGradingScale = errors.New("invalid grading scale")
+
+// ValidateGradingScale checks that a scale has bands with unique grades,
+// percentages between 0 and 100 and a band starting at 0% so every result gets a grade
+func ValidateGradingScale(scale *models.GradingScale) error {
+	if len(scale.Bands) == 0 {
+		return fmt.Errorf("%w: at least one band is required", ErrInvalidGradingScale)
+	}
+
+	seen := make(map[string]bool, len(scale.Bands))
+	hasFloor := false
+	for _, band := range scale.Bands {
+		if band.Grade == "" {
+			return fmt.Errorf("%w: grade cannot be empty", ErrInvalidGradingScale)
+		}
+		if seen[band.Grade] {
+			return fmt.Errorf("%w: grade %s is used more than once", ErrInvalidGradingScale, band.Grade)
+		}
+		seen[band.Grade] = true
+
+		if band.MinPercentage < 0 || band.MinPercentage > 100 {
+			return fmt.Errorf("%w: grade %s minimum must be between 0 and 100", ErrInvalidGradingScale, band.Grade)
+		}
+		if band.MinPercentage == 0 {
+			hasFloor = true
+		}
+	}
+
+	if !hasFloor {
+		return fmt.Errorf("%w: a band starting at 0%% is required", ErrInvalidGradingScale)
+	}
+	return nil
+}
+
+// Grade returns the grade of the highest band whose minimum the percentage reaches
+func Grade(scale *models.GradingScale, percentage float64) string {
+	bands := make([]models.GradingBand, len(scale.Bands))
+	copy(bands, scale.Bands)
+	sort.SliceStable(bands, func(i, j int) bool {
+		return bands[i].MinPercentage > bands[j].MinPercentage
+	})
+
+	for _, band := range bands {
+		if percentage >= band.MinPercentage {
+			return band.Grade
+		}
+	}
+	return ""
+}
+
+// CategoryPassed reports whether a category result meets the minimums the rule sets
+// for its category. Categories without a minimum always pass.
+func CategoryPassed(rule *models.PassRule, result *models.ExamResult) bool {
+	for _, minimum := range rule.CategoryMinimums {
+		if minimum.Category != result.Category {
+			continue
+		}
+		if minimum.MinScore != nil && result.TotalScore < *minimum.MinScore {
+			return false
+		}
+		if minimum.MinPercentage != nil && result.Percentage < *minimum.MinPercentage {
+			return false
+		}
+	}
+	return true
+}
+
+// OverallPassed reports whether a summary meets the overall minimums of the rule and,
+// when the rule requires it, whether every category result passed
+func OverallPassed(rule *models.PassRule, summary *models.ExamSummary, results []models.ExamResult) bool {
+	if rule.OverallMinScore != nil && summary.TotalScore < *rule.OverallMinScore {
+		return false
+	}
+	if rule.OverallMinPercentage != nil && summary.OverallPercentage < *rule.OverallMinPercentage {
+		return false
+	}
+	if rule.RequireAllCategories {
+		for i := range results {
+			if !results[i].IsPassed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ApplyGrading grades category results and the summary with a scale and decides
+// the verdicts with a pass rule, recording which scale and rule were used
+func ApplyGrading(scale *models.GradingScale, rule *models.PassRule, summary *models.ExamSummary, results []models.ExamResult) {
+	for i := range results {
+		results[i].Grade = Grade(scale, results[i].Percentage)
+		results[i].IsPassed = CategoryPassed(rule, &results[i])
+		results[i].GradingScaleID = &scale.ID
+		results[i].PassRuleID = &rule.ID
+	}
+
+	summary.OverallGrade = Grade(scale, summary.OverallPercentage)
+	summary.IsPassed = OverallPassed(rule, summary, results)
+	summary.GradingScaleID = &scale.ID
+	summary.PassRuleID = &rule.ID
+}
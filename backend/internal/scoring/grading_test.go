@@ -0,0 +1,171 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"testing"
+)
+
+// standardScale is the A-E scale of the seeded grading scales, listed out of order on purpose
+var standardScale = models.GradingScale{ID: 1, Bands: []models.GradingBand{
+	{Grade: "C", MinPercentage: 60},
+	{Grade: "A", MinPercentage: 85},
+	{Grade: "E", MinPercentage: 0},
+	{Grade: "B", MinPercentage: 70},
+	{Grade: "D", MinPercentage: 40},
+}}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestValidateGradingScale(t *testing.T) {
+	tests := []struct {
+		name    string
+		bands   []models.GradingBand
+		wantErr bool
+	}{
+		{"standard scale", standardScale.Bands, false},
+		{"single floor band", []models.GradingBand{{Grade: "P", MinPercentage: 0}}, false},
+		{"no bands", nil, true},
+		{"empty grade", []models.GradingBand{{Grade: "", MinPercentage: 0}}, true},
+		{"duplicate grade", []models.GradingBand{{Grade: "A", MinPercentage: 0}, {Grade: "A", MinPercentage: 50}}, true},
+		{"negative minimum", []models.GradingBand{{Grade: "A", MinPercentage: 0}, {Grade: "B", MinPercentage: -1}}, true},
+		{"minimum above 100", []models.GradingBand{{Grade: "A", MinPercentage: 0}, {Grade: "B", MinPercentage: 100.5}}, true},
+		{"no band at 0%", []models.GradingBand{{Grade: "A", MinPercentage: 50}, {Grade: "B", MinPercentage: 10}}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateGradingScale(&models.GradingScale{Bands: tt.bands})
+		if tt.wantErr && !errors.Is(err, ErrInvalidGradingScale) {
+			t.Errorf("%s: ValidateGradingScale() error = %v, want %v", tt.name, err, ErrInvalidGradingScale)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: ValidateGradingScale() error = %v, want nil", tt.name, err)
+		}
+	}
+}
+
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		percentage float64
+		want       string
+	}{
+		{0, "E"},
+		{39.99, "E"},
+		{40, "D"},
+		{59.5, "D"},
+		{60, "C"},
+		{70, "B"},
+		{84.99, "B"},
+		{85, "A"},
+		{100, "A"},
+	}
+
+	for _, tt := range tests {
+		if got := Grade(&standardScale, tt.percentage); got != tt.want {
+			t.Errorf("Grade(%v) = %s, want %s", tt.percentage, got, tt.want)
+		}
+	}
+
+	// Grading must not reorder the bands of the scale
+	if standardScale.Bands[0].Grade != "C" {
+		t.Errorf("Grade() reordered the scale bands")
+	}
+}
+
+func TestCategoryPassed(t *testing.T) {
+	rule := &models.PassRule{CategoryMinimums: []models.PassRuleCategoryMinimum{
+		{Category: "TEKNIS", MinScore: intPtr(60)},
+		{Category: "MANAJERIAL", MinPercentage: floatPtr(65)},
+		{Category: "WAWANCARA", MinScore: intPtr(20), MinPercentage: floatPtr(50)},
+	}}
+
+	tests := []struct {
+		name   string
+		result models.ExamResult
+		want   bool
+	}{
+		{"score minimum met", models.ExamResult{Category: "TEKNIS", TotalScore: 60, Percentage: 10}, true},
+		{"score minimum missed", models.ExamResult{Category: "TEKNIS", TotalScore: 59, Percentage: 99}, false},
+		{"percentage minimum met", models.ExamResult{Category: "MANAJERIAL", TotalScore: 0, Percentage: 65}, true},
+		{"percentage minimum missed", models.ExamResult{Category: "MANAJERIAL", TotalScore: 100, Percentage: 64.9}, false},
+		{"both minimums met", models.ExamResult{Category: "WAWANCARA", TotalScore: 20, Percentage: 50}, true},
+		{"one of both minimums missed", models.ExamResult{Category: "WAWANCARA", TotalScore: 30, Percentage: 40}, false},
+		{"category without minimum", models.ExamResult{Category: "SOSIAL_KULTURAL", TotalScore: 0, Percentage: 0}, true},
+	}
+
+	for _, tt := range tests {
+		if got := CategoryPassed(rule, &tt.result); got != tt.want {
+			t.Errorf("%s: CategoryPassed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOverallPassed(t *testing.T) {
+	passed := []models.ExamResult{{IsPassed: true}, {IsPassed: true}}
+	oneFailed := []models.ExamResult{{IsPassed: true}, {IsPassed: false}}
+
+	tests := []struct {
+		name    string
+		rule    models.PassRule
+		summary models.ExamSummary
+		results []models.ExamResult
+		want    bool
+	}{
+		{"no minimums", models.PassRule{}, models.ExamSummary{}, oneFailed, true},
+		{"score minimum met", models.PassRule{OverallMinScore: intPtr(300)}, models.ExamSummary{TotalScore: 300}, passed, true},
+		{"score minimum missed", models.PassRule{OverallMinScore: intPtr(300)}, models.ExamSummary{TotalScore: 299}, passed, false},
+		{"percentage minimum met", models.PassRule{OverallMinPercentage: floatPtr(60)}, models.ExamSummary{OverallPercentage: 60}, passed, true},
+		{"percentage minimum missed", models.PassRule{OverallMinPercentage: floatPtr(60)}, models.ExamSummary{OverallPercentage: 59.9}, passed, false},
+		{"all categories passed", models.PassRule{RequireAllCategories: true}, models.ExamSummary{}, passed, true},
+		{"a category failed", models.PassRule{RequireAllCategories: true}, models.ExamSummary{}, oneFailed, false},
+	}
+
+	for _, tt := range tests {
+		if got := OverallPassed(&tt.rule, &tt.summary, tt.results); got != tt.want {
+			t.Errorf("%s: OverallPassed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyGrading(t *testing.T) {
+	rule := &models.PassRule{
+		ID:                   2,
+		OverallMinPercentage: floatPtr(60),
+		RequireAllCategories: true,
+		CategoryMinimums:     []models.PassRuleCategoryMinimum{{Category: "TEKNIS", MinPercentage: floatPtr(70)}},
+	}
+	summary := &models.ExamSummary{OverallPercentage: 72}
+	results := []models.ExamResult{
+		{Category: "MANAJERIAL", Percentage: 90},
+		{Category: "TEKNIS", Percentage: 65},
+	}
+
+	ApplyGrading(&standardScale, rule, summary, results)
+
+	wantResults := []struct {
+		grade  string
+		passed bool
+	}{{"A", true}, {"C", false}}
+	for i, want := range wantResults {
+		if results[i].Grade != want.grade || results[i].IsPassed != want.passed {
+			t.Errorf("result %s = %s/%v, want %s/%v", results[i].Category, results[i].Grade, results[i].IsPassed, want.grade, want.passed)
+		}
+		if results[i].GradingScaleID == nil || *results[i].GradingScaleID != 1 || results[i].PassRuleID == nil || *results[i].PassRuleID != 2 {
+			t.Errorf("result %s does not record the scale and rule used", results[i].Category)
+		}
+	}
+
+	// TEKNIS missed its minimum, so the overall verdict fails despite 72%
+	if summary.OverallGrade != "B" || summary.IsPassed {
+		t.Errorf("summary = %s/%v, want B/false", summary.OverallGrade, summary.IsPassed)
+	}
+	if summary.GradingScaleID == nil || *summary.GradingScaleID != 1 || summary.PassRuleID == nil || *summary.PassRuleID != 2 {
+		t.Errorf("summary does not record the scale and rule used")
+	}
+}
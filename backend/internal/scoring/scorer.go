@@ -0,0 +1,197 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidScore is returned when an option score is not allowed by a scoring scheme
+	ErrInvalidScore = errors.New("invalid option score")
+	// ErrUnknownScheme is returned when no scorer exists for a scoring scheme
+	ErrUnknownScheme = errors.New("unknown scoring scheme")
+)
+
+// Scorer scores answers of a category according to its scoring scheme.
+// Option scores stored in question_options are the answer key; the scorer
+// decides how that key translates into points for a selected option.
+type Scorer interface {
+	// Scheme returns the scoring scheme name stored on the category
+	Scheme() string
+	// ValidateOptionScore checks that an option score is allowed by the scheme
+	ValidateOptionScore(score int) error
+	// ScoreAnswer returns the points earned by selecting an option of a question
+	ScoreAnswer(selected models.QuestionOption, options []models.QuestionOption) int
+	// MaxScore returns the highest points obtainable on a question
+	MaxScore(options []models.QuestionOption) int
+	// IsCorrect reports whether the selected option counts as the correct answer
+	IsCorrect(selected models.QuestionOption, options []models.QuestionOption) bool
+	// BestOption returns the option earning the most points
+	BestOption(options []models.QuestionOption) models.QuestionOption
+	// MaxOptionScore returns the highest score an option can carry under the scheme
+	MaxOptionScore() int
+}
+
+// ForScheme returns the scorer for a category scoring scheme with the official PPPK parameters:
+// graded options 1-4, right/wrong 0/5 and negative marking +4/-1.
+func ForScheme(scheme string) (Scorer, error) {
+	switch scheme {
+	case models.ScoringSchemeGraded, "":
+		return NewGradedScorer(1, 4), nil
+	case models.ScoringSchemeRightWrong:
+		return NewRightWrongScorer(5), nil
+	case models.ScoringSchemeNegativeMarking:
+		return NewNegativeMarkingScorer(4, 1), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownScheme, scheme)
+}
+
+// ForCategory returns the scorer configured for a category
+func ForCategory(category *models.Category) (Scorer, error) {
+	return ForScheme(category.ScoringScheme)
+}
+
+// bestOption returns the option with the highest stored score, the first one wins on ties
+func bestOption(options []models.QuestionOption) models.QuestionOption {
+	var best models.QuestionOption
+	for i, option := range options {
+		if i == 0 || option.Score > best.Score {
+			best = option
+		}
+	}
+	return best
+}
+
+// gradedScorer awards the stored option score, every option is worth something
+type gradedScorer struct {
+	min int
+	max int
+}
+
+// NewGradedScorer creates a scorer where each option carries a score between min and max
+func NewGradedScorer(min, max int) Scorer {
+	return &gradedScorer{min: min, max: max}
+}
+
+func (s *gradedScorer) Scheme() string {
+	return models.ScoringSchemeGraded
+}
+
+func (s *gradedScorer) ValidateOptionScore(score int) error {
+	if score < s.min || score > s.max {
+		return fmt.Errorf("%w: graded options must score between %d and %d, got %d", ErrInvalidScore, s.min, s.max, score)
+	}
+	return nil
+}
+
+func (s *gradedScorer) ScoreAnswer(selected models.QuestionOption, options []models.QuestionOption) int {
+	return selected.Score
+}
+
+func (s *gradedScorer) MaxScore(options []models.QuestionOption) int {
+	return bestOption(options).Score
+}
+
+func (s *gradedScorer) IsCorrect(selected models.QuestionOption, options []models.QuestionOption) bool {
+	return selected.Score == s.MaxScore(options)
+}
+
+func (s *gradedScorer) BestOption(options []models.QuestionOption) models.QuestionOption {
+	return bestOption(options)
+}
+
+func (s *gradedScorer) MaxOptionScore() int {
+	return s.max
+}
+
+// rightWrongScorer awards full points for the keyed option and nothing otherwise
+type rightWrongScorer struct {
+	points int
+}
+
+// NewRightWrongScorer creates a scorer where the correct option is keyed with points and the rest with 0
+func NewRightWrongScorer(points int) Scorer {
+	return &rightWrongScorer{points: points}
+}
+
+func (s *rightWrongScorer) Scheme() string {
+	return models.ScoringSchemeRightWrong
+}
+
+func (s *rightWrongScorer) ValidateOptionScore(score int) error {
+	if score != 0 && score != s.points {
+		return fmt.Errorf("%w: right/wrong options must score 0 or %d, got %d", ErrInvalidScore, s.points, score)
+	}
+	return nil
+}
+
+func (s *rightWrongScorer) ScoreAnswer(selected models.QuestionOption, options []models.QuestionOption) int {
+	if s.IsCorrect(selected, options) {
+		return s.points
+	}
+	return 0
+}
+
+func (s *rightWrongScorer) MaxScore(options []models.QuestionOption) int {
+	return s.points
+}
+
+func (s *rightWrongScorer) IsCorrect(selected models.QuestionOption, options []models.QuestionOption) bool {
+	return selected.Score == s.points
+}
+
+func (s *rightWrongScorer) BestOption(options []models.QuestionOption) models.QuestionOption {
+	return bestOption(options)
+}
+
+func (s *rightWrongScorer) MaxOptionScore() int {
+	return s.points
+}
+
+// negativeMarkingScorer awards points for the keyed option and deducts a penalty for a wrong one.
+// Unanswered questions score 0 because they never reach the scorer.
+type negativeMarkingScorer struct {
+	points  int
+	penalty int
+}
+
+// NewNegativeMarkingScorer creates a scorer where the correct option is keyed with points,
+// the rest with 0, and selecting a wrong option costs penalty points
+func NewNegativeMarkingScorer(points, penalty int) Scorer {
+	return &negativeMarkingScorer{points: points, penalty: penalty}
+}
+
+func (s *negativeMarkingScorer) Scheme() string {
+	return models.ScoringSchemeNegativeMarking
+}
+
+func (s *negativeMarkingScorer) ValidateOptionScore(score int) error {
+	if score != 0 && score != s.points {
+		return fmt.Errorf("%w: negative marking options must score 0 or %d, got %d", ErrInvalidScore, s.points, score)
+	}
+	return nil
+}
+
+func (s *negativeMarkingScorer) ScoreAnswer(selected models.QuestionOption, options []models.QuestionOption) int {
+	if s.IsCorrect(selected, options) {
+		return s.points
+	}
+	return -s.penalty
+}
+
+func (s *negativeMarkingScorer) MaxScore(options []models.QuestionOption) int {
+	return s.points
+}
+
+func (s *negativeMarkingScorer) IsCorrect(selected models.QuestionOption, options []models.QuestionOption) bool {
+	return selected.Score == s.points
+}
+
+func (s *negativeMarkingScorer) BestOption(options []models.QuestionOption) models.QuestionOption {
+	return bestOption(options)
+}
+
+func (s *negativeMarkingScorer) MaxOptionScore() int {
+	return s.points
+}
@@ -0,0 +1,128 @@
+package scoring
+
+import (
+	"cutbray/pppk-json/internal/repositories/models"
+	"errors"
+	"testing"
+)
+
+// gradedOptions are options of a graded question where the fourth is the best answer
+var gradedOptions = []models.QuestionOption{{ID: 1, Score: 2}, {ID: 2, Score: 1}, {ID: 3, Score: 3}, {ID: 4, Score: 4}}
+
+// keyedOptions are options of a right/wrong or negative marking question keyed on the second option
+func keyedOptions(points int) []models.QuestionOption {
+	return []models.QuestionOption{{ID: 1, Score: 0}, {ID: 2, Score: points}, {ID: 3, Score: 0}, {ID: 4, Score: 0}}
+}
+
+func TestForScheme(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		want    string
+		wantErr error
+	}{
+		{"", models.ScoringSchemeGraded, nil},
+		{models.ScoringSchemeGraded, models.ScoringSchemeGraded, nil},
+		{models.ScoringSchemeRightWrong, models.ScoringSchemeRightWrong, nil},
+		{models.ScoringSchemeNegativeMarking, models.ScoringSchemeNegativeMarking, nil},
+		{"PARTIAL", "", ErrUnknownScheme},
+	}
+
+	for _, tt := range tests {
+		s, err := ForScheme(tt.scheme)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("ForScheme(%q) error = %v, want %v", tt.scheme, err, tt.wantErr)
+			continue
+		}
+		if err == nil && s.Scheme() != tt.want {
+			t.Errorf("ForScheme(%q).Scheme() = %s, want %s", tt.scheme, s.Scheme(), tt.want)
+		}
+	}
+}
+
+func TestValidateOptionScore(t *testing.T) {
+	tests := []struct {
+		scheme string
+		score  int
+		valid  bool
+	}{
+		{models.ScoringSchemeGraded, 0, false},
+		{models.ScoringSchemeGraded, 1, true},
+		{models.ScoringSchemeGraded, 4, true},
+		{models.ScoringSchemeGraded, 5, false},
+		{models.ScoringSchemeRightWrong, 0, true},
+		{models.ScoringSchemeRightWrong, 5, true},
+		{models.ScoringSchemeRightWrong, 3, false},
+		{models.ScoringSchemeNegativeMarking, 0, true},
+		{models.ScoringSchemeNegativeMarking, 4, true},
+		{models.ScoringSchemeNegativeMarking, -1, false},
+		{models.ScoringSchemeNegativeMarking, 5, false},
+	}
+
+	for _, tt := range tests {
+		s, _ := ForScheme(tt.scheme)
+		err := s.ValidateOptionScore(tt.score)
+		if tt.valid && err != nil {
+			t.Errorf("%s.ValidateOptionScore(%d) error = %v, want nil", tt.scheme, tt.score, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("%s.ValidateOptionScore(%d) error = %v, want %v", tt.scheme, tt.score, err, ErrInvalidScore)
+		}
+	}
+}
+
+func TestScoreAnswer(t *testing.T) {
+	tests := []struct {
+		name        string
+		scheme      string
+		options     []models.QuestionOption
+		selected    int
+		wantScore   int
+		wantCorrect bool
+		wantMax     int
+	}{
+		{"graded best option", models.ScoringSchemeGraded, gradedOptions, 3, 4, true, 4},
+		{"graded partial option", models.ScoringSchemeGraded, gradedOptions, 0, 2, false, 4},
+		{"graded lowest option", models.ScoringSchemeGraded, gradedOptions, 1, 1, false, 4},
+		{"right/wrong correct", models.ScoringSchemeRightWrong, keyedOptions(5), 1, 5, true, 5},
+		{"right/wrong wrong", models.ScoringSchemeRightWrong, keyedOptions(5), 2, 0, false, 5},
+		{"negative marking correct", models.ScoringSchemeNegativeMarking, keyedOptions(4), 1, 4, true, 4},
+		{"negative marking wrong", models.ScoringSchemeNegativeMarking, keyedOptions(4), 3, -1, false, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := ForScheme(tt.scheme)
+			selected := tt.options[tt.selected]
+
+			if got := s.ScoreAnswer(selected, tt.options); got != tt.wantScore {
+				t.Errorf("ScoreAnswer() = %d, want %d", got, tt.wantScore)
+			}
+			if got := s.IsCorrect(selected, tt.options); got != tt.wantCorrect {
+				t.Errorf("IsCorrect() = %v, want %v", got, tt.wantCorrect)
+			}
+			if got := s.MaxScore(tt.options); got != tt.wantMax {
+				t.Errorf("MaxScore() = %d, want %d", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestBestOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []models.QuestionOption
+		want    uint
+	}{
+		{"highest score", gradedOptions, 4},
+		{"keyed option", keyedOptions(5), 2},
+		{"first wins on ties", []models.QuestionOption{{ID: 7, Score: 3}, {ID: 8, Score: 3}}, 7},
+		{"no options", nil, 0},
+	}
+
+	s := NewGradedScorer(1, 4)
+	for _, tt := range tests {
+		if got := s.BestOption(tt.options); got.ID != tt.want {
+			t.Errorf("%s: BestOption() = option %d, want option %d", tt.name, got.ID, tt.want)
+		}
+	}
+}
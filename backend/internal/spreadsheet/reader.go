@@ -0,0 +1,251 @@
+// Package spreadsheet reads and writes the CSV and XLSX files exchanged with editors.
+// XLSX support covers plain cell values of the first worksheet and needs no third-party library.
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Supported file formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+
+// FormatFromFilename returns the format implied by a file extension
+func FormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(filename))
+}
+
+// ReadRows reads all rows of a CSV file or of the first worksheet of an XLSX file
+func ReadRows(r io.ReaderAt, size int64, format string) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		return ReadCSV(io.NewSectionReader(r, 0, size))
+	case FormatXLSX:
+		return ReadXLSX(r, size)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// ReadCSV reads all rows of a CSV file, tolerating a UTF-8 byte order mark and ragged rows
+func ReadCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a shared or inline string, either plain or split in rich text runs
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string    `xml:"r,attr"`
+			Type   string    `xml:"t,attr"`
+			Value  string    `xml:"v"`
+			Inline *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX reads all rows of the first worksheet of an XLSX file as strings.
+// Empty rows and cells in between are kept so row numbers match the spreadsheet.
+func ReadXLSX(r io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXML(file, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("failed to open XLSX: worksheet %s not found", sheetPath)
+	}
+
+	var sheet xlsxWorksheet
+	if err := decodeXML(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		number := row.Number
+		if number <= 0 {
+			number = len(rows) + 1
+		}
+		for len(rows) < number {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				column = columnIndex(cell.Ref)
+			}
+			for len(values) <= column {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscan(cell.Value, &index); err == nil && index >= 0 && index < len(shared.Items) {
+					values[column] = shared.Items[index].String()
+				}
+			case "inlineStr":
+				if cell.Inline != nil {
+					values[column] = cell.Inline.String()
+				}
+			default:
+				values[column] = cell.Value
+			}
+		}
+		rows[number-1] = values
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath resolves the archive path of the first worksheet listed in the workbook
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("failed to open XLSX: xl/workbook.xml not found")
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXML(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("failed to open XLSX: workbook has no sheets")
+	}
+
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return fallback, nil
+	}
+
+	var rels xlsxRelationships
+	if err := decodeXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+// decodeXML decodes an XML part of the archive
+func decodeXML(file *zip.File, v interface{}) error {
+	rc, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" into a 0-based column
+func columnIndex(ref string) int {
+	index := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		index = index*26 + int(ch-'A'+1)
+	}
+	return index - 1
+}
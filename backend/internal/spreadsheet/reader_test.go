@@ -0,0 +1,196 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+		wantErr  bool
+	}{
+		{"questions.csv", FormatCSV, false},
+		{"Questions.XLSX", FormatXLSX, false},
+		{"archive.tar.csv", FormatCSV, false},
+		{"questions.xls", "", true},
+		{"questions", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatFromFilename(tt.filename)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUnsupportedFormat) {
+				t.Errorf("FormatFromFilename(%q) error = %v, want %v", tt.filename, err, ErrUnsupportedFormat)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("FormatFromFilename(%q) = %s, %v, want %s", tt.filename, got, err, tt.want)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    [][]string
+		wantErr bool
+	}{
+		{"plain", "a,b\n1,2\n", [][]string{{"a", "b"}, {"1", "2"}}, false},
+		{"byte order mark", "\ufeffa,b\n1,2\n", [][]string{{"a", "b"}, {"1", "2"}}, false},
+		{"ragged rows", "a,b,c\n1\n", [][]string{{"a", "b", "c"}, {"1"}}, false},
+		{"quoted fields", "\"x, y\",\"line\nbreak\"\n", [][]string{{"x, y", "line\nbreak"}}, false},
+		{"empty", "", nil, false},
+		{"bare quote", "a,\"b\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ReadCSV(strings.NewReader(tt.input))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ReadCSV() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !equalRows(got, tt.want) {
+			t.Errorf("%s: ReadCSV() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// xlsxArchive builds an XLSX archive from its parts
+func xlsxArchive(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		file, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	workbook := `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Questions" r:id="rId3"/><sheet name="Other" r:id="rId1"/></sheets></workbook>`
+	rels := `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId3" Target="worksheets/questions.xml"/></Relationships>`
+	shared := `<sst><si><t>Category</t></si><si><r><t>Rich </t></r><r><t>text</t></r></si></sst>`
+	sheet := `<worksheet><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+		`<row r="3"><c r="A3" t="inlineStr"><is><t>inline</t></is></c><c r="C3"><v>42</v></c><c r="AB3" t="b"><v>1</v></c></row>` +
+		`<row><c t="s"><v>9</v></c><c><v>x</v></c></row>` +
+		`</sheetData></worksheet>`
+
+	tests := []struct {
+		name    string
+		parts   map[string]string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "first sheet through relationships",
+			parts: map[string]string{
+				"xl/workbook.xml":             workbook,
+				"xl/_rels/workbook.xml.rels":  rels,
+				"xl/sharedStrings.xml":        shared,
+				"xl/worksheets/questions.xml": sheet,
+				"xl/worksheets/sheet1.xml":    `<worksheet><sheetData><row r="1"><c><v>wrong sheet</v></c></row></sheetData></worksheet>`,
+			},
+			want: [][]string{
+				{"Category", "Rich text"},
+				nil,
+				append([]string{"inline", "", "42"}, append(make([]string, 24), "1")...),
+				{"", "x"},
+			},
+		},
+		{
+			name: "fallback sheet without relationships",
+			parts: map[string]string{
+				"xl/workbook.xml":          workbook,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="B1"><v>7</v></c></row></sheetData></worksheet>`,
+			},
+			want: [][]string{{"", "7"}},
+		},
+		{
+			name:    "no workbook",
+			parts:   map[string]string{"xl/worksheets/sheet1.xml": sheet},
+			wantErr: true,
+		},
+		{
+			name:    "workbook without sheets",
+			parts:   map[string]string{"xl/workbook.xml": `<workbook><sheets/></workbook>`},
+			wantErr: true,
+		},
+		{
+			name:    "missing worksheet",
+			parts:   map[string]string{"xl/workbook.xml": workbook, "xl/_rels/workbook.xml.rels": rels},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := xlsxArchive(t, tt.parts)
+			got, err := ReadRows(bytes.NewReader(data), int64(len(data)), FormatXLSX)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equalRows(got, tt.want) {
+				t.Fatalf("ReadRows() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadXLSXNotAnArchive(t *testing.T) {
+	data := []byte("a,b\n1,2\n")
+	if _, err := ReadXLSX(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("ReadXLSX() of a CSV file succeeded, want an error")
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{"A1", 0},
+		{"Z9", 25},
+		{"AA10", 26},
+		{"AZ1", 51},
+		{"XFD1048576", 16383},
+	}
+
+	for _, tt := range tests {
+		if got := columnIndex(tt.ref); got != tt.want {
+			t.Errorf("columnIndex(%s) = %d, want %d", tt.ref, got, tt.want)
+		}
+	}
+}
+
+// equalRows compares rows, treating nil and empty rows alike
+func equalRows(got, want [][]string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if strings.Join(got[i], "\x00") != strings.Join(want[i], "\x00") || len(got[i]) != len(want[i]) {
+			return false
+		}
+	}
+	return true
+}
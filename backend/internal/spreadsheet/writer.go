@@ -0,0 +1,277 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RowWriter writes rows one at a time, so large files are never held in memory. Nothing is
+// written to the underlying writer before the first row, so a failure before it can still be
+// reported otherwise.
+// Cells may be strings, integers, floats, booleans, times or their pointers; nil is an empty cell.
+type RowWriter interface {
+	WriteRow(cells ...interface{}) error
+	// Close finishes the file; it does not close the underlying writer
+	Close() error
+}
+
+// NewWriter returns a row writer of the given format
+func NewWriter(w io.Writer, format string) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatXLSX:
+		return NewXLSXWriter(w)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// FileInfo returns the content type and file extension of a format
+func FileInfo(format string) (contentType, extension string) {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ".xlsx"
+	}
+	return "text/csv; charset=utf-8", ".csv"
+}
+
+type csvWriter struct {
+	out     io.Writer
+	writer  *csv.Writer
+	started bool
+}
+
+// NewCSVWriter returns a CSV row writer. The file starts with a UTF-8 byte order mark so
+// spreadsheet programs detect the encoding. Nothing is written before the first row.
+func NewCSVWriter(w io.Writer) (RowWriter, error) {
+	return &csvWriter{out: w, writer: csv.NewWriter(w)}, nil
+}
+
+func (w *csvWriter) WriteRow(cells ...interface{}) error {
+	if !w.started {
+		w.started = true
+		if _, err := io.WriteString(w.out, "\ufeff"); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		value, kind := cellValue(cell)
+		if kind == cellBool && value != "" {
+			value = strconv.FormatBool(value == "1")
+		}
+		record[i] = value
+	}
+	if err := w.writer.Write(record); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// Fixed parts of a single worksheet XLSX workbook
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd   = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	out     io.Writer
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+}
+
+// NewXLSXWriter returns an XLSX row writer of a single worksheet. The fixed parts are written
+// first and the worksheet last, so rows go straight into the archive. Strings are stored inline.
+// Nothing is written before the first row.
+func NewXLSXWriter(w io.Writer) (RowWriter, error) {
+	return &xlsxWriter{out: w}, nil
+}
+
+// start writes the fixed parts and opens the worksheet
+func (w *xlsxWriter) start() error {
+	w.archive = zip.NewWriter(w.out)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbookXML},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		file, err := w.archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to write XLSX: %w", err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return fmt.Errorf("failed to write XLSX: %w", err)
+		}
+	}
+
+	file, err := w.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	w.sheet = bufio.NewWriter(file)
+	if _, err := w.sheet.WriteString(xlsxSheetStart); err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return nil
+}
+
+func (w *xlsxWriter) WriteRow(cells ...interface{}) error {
+	if w.archive == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	w.row++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, w.row)
+	for i, cell := range cells {
+		value, kind := cellValue(cell)
+		if value == "" {
+			continue
+		}
+
+		ref := columnName(i) + strconv.Itoa(w.row)
+		switch kind {
+		case cellNumber:
+			fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, value)
+		case cellBool:
+			fmt.Fprintf(&b, `<c r="%s" t="b"><v>%s</v></c>`, ref, value)
+		default:
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			xml.EscapeText(&b, []byte(value))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	if _, err := w.sheet.WriteString(b.String()); err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return nil
+}
+
+func (w *xlsxWriter) Close() error {
+	if w.archive == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.sheet.WriteString(xlsxSheetEnd); err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	if err := w.archive.Close(); err != nil {
+		return fmt.Errorf("failed to write XLSX: %w", err)
+	}
+	return nil
+}
+
+// Kinds of cell values
+const (
+	cellString = iota
+	cellNumber
+	cellBool
+)
+
+// cellValue formats a cell value and tells how it is stored in XLSX
+func cellValue(cell interface{}) (string, int) {
+	switch v := cell.(type) {
+	case nil:
+		return "", cellString
+	case string:
+		return v, cellString
+	case *string:
+		if v == nil {
+			return "", cellString
+		}
+		return *v, cellString
+	case int:
+		return strconv.Itoa(v), cellNumber
+	case int64:
+		return strconv.FormatInt(v, 10), cellNumber
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), cellNumber
+	case *int:
+		if v == nil {
+			return "", cellNumber
+		}
+		return strconv.Itoa(*v), cellNumber
+	case *uint:
+		if v == nil {
+			return "", cellNumber
+		}
+		return strconv.FormatUint(uint64(*v), 10), cellNumber
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), cellNumber
+	case *float64:
+		if v == nil {
+			return "", cellNumber
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64), cellNumber
+	case bool:
+		if v {
+			return "1", cellBool
+		}
+		return "0", cellBool
+	case *bool:
+		if v == nil {
+			return "", cellBool
+		}
+		return cellValue(*v)
+	case time.Time:
+		return v.Format(time.RFC3339), cellString
+	case *time.Time:
+		if v == nil {
+			return "", cellString
+		}
+		return v.Format(time.RFC3339), cellString
+	}
+	return fmt.Sprint(cell), cellString
+}
+
+// columnName converts a 0-based column index into its letters, 0 is "A" and 26 is "AA"
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
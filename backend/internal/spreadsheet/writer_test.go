@@ -0,0 +1,157 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	score := 42
+	percentage := 87.5
+	passed := true
+	completed := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	var missing *int
+
+	rows := [][]interface{}{
+		{"session_id", "user_id", "score", "percentage", "is_passed", "completed_at", "note"},
+		{uint(7), "user-1", &score, &percentage, &passed, &completed, `says "hi", <b>&</b>`},
+		{int64(-3), "user-2", missing, 0.1, false, nil, "  padded\nmultiline  "},
+	}
+
+	tests := []struct {
+		format string
+		want   [][]string
+	}{
+		{FormatCSV, [][]string{
+			{"session_id", "user_id", "score", "percentage", "is_passed", "completed_at", "note"},
+			{"7", "user-1", "42", "87.5", "true", "2026-03-14T09:30:00Z", `says "hi", <b>&</b>`},
+			{"-3", "user-2", "", "0.1", "false", "", "  padded\nmultiline  "},
+		}},
+		{FormatXLSX, [][]string{
+			{"session_id", "user_id", "score", "percentage", "is_passed", "completed_at", "note"},
+			{"7", "user-1", "42", "87.5", "1", "2026-03-14T09:30:00Z", `says "hi", <b>&</b>`},
+			{"-3", "user-2", "", "0.1", "0", "", "  padded\nmultiline  "},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := NewWriter(&buf, tt.format)
+			if err != nil {
+				t.Fatalf("NewWriter() error = %v", err)
+			}
+			for _, row := range rows {
+				if err := writer.WriteRow(row...); err != nil {
+					t.Fatalf("WriteRow() error = %v", err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			got, err := ReadRows(bytes.NewReader(buf.Bytes()), int64(buf.Len()), tt.format)
+			if err != nil {
+				t.Fatalf("ReadRows() error = %v", err)
+			}
+			if !equalRows(got, tt.want) {
+				t.Fatalf("round trip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriterWritesNothingBeforeFirstRow(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		if _, err := NewWriter(&buf, format); err != nil {
+			t.Fatalf("NewWriter(%s) error = %v", format, err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("NewWriter(%s) wrote %d bytes before the first row", format, buf.Len())
+		}
+	}
+
+	// An XLSX file without rows is still a valid, empty workbook
+	var buf bytes.Buffer
+	writer, _ := NewXLSXWriter(&buf)
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	rows, err := ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil || len(rows) != 0 {
+		t.Fatalf("ReadXLSX() of an empty workbook = %q, %v, want no rows", rows, err)
+	}
+}
+
+func TestNewWriterUnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, "ods"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Fatalf("NewWriter(ods) error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestCellValue(t *testing.T) {
+	var nilString *string
+	var nilFloat *float64
+	var nilBool *bool
+	var nilTime *time.Time
+	text := "text"
+	id := uint(9)
+	yes := true
+
+	tests := []struct {
+		name      string
+		cell      interface{}
+		wantValue string
+		wantKind  int
+	}{
+		{"nil", nil, "", cellString},
+		{"string", "a", "a", cellString},
+		{"string pointer", &text, "text", cellString},
+		{"nil string pointer", nilString, "", cellString},
+		{"int", 12, "12", cellNumber},
+		{"uint pointer", &id, "9", cellNumber},
+		{"float", 1.25, "1.25", cellNumber},
+		{"large float", 1e21, "1000000000000000000000", cellNumber},
+		{"nil float pointer", nilFloat, "", cellNumber},
+		{"true", true, "1", cellBool},
+		{"bool pointer", &yes, "1", cellBool},
+		{"nil bool pointer", nilBool, "", cellBool},
+		{"time", time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("WIB", 7*60*60)), "2026-01-02T03:04:05+07:00", cellString},
+		{"nil time pointer", nilTime, "", cellString},
+		{"other", []int{1}, "[1]", cellString},
+	}
+
+	for _, tt := range tests {
+		value, kind := cellValue(tt.cell)
+		if value != tt.wantValue || kind != tt.wantKind {
+			t.Errorf("%s: cellValue() = %q, %d, want %q, %d", tt.name, value, kind, tt.wantValue, tt.wantKind)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+	}
+
+	for _, tt := range tests {
+		got := columnName(tt.index)
+		if got != tt.want {
+			t.Errorf("columnName(%d) = %s, want %s", tt.index, got, tt.want)
+		}
+		if back := columnIndex(got + "1"); back != tt.index {
+			t.Errorf("columnIndex(%s1) = %d, want %d", got, back, tt.index)
+		}
+	}
+}
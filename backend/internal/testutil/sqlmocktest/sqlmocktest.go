@@ -0,0 +1,82 @@
+// Package sqlmocktest opens gorm on a mocked postgres connection and provides argument
+// matchers for the statements services run, for tests of code that needs a database.
+package sqlmocktest
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewDB returns a gorm DB backed by a mocked postgres connection, closed when the test ends
+func NewDB(t testing.TB) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open mock database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return db, mock
+}
+
+// timeFromNow matches a time about wait after the moment the statement runs
+type timeFromNow struct {
+	wait time.Duration
+}
+
+// TimeFromNow matches a time argument within a few seconds of now plus wait
+func TimeFromNow(wait time.Duration) sqlmock.Argument {
+	return timeFromNow{wait: wait}
+}
+
+func (m timeFromNow) Match(v driver.Value) bool {
+	at, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := at.Sub(time.Now().Add(m.wait))
+	return diff > -5*time.Second && diff < 5*time.Second
+}
+
+// containing matches a string argument containing a substring
+type containing string
+
+// Containing matches a string argument containing substr
+func Containing(substr string) sqlmock.Argument {
+	return containing(substr)
+}
+
+func (m containing) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && strings.Contains(s, string(m))
+}
+
+// captured matches any string argument and keeps it
+type captured struct {
+	value *string
+}
+
+// Captured matches any string argument and stores it in value
+func Captured(value *string) sqlmock.Argument {
+	return captured{value: value}
+}
+
+func (m captured) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if ok {
+		*m.value = s
+	}
+	return ok
+}
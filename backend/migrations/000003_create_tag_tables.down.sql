@@ -0,0 +1,21 @@
+-- Drop tables in reverse order due to foreign key constraints
+
+-- Drop exam_tag_results table
+DROP INDEX IF EXISTS idx_exam_tag_results_deleted_at;
+DROP INDEX IF EXISTS idx_exam_tag_results_tag_id;
+DROP INDEX IF EXISTS idx_exam_tag_results_exam_session_id;
+DROP TABLE IF EXISTS exam_tag_results;
+
+-- Drop tag_quotas table
+DROP INDEX IF EXISTS idx_tag_quotas_deleted_at;
+DROP INDEX IF EXISTS idx_tag_quotas_category_tag;
+DROP TABLE IF EXISTS tag_quotas;
+
+-- Drop question_tags table
+DROP INDEX IF EXISTS idx_question_tags_tag_id;
+DROP TABLE IF EXISTS question_tags;
+
+-- Drop tags table
+DROP INDEX IF EXISTS idx_tags_deleted_at;
+DROP INDEX IF EXISTS idx_tags_name;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,83 @@
+-- Create tags table (sub-topics such as pedagogi, regulasi, materi bidang studi)
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for tags
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags(name);
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at ON tags(deleted_at);
+
+-- Create question_tags join table (many-to-many between questions and tags)
+CREATE TABLE IF NOT EXISTS question_tags (
+    question_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+
+    PRIMARY KEY (question_id, tag_id),
+
+    -- Foreign key constraints
+    CONSTRAINT fk_question_tags_question
+    FOREIGN KEY (question_id)
+    REFERENCES questions(id)
+    ON DELETE CASCADE,
+
+    CONSTRAINT fk_question_tags_tag
+    FOREIGN KEY (tag_id)
+    REFERENCES tags(id)
+    ON DELETE CASCADE
+);
+
+-- Create indexes for question_tags
+CREATE INDEX IF NOT EXISTS idx_question_tags_tag_id ON question_tags(tag_id);
+
+-- Create tag_quotas table (reserved questions per tag inside a category during exam generation)
+CREATE TABLE IF NOT EXISTS tag_quotas (
+    id BIGSERIAL PRIMARY KEY,
+    category VARCHAR(100) NOT NULL,
+    tag_id BIGINT NOT NULL,
+    question_count INTEGER NOT NULL CHECK (question_count > 0),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    -- Foreign key constraints
+    CONSTRAINT fk_tag_quotas_tag
+    FOREIGN KEY (tag_id)
+    REFERENCES tags(id)
+    ON DELETE CASCADE
+);
+
+-- Create indexes for tag_quotas
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_quotas_category_tag ON tag_quotas(category, tag_id);
+CREATE INDEX IF NOT EXISTS idx_tag_quotas_deleted_at ON tag_quotas(deleted_at);
+
+-- Create exam_tag_results table (results per tag)
+CREATE TABLE IF NOT EXISTS exam_tag_results (
+    id BIGSERIAL PRIMARY KEY,
+    exam_session_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+    tag_name VARCHAR(100) NOT NULL,
+    total_questions INTEGER NOT NULL,
+    total_answered INTEGER NOT NULL,
+    total_score INTEGER NOT NULL,
+    max_score INTEGER NOT NULL,
+    percentage DECIMAL(5,2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+
+    -- Foreign key constraints
+    CONSTRAINT fk_exam_tag_results_exam_session
+    FOREIGN KEY (exam_session_id)
+    REFERENCES exam_sessions(id)
+    ON DELETE CASCADE
+);
+
+-- Create indexes for exam_tag_results
+CREATE INDEX IF NOT EXISTS idx_exam_tag_results_exam_session_id ON exam_tag_results(exam_session_id);
+CREATE INDEX IF NOT EXISTS idx_exam_tag_results_tag_id ON exam_tag_results(tag_id);
+CREATE INDEX IF NOT EXISTS idx_exam_tag_results_deleted_at ON exam_tag_results(deleted_at);
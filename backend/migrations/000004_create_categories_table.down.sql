@@ -0,0 +1,10 @@
+-- Drop foreign keys referencing categories before dropping the table
+ALTER TABLE tag_quotas DROP CONSTRAINT IF EXISTS fk_tag_quotas_category;
+
+DROP INDEX IF EXISTS idx_questions_category;
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS fk_questions_category;
+
+-- Drop categories table
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS idx_categories_code;
+DROP TABLE IF EXISTS categories;
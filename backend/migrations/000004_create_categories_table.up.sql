@@ -0,0 +1,50 @@
+-- Create categories table (exam sections managed as first-class entities)
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(100) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT,
+    display_order INTEGER NOT NULL DEFAULT 0,
+    scoring_scheme VARCHAR(30) NOT NULL DEFAULT 'GRADED',
+    question_count INTEGER NOT NULL DEFAULT 0, -- Questions drawn per exam session
+    max_score INTEGER NOT NULL DEFAULT 0, -- Maximum score of the section in one exam
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for categories
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_code ON categories(code);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories(deleted_at);
+
+-- Seed the official PPPK sections (previously hardcoded in the exam service)
+INSERT INTO categories (code, name, description, display_order, scoring_scheme, question_count, max_score, created_at, updated_at)
+VALUES
+    ('TEKNIS', 'Teknis', 'Kompetensi teknis sesuai jabatan yang dilamar', 1, 'GRADED', 90, 450, NOW(), NOW()),
+    ('MANAJERIAL', 'Manajerial', 'Kompetensi manajerial', 2, 'GRADED', 25, 100, NOW(), NOW()),
+    ('SOSIAL KULTURAL', 'Sosial Kultural', 'Kompetensi sosial kultural', 3, 'GRADED', 20, 100, NOW(), NOW()),
+    ('WAWANCARA', 'Wawancara', 'Wawancara integritas dan moralitas', 4, 'GRADED', 10, 40, NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- Register any other category already used by questions so the foreign key can be created
+INSERT INTO categories (code, name, display_order, created_at, updated_at)
+SELECT DISTINCT q.category, q.category, 100, NOW(), NOW()
+FROM questions q
+ON CONFLICT (code) DO NOTHING;
+
+-- Link questions and tag quotas to categories
+ALTER TABLE questions
+    ADD CONSTRAINT fk_questions_category
+    FOREIGN KEY (category)
+    REFERENCES categories(code)
+    ON UPDATE CASCADE
+    ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_questions_category ON questions(category);
+
+ALTER TABLE tag_quotas
+    ADD CONSTRAINT fk_tag_quotas_category
+    FOREIGN KEY (category)
+    REFERENCES categories(code)
+    ON UPDATE CASCADE
+    ON DELETE CASCADE;
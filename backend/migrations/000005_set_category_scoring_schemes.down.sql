@@ -0,0 +1,9 @@
+-- Key TEKNIS right/wrong options as graded again: the right answer scores 4, the others 1.
+-- The graded scores of the wrong options before the up migration are not restored.
+UPDATE question_options o
+SET score = CASE WHEN o.score = 5 THEN 4 ELSE 1 END,
+    updated_at = NOW()
+FROM questions q
+WHERE q.id = o.question_id AND q.category = 'TEKNIS' AND o.score IN (0, 5);
+
+UPDATE categories SET scoring_scheme = 'GRADED', updated_at = NOW() WHERE code = 'TEKNIS';
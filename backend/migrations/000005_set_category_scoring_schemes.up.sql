@@ -0,0 +1,45 @@
+-- TEKNIS is scored right/wrong (0 or 5 points), the other sections keep graded options (1-4)
+UPDATE categories SET scoring_scheme = 'RIGHT_WRONG', updated_at = NOW() WHERE code = 'TEKNIS';
+UPDATE categories SET scoring_scheme = 'GRADED', updated_at = NOW() WHERE code IN ('MANAJERIAL', 'SOSIAL KULTURAL', 'WAWANCARA');
+
+-- Existing TEKNIS questions are keyed with graded scores (1-4). Their best option becomes the
+-- right answer, which needs exactly one best option; abort instead of guessing otherwise.
+DO $$
+DECLARE
+    ambiguous INTEGER;
+BEGIN
+    SELECT COUNT(*) INTO ambiguous
+    FROM (
+        SELECT o.question_id
+        FROM question_options o
+        JOIN questions q ON q.id = o.question_id
+        WHERE q.category = 'TEKNIS' AND o.deleted_at IS NULL
+        GROUP BY o.question_id
+        HAVING BOOL_OR(o.score NOT IN (0, 5))
+            AND COUNT(*) FILTER (WHERE o.score = (
+                SELECT MAX(score) FROM question_options WHERE question_id = o.question_id AND deleted_at IS NULL
+            )) <> 1
+    ) graded;
+
+    IF ambiguous > 0 THEN
+        RAISE EXCEPTION '% TEKNIS question(s) have graded option scores without a single best option; key them 0 or 5 before migrating', ambiguous;
+    END IF;
+END $$;
+
+-- Convert graded TEKNIS keys: the best option scores 5, the others 0.
+-- Stored answers, category results and summaries of completed sessions are not re-scored here:
+-- their grades and verdicts come from the application. After migrating, re-score every TEKNIS
+-- question completed sessions drew with POST /api/v1/questions/{questionID}/rescore, e.g. for
+--   SELECT DISTINCT question_id FROM exam_questions WHERE category = 'TEKNIS';
+UPDATE question_options o
+SET score = CASE WHEN o.score = graded.max_score THEN 5 ELSE 0 END,
+    updated_at = NOW()
+FROM (
+    SELECT o.question_id, MAX(o.score) FILTER (WHERE o.deleted_at IS NULL) AS max_score
+    FROM question_options o
+    JOIN questions q ON q.id = o.question_id
+    WHERE q.category = 'TEKNIS'
+    GROUP BY o.question_id
+    HAVING BOOL_OR(o.score NOT IN (0, 5) AND o.deleted_at IS NULL)
+) graded
+WHERE o.question_id = graded.question_id;
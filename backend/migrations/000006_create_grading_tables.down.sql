@@ -0,0 +1,16 @@
+-- Drop grading columns from exam tables
+ALTER TABLE exam_summaries DROP COLUMN IF EXISTS pass_rule_id;
+ALTER TABLE exam_summaries DROP COLUMN IF EXISTS grading_scale_id;
+ALTER TABLE exam_results DROP COLUMN IF EXISTS pass_rule_id;
+ALTER TABLE exam_results DROP COLUMN IF EXISTS grading_scale_id;
+
+DROP INDEX IF EXISTS idx_exam_sessions_blueprint_id;
+ALTER TABLE exam_sessions DROP CONSTRAINT IF EXISTS fk_exam_sessions_blueprint;
+ALTER TABLE exam_sessions DROP COLUMN IF EXISTS blueprint_id;
+
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS exam_blueprints;
+DROP TABLE IF EXISTS pass_rule_category_minimums;
+DROP TABLE IF EXISTS pass_rules;
+DROP TABLE IF EXISTS grading_bands;
+DROP TABLE IF EXISTS grading_scales;
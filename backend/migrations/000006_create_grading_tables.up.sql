@@ -0,0 +1,162 @@
+-- Create grading_scales table (named sets of grade bands)
+CREATE TABLE IF NOT EXISTS grading_scales (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_grading_scales_code ON grading_scales(code);
+CREATE INDEX IF NOT EXISTS idx_grading_scales_deleted_at ON grading_scales(deleted_at);
+
+-- Create grading_bands table (a grade is awarded from min_percentage upwards)
+CREATE TABLE IF NOT EXISTS grading_bands (
+    id BIGSERIAL PRIMARY KEY,
+    grading_scale_id BIGINT NOT NULL,
+    grade VARCHAR(5) NOT NULL,
+    min_percentage DECIMAL(5,2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_grading_bands_grading_scale
+        FOREIGN KEY (grading_scale_id)
+        REFERENCES grading_scales(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_grading_bands_grading_scale_id ON grading_bands(grading_scale_id);
+CREATE INDEX IF NOT EXISTS idx_grading_bands_deleted_at ON grading_bands(deleted_at);
+
+-- Create pass_rules table (overall minimums and must-pass-all flag)
+CREATE TABLE IF NOT EXISTS pass_rules (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT,
+    overall_min_percentage DECIMAL(5,2),
+    overall_min_score INTEGER,
+    require_all_categories BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pass_rules_code ON pass_rules(code);
+CREATE INDEX IF NOT EXISTS idx_pass_rules_deleted_at ON pass_rules(deleted_at);
+
+-- Create pass_rule_category_minimums table (per-category raw score / percentage minimums)
+CREATE TABLE IF NOT EXISTS pass_rule_category_minimums (
+    id BIGSERIAL PRIMARY KEY,
+    pass_rule_id BIGINT NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    min_score INTEGER,
+    min_percentage DECIMAL(5,2),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_pass_rule_category_minimums_pass_rule
+        FOREIGN KEY (pass_rule_id)
+        REFERENCES pass_rules(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_pass_rule_category_minimums_category
+        FOREIGN KEY (category)
+        REFERENCES categories(code)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pass_rule_category_minimums_pass_rule_id ON pass_rule_category_minimums(pass_rule_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pass_rule_category_minimums_rule_category ON pass_rule_category_minimums(pass_rule_id, category);
+CREATE INDEX IF NOT EXISTS idx_pass_rule_category_minimums_deleted_at ON pass_rule_category_minimums(deleted_at);
+
+-- Create exam_blueprints table (duration, grading scale and pass rule of an exam)
+CREATE TABLE IF NOT EXISTS exam_blueprints (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT,
+    duration_minutes INTEGER NOT NULL DEFAULT 130,
+    grading_scale_id BIGINT NOT NULL,
+    pass_rule_id BIGINT NOT NULL,
+    is_default BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_exam_blueprints_grading_scale
+        FOREIGN KEY (grading_scale_id)
+        REFERENCES grading_scales(id)
+        ON DELETE RESTRICT,
+    CONSTRAINT fk_exam_blueprints_pass_rule
+        FOREIGN KEY (pass_rule_id)
+        REFERENCES pass_rules(id)
+        ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exam_blueprints_code ON exam_blueprints(code);
+CREATE INDEX IF NOT EXISTS idx_exam_blueprints_grading_scale_id ON exam_blueprints(grading_scale_id);
+CREATE INDEX IF NOT EXISTS idx_exam_blueprints_pass_rule_id ON exam_blueprints(pass_rule_id);
+CREATE INDEX IF NOT EXISTS idx_exam_blueprints_deleted_at ON exam_blueprints(deleted_at);
+-- Only one blueprint can be the default
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exam_blueprints_is_default ON exam_blueprints(is_default) WHERE is_default AND deleted_at IS NULL;
+
+-- Seed the grading previously hardcoded in the exam service:
+-- 100%=A, 90%=B, 80%=C, 70%=D, <70%=E and a 90% minimum overall and per category
+INSERT INTO grading_scales (code, name, description, created_at, updated_at)
+VALUES ('DEFAULT', 'Skala Nilai PPPK', '100%=A, 90%=B, 80%=C, 70%=D, <70%=E', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO grading_bands (grading_scale_id, grade, min_percentage, created_at, updated_at)
+SELECT gs.id, b.grade, b.min_percentage, NOW(), NOW()
+FROM grading_scales gs
+CROSS JOIN (VALUES ('A', 100.0), ('B', 90.0), ('C', 80.0), ('D', 70.0), ('E', 0.0)) AS b(grade, min_percentage)
+WHERE gs.code = 'DEFAULT';
+
+INSERT INTO pass_rules (code, name, description, overall_min_percentage, require_all_categories, created_at, updated_at)
+VALUES ('DEFAULT', 'Kelulusan PPPK', 'Minimal 90% keseluruhan dan 90% per kategori', 90.0, FALSE, NOW(), NOW())
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO pass_rule_category_minimums (pass_rule_id, category, min_percentage, created_at, updated_at)
+SELECT pr.id, c.code, 90.0, NOW(), NOW()
+FROM pass_rules pr
+CROSS JOIN categories c
+WHERE pr.code = 'DEFAULT'
+ON CONFLICT (pass_rule_id, category) DO NOTHING;
+
+INSERT INTO exam_blueprints (code, name, description, duration_minutes, grading_scale_id, pass_rule_id, is_default, created_at, updated_at)
+SELECT 'DEFAULT', 'Ujian PPPK', 'Blueprint default ujian PPPK', 130, gs.id, pr.id, TRUE, NOW(), NOW()
+FROM grading_scales gs, pass_rules pr
+WHERE gs.code = 'DEFAULT' AND pr.code = 'DEFAULT'
+ON CONFLICT (code) DO NOTHING;
+
+-- Link exam sessions to the blueprint they were generated from
+ALTER TABLE exam_sessions ADD COLUMN IF NOT EXISTS blueprint_id BIGINT;
+ALTER TABLE exam_sessions
+    ADD CONSTRAINT fk_exam_sessions_blueprint
+    FOREIGN KEY (blueprint_id)
+    REFERENCES exam_blueprints(id)
+    ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_exam_sessions_blueprint_id ON exam_sessions(blueprint_id);
+
+-- Record which grading scale and pass rule produced each verdict
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS grading_scale_id BIGINT;
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS pass_rule_id BIGINT;
+ALTER TABLE exam_summaries ADD COLUMN IF NOT EXISTS grading_scale_id BIGINT;
+ALTER TABLE exam_summaries ADD COLUMN IF NOT EXISTS pass_rule_id BIGINT;
+
+-- Existing sessions were generated and graded with the default configuration
+UPDATE exam_sessions
+SET blueprint_id = (SELECT id FROM exam_blueprints WHERE code = 'DEFAULT')
+WHERE blueprint_id IS NULL;
+
+UPDATE exam_results
+SET grading_scale_id = (SELECT id FROM grading_scales WHERE code = 'DEFAULT'),
+    pass_rule_id = (SELECT id FROM pass_rules WHERE code = 'DEFAULT')
+WHERE grading_scale_id IS NULL;
+
+UPDATE exam_summaries
+SET grading_scale_id = (SELECT id FROM grading_scales WHERE code = 'DEFAULT'),
+    pass_rule_id = (SELECT id FROM pass_rules WHERE code = 'DEFAULT')
+WHERE grading_scale_id IS NULL;
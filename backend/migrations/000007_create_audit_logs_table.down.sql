@@ -0,0 +1,7 @@
+-- Drop audit_logs table
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_request_id;
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+DROP INDEX IF EXISTS idx_audit_logs_action;
+DROP INDEX IF EXISTS idx_audit_logs_actor;
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,9 @@
+-- Drop assignment column from exam sessions
+DROP INDEX IF EXISTS idx_exam_sessions_assignment_id;
+ALTER TABLE exam_sessions DROP CONSTRAINT IF EXISTS fk_exam_sessions_assignment;
+ALTER TABLE exam_sessions DROP COLUMN IF EXISTS assignment_id;
+
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS cohort_assignments;
+DROP TABLE IF EXISTS cohort_members;
+DROP TABLE IF EXISTS cohorts;
@@ -0,0 +1,69 @@
+-- Create cohorts table (tryout classes grouping candidates and their instructors)
+CREATE TABLE IF NOT EXISTS cohorts (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cohorts_code ON cohorts(code);
+CREATE INDEX IF NOT EXISTS idx_cohorts_deleted_at ON cohorts(deleted_at);
+
+-- Create cohort_members table (users are the free-form user_id of exam sessions)
+CREATE TABLE IF NOT EXISTS cohort_members (
+    id BIGSERIAL PRIMARY KEY,
+    cohort_id BIGINT NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'MEMBER', -- MEMBER, INSTRUCTOR
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_cohort_members_cohort
+        FOREIGN KEY (cohort_id)
+        REFERENCES cohorts(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_cohort_members_cohort_id ON cohort_members(cohort_id);
+CREATE INDEX IF NOT EXISTS idx_cohort_members_user_id ON cohort_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_cohort_members_deleted_at ON cohort_members(deleted_at);
+-- A user has one role per cohort
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cohort_members_cohort_user ON cohort_members(cohort_id, user_id) WHERE deleted_at IS NULL;
+
+-- Create cohort_assignments table (blueprint a cohort sits within an availability window)
+CREATE TABLE IF NOT EXISTS cohort_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    cohort_id BIGINT NOT NULL,
+    blueprint_id BIGINT NOT NULL,
+    available_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    available_until TIMESTAMP WITH TIME ZONE,
+    assigned_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_cohort_assignments_cohort
+        FOREIGN KEY (cohort_id)
+        REFERENCES cohorts(id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_cohort_assignments_blueprint
+        FOREIGN KEY (blueprint_id)
+        REFERENCES exam_blueprints(id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_cohort_assignments_cohort_id ON cohort_assignments(cohort_id);
+CREATE INDEX IF NOT EXISTS idx_cohort_assignments_blueprint_id ON cohort_assignments(blueprint_id);
+CREATE INDEX IF NOT EXISTS idx_cohort_assignments_window ON cohort_assignments(available_from, available_until);
+CREATE INDEX IF NOT EXISTS idx_cohort_assignments_deleted_at ON cohort_assignments(deleted_at);
+
+-- Link exam sessions to the assignment they were started for
+ALTER TABLE exam_sessions ADD COLUMN IF NOT EXISTS assignment_id BIGINT;
+ALTER TABLE exam_sessions
+    ADD CONSTRAINT fk_exam_sessions_assignment
+    FOREIGN KEY (assignment_id)
+    REFERENCES cohort_assignments(id)
+    ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_exam_sessions_assignment_id ON exam_sessions(assignment_id);
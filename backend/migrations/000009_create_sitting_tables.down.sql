@@ -0,0 +1,8 @@
+-- Drop sitting column from exam sessions
+DROP INDEX IF EXISTS idx_exam_sessions_sitting_id;
+ALTER TABLE exam_sessions DROP CONSTRAINT IF EXISTS fk_exam_sessions_sitting;
+ALTER TABLE exam_sessions DROP COLUMN IF EXISTS sitting_id;
+
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS sitting_candidates;
+DROP TABLE IF EXISTS sittings;
@@ -0,0 +1,53 @@
+-- Create sittings table (scheduled test-centre sittings with a shared hard deadline)
+CREATE TABLE IF NOT EXISTS sittings (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    location VARCHAR(255),
+    blueprint_id BIGINT,
+    opens_at TIMESTAMP WITH TIME ZONE NOT NULL,  -- Candidates can start from
+    closes_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Candidates can no longer start from
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,   -- Every session of the sitting expires at
+    capacity INTEGER NOT NULL,
+    access_code VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_sittings_blueprint
+        FOREIGN KEY (blueprint_id)
+        REFERENCES exam_blueprints(id)
+        ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sittings_code ON sittings(code);
+CREATE INDEX IF NOT EXISTS idx_sittings_blueprint_id ON sittings(blueprint_id);
+CREATE INDEX IF NOT EXISTS idx_sittings_ends_at ON sittings(ends_at);
+CREATE INDEX IF NOT EXISTS idx_sittings_deleted_at ON sittings(deleted_at);
+
+-- Create sitting_candidates table (users booked into a sitting, bounded by its capacity)
+CREATE TABLE IF NOT EXISTS sitting_candidates (
+    id BIGSERIAL PRIMARY KEY,
+    sitting_id BIGINT NOT NULL,
+    user_id VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_sitting_candidates_sitting
+        FOREIGN KEY (sitting_id)
+        REFERENCES sittings(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sitting_candidates_sitting_id ON sitting_candidates(sitting_id);
+CREATE INDEX IF NOT EXISTS idx_sitting_candidates_user_id ON sitting_candidates(user_id);
+CREATE INDEX IF NOT EXISTS idx_sitting_candidates_deleted_at ON sitting_candidates(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sitting_candidates_sitting_user ON sitting_candidates(sitting_id, user_id) WHERE deleted_at IS NULL;
+
+-- Link exam sessions to the sitting they are taken in
+ALTER TABLE exam_sessions ADD COLUMN IF NOT EXISTS sitting_id BIGINT;
+ALTER TABLE exam_sessions
+    ADD CONSTRAINT fk_exam_sessions_sitting
+    FOREIGN KEY (sitting_id)
+    REFERENCES sittings(id)
+    ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_exam_sessions_sitting_id ON exam_sessions(sitting_id);
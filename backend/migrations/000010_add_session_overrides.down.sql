@@ -0,0 +1,2 @@
+-- Drop reason column from audit logs
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS reason;
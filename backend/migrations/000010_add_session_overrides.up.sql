@@ -0,0 +1,2 @@
+-- Record why an admin overrode an exam session (extend, force-complete, reset, void, reopen)
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS reason TEXT;
@@ -0,0 +1,7 @@
+-- Drop accommodation column from exam sessions
+ALTER TABLE exam_sessions DROP COLUMN IF EXISTS accommodation_minutes;
+
+-- Drop accommodations table
+DROP INDEX IF EXISTS idx_accommodations_deleted_at;
+DROP INDEX IF EXISTS idx_accommodations_user_id;
+DROP TABLE IF EXISTS accommodations;
@@ -0,0 +1,19 @@
+-- Create accommodations table (per-user exam accommodations such as extended time)
+CREATE TABLE IF NOT EXISTS accommodations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    time_multiplier DECIMAL(4,2) NOT NULL DEFAULT 1.00,
+    large_font BOOLEAN DEFAULT FALSE,
+    extra_breaks INTEGER NOT NULL DEFAULT 0,
+    break_minutes INTEGER NOT NULL DEFAULT 0,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accommodations_user_id ON accommodations(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_accommodations_deleted_at ON accommodations(deleted_at);
+
+-- Record the extra minutes an accommodation added to each session deadline
+ALTER TABLE exam_sessions ADD COLUMN IF NOT EXISTS accommodation_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
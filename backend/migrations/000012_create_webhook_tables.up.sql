@@ -0,0 +1,55 @@
+-- Create webhooks table (external endpoints notified of exam lifecycle events)
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,  -- Key of the HMAC-SHA256 signature
+    events VARCHAR(500) NOT NULL,  -- Comma separated subscribed events
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks(deleted_at);
+
+-- Create webhook_deliveries table (outbox of events to deliver, retried with backoff)
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,  -- Shared by the deliveries of one event
+    event VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,         -- Exact body that is signed and sent
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, DELIVERED, FAILED
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_webhook_deliveries_webhook
+        FOREIGN KEY (webhook_id)
+        REFERENCES webhooks(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+-- Create webhook_delivery_attempts table (one row per HTTP request of a delivery)
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL,
+    attempt INTEGER NOT NULL,
+    response_status INTEGER,  -- NULL when no response was received
+    response_body TEXT,       -- Truncated
+    error TEXT,
+    duration_ms INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_webhook_delivery_attempts_delivery
+        FOREIGN KEY (delivery_id)
+        REFERENCES webhook_deliveries(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts(delivery_id);
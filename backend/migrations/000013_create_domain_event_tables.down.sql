@@ -0,0 +1,3 @@
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS domain_event_receipts;
+DROP TABLE IF EXISTS domain_events;
@@ -0,0 +1,35 @@
+-- Create domain_events table (outbox of domain events, written in the transaction of the change)
+CREATE TABLE IF NOT EXISTS domain_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(64) NOT NULL,       -- Random ID shared with everything the event is passed on to
+    event_type VARCHAR(100) NOT NULL,    -- ExamSessionCreated, ExamStarted, AnswerSubmitted, ExamCompleted, ExamExpired
+    aggregate_type VARCHAR(50) NOT NULL, -- Kind of the changed entity, e.g. exam_session
+    aggregate_id VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, PROCESSED, FAILED
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_domain_events_event_id ON domain_events(event_id);
+CREATE INDEX IF NOT EXISTS idx_domain_events_aggregate ON domain_events(aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_domain_events_due ON domain_events(next_attempt_at) WHERE status = 'PENDING';
+
+-- Create domain_event_receipts table (subscribers that handled an event, skipped on redelivery)
+CREATE TABLE IF NOT EXISTS domain_event_receipts (
+    id BIGSERIAL PRIMARY KEY,
+    domain_event_id BIGINT NOT NULL,
+    subscriber VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_domain_event_receipts_domain_event
+        FOREIGN KEY (domain_event_id)
+        REFERENCES domain_events(id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_domain_event_receipts_event_subscriber ON domain_event_receipts(domain_event_id, subscriber);
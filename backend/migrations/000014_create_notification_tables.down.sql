@@ -0,0 +1,3 @@
+-- Drop tables in reverse order
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_contacts;
@@ -0,0 +1,36 @@
+-- Create notification_contacts table (where and in which language candidates are notified)
+CREATE TABLE IF NOT EXISTS notification_contacts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(150),
+    locale VARCHAR(5) NOT NULL DEFAULT 'id', -- id or en
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_contacts_user_id ON notification_contacts(user_id);
+
+-- Create notifications table (persistent email send queue, retried with backoff)
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL,
+    kind VARCHAR(30) NOT NULL,          -- SCORE_REPORT, SITTING_REMINDER
+    dedupe_key VARCHAR(150) NOT NULL,   -- Queues each notification once, e.g. score_report:42
+    recipient VARCHAR(255) NOT NULL,
+    locale VARCHAR(5) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, SENT, FAILED, SKIPPED
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_dedupe_key ON notifications(dedupe_key);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'PENDING';
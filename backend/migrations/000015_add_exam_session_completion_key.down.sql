@@ -0,0 +1,3 @@
+-- Drop completion key of exam sessions
+DROP INDEX IF EXISTS idx_exam_sessions_user_id_completion_key;
+ALTER TABLE exam_sessions DROP COLUMN IF EXISTS completion_key;
@@ -0,0 +1,5 @@
+-- Record the Idempotency-Key an exam session was completed with, so a retried completion
+-- finds the same session even after the user started a new one
+ALTER TABLE exam_sessions ADD COLUMN IF NOT EXISTS completion_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exam_sessions_user_id_completion_key ON exam_sessions(user_id, completion_key) WHERE completion_key IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Drop session_transitions table
+DROP TABLE IF EXISTS session_transitions;
@@ -0,0 +1,17 @@
+-- Create session_transitions table (history of exam session status changes)
+CREATE TABLE IF NOT EXISTS session_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    exam_session_id BIGINT NOT NULL,
+    from_status VARCHAR(20) NOT NULL, -- NOT_STARTED, IN_PROGRESS, COMPLETED, EXPIRED, VOIDED
+    to_status VARCHAR(20) NOT NULL,
+    trigger VARCHAR(50) NOT NULL,     -- START, COMPLETE, EXPIRE, OFFLINE_COMPLETE or the audit action of an admin override
+    actor VARCHAR(100),
+    request_id VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_session_transitions_exam_session
+        FOREIGN KEY (exam_session_id)
+        REFERENCES exam_sessions(id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_transitions_exam_session_id ON session_transitions(exam_session_id);
@@ -0,0 +1,2 @@
+-- Drop category maximum of exam results
+ALTER TABLE exam_results DROP COLUMN IF EXISTS category_max_score;
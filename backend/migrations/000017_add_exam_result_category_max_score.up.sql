@@ -0,0 +1,8 @@
+-- Record the section maximum configured on the category when a session was scored, so
+-- re-scoring keeps it after the category changes. Existing results take the current one.
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS category_max_score INTEGER NOT NULL DEFAULT 0;
+
+UPDATE exam_results er
+SET category_max_score = c.max_score
+FROM categories c
+WHERE c.code = er.category;
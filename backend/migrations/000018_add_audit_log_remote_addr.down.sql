@@ -0,0 +1,3 @@
+-- Drop actor claim and remote address of audit logs
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS remote_addr;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS actor_claimed;
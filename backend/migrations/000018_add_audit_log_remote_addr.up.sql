@@ -0,0 +1,6 @@
+-- Record that the actor of an audit entry was only claimed by the client, and where the request came from
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS actor_claimed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS remote_addr VARCHAR(64);
+
+-- Entries written through the API before now carry the X-Actor header as well
+UPDATE audit_logs SET actor_claimed = TRUE WHERE actor <> 'system';
@@ -0,0 +1,3 @@
+-- Drop event_counts table and the receipts of the analytics subscriber
+DELETE FROM domain_event_receipts WHERE subscriber = 'analytics';
+DROP TABLE IF EXISTS event_counts;
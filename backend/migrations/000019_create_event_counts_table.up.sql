@@ -0,0 +1,21 @@
+-- Create event_counts table (domain events per type and day, kept by the analytics subscriber)
+CREATE TABLE IF NOT EXISTS event_counts (
+    day DATE NOT NULL,                -- Day the events occurred on, in UTC
+    event_type VARCHAR(100) NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (day, event_type)
+);
+
+-- Count the events published so far and mark them handled by the analytics subscriber,
+-- so pending and retried events are not counted again when they are dispatched
+INSERT INTO event_counts (day, event_type, count, updated_at)
+SELECT (occurred_at AT TIME ZONE 'UTC')::date, event_type, COUNT(*), NOW()
+FROM domain_events
+GROUP BY 1, 2
+ON CONFLICT (day, event_type) DO NOTHING;
+
+INSERT INTO domain_event_receipts (domain_event_id, subscriber, created_at)
+SELECT id, 'analytics', NOW()
+FROM domain_events
+ON CONFLICT (domain_event_id, subscriber) DO NOTHING;